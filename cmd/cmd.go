@@ -16,18 +16,57 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// InitBackendContext initializes the backend context.
+// InitBackendContext initializes the backend context. It also opens the
+// encrypted storage for the whole command, before anything reads
+// repositories.
 func InitBackendContext(cmd *cobra.Command, _ []string) error {
+	be, err := initBackendContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	release, err := be.OpenStorage(ctx)
+	if err != nil {
+		db.FromContext(ctx).Close() // nolint: errcheck
+		return fmt.Errorf("mount encrypted storage: %w", err)
+	}
+
+	// Finalizers run even when the command fails, unlike
+	// PersistentPostRunE.
+	cobra.OnFinalize(func() {
+		release()
+		be.CloseStorage()
+	})
+
+	return nil
+}
+
+// InitServerBackendContext initializes the backend context of the server.
+// Unlike InitBackendContext, the encrypted storage isn't opened for the whole
+// command, the server opens it for each of its operations.
+func InitServerBackendContext(cmd *cobra.Command, _ []string) error {
+	be, err := initBackendContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	cobra.OnFinalize(be.CloseStorage)
+
+	return nil
+}
+
+func initBackendContext(cmd *cobra.Command) (*backend.Backend, error) {
 	ctx := cmd.Context()
 	cfg := config.FromContext(ctx)
 	if _, err := os.Stat(cfg.DataPath); errors.Is(err, fs.ErrNotExist) {
 		if err := os.MkdirAll(cfg.DataPath, os.ModePerm); err != nil {
-			return fmt.Errorf("create data directory: %w", err)
+			return nil, fmt.Errorf("create data directory: %w", err)
 		}
 	}
 	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
 	if err != nil {
-		return fmt.Errorf("open database: %w", err)
+		return nil, fmt.Errorf("open database: %w", err)
 	}
 
 	ctx = db.WithContext(ctx, dbx)
@@ -35,10 +74,9 @@ func InitBackendContext(cmd *cobra.Command, _ []string) error {
 	ctx = store.WithContext(ctx, dbstore)
 	be := backend.New(ctx, cfg, dbx, dbstore)
 	ctx = backend.WithContext(ctx, be)
-
 	cmd.SetContext(ctx)
 
-	return nil
+	return be, nil
 }
 
 // CloseDBContext closes the database context.
@@ -55,7 +93,7 @@ func CloseDBContext(cmd *cobra.Command, _ []string) error {
 }
 
 // InitializeHooks initializes the hooks.
-func InitializeHooks(ctx context.Context, cfg *config.Config, be *backend.Backend) error {
+func InitializeHooks(ctx context.Context, cfg *config.Config, be backend.Service) error {
 	repos, err := be.Repositories(ctx)
 	if err != nil {
 		return err
@@ -257,6 +257,11 @@ func (repository) IsHidden() bool {
 	return false
 }
 
+// IsArchived implements proto.Repository.
+func (repository) IsArchived() bool {
+	return false
+}
+
 // IsMirror implements proto.Repository.
 func (repository) IsMirror() bool {
 	return false
@@ -297,6 +302,11 @@ func (r repository) UserID() int64 {
 	return 0
 }
 
+// CreatedBy implements proto.Repository.
+func (r repository) CreatedBy() int64 {
+	return 0
+}
+
 // CreatedAt implements proto.Repository.
 func (r repository) CreatedAt() time.Time {
 	return time.Time{}
@@ -24,6 +24,10 @@ var (
 	// ErrInternalServerError indicates that an internal server error occurred.
 	ErrInternalServerError = errors.New("internal server error")
 
+	// ErrInMemoryDatabase indicates that pushes are rejected because the
+	// in-memory database of the server isn't reachable from the hook.
+	ErrInMemoryDatabase = errors.New("pushes are disabled with an in-memory database, the push rules can't be checked")
+
 	// Deprecated: this flag is ignored.
 	configPath string
 
@@ -77,8 +81,16 @@ var (
 		var buf bytes.Buffer
 		opts := make([]hooks.HookArg, 0)
 
-		switch cmdName {
-		case hooks.PreReceiveHook, hooks.PostReceiveHook:
+		switch {
+		case cfg.DB.InMemory():
+			// The in-memory database of the server isn't reachable from the
+			// hook process. Pushes are rejected instead of skipping the push
+			// rules.
+			if cmdName == hooks.PreReceiveHook {
+				logger.Info("push rejected", "err", ErrInMemoryDatabase)
+				return ErrInMemoryDatabase
+			}
+		case cmdName == hooks.PreReceiveHook, cmdName == hooks.PostReceiveHook:
 			scanner := bufio.NewScanner(stdin)
 			for scanner.Scan() {
 				buf.Write(scanner.Bytes())
@@ -97,11 +109,16 @@ var (
 
 			switch cmdName {
 			case hooks.PreReceiveHook:
+				if err := hks.ValidatePreReceive(ctx, repoName, opts); err != nil {
+					logger.Info("push rejected", "err", err)
+					return err
+				}
+
 				hks.PreReceive(ctx, stdout, stderr, repoName, opts)
 			case hooks.PostReceiveHook:
 				hks.PostReceive(ctx, stdout, stderr, repoName, opts)
 			}
-		case hooks.UpdateHook:
+		case cmdName == hooks.UpdateHook:
 			if len(args) != 3 {
 				logger.Error("invalid update hook input", "input", args)
 				break
@@ -112,7 +129,7 @@ var (
 				OldSha:  args[1],
 				NewSha:  args[2],
 			})
-		case hooks.PostUpdateHook:
+		case cmdName == hooks.PostUpdateHook:
 			hks.PostUpdate(ctx, stdout, stderr, repoName, args...)
 		}
 
@@ -165,6 +182,7 @@ func init() {
 
 func runCommand(ctx context.Context, in io.Reader, out io.Writer, err io.Writer, name string, args ...string) error {
 	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = config.HookEnviron(os.Environ())
 	cmd.Stdin = in
 	cmd.Stdout = out
 	cmd.Stderr = err
@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/cmd"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
@@ -28,7 +29,7 @@ var (
 		Use:                "serve",
 		Short:              "Start the server",
 		Args:               cobra.NoArgs,
-		PersistentPreRunE:  cmd.InitBackendContext,
+		PersistentPreRunE:  cmd.InitServerBackendContext,
 		PersistentPostRunE: cmd.CloseDBContext,
 		RunE: func(c *cobra.Command, _ []string) error {
 			ctx := c.Context()
@@ -65,9 +66,13 @@ var (
 				os.MkdirAll(logPath, os.ModePerm) // nolint: errcheck
 			}
 
-			db := db.FromContext(ctx)
-			if err := migrate.Migrate(ctx, db); err != nil {
-				return fmt.Errorf("migration error: %w", err)
+			if cfg.DB.InMemory() {
+				log.FromContext(ctx).Warn("using an in-memory database, everything but the repositories is lost when the server stops " +
+					"and pushes are rejected")
+			}
+
+			if err := prepareRepositories(ctx, cfg); err != nil {
+				return err
 			}
 
 			s, err := NewServer(ctx)
@@ -75,13 +80,6 @@ var (
 				return fmt.Errorf("start server: %w", err)
 			}
 
-			if syncHooks {
-				be := backend.FromContext(ctx)
-				if err := cmd.InitializeHooks(ctx, cfg, be); err != nil {
-					return fmt.Errorf("initialize hooks: %w", err)
-				}
-			}
-
 			lch := make(chan error, 1)
 			done := make(chan os.Signal, 1)
 			doneOnce := sync.OnceFunc(func() { close(done) })
@@ -121,6 +119,34 @@ var (
 	}
 )
 
+// prepareRepositories migrates the database and applies the config to the
+// existing repositories, with the encrypted storage open.
+func prepareRepositories(ctx context.Context, cfg *config.Config) error {
+	be := backend.FromContext(ctx)
+	release, err := be.OpenStorage(ctx)
+	if err != nil {
+		return fmt.Errorf("mount encrypted storage: %w", err)
+	}
+
+	defer release()
+	if err := migrate.Migrate(ctx, db.FromContext(ctx)); err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+
+	if syncHooks {
+		if err := cmd.InitializeHooks(ctx, cfg, be); err != nil {
+			return fmt.Errorf("initialize hooks: %w", err)
+		}
+	}
+
+	// Apply config changes to existing repositories.
+	if err := be.ReconcileRepoConfigAll(ctx); err != nil {
+		return fmt.Errorf("reconcile repo config: %w", err)
+	}
+
+	return nil
+}
+
 func init() {
 	Command.Flags().BoolVarP(&syncHooks, "sync-hooks", "", false, "synchronize hooks for all repositories before running the server")
 }
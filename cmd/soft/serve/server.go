@@ -29,7 +29,7 @@ type Server struct {
 	StatsServer *stats.StatsServer
 	Cron        *cron.Scheduler
 	Config      *config.Config
-	Backend     *backend.Backend
+	Backend     backend.Service
 	DB          *db.DB
 
 	logger *log.Logger
@@ -38,7 +38,7 @@ type Server struct {
 
 // NewServer returns a new *Server configured to serve Soft Serve. The SSH
 // server key-pair will be created if none exists.
-// It expects a context with *backend.Backend, *db.DB, *log.Logger, and
+// It expects a context with backend.Service, *db.DB, *log.Logger, and
 // *config.Config attached.
 func NewServer(ctx context.Context) (*Server, error) {
 	var err error
@@ -57,7 +57,13 @@ func NewServer(ctx context.Context) (*Server, error) {
 	// Add cron jobs.
 	sched := cron.NewScheduler(ctx)
 	for n, j := range jobs.List() {
-		id, err := sched.AddFunc(j.Runner.Spec(ctx), j.Runner.Func(ctx))
+		spec := j.Runner.Spec(ctx)
+		if spec == "" {
+			logger.Debug("cron job disabled", "job", n)
+			continue
+		}
+
+		id, err := sched.AddFunc(spec, withStorage(ctx, be, n, j.Runner.Func(ctx)))
 		if err != nil {
 			logger.Warn("error adding cron job", "job", n, "err", err)
 		}
@@ -90,6 +96,21 @@ func NewServer(ctx context.Context) (*Server, error) {
 	return srv, nil
 }
 
+// withStorage runs a cron job with the encrypted storage open.
+func withStorage(ctx context.Context, be backend.Service, name string, fn func()) func() {
+	logger := log.FromContext(ctx).WithPrefix("server")
+	return func() {
+		release, err := be.OpenStorage(ctx)
+		if err != nil {
+			logger.Error("error mounting encrypted storage", "job", name, "err", err)
+			return
+		}
+
+		defer release()
+		fn()
+	}
+}
+
 // Start starts the SSH server.
 func (s *Server) Start() error {
 	errg, _ := errgroup.WithContext(s.ctx)
@@ -147,18 +168,18 @@ func (s *Server) Start() error {
 
 // Shutdown lets the server gracefully shutdown.
 func (s *Server) Shutdown(ctx context.Context) error {
-	errg, ctx := errgroup.WithContext(ctx)
+	errg, gctx := errgroup.WithContext(ctx)
 	errg.Go(func() error {
-		return s.GitDaemon.Shutdown(ctx)
+		return s.GitDaemon.Shutdown(gctx)
 	})
 	errg.Go(func() error {
-		return s.HTTPServer.Shutdown(ctx)
+		return s.HTTPServer.Shutdown(gctx)
 	})
 	errg.Go(func() error {
-		return s.SSHServer.Shutdown(ctx)
+		return s.SSHServer.Shutdown(gctx)
 	})
 	errg.Go(func() error {
-		return s.StatsServer.Shutdown(ctx)
+		return s.StatsServer.Shutdown(gctx)
 	})
 	errg.Go(func() error {
 		for _, j := range jobs.List() {
@@ -168,7 +189,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return nil
 	})
 	// defer s.DB.Close() // nolint: errcheck
-	return errg.Wait()
+	if err := errg.Wait(); err != nil {
+		return err
+	}
+
+	// Write the key usage buffered since the last job run.
+	return s.Backend.FlushPublicKeyUsage(ctx)
 }
 
 // Close closes the SSH server.
@@ -0,0 +1,101 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivityInterval is the time span of commit activity buckets.
+type ActivityInterval string
+
+const (
+	// ActivityDay buckets commits by day.
+	ActivityDay ActivityInterval = "day"
+	// ActivityWeek buckets commits by week, weeks start on Monday.
+	ActivityWeek ActivityInterval = "week"
+)
+
+// ParseActivityInterval parses a commit activity interval.
+func ParseActivityInterval(s string) (ActivityInterval, error) {
+	switch i := ActivityInterval(s); i {
+	case ActivityDay, ActivityWeek:
+		return i, nil
+	}
+	return "", fmt.Errorf("invalid activity interval %q, must be day or week", s)
+}
+
+// Start returns the start of the bucket t falls in, in UTC.
+func (i ActivityInterval) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if i == ActivityWeek {
+		// Go weeks start on Sunday.
+		offset := (int(day.Weekday()) + 6) % 7
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// next returns the start of the bucket following the bucket starting at t.
+func (i ActivityInterval) next(t time.Time) time.Time {
+	if i == ActivityWeek {
+		return t.AddDate(0, 0, 7)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// ActivityBucket is the number of commits of a time bucket.
+type ActivityBucket struct {
+	// Start is the start of the bucket in UTC.
+	Start time.Time `json:"start"`
+	// Commits is the number of commits of the bucket.
+	Commits int64 `json:"commits"`
+}
+
+// CommitActivity returns the number of commits reachable from ref bucketed by
+// their committer date, from the bucket of since to the bucket of until.
+// Buckets without commits are included.
+//
+// History is walked back to since only, bound it to keep this fast on large
+// histories.
+func (r *Repository) CommitActivity(ref string, since, until time.Time, interval ActivityInterval) ([]ActivityBucket, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid ref: %q", ref)
+	}
+
+	since = interval.Start(since)
+	out, err := NewCommand("log", "--since=@"+strconv.FormatInt(since.Unix(), 10),
+		"--format=%ct", ref, "--").RunInDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucketCommits(out, since, until, interval), nil
+}
+
+// bucketCommits counts the commit timestamps, one per line, in the buckets
+// from the bucket of since to the bucket of until.
+func bucketCommits(buf []byte, since, until time.Time, interval ActivityInterval) []ActivityBucket {
+	buckets := make([]ActivityBucket, 0)
+	index := map[int64]int{}
+	for t := interval.Start(since); !t.After(until); t = interval.next(t) {
+		index[t.Unix()] = len(buckets)
+		buckets = append(buckets, ActivityBucket{Start: t})
+	}
+
+	for _, line := range strings.Split(string(buf), "\n") {
+		ts, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// Commits outside the range, e.g. with a skewed clock, are dropped.
+		if i, ok := index[interval.Start(time.Unix(ts, 0)).Unix()]; ok {
+			buckets[i].Commits++
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestActivityIntervalStart(t *testing.T) {
+	is := is.New(t)
+	// Wednesday.
+	ts := time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC)
+	is.Equal(ActivityDay.Start(ts), time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+	is.Equal(ActivityWeek.Start(ts), time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC))
+	// Sunday belongs to the week started on the previous Monday.
+	is.Equal(ActivityWeek.Start(time.Date(2024, 5, 19, 23, 0, 0, 0, time.UTC)), time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC))
+	// Times are bucketed in UTC.
+	est := time.FixedZone("EST", -5*3600)
+	is.Equal(ActivityDay.Start(time.Date(2024, 5, 15, 22, 0, 0, 0, est)), time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC))
+}
+
+func TestBucketCommits(t *testing.T) {
+	is := is.New(t)
+	day := func(d, h int) int64 {
+		return time.Date(2024, 5, d, h, 0, 0, 0, time.UTC).Unix()
+	}
+	out := fmt.Sprintf("%d\n%d\n%d\n%d\n%d\n", day(17, 9), day(15, 18), day(15, 10), day(13, 1), day(1, 0))
+	since := time.Date(2024, 5, 13, 12, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+
+	is.Equal(bucketCommits([]byte(out), since, until, ActivityDay), []ActivityBucket{
+		{Start: time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), Commits: 1},
+		{Start: time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC), Commits: 0},
+		{Start: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), Commits: 2},
+		{Start: time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), Commits: 0},
+		{Start: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), Commits: 1},
+	})
+	is.Equal(bucketCommits([]byte(out), since, until, ActivityWeek), []ActivityBucket{
+		{Start: time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), Commits: 4},
+	})
+	is.Equal(len(bucketCommits(nil, since, until, ActivityDay)), 5)
+}
+
+func TestParseActivityInterval(t *testing.T) {
+	is := is.New(t)
+	i, err := ParseActivityInterval("week")
+	is.NoErr(err)
+	is.Equal(i, ActivityWeek)
+	_, err = ParseActivityInterval("month")
+	is.True(err != nil)
+}
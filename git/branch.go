@@ -0,0 +1,132 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/git-module"
+)
+
+// BranchTip is a branch and information about its tip commit.
+type BranchTip struct {
+	*Reference
+
+	// Summary is the first line of the tip commit message.
+	Summary string
+	// Author is the name of the tip commit author.
+	Author string
+	// When is the tip commit committer date.
+	When time.Time
+}
+
+// branchTipsFormat separates the for-each-ref fields with NUL bytes, they
+// can't appear in any of them.
+const branchTipsFormat = "%(refname)%00%(objectname)%00%(committerdate:unix)%00%(authorname)%00%(contents:subject)"
+
+// BranchTips returns all the branches and their tip commit, most recently
+// committed first. It runs a single git command, so it stays fast on
+// repositories with many branches.
+func (r *Repository) BranchTips() ([]BranchTip, error) {
+	out, err := NewCommand("for-each-ref", "--sort=-committerdate", "--format="+branchTipsFormat, RefsHeads).RunInDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBranchTips(r.Path, out), nil
+}
+
+// MergedBranchTips returns the branches whose tip is reachable from rev,
+// i.e. that are fully merged into it, most recently committed first.
+func (r *Repository) MergedBranchTips(rev string) ([]BranchTip, error) {
+	out, err := NewCommand("for-each-ref", "--sort=-committerdate", "--format="+branchTipsFormat, "--merged="+rev, RefsHeads).RunInDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBranchTips(r.Path, out), nil
+}
+
+func parseBranchTips(path string, out []byte) []BranchTip {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	tips := make([]BranchTip, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.SplitN(line, "\x00", 5)
+		if len(fields) != 5 {
+			continue
+		}
+
+		var when time.Time
+		if sec, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			when = time.Unix(sec, 0)
+		}
+
+		tips = append(tips, BranchTip{
+			Reference: &Reference{
+				Reference: &git.Reference{
+					ID:      fields[1],
+					Refspec: fields[0],
+				},
+				path: path,
+			},
+			Summary: fields[4],
+			Author:  fields[3],
+			When:    when,
+		})
+	}
+
+	return tips
+}
+
+// AheadBehind returns the number of commits of rev that aren't reachable
+// from base, and the number of commits of base that aren't reachable from
+// rev.
+func (r *Repository) AheadBehind(base, rev string) (ahead int64, behind int64, err error) {
+	out, err := NewCommand("rev-list", "--left-right", "--count", fmt.Sprintf("%s...%s", base, rev), "--").RunInDir(r.Path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
+	}
+
+	behind, err = strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	ahead, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return ahead, behind, nil
+}
+
+// RenameBranch renames a branch whose tip is the commit id. The new branch is
+// created and the old one deleted in a single transaction, it fails if the
+// new branch exists or the old one moved.
+func (r *Repository) RenameBranch(ctx context.Context, oldName, newName, id string) error {
+	if _, err := NewCommand("check-ref-format", RefsHeads+newName).WithContext(ctx).RunInDir(r.Path); err != nil {
+		return fmt.Errorf("invalid branch name: %q", newName)
+	}
+
+	var stderr bytes.Buffer
+	stdin := fmt.Sprintf("start\ncreate %s %s\ndelete %s %s\ncommit\n", RefsHeads+newName, id, RefsHeads+oldName, id)
+	if err := NewCommand("update-ref", "--stdin").WithContext(ctx).
+		RunInDirWithOptions(r.Path, RunInDirOptions{
+			Stdin:  strings.NewReader(stdin),
+			Stderr: &stderr,
+		}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+
+	return nil
+}
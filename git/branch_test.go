@@ -0,0 +1,25 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParseBranchTips(t *testing.T) {
+	is := is.New(t)
+	out := "refs/heads/main\x000123456789012345678901234567890123456789\x001700000000\x00John Doe\x00first commit\n" +
+		"refs/heads/feature/a\x00abcdefabcdefabcdefabcdefabcdefabcdefabcd\x001600000000\x00Jane Doe\x00\n"
+	tips := parseBranchTips("/repo", []byte(out))
+	is.Equal(len(tips), 2)
+	is.Equal(tips[0].Name().Short(), "main")
+	is.Equal(tips[0].ID, "0123456789012345678901234567890123456789")
+	is.Equal(tips[0].Summary, "first commit")
+	is.Equal(tips[0].Author, "John Doe")
+	is.True(tips[0].When.Equal(time.Unix(1700000000, 0)))
+	is.Equal(tips[1].Name().Short(), "feature/a")
+	is.Equal(tips[1].Summary, "")
+
+	is.Equal(len(parseBranchTips("/repo", nil)), 0)
+}
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrEmptyRepository is returned when creating a bundle of a repository
+// without any references.
+var ErrEmptyRepository = errors.New("repository is empty")
+
+// Bundle writes a bundle of all the references of the repository to w. The
+// bundle can be cloned like a remote repository, e.g. git clone repo.bundle.
+func (r *Repository) Bundle(ctx context.Context, w io.Writer) error {
+	refs, err := NewCommand("for-each-ref", "--count=1").WithContext(ctx).RunInDir(r.Path)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(refs)) == 0 {
+		return ErrEmptyRepository
+	}
+
+	var stderr bytes.Buffer
+	// Bundling large repositories takes a while, don't time out.
+	if err := NewCommand("bundle", "create", "--quiet", "-", "--all").WithContext(ctx).WithTimeout(-1).
+		RunInDirWithOptions(r.Path, RunInDirOptions{
+			Stdout: w,
+			Stderr: &stderr,
+		}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+
+	return nil
+}
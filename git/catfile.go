@@ -0,0 +1,231 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrObjectReaderClosed is returned when reading objects from a closed
+// ObjectReader.
+var ErrObjectReaderClosed = errors.New("object reader is closed")
+
+// Object is a git object read with git cat-file --batch.
+type Object struct {
+	ID   string
+	Type string
+	Size int64
+	// Data is nil when only the size of the object was read.
+	Data []byte
+}
+
+// ObjectReader reads git objects through long-lived git cat-file --batch
+// processes instead of spawning a git process per object. Processes are
+// started on demand, at most size of them run at the same time, and they're
+// reused until the reader is closed.
+//
+// It's safe for concurrent use.
+type ObjectReader struct {
+	path string
+	sem  chan struct{}
+
+	mu     sync.Mutex
+	idle   []*catFile
+	closed bool
+}
+
+// NewObjectReader returns an object reader for the repository at path that
+// runs at most size git cat-file processes.
+func NewObjectReader(path string, size int) *ObjectReader {
+	if size < 1 {
+		size = 1
+	}
+	return &ObjectReader{
+		path: path,
+		sem:  make(chan struct{}, size),
+	}
+}
+
+// Object reads the object rev resolves to. It returns ErrRevisionNotExist
+// if rev doesn't name an object.
+func (o *ObjectReader) Object(rev string) (*Object, error) {
+	return o.read(rev, false)
+}
+
+// ObjectSize returns the size of the object rev resolves to without reading
+// its contents. It returns ErrRevisionNotExist if rev doesn't name an
+// object.
+func (o *ObjectReader) ObjectSize(rev string) (int64, error) {
+	obj, err := o.read(rev, true)
+	if err != nil {
+		return 0, err
+	}
+	return obj.Size, nil
+}
+
+func (o *ObjectReader) read(rev string, check bool) (*Object, error) {
+	// A newline would be read as a second request and desync the process.
+	if rev == "" || strings.ContainsAny(rev, "\r\n") {
+		return nil, fmt.Errorf("invalid revision: %q", rev)
+	}
+
+	o.sem <- struct{}{}
+	defer func() { <-o.sem }()
+
+	cf, err := o.get(check)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := cf.read(rev)
+	if err != nil && !errors.Is(err, ErrRevisionNotExist) {
+		// The state of the process is unknown, don't reuse it.
+		cf.close()
+		return nil, err
+	}
+
+	o.put(cf)
+	return obj, err
+}
+
+// Close stops the git cat-file processes. Reads in progress finish, and
+// further reads return ErrObjectReaderClosed.
+func (o *ObjectReader) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	for _, cf := range o.idle {
+		cf.close()
+	}
+	o.idle = nil
+	return nil
+}
+
+// get returns an idle process of the given kind or starts a new one.
+func (o *ObjectReader) get(check bool) (*catFile, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return nil, ErrObjectReaderClosed
+	}
+	for i := len(o.idle) - 1; i >= 0; i-- {
+		if cf := o.idle[i]; cf.check == check {
+			o.idle = append(o.idle[:i], o.idle[i+1:]...)
+			return cf, nil
+		}
+	}
+	if len(o.idle) > 0 && len(o.idle)+len(o.sem) > cap(o.sem) {
+		// Every read holds a slot of sem, stop an idle process of the other
+		// kind to run at most size processes.
+		o.idle[0].close()
+		o.idle = o.idle[1:]
+	}
+	return startCatFile(o.path, check)
+}
+
+// put returns a process to the idle list, or stops it if the reader was
+// closed in the meantime.
+func (o *ObjectReader) put(cf *catFile) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		cf.close()
+		return
+	}
+	o.idle = append(o.idle, cf)
+}
+
+// catFile is a running git cat-file --batch process, or --batch-check if
+// check is true.
+type catFile struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	check  bool
+}
+
+func startCatFile(path string, check bool) (*catFile, error) {
+	mode := "--batch"
+	if check {
+		mode = "--batch-check"
+	}
+	cmd := exec.Command("git", "cat-file", mode)
+	cmd.Dir = path
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &catFile{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+		check:  check,
+	}, nil
+}
+
+// read requests an object and reads the response, either
+// "<oid> <type> <size>\n" followed by "<contents>\n" unless check is true,
+// or "<rev> missing\n".
+func (c *catFile) read(rev string) (*Object, error) {
+	if _, err := io.WriteString(c.stdin, rev+"\n"); err != nil {
+		return nil, err
+	}
+
+	header, err := c.stdout.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(header, " missing\n") || strings.HasSuffix(header, " ambiguous\n") {
+		return nil, ErrRevisionNotExist
+	}
+	fields := strings.Fields(header)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("unexpected cat-file header: %q", header)
+	}
+
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("unexpected cat-file header: %q", header)
+	}
+
+	obj := &Object{
+		ID:   fields[0],
+		Type: fields[1],
+		Size: size,
+	}
+	if c.check {
+		return obj, nil
+	}
+
+	// The contents are followed by a newline.
+	data := make([]byte, size+1)
+	if _, err := io.ReadFull(c.stdout, data); err != nil {
+		return nil, err
+	}
+	obj.Data = data[:size]
+
+	return obj, nil
+}
+
+// close stops the process. It's killed rather than waited on since it might
+// be blocked writing a response nobody reads.
+func (c *catFile) close() {
+	_ = c.stdin.Close()
+	_ = c.cmd.Process.Kill()
+	_ = c.cmd.Wait()
+}
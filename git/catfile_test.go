@@ -0,0 +1,120 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func writeTestBlob(t *testing.T, path, contents string) string {
+	t.Helper()
+	cmd := exec.Command("git", "hash-object", "-w", "--stdin")
+	cmd.Dir = path
+	cmd.Stdin = strings.NewReader(contents)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("hash-object: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestObjectReader(t *testing.T) {
+	is := is.New(t)
+	r, err := Init(filepath.Join(t.TempDir(), "repo"), true)
+	is.NoErr(err)
+
+	id := writeTestBlob(t, r.Path, "hello\nworld\n")
+	empty := writeTestBlob(t, r.Path, "")
+
+	o := NewObjectReader(r.Path, 2)
+	defer o.Close() // nolint: errcheck
+
+	obj, err := o.Object(id)
+	is.NoErr(err)
+	is.Equal(obj.ID, id)
+	is.Equal(obj.Type, "blob")
+	is.Equal(obj.Size, int64(12))
+	is.Equal(string(obj.Data), "hello\nworld\n")
+
+	obj, err = o.Object(empty)
+	is.NoErr(err)
+	is.Equal(len(obj.Data), 0)
+
+	size, err := o.ObjectSize(id)
+	is.NoErr(err)
+	is.Equal(size, int64(12))
+
+	_, err = o.Object("0123456789012345678901234567890123456789")
+	is.True(errors.Is(err, ErrRevisionNotExist))
+
+	// The process is still usable after a missing object.
+	obj, err = o.Object(id)
+	is.NoErr(err)
+	is.Equal(string(obj.Data), "hello\nworld\n")
+
+	_, err = o.Object(id + "\n" + id)
+	is.True(err != nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj, err := o.Object(id)
+			if err != nil || string(obj.Data) != "hello\nworld\n" {
+				t.Errorf("concurrent read: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	o.mu.Lock()
+	is.True(len(o.idle) <= 2)
+	o.mu.Unlock()
+
+	is.NoErr(o.Close())
+	_, err = o.Object(id)
+	is.True(errors.Is(err, ErrObjectReaderClosed))
+}
+
+func TestObjectReaderFiles(t *testing.T) {
+	is := is.New(t)
+	path := filepath.Join(t.TempDir(), "repo")
+	r, err := Init(path, false)
+	is.NoErr(err)
+	is.NoErr(os.WriteFile(filepath.Join(path, "README.md"), []byte("# Project\n"), 0o600))
+	cmd := exec.Command("git", "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "-q", "--no-gpg-sign", "-m", "first")
+	cmd.Dir = path
+	add := exec.Command("git", "add", "README.md")
+	add.Dir = path
+	is.NoErr(add.Run())
+	is.NoErr(cmd.Run())
+
+	o := NewObjectReader(path, 1)
+	defer o.Close() // nolint: errcheck
+
+	tree, err := r.WithObjectReader(o).TreePath(nil, "")
+	is.NoErr(err)
+	ents, err := tree.Entries()
+	is.NoErr(err)
+	is.Equal(len(ents), 1)
+	is.Equal(ents[0].Size(), int64(10))
+	bts, err := ents[0].Contents()
+	is.NoErr(err)
+	is.Equal(string(bts), "# Project\n")
+	bin, err := ents[0].File().IsBinary()
+	is.NoErr(err)
+	is.True(!bin)
+
+	// The files are read through the object reader.
+	is.NoErr(o.Close())
+	_, err = ents[0].Contents()
+	is.True(errors.Is(err, ErrObjectReaderClosed))
+}
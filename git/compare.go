@@ -0,0 +1,118 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aymanbagabas/git-module"
+)
+
+// Comparison is the result of comparing two revisions.
+type Comparison struct {
+	// Base and Head are the commit hashes of the compared revisions.
+	Base string
+	Head string
+	// MergeBase is the best common ancestor of the revisions. It's empty if
+	// they don't share any history.
+	MergeBase string
+	// Ahead is the number of commits of head that aren't reachable from
+	// base, and Behind the number of commits of base that aren't reachable
+	// from head. The revisions have diverged when both are non-zero.
+	Ahead  int64
+	Behind int64
+	// Commits are the commits of head that aren't reachable from base,
+	// newest first, i.e. git log base..head. The list is truncated when
+	// there are more than Ahead commits.
+	Commits Commits
+	// DiffStat is the summary of the changes of head since it diverged from
+	// base, i.e. git diff --stat base...head. It's empty if the revisions
+	// don't share any history.
+	DiffStat string
+}
+
+// Diverged returns true if both revisions have commits the other one doesn't
+// have.
+func (c *Comparison) Diverged() bool {
+	return c.Ahead > 0 && c.Behind > 0
+}
+
+// Summary describes how head compares to base, e.g. "dev is 2 commits ahead
+// of main". base and head are the names of the revisions.
+func (c *Comparison) Summary(base, head string) string {
+	switch {
+	case c.Ahead == 0 && c.Behind == 0:
+		return fmt.Sprintf("%s and %s are identical.", base, head)
+	case c.Behind == 0:
+		return fmt.Sprintf("%s is %s ahead of %s.", head, pluralCommits(c.Ahead), base)
+	case c.Ahead == 0:
+		return fmt.Sprintf("%s is %s behind %s.", head, pluralCommits(c.Behind), base)
+	case c.MergeBase == "":
+		return fmt.Sprintf("%s and %s don't share any history.", base, head)
+	default:
+		return fmt.Sprintf("%s is %s ahead and %s behind %s, they have diverged.", head, pluralCommits(c.Ahead), pluralCommits(c.Behind), base)
+	}
+}
+
+func pluralCommits(n int64) string {
+	if n == 1 {
+		return "1 commit"
+	}
+	return fmt.Sprintf("%d commits", n)
+}
+
+// ResolveCommit returns the hash of the commit rev points to. It returns
+// ErrRevisionNotExist if rev isn't a commit.
+func (r *Repository) ResolveCommit(rev string) (string, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", ErrRevisionNotExist
+	}
+
+	out, err := NewCommand("rev-parse", "--verify", "--quiet", "--end-of-options", rev+"^{commit}").RunInDir(r.Path)
+	if err != nil {
+		return "", ErrRevisionNotExist
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Compare compares the revisions base and head. At most maxCommits commits
+// are listed, and at most maxFiles files in the diffstat. 0 lists all of
+// them.
+func (r *Repository) Compare(base, head string, maxCommits, maxFiles int) (*Comparison, error) {
+	b, err := r.ResolveCommit(base)
+	if err != nil {
+		return nil, err
+	}
+	h, err := r.ResolveCommit(head)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Comparison{Base: b, Head: h}
+	c.Ahead, c.Behind, err = r.AheadBehind(b, h)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Ahead > 0 {
+		commits, err := r.Log(b+".."+h, git.LogOptions{MaxCount: maxCommits})
+		if err != nil {
+			return nil, err
+		}
+		c.Commits = commits
+	}
+
+	// git merge-base exits with 1 when there's no common ancestor.
+	out, err := NewCommand("merge-base", b, h).RunInDir(r.Path)
+	if err != nil {
+		return c, nil
+	}
+	c.MergeBase = strings.TrimSpace(string(out))
+
+	c.DiffStat, err = r.DiffStat(maxFiles, c.MergeBase, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
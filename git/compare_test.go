@@ -0,0 +1,26 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestComparisonSummary(t *testing.T) {
+	is := is.New(t)
+	cases := []struct {
+		c    Comparison
+		want string
+	}{
+		{Comparison{MergeBase: "a"}, "main and dev are identical."},
+		{Comparison{Ahead: 1, MergeBase: "a"}, "dev is 1 commit ahead of main."},
+		{Comparison{Behind: 3, MergeBase: "a"}, "dev is 3 commits behind main."},
+		{Comparison{Ahead: 2, Behind: 1, MergeBase: "a"}, "dev is 2 commits ahead and 1 commit behind main, they have diverged."},
+		{Comparison{Ahead: 2, Behind: 1}, "main and dev don't share any history."},
+	}
+	for _, c := range cases {
+		is.Equal(c.c.Summary("main", "dev"), c.want)
+	}
+	is.True(cases[3].c.Diverged())
+	is.True(!cases[1].c.Diverged())
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Contributor is a commit author and the number of commits they authored as
+// reported by git shortlog.
+type Contributor struct {
+	// Name is the name of the author.
+	Name string `json:"name"`
+	// Email is the email of the author.
+	Email string `json:"email"`
+	// Commits is the number of commits authored.
+	Commits int64 `json:"commits"`
+}
+
+// Contributors returns the authors of the commits reachable from ref, sorted
+// by number of commits. Authors are mapped using the .mailmap file of ref if
+// there's one.
+func (r *Repository) Contributors(ref string) ([]Contributor, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid ref: %q", ref)
+	}
+
+	// Repositories are bare, point git at the mailmap of the ref being
+	// summarized. Missing mailmap blobs are ignored.
+	out, err := NewCommand("-c", "mailmap.blob="+ref+":.mailmap",
+		"shortlog", "-sne", ref, "--").RunInDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseShortlog(out), nil
+}
+
+// parseShortlog parses the output of git shortlog -sne, lines are of the form
+// "<count>\t<name> <<email>>".
+func parseShortlog(buf []byte) []Contributor {
+	contributors := make([]Contributor, 0)
+	for _, line := range strings.Split(string(buf), "\n") {
+		count, author, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.ParseInt(count, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		c := Contributor{Name: author, Commits: n}
+		if i := strings.LastIndex(author, " <"); i >= 0 && strings.HasSuffix(author, ">") {
+			c.Name = author[:i]
+			c.Email = author[i+2 : len(author)-1]
+		}
+		contributors = append(contributors, c)
+	}
+
+	return contributors
+}
@@ -0,0 +1,18 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseShortlog(t *testing.T) {
+	is := is.New(t)
+	out := "    12\tJohn Doe <john@example.com>\n     3\tJane <j@example.com>\n     1\tNo Email <>\n"
+	is.Equal(parseShortlog([]byte(out)), []Contributor{
+		{Name: "John Doe", Email: "john@example.com", Commits: 12},
+		{Name: "Jane", Email: "j@example.com", Commits: 3},
+		{Name: "No Email", Email: "", Commits: 1},
+	})
+	is.Equal(len(parseShortlog(nil)), 0)
+}
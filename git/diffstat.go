@@ -0,0 +1,22 @@
+package git
+
+import "strconv"
+
+// DiffStat returns the summary of the changes between revisions as shown by
+// git diff --stat, e.g. DiffStat(0, "a", "b") or DiffStat(0, "a...b"). At
+// most maxFiles files are listed, 0 lists all of them.
+func (r *Repository) DiffStat(maxFiles int, revs ...string) (string, error) {
+	args := []string{"diff", "--stat=72"}
+	if maxFiles > 0 {
+		args = append(args, "--stat-count="+strconv.Itoa(maxFiles))
+	}
+	args = append(args, revs...)
+	args = append(args, "--")
+
+	out, err := NewCommand(args...).RunInDir(r.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
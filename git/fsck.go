@@ -0,0 +1,93 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// FsckResult is the result of checking the objects of a repository with git
+// fsck.
+type FsckResult struct {
+	// Dangling lists the objects that aren't reachable from any ref, e.g.
+	// "dangling blob <hash>". They're harmless and removed by gc.
+	Dangling []string `json:"dangling"`
+	// Warnings lists the objects that are valid but malformed, e.g. trees
+	// with zero-padded file modes.
+	Warnings []string `json:"warnings"`
+	// Problems lists the broken and missing objects, and other errors.
+	Problems []string `json:"problems"`
+}
+
+// OK returns true if git fsck didn't report any problems.
+func (r *FsckResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Fsck checks the connectivity and validity of the objects of the
+// repository. Corruptions are reported in the result, an error is only
+// returned if git fsck couldn't run.
+func (r *Repository) Fsck(ctx context.Context) (*FsckResult, error) {
+	var stdout, stderr bytes.Buffer
+	// Checking large repositories takes a while, don't time out.
+	err := NewCommand("fsck", "--no-progress").WithContext(ctx).WithTimeout(-1).
+		RunInDirWithOptions(r.Path, RunInDirOptions{
+			Stdout: &stdout,
+			Stderr: &stderr,
+		})
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// git fsck exits with a non-zero status when it finds problems.
+	res := parseFsck(stdout.Bytes(), stderr.Bytes())
+	if err != nil && res.OK() {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// parseFsck parses the output of git fsck. Entries spanning several lines,
+// e.g. "broken link from", are continued by indented lines.
+func parseFsck(stdout, stderr []byte) *FsckResult {
+	res := &FsckResult{
+		Dangling: make([]string, 0),
+		Warnings: make([]string, 0),
+		Problems: make([]string, 0),
+	}
+
+	var last *[]string
+	for _, line := range strings.Split(string(stdout)+string(stderr), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		continued := line[0] == ' ' || line[0] == '\t'
+		// Collapse the alignment of multi-line entries.
+		line = strings.Join(strings.Fields(line), " ")
+		if continued {
+			if last != nil && len(*last) > 0 {
+				(*last)[len(*last)-1] += " " + line
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "notice:"), strings.HasPrefix(line, "Checking "):
+			// e.g. "notice: HEAD points to an unborn branch".
+			last = nil
+			continue
+		case strings.HasPrefix(line, "dangling "):
+			last = &res.Dangling
+		case strings.HasPrefix(line, "warning"):
+			last = &res.Warnings
+		default:
+			last = &res.Problems
+		}
+		*last = append(*last, line)
+	}
+
+	return res
+}
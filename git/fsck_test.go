@@ -0,0 +1,30 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseFsck(t *testing.T) {
+	is := is.New(t)
+	stdout := "dangling blob 587be6b4c3f93f93c489c0111bba5596147a26cb\n" +
+		"missing blob dab306f45e6a154ab0fe50d67298f165cfc75392\n" +
+		"broken link from    tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"              to    blob dab306f45e6a154ab0fe50d67298f165cfc75392\n"
+	stderr := "notice: HEAD points to an unborn branch (master)\n" +
+		"warning in tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904: zeroPaddedFilemode: contains zero-padded file modes\n"
+
+	res := parseFsck([]byte(stdout), []byte(stderr))
+	is.Equal(res.Dangling, []string{"dangling blob 587be6b4c3f93f93c489c0111bba5596147a26cb"})
+	is.Equal(res.Warnings, []string{"warning in tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904: zeroPaddedFilemode: contains zero-padded file modes"})
+	is.Equal(res.Problems, []string{
+		"missing blob dab306f45e6a154ab0fe50d67298f165cfc75392",
+		"broken link from tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904 to blob dab306f45e6a154ab0fe50d67298f165cfc75392",
+	})
+	is.True(!res.OK())
+
+	res = parseFsck(nil, []byte("notice: No default references\n"))
+	is.True(res.OK())
+	is.Equal(len(res.Dangling), 0)
+}
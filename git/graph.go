@@ -0,0 +1,112 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GraphCommit is a commit of a commit graph.
+type GraphCommit struct {
+	Hash    string   `json:"hash"`
+	Parents []string `json:"parents"`
+	// Lane is the column the commit is drawn in, starting at 0. Edges go
+	// from a commit to the lanes of its parents.
+	Lane int `json:"lane"`
+}
+
+// Graph returns up to limit commits reachable from ref in topological
+// order, skipping the first skip commits, with the lanes to draw them in.
+// Lanes are assigned from the tip of ref so they're stable across pages.
+// more is true if there are commits after the returned ones.
+func (r *Repository) Graph(ref string, skip, limit int) (commits []GraphCommit, more bool, err error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, false, fmt.Errorf("invalid ref: %q", ref)
+	}
+
+	out, err := NewCommand("log", "--topo-order", "--parents", "--format=%H %P",
+		"--max-count="+strconv.Itoa(skip+limit+1), ref, "--").RunInDir(r.Path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	commits = assignLanes(parseGraph(out))
+	if len(commits) <= skip {
+		return []GraphCommit{}, false, nil
+	}
+	commits = commits[skip:]
+	if len(commits) > limit {
+		return commits[:limit], true, nil
+	}
+
+	return commits, false, nil
+}
+
+// parseGraph parses the output of git log --format="%H %P".
+func parseGraph(buf []byte) []GraphCommit {
+	commits := make([]GraphCommit, 0)
+	for _, line := range strings.Split(string(buf), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		commits = append(commits, GraphCommit{Hash: fields[0], Parents: fields[1:]})
+	}
+
+	return commits
+}
+
+// assignLanes assigns lanes to commits in topological order. A commit takes
+// the leftmost lane waiting for it, its first parent continues in that lane
+// and other parents get the leftmost free lanes.
+func assignLanes(commits []GraphCommit) []GraphCommit {
+	// lanes holds the commit each lane is waiting for, free lanes are empty.
+	var lanes []string
+	freeLane := func() int {
+		for i, h := range lanes {
+			if h == "" {
+				return i
+			}
+		}
+		lanes = append(lanes, "")
+		return len(lanes) - 1
+	}
+
+	for i, c := range commits {
+		lane := -1
+		for j, h := range lanes {
+			if h != c.Hash {
+				continue
+			}
+			if lane < 0 {
+				lane = j
+			}
+			lanes[j] = ""
+		}
+		if lane < 0 {
+			lane = freeLane()
+		}
+		commits[i].Lane = lane
+
+		for k, p := range c.Parents {
+			if k == 0 {
+				lanes[lane] = p
+				continue
+			}
+			waiting := false
+			for _, h := range lanes {
+				waiting = waiting || h == p
+			}
+			if !waiting {
+				lanes[freeLane()] = p
+			}
+		}
+
+		// Drop trailing free lanes to keep the graph narrow.
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+	}
+
+	return commits
+}
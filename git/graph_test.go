@@ -0,0 +1,34 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAssignLanes(t *testing.T) {
+	is := is.New(t)
+	// m merges b into a, both branch off r.
+	//
+	//	m
+	//	|\
+	//	a b
+	//	|/
+	//	r
+	out := "m a b\na r\nb r\nr\n"
+	is.Equal(assignLanes(parseGraph([]byte(out))), []GraphCommit{
+		{Hash: "m", Parents: []string{"a", "b"}, Lane: 0},
+		{Hash: "a", Parents: []string{"r"}, Lane: 0},
+		{Hash: "b", Parents: []string{"r"}, Lane: 1},
+		{Hash: "r", Parents: []string{}, Lane: 0},
+	})
+
+	// Unrelated histories get their own lanes.
+	out = "x y\ny\nz\n"
+	is.Equal(assignLanes(parseGraph([]byte(out))), []GraphCommit{
+		{Hash: "x", Parents: []string{"y"}, Lane: 0},
+		{Hash: "y", Parents: []string{}, Lane: 0},
+		{Hash: "z", Parents: []string{}, Lane: 0},
+	})
+	is.Equal(len(parseGraph(nil)), 0)
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ObjectStats represents the object database statistics of a repository as
+// reported by git count-objects.
+type ObjectStats struct {
+	// Count is the number of loose objects.
+	Count int64 `json:"count"`
+	// Size is the disk space consumed by loose objects in bytes.
+	Size int64 `json:"size"`
+	// InPack is the number of packed objects.
+	InPack int64 `json:"in_pack"`
+	// Packs is the number of packs.
+	Packs int64 `json:"packs"`
+	// SizePack is the disk space consumed by packs in bytes.
+	SizePack int64 `json:"size_pack"`
+	// PrunePackable is the number of loose objects that are also present in
+	// packs.
+	PrunePackable int64 `json:"prune_packable"`
+	// Garbage is the number of files in the object database that are neither
+	// valid loose objects nor valid packs.
+	Garbage int64 `json:"garbage"`
+	// SizeGarbage is the disk space consumed by garbage files in bytes.
+	SizeGarbage int64 `json:"size_garbage"`
+}
+
+// Objects returns the total number of loose and packed objects.
+func (s ObjectStats) Objects() int64 {
+	return s.Count + s.InPack
+}
+
+// TotalSize returns the total disk space consumed by the object database in
+// bytes.
+func (s ObjectStats) TotalSize() int64 {
+	return s.Size + s.SizePack + s.SizeGarbage
+}
+
+// LooseRatio returns the ratio of loose objects to all objects. It returns 0
+// if the repository has no objects.
+func (s ObjectStats) LooseRatio() float64 {
+	total := s.Objects()
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Count) / float64(total)
+}
+
+// CountObjects returns the object database statistics of the repository.
+func (r *Repository) CountObjects() (*ObjectStats, error) {
+	out, err := NewCommand("count-objects", "-v").RunInDir(r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCountObjects(out), nil
+}
+
+// parseCountObjects parses the output of git count-objects -v. Sizes are
+// reported in KiB and converted to bytes.
+func parseCountObjects(buf []byte) *ObjectStats {
+	var s ObjectStats
+	for _, line := range strings.Split(string(buf), "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "count":
+			s.Count = n
+		case "size":
+			s.Size = n * 1024
+		case "in-pack":
+			s.InPack = n
+		case "packs":
+			s.Packs = n
+		case "size-pack":
+			s.SizePack = n * 1024
+		case "prune-packable":
+			s.PrunePackable = n
+		case "garbage":
+			s.Garbage = n
+		case "size-garbage":
+			s.SizeGarbage = n * 1024
+		}
+	}
+
+	return &s
+}
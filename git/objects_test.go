@@ -0,0 +1,58 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseCountObjects(t *testing.T) {
+	cases := []struct {
+		in   string
+		want ObjectStats
+	}{
+		{
+			in: "",
+		},
+		{
+			in: `count: 12
+size: 48
+in-pack: 300
+packs: 2
+size-pack: 1024
+prune-packable: 1
+garbage: 0
+size-garbage: 0
+`,
+			want: ObjectStats{
+				Count:         12,
+				Size:          48 * 1024,
+				InPack:        300,
+				Packs:         2,
+				SizePack:      1024 * 1024,
+				PrunePackable: 1,
+			},
+		},
+		{
+			in: "count: 3\nsize: 12\nwarning: garbage found: ./objects/foo\ngarbage: 1\nsize-garbage: 4\n",
+			want: ObjectStats{
+				Count:       3,
+				Size:        12 * 1024,
+				Garbage:     1,
+				SizeGarbage: 4 * 1024,
+			},
+		},
+	}
+
+	is := is.New(t)
+	for _, c := range cases {
+		got := parseCountObjects([]byte(c.in))
+		is.Equal(*got, c.want)
+	}
+}
+
+func TestObjectStatsLooseRatio(t *testing.T) {
+	is := is.New(t)
+	is.Equal(ObjectStats{}.LooseRatio(), float64(0))
+	is.Equal(ObjectStats{Count: 1, InPack: 3}.LooseRatio(), 0.25)
+}
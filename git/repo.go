@@ -21,6 +21,9 @@ type Repository struct {
 	*git.Repository
 	Path   string
 	IsBare bool
+
+	// objects reads the blobs of the repository's trees when set.
+	objects *ObjectReader
 }
 
 // Clone clones a repository.
@@ -62,6 +65,14 @@ func Open(path string) (*Repository, error) {
 	}, nil
 }
 
+// WithObjectReader returns a copy of the repository that reads the contents
+// and sizes of the files in its trees through o.
+func (r *Repository) WithObjectReader(o *ObjectReader) *Repository {
+	rr := *r
+	rr.objects = o
+	return &rr
+}
+
 // HEAD returns the HEAD reference for a repository.
 func (r *Repository) HEAD() (*Reference, error) {
 	rn, err := r.Repository.SymbolicRef(git.SymbolicRefOptions{Name: "HEAD"})
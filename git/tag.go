@@ -1,6 +1,47 @@
 package git
 
-import "github.com/aymanbagabas/git-module"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aymanbagabas/git-module"
+)
 
 // Tag is a git tag.
 type Tag = git.Tag
+
+// CreateTag creates the tag name pointing to the commit id. The tag is
+// annotated with message, by tagger, unless message is empty. It fails if the
+// tag exists and force is false.
+func (r *Repository) CreateTag(ctx context.Context, name, id, message string, tagger *git.Signature, force bool) error {
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid tag name: %q", name)
+	}
+	if _, err := NewCommand("check-ref-format", RefsTags+name).WithContext(ctx).RunInDir(r.Path); err != nil {
+		return fmt.Errorf("invalid tag name: %q", name)
+	}
+
+	cmd := NewCommand("tag").WithContext(ctx)
+	if force {
+		cmd.AddArgs("--force")
+	}
+	if message != "" {
+		cmd.AddArgs("--annotate", "--message", message)
+		if tagger != nil {
+			cmd.AddCommitter(tagger)
+		}
+	}
+	cmd.AddArgs(name, id)
+
+	var stderr bytes.Buffer
+	if err := cmd.RunInDirWithOptions(r.Path, RunInDirOptions{Stderr: &stderr}); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"sort"
+	"sync"
 
 	"github.com/aymanbagabas/git-module"
 )
@@ -23,6 +24,10 @@ type TreeEntry struct {
 	*git.TreeEntry
 	// path is the full path of the file
 	path string
+	// objects reads the contents and size of the file when set.
+	objects  *ObjectReader
+	sizeOnce sync.Once
+	size     int64
 }
 
 // Entries is a wrapper around git.Entries.
@@ -104,6 +109,7 @@ func (t *Tree) Entries() (Entries, error) {
 		ret[i] = &TreeEntry{
 			TreeEntry: e,
 			path:      filepath.Join(t.Path, e.Name()),
+			objects:   t.objects(),
 		}
 	}
 	return ret, nil
@@ -118,9 +124,17 @@ func (t *Tree) TreeEntry(path string) (*TreeEntry, error) {
 	return &TreeEntry{
 		TreeEntry: entry,
 		path:      filepath.Join(t.Path, entry.Name()),
+		objects:   t.objects(),
 	}, nil
 }
 
+func (t *Tree) objects() *ObjectReader {
+	if t.Repository == nil {
+		return nil
+	}
+	return t.Repository.objects
+}
+
 const sniffLen = 8000
 
 // IsBinary detects if data is a binary value based on:
@@ -153,6 +167,13 @@ func IsBinary(r io.Reader) (bool, error) {
 
 // IsBinary returns true if the file is binary.
 func (f *File) IsBinary() (bool, error) {
+	if f.Entry.objects != nil {
+		bts, err := f.Contents()
+		if err != nil {
+			return false, err
+		}
+		return IsBinary(bytes.NewReader(bts))
+	}
 	stdout := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
 	err := f.Pipeline(stdout, stderr)
@@ -174,6 +195,17 @@ func (e *TreeEntry) Mode() fs.FileMode {
 	}
 }
 
+// Size returns the size of the file.
+func (e *TreeEntry) Size() int64 {
+	if e.objects == nil || e.IsTree() {
+		return e.TreeEntry.Size()
+	}
+	e.sizeOnce.Do(func() {
+		e.size, _ = e.objects.ObjectSize(e.ID().String())
+	})
+	return e.size
+}
+
 // File returns the file for the TreeEntry.
 func (e *TreeEntry) File() *File {
 	b := e.Blob()
@@ -190,5 +222,12 @@ func (e *TreeEntry) Contents() ([]byte, error) {
 
 // Contents returns the contents of the file.
 func (f *File) Contents() ([]byte, error) {
+	if f.Entry.objects != nil {
+		obj, err := f.Entry.objects.Object(f.Entry.ID().String())
+		if err != nil {
+			return nil, err
+		}
+		return obj.Data, nil
+	}
 	return f.Blob.Bytes()
 }
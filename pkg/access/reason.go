@@ -0,0 +1,94 @@
+package access
+
+// Reason is the rule that contributed an access level.
+type Reason string
+
+const (
+	// ReasonAdminKey is used when the public key is one of the server's
+	// initial admin keys.
+	ReasonAdminKey Reason = "admin-key"
+
+	// ReasonAdmin is used when the user is a server admin.
+	ReasonAdmin Reason = "admin"
+
+	// ReasonOwner is used when the user owns the repository.
+	ReasonOwner Reason = "owner"
+
+	// ReasonCollaborator is used when the user is a collaborator of the
+	// repository.
+	ReasonCollaborator Reason = "collaborator"
+
+	// ReasonPrivate is used when the repository is private and the user has
+	// no other access to it.
+	ReasonPrivate Reason = "private"
+
+	// ReasonUser is used when an authenticated user accesses a public
+	// repository.
+	ReasonUser Reason = "user"
+
+	// ReasonAnon is used when the anonymous access level applies.
+	ReasonAnon Reason = "anon-access"
+
+	// ReasonAnonymousRepo is used when the repository is one of the
+	// repositories anonymous users can read.
+	ReasonAnonymousRepo Reason = "anonymous-repo"
+
+	// ReasonKeyless is used when a connection without a public key is made
+	// and keyless access is disabled.
+	ReasonKeyless Reason = "keyless"
+
+	// ReasonExecHook is used when the auth exec hook decided the access
+	// level of the public key.
+	ReasonExecHook Reason = "exec-hook"
+
+	// ReasonRepoNotFound is used when the repository doesn't exist and
+	// authenticated users are allowed to create it.
+	ReasonRepoNotFound Reason = "repo-not-found"
+
+	// ReasonDeployKey is used when the public key is a deploy key. Deploy
+	// keys only have access to their repository.
+	ReasonDeployKey Reason = "deploy-key"
+
+	// ReasonPending is used when the user enrolled themselves and hasn't
+	// been approved by an admin yet.
+	ReasonPending Reason = "pending"
+)
+
+// String returns the string representation of the reason.
+func (r Reason) String() string {
+	return string(r)
+}
+
+// Description returns a human readable explanation of the reason.
+func (r Reason) Description() string {
+	switch r {
+	case ReasonAdminKey:
+		return "the public key is a server admin key"
+	case ReasonAdmin:
+		return "the user is a server admin"
+	case ReasonOwner:
+		return "the user owns the repository"
+	case ReasonCollaborator:
+		return "the user is a collaborator of the repository"
+	case ReasonPrivate:
+		return "the repository is private"
+	case ReasonUser:
+		return "authenticated users have read-only access to public repositories"
+	case ReasonAnon:
+		return "the anonymous access level applies"
+	case ReasonAnonymousRepo:
+		return "the repository can be read anonymously"
+	case ReasonKeyless:
+		return "keyless access is disabled"
+	case ReasonExecHook:
+		return "the auth exec hook decided the access level"
+	case ReasonRepoNotFound:
+		return "the repository doesn't exist and can be created by the user"
+	case ReasonDeployKey:
+		return "the public key is a deploy key of a repository"
+	case ReasonPending:
+		return "the user is waiting for an admin to approve them"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,56 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// maxCommitActivityRange is how far back commit activity goes, which keeps
+// it fast on large histories.
+const maxCommitActivityRange = 366 * 24 * time.Hour
+
+// CommitActivity returns the number of commits of a repository at ref,
+// bucketed by interval, from since to now. An empty ref means the default
+// branch. A zero since, or one further back than a year, means a year ago. It
+// returns nil if the repository has no commits.
+//
+// Activity is cached by the commit ref points to and the range, so it's only
+// computed again once the branch moves or a new bucket starts.
+func (d *Backend) CommitActivity(_ context.Context, repo proto.Repository, ref string, since time.Time, interval git.ActivityInterval) ([]git.ActivityBucket, error) {
+	if _, err := git.ParseActivityInterval(string(interval)); err != nil {
+		return nil, err
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := resolveCommit(r, ref)
+	if err != nil || hash == "" {
+		return nil, err
+	}
+
+	now := time.Now()
+	if oldest := now.Add(-maxCommitActivityRange); since.Before(oldest) {
+		since = oldest
+	}
+	since, until := interval.Start(since), interval.Start(now)
+
+	key := fmt.Sprintf("%s:%s:%d:%d", hash, interval, since.Unix(), until.Unix())
+	if a, ok := d.cache.GetActivity(key); ok {
+		return a, nil
+	}
+
+	activity, err := r.CommitActivity(hash, since, until, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	d.cache.SetActivity(key, activity)
+	return activity, nil
+}
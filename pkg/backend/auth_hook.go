@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/anmitsu/go-shlex"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/crypto/ssh"
+)
+
+// authHookTimeout is how long the auth exec hook can run before access is
+// denied.
+const authHookTimeout = 10 * time.Second
+
+// authHookResult is the decision of the auth exec hook. Decided is false
+// when the hook defers to the built-in rules.
+type authHookResult struct {
+	level   access.AccessLevel
+	decided bool
+}
+
+// newAuthHookCache returns the cache of the auth exec hook results, or nil
+// if they aren't cached.
+func newAuthHookCache(ttl time.Duration) *expirable.LRU[string, authHookResult] {
+	if ttl <= 0 {
+		return nil
+	}
+	return expirable.NewLRU[string, authHookResult](1000, nil, ttl)
+}
+
+// authHookAccessLevel returns the access level the auth exec hook gives a
+// public key for a repository. It returns false if the hook isn't
+// configured or defers to the built-in rules. The hook only authorizes
+// repositories, it isn't run for server-wide lookups without one, so it
+// can't make a key a server admin.
+func (d *Backend) authHookAccessLevel(ctx context.Context, repo string, user proto.User, pk ssh.PublicKey) (access.AccessLevel, bool) {
+	if d.cfg.Auth.ExecHook == "" || pk == nil || repo == "" {
+		return access.NoAccess, false
+	}
+
+	var username string
+	if user != nil {
+		username = user.Username()
+	}
+
+	fp := ssh.FingerprintSHA256(pk)
+	key := strings.Join([]string{fp, repo, username}, "\x00")
+	if d.authHookCache != nil {
+		if res, ok := d.authHookCache.Get(key); ok {
+			return res.level, res.decided
+		}
+	}
+
+	res, err := d.runAuthHook(ctx, fp, repo, username)
+	if err != nil {
+		d.logger.Error("auth exec hook failed", "fingerprint", fp, "repo", repo, "err", err)
+		// Don't cache failures caused by the request going away.
+		if ctx.Err() != nil {
+			return access.NoAccess, true
+		}
+	}
+
+	if d.authHookCache != nil {
+		d.authHookCache.Add(key, res)
+	}
+
+	return res.level, res.decided
+}
+
+// runAuthHook runs the auth exec hook. It fails closed: errors deny access.
+func (d *Backend) runAuthHook(ctx context.Context, fp, repo, username string) (authHookResult, error) {
+	denied := authHookResult{level: access.NoAccess, decided: true}
+	args, err := shlex.Split(d.cfg.Auth.ExecHook, true)
+	if err != nil || len(args) == 0 {
+		return denied, fmt.Errorf("invalid command: %q", d.cfg.Auth.ExecHook)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, authHookTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) // nolint: gosec
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("fingerprint %s\nrepo %s\nusername %s\n", fp, repo, username))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			// A non-zero exit status is how the hook denies access.
+			d.logger.Debug("auth exec hook denied access", "fingerprint", fp, "repo", repo, "status", exitErr.ExitCode(), "stderr", msg)
+			return denied, nil
+		}
+		if msg != "" {
+			return denied, fmt.Errorf("%w: %s", err, msg)
+		}
+		return denied, err
+	}
+
+	out := strings.TrimSpace(stdout.String())
+	if out == "" {
+		return authHookResult{}, nil
+	}
+
+	level := access.ParseAccessLevel(out)
+	if level < 0 {
+		return denied, fmt.Errorf("invalid access level: %q", out)
+	}
+
+	return authHookResult{level: level, decided: true}, nil
+}
+
+// contextPublicKey returns the public key of the connection if it belongs
+// to user, or if the user is unknown.
+func contextPublicKey(ctx context.Context, user proto.User) ssh.PublicKey {
+	pk := sshutils.PublicKeyFromContext(ctx)
+	if pk == nil || user == nil {
+		return pk
+	}
+	for _, k := range user.PublicKeys() {
+		if sshutils.KeysEqual(pk, k) {
+			return pk
+		}
+	}
+	return nil
+}
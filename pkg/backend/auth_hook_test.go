@@ -0,0 +1,79 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+const authHookScript = `#!/bin/sh
+in=$(cat)
+echo "$in" >> "$(dirname "$0")/calls"
+case "$in" in
+*"repo writable"*) echo read-write ;;
+*"repo denied"*) echo "go away" >&2; exit 1 ;;
+*"repo bogus"*) echo superuser ;;
+*"repo
+username"*) echo admin-access ;;
+esac
+`
+
+func TestAuthExecHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook is a shell script")
+	}
+
+	is := is.New(t)
+	dir := t.TempDir()
+	hook := filepath.Join(dir, "auth hook")
+	is.NoErr(os.WriteFile(hook, []byte(authHookScript), 0o755))
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Auth.ExecHook = `"` + hook + `" --realm git`
+	})
+
+	pk, _, err := sshutils.ParseAuthorizedKey("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBFb3KwQyPHlVURFNiVYc3+BjUFennJeMOq01X/cEiyj")
+	is.NoErr(err)
+
+	cases := []struct {
+		repo   string
+		level  access.AccessLevel
+		reason access.Reason
+	}{
+		{"writable", access.ReadWriteAccess, access.ReasonExecHook},
+		{"denied", access.NoAccess, access.ReasonExecHook},
+		{"bogus", access.NoAccess, access.ReasonExecHook},
+		// The hook prints nothing, the built-in rules apply.
+		{"other", access.ReadOnlyAccess, access.ReasonAnon},
+	}
+	for _, c := range cases {
+		level, reason := be.AccessLevelByPublicKeyWithReason(ctx, c.repo, pk)
+		is.Equal(level, c.level)
+		is.Equal(reason, c.reason)
+	}
+
+	// The hook isn't run without a repository, it can't grant server admin
+	// access.
+	level, reason := be.AccessLevelByPublicKeyWithReason(ctx, "", pk)
+	is.True(level < access.AdminAccess)
+	is.True(reason != access.ReasonExecHook)
+
+	calls, err := os.ReadFile(filepath.Join(dir, "calls"))
+	is.NoErr(err)
+	is.Equal(strings.Count(string(calls), "fingerprint "+`SHA256:`), 4)
+	is.True(strings.Contains(string(calls), "repo writable\nusername \n"))
+
+	// Results are cached.
+	is.Equal(be.AccessLevelByPublicKey(ctx, "writable", pk), access.ReadWriteAccess)
+	is.Equal(be.AccessLevelByPublicKey(ctx, "denied", pk), access.NoAccess)
+	again, err := os.ReadFile(filepath.Join(dir, "calls"))
+	is.NoErr(err)
+	is.Equal(string(again), string(calls))
+}
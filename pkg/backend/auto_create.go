@@ -0,0 +1,144 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// autoCreates records the repositories each user created by pushing in the
+// last hour.
+type autoCreates struct {
+	mu    sync.Mutex
+	times map[string][]time.Time
+}
+
+// reserve records a creation for key at now unless key already created max
+// repositories in the hour before now. The returned function removes the
+// record.
+func (a *autoCreates) reserve(key string, max int, now time.Time) (func(), bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.times == nil {
+		a.times = make(map[string][]time.Time)
+	}
+
+	times := a.times[key][:0]
+	for _, t := range a.times[key] {
+		if now.Sub(t) < time.Hour {
+			times = append(times, t)
+		}
+	}
+	if len(times) >= max {
+		a.times[key] = times
+		return nil, false
+	}
+
+	a.times[key] = append(times, now)
+	return func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		for i, t := range a.times[key] {
+			if t.Equal(now) {
+				a.times[key] = append(a.times[key][:i], a.times[key][i+1:]...)
+				break
+			}
+		}
+	}, true
+}
+
+// AutoCreateRepository creates a repository pushed to before it existed.
+// created is false if the repository was created by a concurrent push in the
+// meantime, in which case that repository is returned.
+//
+// Each user can create at most Git.MaxAutoCreatePerHour repositories this way
+// in an hour, further pushes to new repositories fail with
+// proto.ErrAutoCreateLimit. Pushes to existing repositories aren't limited.
+// Names matching Git.ReservedNames can't be created this way, pushes to them
+// fail with proto.ErrReservedName.
+func (d *Backend) AutoCreateRepository(ctx context.Context, name string, user proto.User) (r proto.Repository, created bool, err error) {
+	name = d.cfg.SanitizeRepo(name)
+	unlock := d.repoLocks.lock(name)
+	defer unlock()
+
+	// Concurrent first pushes converge on the repository created by the
+	// first one.
+	if r, err := d.Repository(ctx, name); err == nil {
+		return r, false, nil
+	}
+
+	if d.isReservedName(name) {
+		d.logger.Info("rejecting push to reserved name", "repo", name)
+		return nil, false, fmt.Errorf("%w: %s", proto.ErrReservedName, name)
+	}
+
+	release := func() {}
+	if max := d.cfg.Git.MaxAutoCreatePerHour; max > 0 {
+		var username string
+		if user != nil {
+			username = user.Username()
+		}
+
+		var ok bool
+		release, ok = d.autoCreates.reserve(username, max, time.Now())
+		if !ok {
+			d.logger.Warn("auto-create limit reached", "user", username, "repo", name, "limit", max)
+			return nil, false, fmt.Errorf("%w: at most %d per hour", proto.ErrAutoCreateLimit, max)
+		}
+	}
+
+	r, err = d.CreateRepository(ctx, name, user, proto.RepositoryOptions{})
+	if errors.Is(err, proto.ErrRepoExist) {
+		// Created by a server sharing the database.
+		release()
+		r, err = d.Repository(ctx, name)
+		return r, false, err
+	} else if err != nil {
+		release()
+		return nil, false, err
+	}
+
+	return r, true, nil
+}
+
+// isReservedName returns true if name matches one of Git.ReservedNames.
+func (d *Backend) isReservedName(name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range d.cfg.Git.ReservedNames {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteAutoCreatedRepository deletes a repository created by a push that
+// failed, unless a concurrent push to it already created references.
+func (d *Backend) DeleteAutoCreatedRepository(ctx context.Context, name string) error {
+	name = d.cfg.SanitizeRepo(name)
+	unlock := d.repoLocks.lock(name)
+	defer unlock()
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	rr, err := r.Open()
+	if err != nil {
+		return err
+	}
+
+	if refs, err := rr.References(); err == nil && len(refs) > 0 {
+		return nil
+	}
+
+	return d.DeleteRepository(ctx, name)
+}
@@ -0,0 +1,93 @@
+package backend_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestAutoCreateRepositoryConcurrent(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Git.MaxAutoCreatePerHour = 1
+	})
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+
+	const pushes = 16
+	var wg sync.WaitGroup
+	created := make(chan bool, pushes)
+	errs := make(chan error, pushes)
+	for i := 0; i < pushes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, ok, err := be.AutoCreateRepository(ctx, "ci/new", alice)
+			if err == nil && r.Name() != "ci/new" {
+				t.Errorf("unexpected repository %q", r.Name())
+			}
+			created <- ok
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(created)
+	close(errs)
+
+	// All pushes succeed and converge on the one repository, which only
+	// counts once against the auto-create limit.
+	for err := range errs {
+		is.NoErr(err)
+	}
+	var n int
+	for ok := range created {
+		if ok {
+			n++
+		}
+	}
+	is.Equal(n, 1)
+
+	repos, err := be.Repositories(ctx)
+	is.NoErr(err)
+	is.Equal(len(repos), 1)
+	is.Equal(repos[0].Name(), "ci/new")
+
+	// A failed first push deletes the repository while it's empty.
+	is.NoErr(be.DeleteAutoCreatedRepository(ctx, "ci/new"))
+	_, err = be.Repository(ctx, "ci/new")
+	is.True(err != nil)
+}
+
+func TestAutoCreateRepositoryReservedName(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Git.ReservedNames = []string{"admin", "system/*"}
+	})
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+
+	for _, name := range []string{"admin", "Admin.git", "system/config"} {
+		_, _, err := be.AutoCreateRepository(ctx, name, alice)
+		is.True(errors.Is(err, proto.ErrReservedName))
+	}
+	_, err = be.Repository(ctx, "admin")
+	is.True(errors.Is(err, proto.ErrRepoNotFound))
+
+	// Patterns match whole names, and explicit creations aren't limited.
+	_, created, err := be.AutoCreateRepository(ctx, "admin-tools", alice)
+	is.NoErr(err)
+	is.True(created)
+	_, err = be.CreateRepository(ctx, "admin", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+	_, created, err = be.AutoCreateRepository(ctx, "admin", alice)
+	is.NoErr(err)
+	is.True(!created)
+}
@@ -0,0 +1,35 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestAutoCreatesReserve(t *testing.T) {
+	is := is.New(t)
+	var a autoCreates
+	now := time.Now()
+
+	_, ok := a.reserve("alice", 2, now.Add(-time.Hour))
+	is.True(ok)
+	_, ok = a.reserve("alice", 2, now.Add(-time.Minute))
+	is.True(ok)
+	// Creations older than an hour don't count.
+	release, ok := a.reserve("alice", 2, now)
+	is.True(ok)
+	_, ok = a.reserve("alice", 2, now)
+	is.True(!ok)
+
+	// Other users have their own limit.
+	_, ok = a.reserve("bob", 2, now)
+	is.True(ok)
+
+	// Failed creations are released.
+	release()
+	_, ok = a.reserve("alice", 2, now)
+	is.True(ok)
+	_, ok = a.reserve("alice", 2, now)
+	is.True(!ok)
+}
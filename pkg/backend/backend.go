@@ -2,12 +2,17 @@ package backend
 
 import (
 	"context"
+	"sync"
 
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
 	"github.com/charmbracelet/soft-serve/pkg/store"
+	gsync "github.com/charmbracelet/soft-serve/pkg/sync"
 	"github.com/charmbracelet/soft-serve/pkg/task"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 )
 
 // Backend is the Soft Serve backend that handles users, repositories, and
@@ -20,19 +25,70 @@ type Backend struct {
 	logger  *log.Logger
 	cache   *cache
 	manager *task.Manager
+
+	// scheduler admits git operations across all transports.
+	scheduler *gsync.Scheduler
+
+	// notifier sends server event notifications, nil when disabled.
+	notifier notify.Notifier
+
+	// metadataMu guards the repository metadata files.
+	metadataMu sync.Mutex
+
+	// pushEmailMu prevents concurrent deliveries of the push email queue.
+	pushEmailMu sync.Mutex
+
+	// authHookCache caches the results of the auth exec hook, nil when
+	// they aren't cached.
+	authHookCache *expirable.LRU[string, authHookResult]
+
+	// keyUsage buffers the last-used times of public keys.
+	keyUsage keyUsage
+
+	// autoCreates limits the repositories users create by pushing.
+	autoCreates autoCreates
+
+	// repoLocks serializes concurrent creations of the same repository.
+	repoLocks repoLocks
+
+	// pushLocks blocks pushes while the history of a repository is
+	// rewritten.
+	pushLocks pushLocks
+
+	// hostKeys caches the SSH host keys.
+	hostKeys hostKeys
+
+	// storage is the decrypted view of the encrypted storage opened by the
+	// operations of the process.
+	storage storageView
+
+	// hooks are the embedder hooks called before git operations, nil when
+	// there are none.
+	hooks hooks.Hooks
 }
 
 // New returns a new Soft Serve backend.
 func New(ctx context.Context, cfg *config.Config, db *db.DB, st store.Store) *Backend {
 	logger := log.FromContext(ctx).WithPrefix("backend")
 	b := &Backend{
-		ctx:     ctx,
-		cfg:     cfg,
-		db:      db,
-		store:   st,
-		logger:  logger,
-		manager: task.NewManager(ctx),
+		ctx:           ctx,
+		cfg:           cfg,
+		db:            db,
+		store:         st,
+		logger:        logger,
+		manager:       task.NewManager(ctx),
+		authHookCache: newAuthHookCache(cfg.Auth.ExecHookCacheTTL),
+		scheduler: gsync.NewScheduler(
+			gsync.SchedulerPolicy(cfg.Git.Scheduler),
+			cfg.Git.MaxOperations,
+		),
+	}
+
+	notifier, err := notify.New(cfg.Notify)
+	if err != nil {
+		logger.Error("error creating notifier", "err", err)
 	}
+	b.notifier = notifier
 
 	// TODO: implement a proper caching interface
 	cache := newCache(b, 1000)
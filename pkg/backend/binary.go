@@ -0,0 +1,253 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/dustin/go-humanize"
+)
+
+// binaryLimitFile is the name of the file, inside the repository metadata
+// directory, that holds the binary limit of the repository.
+const binaryLimitFile = "binary-limit.json"
+
+// maxBinaryFilesListed is the number of files listed when a push goes over
+// the binary limit.
+const maxBinaryFilesListed = 5
+
+// BinaryLimit is the number of bytes of binary files a push can add to a
+// repository before the client is told to use Git LFS.
+type BinaryLimit struct {
+	// Threshold is the limit in bytes, 0 disables it.
+	Threshold int64 `json:"threshold"`
+	// Reject rejects pushes over the limit instead of warning about them.
+	Reject bool `json:"reject,omitempty"`
+}
+
+// binaryFile is a binary file added by a push.
+type binaryFile struct {
+	path string
+	size int64
+}
+
+// BinaryLimit returns the binary limit of a repository.
+func (d *Backend) BinaryLimit(ctx context.Context, repo string) (BinaryLimit, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return BinaryLimit{}, err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.readBinaryLimit(r.Name())
+}
+
+// SetBinaryLimit sets the binary limit of a repository. A zero threshold
+// removes it.
+func (d *Backend) SetBinaryLimit(ctx context.Context, repo string, limit BinaryLimit) error {
+	if limit.Threshold < 0 {
+		return fmt.Errorf("binary limit must be positive")
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	fp := d.repoMetadataPath(r.Name(), binaryLimitFile)
+	if limit.Threshold == 0 {
+		if err := os.Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	bts, err := json.MarshalIndent(limit, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(fp, bts)
+}
+
+// verifyBinaryLimit rejects pushes adding more binary bytes than the binary
+// limit of repositories set to reject them.
+func (d *Backend) verifyBinaryLimit(ctx context.Context, repo string, args []hooks.HookArg) error {
+	limit, err := d.BinaryLimit(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if limit.Threshold <= 0 || !limit.Reject {
+		return nil
+	}
+
+	return d.checkBinaryLimit(ctx, repo, args, limit)
+}
+
+// warnBinaryLimit warns the client about pushes adding more binary bytes than
+// the binary limit of repositories set to warn about them.
+func (d *Backend) warnBinaryLimit(ctx context.Context, stderr io.Writer, repo string, args []hooks.HookArg) {
+	limit, err := d.BinaryLimit(ctx, repo)
+	if err != nil {
+		d.logger.Error("error getting binary limit", "repo", repo, "err", err)
+		return
+	}
+	if limit.Threshold <= 0 || limit.Reject {
+		return
+	}
+
+	if err := d.checkBinaryLimit(ctx, repo, args, limit); err != nil {
+		fmt.Fprintf(stderr, "warning: %v\n", err) //nolint:errcheck
+	}
+}
+
+// checkBinaryLimit returns an error suggesting Git LFS if the push adds more
+// bytes of binary files than the limit.
+func (d *Backend) checkBinaryLimit(ctx context.Context, repo string, args []hooks.HookArg, limit BinaryLimit) error {
+	files, err := d.addedBinaryFiles(ctx, repo, args)
+	if err != nil {
+		return fmt.Errorf("failed to find binary files: %w", err)
+	}
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	if total <= limit.Threshold {
+		return nil
+	}
+
+	d.logger.Info("push over binary limit", "repo", repo, "size", total, "limit", limit.Threshold, "reject", limit.Reject)
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].size > files[j].size
+	})
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "push adds %s of binary files to %s, more than the limit of %s:",
+		humanize.IBytes(uint64(total)), repo, humanize.IBytes(uint64(limit.Threshold))) //nolint:gosec
+	for i, f := range files {
+		if i == maxBinaryFilesListed {
+			fmt.Fprintf(&s, "\n  ... and %d more", len(files)-i)
+			break
+		}
+		fmt.Fprintf(&s, "\n  %s (%s)", f.path, humanize.IBytes(uint64(f.size))) //nolint:gosec
+	}
+	pattern := "*" + path.Ext(files[0].path)
+	if pattern == "*" {
+		pattern = files[0].path
+	}
+	fmt.Fprintf(&s, "\nUse Git LFS for large binary files, e.g. git lfs track '%s'", pattern)
+
+	return errors.New(s.String())
+}
+
+// addedBinaryFiles returns the binary files added or modified by the commits
+// a push adds to a repository. Files are binary if git diff would say so,
+// following the attributes and heuristics of git. Blobs are counted once.
+func (d *Backend) addedBinaryFiles(ctx context.Context, repo string, args []hooks.HookArg) ([]binaryFile, error) {
+	revs := []string{"rev-list"}
+	for _, arg := range args {
+		if !git.IsZeroHash(arg.NewSha) {
+			revs = append(revs, arg.NewSha)
+		}
+	}
+	if len(revs) == 1 {
+		return nil, nil
+	}
+
+	// The refs aren't updated yet, commits reachable from them were already
+	// pushed.
+	rp := d.repoPath(repo)
+	out, err := git.NewCommand(append(revs, "--not", "--all")...).WithContext(ctx).WithTimeout(-1).RunInDir(rp)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	// Merge commits are skipped, their changes are counted in the merged
+	// commits.
+	var diff bytes.Buffer
+	if err := git.NewCommand("diff-tree", "--stdin", "-r", "-z", "--raw", "--numstat", "--no-renames", "--root", "--diff-filter=AM").
+		WithContext(ctx).WithTimeout(-1).
+		RunInDirWithOptions(rp, git.RunInDirOptions{
+			Stdin:  bytes.NewReader(out),
+			Stdout: &diff,
+		}); err != nil {
+		return nil, err
+	}
+
+	// The output of each commit is its hash, a raw entry and its path per
+	// changed file, then a numstat entry per changed file, "-\t-\t<path>"
+	// for binary files.
+	blobs := map[string]string{}
+	seen := map[string]bool{}
+	var ids, paths []string
+	toks := strings.Split(diff.String(), "\x00")
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		switch {
+		case strings.HasPrefix(tok, ":"):
+			// :<old mode> <new mode> <old id> <new id> <status>
+			fields := strings.Fields(tok)
+			if i+1 < len(toks) && len(fields) == 5 && fields[1] != "160000" {
+				blobs[toks[i+1]] = fields[3]
+			}
+			i++
+		case strings.HasPrefix(tok, "-\t-\t"):
+			fp := strings.TrimPrefix(tok, "-\t-\t")
+			if id, ok := blobs[fp]; ok && !seen[id] {
+				seen[id] = true
+				ids, paths = append(ids, id), append(paths, fp)
+			}
+		case !strings.Contains(tok, "\t"):
+			// A commit hash starts the changes of the next commit.
+			clear(blobs)
+		}
+	}
+
+	or := git.NewObjectReader(rp, 1)
+	defer or.Close() // nolint: errcheck
+	files := make([]binaryFile, 0, len(ids))
+	for i, id := range ids {
+		size, err := or.ObjectSize(id)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, binaryFile{path: paths[i], size: size})
+	}
+
+	return files, nil
+}
+
+// readBinaryLimit reads the binary limit of a repository. It must be called
+// with the metadata lock held.
+func (d *Backend) readBinaryLimit(repo string) (BinaryLimit, error) {
+	var limit BinaryLimit
+	bts, err := os.ReadFile(d.repoMetadataPath(repo, binaryLimitFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return limit, nil
+	} else if err != nil {
+		return limit, err
+	}
+
+	if err := json.Unmarshal(bts, &limit); err != nil {
+		return limit, fmt.Errorf("failed to decode binary limit: %w", err)
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,111 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// RenameBranch renames a branch of a repository. The default branch follows
+// the rename. Branches can't be renamed from or to a protected name, and only
+// repository admins can rename branches when branch deletion is disabled.
+//
+// The rename is validated like a push creating the new branch and deleting
+// the old one, with ValidatePreReceive. The custom git hooks of the
+// repository don't run.
+func (d *Backend) RenameBranch(ctx context.Context, repo proto.Repository, user proto.User, oldName, newName string) error {
+	if d.isProtectedBranch(oldName) {
+		return fmt.Errorf("cannot rename protected branch %q", oldName)
+	}
+	if d.isProtectedBranch(newName) {
+		return fmt.Errorf("cannot rename branch %q to protected branch %q", oldName, newName)
+	}
+
+	end, err := d.BeginPush(repo.Name())
+	if err != nil {
+		return err
+	}
+
+	defer end()
+
+	allow, err := d.AllowBranchDeletion(ctx, repo.Name())
+	if err != nil {
+		return err
+	}
+	if !allow && d.AccessLevelForUser(ctx, repo.Name(), user) < access.AdminAccess {
+		return fmt.Errorf("branch deletion is disabled for %s, ask an admin to rename %q", repo.Name(), oldName)
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return err
+	}
+
+	id, err := r.ShowRefVerify(git.RefsHeads + oldName)
+	if err != nil {
+		return git.ErrReferenceNotExist
+	}
+	if _, err := r.ShowRefVerify(git.RefsHeads + newName); err == nil {
+		return proto.ErrBranchExist
+	}
+
+	// The pre-receive checks find the user in the context, instead of the
+	// environment of the hook. Admins can rename branches when branch
+	// deletion is disabled, the deletion of the old name is only validated
+	// when it's allowed.
+	ctx = proto.WithUserContext(ctx, user)
+	args := []hooks.HookArg{{OldSha: git.ZeroID, NewSha: id, RefName: git.RefsHeads + newName}}
+	if allow {
+		args = append(args, hooks.HookArg{OldSha: id, NewSha: git.ZeroID, RefName: git.RefsHeads + oldName})
+	}
+	if err := d.ValidatePreReceive(ctx, repo.Name(), args); err != nil {
+		return err
+	}
+
+	head, _ := r.SymbolicRef(git.HEAD, "")
+	if err := r.RenameBranch(ctx, oldName, newName, id); err != nil {
+		return err
+	}
+
+	d.logger.Info("renamed branch", "repo", repo.Name(), "branch", oldName, "new", newName)
+
+	events := []struct {
+		ref, from, to string
+	}{
+		{git.RefsHeads + newName, git.ZeroID, id},
+		{git.RefsHeads + oldName, id, git.ZeroID},
+	}
+	for _, e := range events {
+		wh, err := webhook.NewBranchTagEvent(ctx, user, repo, e.ref, e.from, e.to)
+		if err != nil {
+			d.logger.Error("error creating branch_tag webhook", "err", err)
+		} else if err := webhook.SendEvent(ctx, wh); err != nil {
+			d.logger.Error("error sending branch_tag webhook", "err", err)
+		}
+	}
+
+	if head != git.RefsHeads+oldName {
+		return nil
+	}
+
+	if _, err := r.SymbolicRef(git.HEAD, git.RefsHeads+newName, gitm.SymbolicRefOptions{
+		CommandOptions: gitm.CommandOptions{
+			Context: ctx,
+		},
+	}); err != nil {
+		return err
+	}
+
+	wh, err := webhook.NewRepositoryEvent(ctx, user, repo, webhook.RepositoryEventActionDefaultBranchChange)
+	if err != nil {
+		return err
+	}
+
+	return webhook.SendEvent(ctx, wh)
+}
@@ -1,11 +1,24 @@
 package backend
 
-import lru "github.com/hashicorp/golang-lru/v2"
+import (
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
 
 // TODO: implement a caching interface.
 type cache struct {
 	b     *Backend
 	repos *lru.Cache[string, *repo]
+
+	// commits caches commit summaries by commit hash.
+	commits *lru.Cache[string, proto.CommitSummary]
+
+	// contributors caches contributors by commit hash.
+	contributors *lru.Cache[string, []git.Contributor]
+
+	// activity caches commit activity by commit hash and range.
+	activity *lru.Cache[string, []git.ActivityBucket]
 }
 
 func newCache(b *Backend, size int) *cache {
@@ -15,6 +28,12 @@ func newCache(b *Backend, size int) *cache {
 	c := &cache{b: b}
 	cache, _ := lru.New[string, *repo](size)
 	c.repos = cache
+	commits, _ := lru.New[string, proto.CommitSummary](size)
+	c.commits = commits
+	contributors, _ := lru.New[string, []git.Contributor](size)
+	c.contributors = contributors
+	activity, _ := lru.New[string, []git.ActivityBucket](size)
+	c.activity = activity
 	return c
 }
 
@@ -33,3 +52,27 @@ func (c *cache) Delete(repo string) {
 func (c *cache) Len() int {
 	return c.repos.Len()
 }
+
+func (c *cache) GetCommit(hash string) (proto.CommitSummary, bool) {
+	return c.commits.Get(hash)
+}
+
+func (c *cache) SetCommit(hash string, commit proto.CommitSummary) {
+	c.commits.Add(hash, commit)
+}
+
+func (c *cache) GetContributors(hash string) ([]git.Contributor, bool) {
+	return c.contributors.Get(hash)
+}
+
+func (c *cache) SetContributors(hash string, contributors []git.Contributor) {
+	c.contributors.Add(hash, contributors)
+}
+
+func (c *cache) GetActivity(key string) ([]git.ActivityBucket, bool) {
+	return c.activity.Get(key)
+}
+
+func (c *cache) SetActivity(key string, buckets []git.ActivityBucket) {
+	c.activity.Add(key, buckets)
+}
@@ -0,0 +1,205 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// checksFile is the name of the file, inside the repository metadata
+// directory, that lists the push checks of the repository.
+const checksFile = "checks.json"
+
+// maxCheckOutput is the maximum size of the output of a failed check relayed
+// to the client.
+const maxCheckOutput = 64 * 1024
+
+// RepoChecks returns the commands run against the pushed trees of a
+// repository before accepting a push.
+func (d *Backend) RepoChecks(ctx context.Context, repo string) ([]string, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.readChecks(r.Name())
+}
+
+// AddRepoCheck adds a command to the push checks of a repository. Commands
+// run with sh in a checkout of each pushed ref, a non-zero exit status
+// rejects the push.
+func (d *Backend) AddRepoCheck(ctx context.Context, repo string, command string) error {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return fmt.Errorf("empty check command")
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	checks, err := d.readChecks(r.Name())
+	if err != nil {
+		return err
+	}
+
+	return d.writeChecks(r.Name(), append(checks, command))
+}
+
+// RemoveRepoCheck removes the push check at index, starting at 1, from a
+// repository.
+func (d *Backend) RemoveRepoCheck(ctx context.Context, repo string, index int) error {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	checks, err := d.readChecks(r.Name())
+	if err != nil {
+		return err
+	}
+	if index < 1 || index > len(checks) {
+		return proto.ErrCheckNotFound
+	}
+
+	return d.writeChecks(r.Name(), append(checks[:index-1], checks[index:]...))
+}
+
+// verifyChecks runs the push checks of a repository against a checkout of
+// every pushed ref, and rejects the push with the output of the first
+// failing check. All the checks of a push share Git.CheckTimeout.
+func (d *Backend) verifyChecks(ctx context.Context, repo string, args []hooks.HookArg) error {
+	checks, err := d.RepoChecks(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+
+	if d.cfg.Git.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.cfg.Git.CheckTimeout)
+		defer cancel()
+	}
+
+	checked := map[string]bool{}
+	for _, arg := range args {
+		if git.IsZeroHash(arg.NewSha) || checked[arg.NewSha] {
+			continue
+		}
+		checked[arg.NewSha] = true
+
+		if err := d.runChecks(ctx, repo, arg, checks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runChecks checks out the tree of a pushed ref in a temporary directory and
+// runs the checks in it.
+func (d *Backend) runChecks(ctx context.Context, repo string, arg hooks.HookArg, checks []string) error {
+	tmp, err := os.MkdirTemp("", "soft-serve-check-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp) // nolint: errcheck
+
+	// The pushed objects are only visible through the quarantine
+	// environment of the hook, which git commands inherit.
+	rp := d.repoPath(repo)
+	tree := filepath.Join(tmp, "tree")
+	if err := os.Mkdir(tree, os.ModePerm); err != nil {
+		return err
+	}
+	envs := []string{"GIT_INDEX_FILE=" + filepath.Join(tmp, "index"), "GIT_WORK_TREE=" + tree}
+	if _, err := git.NewCommand("read-tree", arg.NewSha).AddEnvs(envs...).WithContext(ctx).RunInDir(rp); err != nil {
+		return fmt.Errorf("failed to read the tree of %s: %w", arg.RefName, err)
+	}
+	if _, err := git.NewCommand("checkout-index", "--all").AddEnvs(envs...).WithContext(ctx).RunInDir(rp); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", arg.RefName, err)
+	}
+
+	env := []string{
+		"SOFT_SERVE_CHECK_REF=" + arg.RefName,
+		"SOFT_SERVE_CHECK_COMMIT=" + arg.NewSha,
+	}
+	for _, e := range config.HookEnviron(os.Environ()) {
+		// The checkout isn't a git repository, don't let checks use the
+		// hook's.
+		if !strings.HasPrefix(e, "GIT_") {
+			env = append(env, e)
+		}
+	}
+
+	for _, check := range checks {
+		cmd := exec.CommandContext(ctx, "sh", "-c", check)
+		cmd.Dir = tree
+		cmd.Env = env
+		// Don't wait for the children of a killed check holding the output.
+		cmd.WaitDelay = time.Second
+		out, err := cmd.CombinedOutput()
+		if ctx.Err() != nil {
+			return fmt.Errorf("check %q timed out on %s after %s", check, arg.RefName, d.cfg.Git.CheckTimeout)
+		}
+		if err != nil {
+			d.logger.Info("push check failed", "repo", repo, "ref", arg.RefName, "check", check, "err", err)
+			if len(out) > maxCheckOutput {
+				out = append(out[:maxCheckOutput], "\n[output truncated]"...)
+			}
+			return fmt.Errorf("check %q failed on %s: %w\n%s", check, arg.RefName, err, strings.TrimRight(string(out), "\n"))
+		}
+	}
+
+	return nil
+}
+
+// readChecks reads the push checks of a repository. It must be called with
+// the metadata lock held.
+func (d *Backend) readChecks(repo string) ([]string, error) {
+	bts, err := os.ReadFile(d.repoMetadataPath(repo, checksFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var checks []string
+	if err := json.Unmarshal(bts, &checks); err != nil {
+		return nil, fmt.Errorf("failed to decode checks: %w", err)
+	}
+
+	return checks, nil
+}
+
+// writeChecks writes the push checks of a repository. It must be called with
+// the metadata lock held.
+func (d *Backend) writeChecks(repo string, checks []string) error {
+	bts, err := json.MarshalIndent(checks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(d.repoMetadataPath(repo, checksFile), bts)
+}
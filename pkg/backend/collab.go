@@ -3,7 +3,9 @@ package backend
 import (
 	"context"
 	"errors"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
@@ -13,16 +15,17 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/webhook"
 )
 
-// AddCollaborator adds a collaborator to a repository.
+// AddCollaborator adds a collaborator to a repository. The collaborator
+// loses access to the repository at expiresAt, or never if it's zero.
 //
-// It implements backend.Backend.
-func (d *Backend) AddCollaborator(ctx context.Context, repo string, username string, level access.AccessLevel) error {
+// It implements backend.Service.
+func (d *Backend) AddCollaborator(ctx context.Context, repo string, username string, level access.AccessLevel, expiresAt time.Time) error {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
 		return err
 	}
 
-	repo = utils.SanitizeRepo(repo)
+	repo = d.cfg.SanitizeRepo(repo)
 	r, err := d.Repository(ctx, repo)
 	if err != nil {
 		return err
@@ -30,7 +33,7 @@ func (d *Backend) AddCollaborator(ctx context.Context, repo string, username str
 
 	if err := db.WrapError(
 		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-			return d.store.AddCollabByUsernameAndRepo(ctx, tx, username, repo, level)
+			return d.store.AddCollabByUsernameAndRepo(ctx, tx, username, repo, level, expiresAt)
 		}),
 	); err != nil {
 		if errors.Is(err, db.ErrDuplicateKey) {
@@ -50,9 +53,9 @@ func (d *Backend) AddCollaborator(ctx context.Context, repo string, username str
 
 // Collaborators returns a list of collaborators for a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) Collaborators(ctx context.Context, repo string) ([]string, error) {
-	repo = utils.SanitizeRepo(repo)
+	repo = d.cfg.SanitizeRepo(repo)
 	var users []models.User
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -70,15 +73,61 @@ func (d *Backend) Collaborators(ctx context.Context, repo string) ([]string, err
 	return usernames, nil
 }
 
-// IsCollaborator returns the access level and true if the user is a collaborator of the repository.
+// CollaboratorsWithAccess returns the collaborators of a repository with
+// their access level and expiry, sorted by username. Collaborators whose
+// access expired are included.
+func (d *Backend) CollaboratorsWithAccess(ctx context.Context, repo string) ([]proto.Collaborator, error) {
+	repo = d.cfg.SanitizeRepo(repo)
+	var collabs []models.Collab
+	var users []models.User
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		collabs, err = d.store.ListCollabsByRepo(ctx, tx, repo)
+		if err != nil {
+			return err
+		}
+		users, err = d.store.ListCollabsByRepoAsUsers(ctx, tx, repo)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	usernames := make(map[int64]string, len(users))
+	for _, u := range users {
+		usernames[u.ID] = u.Username
+	}
+
+	list := make([]proto.Collaborator, 0, len(collabs))
+	for _, m := range collabs {
+		c := proto.Collaborator{
+			Username:    usernames[m.UserID],
+			AccessLevel: m.AccessLevel,
+			CreatedAt:   m.CreatedAt,
+		}
+		if m.ExpiresAt.Valid {
+			c.ExpiresAt = m.ExpiresAt.Time
+		}
+		c.Paths = splitCollabPaths(m.Paths)
+		list = append(list, c)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Username < list[j].Username
+	})
+
+	return list, nil
+}
+
+// IsCollaborator returns the access level and true if the user is a
+// collaborator of the repository whose access hasn't expired.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) IsCollaborator(ctx context.Context, repo string, username string) (access.AccessLevel, bool, error) {
 	if username == "" {
 		return -1, false, nil
 	}
 
-	repo = utils.SanitizeRepo(repo)
+	repo = d.cfg.SanitizeRepo(repo)
 	var m models.Collab
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -88,14 +137,19 @@ func (d *Backend) IsCollaborator(ctx context.Context, repo string, username stri
 		return -1, false, db.WrapError(err)
 	}
 
+	// Expired grants are kept for auditing but don't give access.
+	if m.ExpiresAt.Valid && !time.Now().Before(m.ExpiresAt.Time) {
+		return -1, false, nil
+	}
+
 	return m.AccessLevel, m.ID > 0, nil
 }
 
 // RemoveCollaborator removes a collaborator from a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) RemoveCollaborator(ctx context.Context, repo string, username string) error {
-	repo = utils.SanitizeRepo(repo)
+	repo = d.cfg.SanitizeRepo(repo)
 	r, err := d.Repository(ctx, repo)
 	if err != nil {
 		return err
@@ -0,0 +1,230 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// SetCollaboratorPaths limits the paths a collaborator can push changes to.
+// A path pattern uses path.Match syntax and also matches everything under the
+// directories it matches, e.g. "docs" and "services/*" allow changing any
+// file under them. No patterns let the collaborator change the whole
+// repository.
+func (d *Backend) SetCollaboratorPaths(ctx context.Context, repo string, username string, patterns []string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.Trim(strings.TrimSpace(p), "/")
+		if _, err := path.Match(p, ""); err != nil || p == "" {
+			return fmt.Errorf("invalid path pattern: %q", p)
+		}
+		paths = append(paths, p)
+	}
+
+	repo = d.cfg.SanitizeRepo(repo)
+	if err := db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetCollabPathsByUsernameAndRepo(ctx, tx, username, repo, paths)
+		}),
+	); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return proto.ErrCollaboratorNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// verifyCollabPaths rejects pushes of collaborators that change files outside
+// of their allowed paths.
+func (d *Backend) verifyCollabPaths(ctx context.Context, repo string, args []hooks.HookArg) error {
+	user, err := d.hookUser(ctx)
+	if errors.Is(err, proto.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user == nil || user.IsAdmin() {
+		return nil
+	}
+
+	repo = d.cfg.SanitizeRepo(repo)
+	var m models.Collab
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.GetCollabByUsernameAndRepo(ctx, tx, user.Username(), repo)
+		return err
+	}); err != nil {
+		if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return nil
+		}
+		return db.WrapError(err)
+	}
+
+	paths := splitCollabPaths(m.Paths)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	checkFiles := func(files []string, what string, refName string) error {
+		for _, file := range files {
+			if matchCollabPath(paths, file) {
+				continue
+			}
+
+			return fmt.Errorf("%s can only push changes to %s, %s (%s) changes %q",
+				user.Username(), strings.Join(paths, ", "), what, refName, file)
+		}
+		return nil
+	}
+
+	rp := d.repoPath(repo)
+	for _, arg := range args {
+		if git.IsZeroHash(arg.NewSha) {
+			// Deleting a ref doesn't change any files.
+			continue
+		}
+
+		// List the commits that aren't reachable from any existing ref along
+		// with their parents.
+		out, err := git.NewCommand("rev-list", "--parents", arg.NewSha, "--not", "--all").
+			WithContext(ctx).RunInDir(rp)
+		if err != nil {
+			return fmt.Errorf("failed to list new commits of %s: %w", arg.RefName, err)
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+
+			// Merge commits are compared to their first parent so that
+			// merging an old commit can't hide the changes it brings in.
+			sha := fields[0]
+			var files []string
+			if len(fields) > 1 {
+				files, err = diffFiles(ctx, rp, fields[1], sha)
+			} else {
+				files, err = rootFiles(ctx, rp, sha)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to list changed files of %s: %w", sha, err)
+			}
+			if err := checkFiles(files, "commit "+sha, arg.RefName); err != nil {
+				return err
+			}
+		}
+
+		// Compare the old and new tips so that moving a ref to an existing
+		// commit, e.g. a force-push rollback, can't change other paths. New
+		// branches are compared to the default branch, so that a deleted
+		// branch can't be pushed back at an old commit either.
+		from := arg.OldSha
+		if git.IsZeroHash(from) {
+			if !strings.HasPrefix(arg.RefName, git.RefsHeads) {
+				continue
+			}
+			from, err = defaultBranchTip(ctx, rp)
+			if err != nil {
+				// The repository has no default branch yet, the new commits
+				// were checked.
+				continue
+			}
+		}
+
+		files, err := diffFiles(ctx, rp, from, arg.NewSha)
+		if err != nil {
+			return fmt.Errorf("failed to list changed files of %s: %w", arg.RefName, err)
+		}
+		if err := checkFiles(files, "update "+from+".."+arg.NewSha, arg.RefName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// defaultBranchTip returns the commit the default branch of a repository
+// points to.
+func defaultBranchTip(ctx context.Context, rp string) (string, error) {
+	out, err := git.NewCommand("rev-parse", "--verify", "--quiet", "HEAD^{commit}").
+		WithContext(ctx).RunInDir(rp)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// diffFiles returns the files that differ between two commits.
+func diffFiles(ctx context.Context, rp string, from, to string) ([]string, error) {
+	out, err := git.NewCommand("diff", "--name-only", "--no-renames", "-z", from, to).
+		WithContext(ctx).RunInDir(rp)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitNulFiles(out), nil
+}
+
+// rootFiles returns the files added by a root commit.
+func rootFiles(ctx context.Context, rp string, sha string) ([]string, error) {
+	out, err := git.NewCommand("diff-tree", "-r", "--root", "--no-commit-id", "--name-only", "--no-renames", "-z", sha).
+		WithContext(ctx).RunInDir(rp)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitNulFiles(out), nil
+}
+
+func splitNulFiles(out []byte) []string {
+	var files []string
+	for _, file := range strings.Split(string(out), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// matchCollabPath returns true if a file, or one of its parent directories,
+// matches one of the path patterns.
+func matchCollabPath(patterns []string, file string) bool {
+	for p := file; p != "." && p != "/"; p = path.Dir(p) {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func splitCollabPaths(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,23 @@
+package backend
+
+import "testing"
+
+func TestMatchCollabPath(t *testing.T) {
+	patterns := []string{"docs", "services/*", "*.md"}
+	cases := map[string]bool{
+		"docs/index.md":        true,
+		"docs/api/v1.txt":      true,
+		"services/api/main.go": true,
+		"services/README":      true,
+		"README.md":            true,
+		"src/README.md":        false,
+		"documentation/x":      false,
+		"services":             false,
+		"main.go":              false,
+	}
+	for file, want := range cases {
+		if got := matchCollabPath(patterns, file); got != want {
+			t.Errorf("matchCollabPath(%q) = %t, want %t", file, got, want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestCollaboratorExpiry(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	_, err = be.CreateUser(ctx, "bob", proto.UserOptions{})
+	is.NoErr(err)
+	_, err = be.CreateUser(ctx, "carol", proto.UserOptions{})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+
+	_, err = be.CreateRepository(ctx, "repo1", alice, proto.RepositoryOptions{Private: true})
+	is.NoErr(err)
+
+	is.NoErr(be.AddCollaborator(ctx, "repo1", "bob", access.ReadWriteAccess, time.Now().Add(-time.Minute)))
+	is.NoErr(be.AddCollaborator(ctx, "repo1", "carol", access.ReadOnlyAccess, time.Now().Add(time.Hour)))
+
+	// Expired grants are ignored.
+	_, isCollab, err := be.IsCollaborator(ctx, "repo1", "bob")
+	is.NoErr(err)
+	is.True(!isCollab)
+	level, isCollab, err := be.IsCollaborator(ctx, "repo1", "carol")
+	is.NoErr(err)
+	is.True(isCollab)
+	is.Equal(level, access.ReadOnlyAccess)
+
+	// But they're still listed.
+	collabs, err := be.CollaboratorsWithAccess(ctx, "repo1")
+	is.NoErr(err)
+	is.Equal(len(collabs), 2)
+	is.Equal(collabs[0].Username, "bob")
+	is.Equal(collabs[0].AccessLevel, access.ReadWriteAccess)
+	is.True(collabs[0].Expired())
+	is.Equal(collabs[1].Username, "carol")
+	is.True(!collabs[1].Expired())
+	is.True(!collabs[1].ExpiresAt.IsZero())
+}
@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// LastCommit returns a summary of the last commit on the default branch of a
+// repository. It returns nil if the repository has no commits.
+//
+// Summaries are cached by commit hash, so only the default branch needs to be
+// resolved once the commit has been seen.
+func (d *Backend) LastCommit(_ context.Context, repo proto.Repository) (*proto.CommitSummary, error) {
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := r.HEAD()
+	if err != nil {
+		if errors.Is(err, git.ErrReferenceNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	hash := head.ID
+	if c, ok := d.cache.GetCommit(hash); ok {
+		return &c, nil
+	}
+
+	commit, err := r.CatFileCommit(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	c := proto.CommitSummary{
+		Hash:    commit.ID.String(),
+		Summary: commit.Summary(),
+	}
+	if commit.Author != nil {
+		c.Author = commit.Author.Name
+		c.When = commit.Author.When
+	}
+
+	d.cache.SetCommit(hash, c)
+	return &c, nil
+}
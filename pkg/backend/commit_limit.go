@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+)
+
+// verifyCommitLimit rejects pushes adding more new commits than
+// Git.MaxCommitsPerPush, or Git.MaxCommitsPerInitialPush when the repository
+// is empty.
+func (d *Backend) verifyCommitLimit(ctx context.Context, repo string, args []hooks.HookArg) error {
+	limit, initialLimit := d.cfg.Git.MaxCommitsPerPush, d.cfg.Git.MaxCommitsPerInitialPush
+	if limit <= 0 && initialLimit <= 0 {
+		return nil
+	}
+
+	revs := []string{"rev-list", "--count"}
+	for _, arg := range args {
+		if !git.IsZeroHash(arg.NewSha) {
+			revs = append(revs, arg.NewSha)
+		}
+	}
+	if len(revs) == 2 {
+		return nil
+	}
+
+	rp := d.repoPath(repo)
+	refs, err := git.NewCommand("for-each-ref", "--count=1").WithContext(ctx).RunInDir(rp)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(refs)) == 0 {
+		limit = initialLimit
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	// The refs aren't updated yet, commits reachable from them were already
+	// pushed.
+	out, err := git.NewCommand(append(revs, "--not", "--all")...).WithContext(ctx).WithTimeout(-1).RunInDir(rp)
+	if err != nil {
+		return fmt.Errorf("failed to count new commits: %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return fmt.Errorf("failed to count new commits: %w", err)
+	}
+	if count <= limit {
+		return nil
+	}
+
+	d.logger.Info("push over commit limit", "repo", repo, "commits", count, "limit", limit)
+	return fmt.Errorf("push adds %d commits to %s, more than the limit of %d, push them in smaller batches, e.g. git push origin <branch>~%d:refs/heads/<branch> then push again",
+		count, repo, limit, count-limit)
+}
@@ -6,8 +6,8 @@ import "context"
 var ContextKey = &struct{ string }{"backend"}
 
 // FromContext returns the backend from a context.
-func FromContext(ctx context.Context) *Backend {
-	if b, ok := ctx.Value(ContextKey).(*Backend); ok {
+func FromContext(ctx context.Context) Service {
+	if b, ok := ctx.Value(ContextKey).(Service); ok {
 		return b
 	}
 
@@ -15,6 +15,6 @@ func FromContext(ctx context.Context) *Backend {
 }
 
 // WithContext returns a new context with the backend attached.
-func WithContext(ctx context.Context, b *Backend) context.Context {
+func WithContext(ctx context.Context, b Service) context.Context {
 	return context.WithValue(ctx, ContextKey, b)
 }
@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// Contributors returns the commit authors of a repository at ref, sorted by
+// number of commits. An empty ref means the default branch. It returns nil if
+// the repository has no commits.
+//
+// Contributors are cached by the commit ref points to, so they're only
+// computed again once the branch moves.
+func (d *Backend) Contributors(_ context.Context, repo proto.Repository, ref string) ([]git.Contributor, error) {
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := resolveCommit(r, ref)
+	if err != nil || hash == "" {
+		return nil, err
+	}
+
+	if c, ok := d.cache.GetContributors(hash); ok {
+		return c, nil
+	}
+
+	contributors, err := r.Contributors(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	d.cache.SetContributors(hash, contributors)
+	return contributors, nil
+}
+
+// resolveCommit returns the commit hash ref points to. An empty ref means
+// the default branch, an empty hash is returned if the repository has no
+// commits.
+func resolveCommit(r *git.Repository, ref string) (string, error) {
+	if ref == "" {
+		head, err := r.HEAD()
+		if err != nil {
+			if errors.Is(err, git.ErrReferenceNotExist) {
+				return "", nil
+			}
+			return "", err
+		}
+		return head.ID, nil
+	}
+
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("%w: %s", git.ErrRevisionNotExist, ref)
+	}
+	hash, err := r.RevParse(ref + "^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", git.ErrRevisionNotExist, ref)
+	}
+
+	return hash, nil
+}
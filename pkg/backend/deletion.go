@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+)
+
+// verifyBranchDeletion rejects pushes deleting branches of a repository that
+// doesn't allow it. Branches can still be deleted with the admin CLI, tags
+// aren't affected.
+func (d *Backend) verifyBranchDeletion(ctx context.Context, repo string, args []hooks.HookArg) error {
+	repo = d.cfg.SanitizeRepo(repo)
+	var deleted []string
+	for _, arg := range args {
+		if git.IsZeroHash(arg.NewSha) && strings.HasPrefix(arg.RefName, git.RefsHeads) {
+			deleted = append(deleted, strings.TrimPrefix(arg.RefName, git.RefsHeads))
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	allow, err := d.AllowBranchDeletion(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if allow {
+		return nil
+	}
+
+	return fmt.Errorf("branch deletion is disabled for %s, ask an admin to run \"repo branch delete %s %s\"",
+		repo, repo, deleted[0])
+}
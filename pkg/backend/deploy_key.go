@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"golang.org/x/crypto/ssh"
+)
+
+// AddDeployKey adds a deploy key with read-only or read-write access to a
+// repository. A public key can be the deploy key of a single repository and
+// can't belong to a user.
+func (d *Backend) AddDeployKey(ctx context.Context, repo string, pk ssh.PublicKey, comment string, level access.AccessLevel) error {
+	if level != access.ReadOnlyAccess && level != access.ReadWriteAccess {
+		return access.ErrInvalidAccessLevel
+	}
+
+	repo = d.cfg.SanitizeRepo(repo)
+	comment = strings.TrimSpace(comment)
+	if _, err := d.Repository(ctx, repo); err != nil {
+		return err
+	}
+
+	for _, k := range d.cfg.AdminKeys() {
+		if sshutils.KeysEqual(pk, k) {
+			return proto.ErrDeployKeyIsUserKey
+		}
+	}
+
+	if err := db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			if _, err := d.store.FindUserByPublicKey(ctx, tx, pk); err == nil {
+				return proto.ErrDeployKeyIsUserKey
+			} else if !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+				return err
+			}
+
+			return d.store.AddDeployKeyByRepo(ctx, tx, repo, pk, comment, level)
+		}),
+	); err != nil {
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return proto.ErrDeployKeyExist
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeployKey removes a deploy key from a repository.
+func (d *Backend) RemoveDeployKey(ctx context.Context, repo string, pk ssh.PublicKey) error {
+	repo = d.cfg.SanitizeRepo(repo)
+	if err := db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.RemoveDeployKeyByRepo(ctx, tx, repo, pk)
+		}),
+	); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return proto.ErrDeployKeyNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// DeployKeys returns the deploy keys of a repository.
+func (d *Backend) DeployKeys(ctx context.Context, repo string) ([]proto.DeployKey, error) {
+	repo = d.cfg.SanitizeRepo(repo)
+	var ms []models.DeployKey
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.ListDeployKeysByRepo(ctx, tx, repo)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	keys := make([]proto.DeployKey, 0, len(ms))
+	for _, m := range ms {
+		dk, err := deployKeyFromModel(m)
+		if err != nil {
+			d.logger.Error("error parsing deploy key", "repo", repo, "err", err)
+			continue
+		}
+		keys = append(keys, dk)
+	}
+
+	return keys, nil
+}
+
+// DeployKeyByPublicKey returns the deploy key of a public key.
+func (d *Backend) DeployKeyByPublicKey(ctx context.Context, pk ssh.PublicKey) (proto.DeployKey, error) {
+	var m models.DeployKey
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.FindDeployKeyByPublicKey(ctx, tx, pk)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return proto.DeployKey{}, proto.ErrDeployKeyNotFound
+		}
+		return proto.DeployKey{}, err
+	}
+
+	return deployKeyFromModel(m)
+}
+
+func deployKeyFromModel(m models.DeployKey) (proto.DeployKey, error) {
+	pk, _, err := sshutils.ParseAuthorizedKey(m.PublicKey)
+	if err != nil {
+		return proto.DeployKey{}, err
+	}
+
+	return proto.DeployKey{
+		Repo:        m.RepoName,
+		PublicKey:   pk,
+		Comment:     m.Comment,
+		AccessLevel: m.AccessLevel,
+		CreatedAt:   m.CreatedAt,
+	}, nil
+}
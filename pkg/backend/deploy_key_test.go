@@ -0,0 +1,66 @@
+package backend_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestDeployKeys(t *testing.T) {
+	is := is.New(t)
+	newKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		is.NoErr(err)
+		pk, err := ssh.NewPublicKey(pub)
+		is.NoErr(err)
+		return pk
+	}
+
+	ctx, be := test.NewBackend(t)
+	userKey, roKey, rwKey := newKey(), newKey(), newKey()
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{PublicKeys: []ssh.PublicKey{userKey}})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+	for _, name := range []string{"repo1", "repo2"} {
+		_, err := be.CreateRepository(ctx, name, alice, proto.RepositoryOptions{Private: true})
+		is.NoErr(err)
+	}
+
+	is.NoErr(be.AddDeployKey(ctx, "repo1", roKey, " ci ", access.ReadOnlyAccess))
+	is.NoErr(be.AddDeployKey(ctx, "repo1", rwKey, "", access.ReadWriteAccess))
+	is.True(errors.Is(be.AddDeployKey(ctx, "repo2", roKey, "", access.ReadOnlyAccess), proto.ErrDeployKeyExist))
+	is.True(errors.Is(be.AddDeployKey(ctx, "repo2", userKey, "", access.ReadOnlyAccess), proto.ErrDeployKeyIsUserKey))
+	is.True(errors.Is(be.AddDeployKey(ctx, "repo2", newKey(), "", access.AdminAccess), access.ErrInvalidAccessLevel))
+	is.True(errors.Is(be.AddDeployKey(ctx, "nope", newKey(), "", access.ReadOnlyAccess), proto.ErrRepoNotFound))
+
+	keys, err := be.DeployKeys(ctx, "repo1")
+	is.NoErr(err)
+	is.Equal(len(keys), 2)
+	is.Equal(keys[0].Repo, "repo1")
+	is.Equal(keys[0].Comment, "ci")
+	is.True(sshutils.KeysEqual(keys[0].PublicKey, roKey))
+	is.Equal(keys[1].AccessLevel, access.ReadWriteAccess)
+
+	// Deploy keys only have access to their repository.
+	level, reason := be.AccessLevelByPublicKeyWithReason(ctx, "repo1", roKey)
+	is.Equal(level, access.ReadOnlyAccess)
+	is.Equal(reason, access.ReasonDeployKey)
+	is.Equal(be.AccessLevelByPublicKey(ctx, "repo1", rwKey), access.ReadWriteAccess)
+	is.Equal(be.AccessLevelByPublicKey(ctx, "repo2", rwKey), access.NoAccess)
+	is.Equal(be.AccessLevelByPublicKey(ctx, "new-repo", rwKey), access.NoAccess)
+
+	is.NoErr(be.RemoveDeployKey(ctx, "repo1", roKey))
+	is.True(errors.Is(be.RemoveDeployKey(ctx, "repo1", roKey), proto.ErrDeployKeyNotFound))
+	is.True(errors.Is(be.RemoveDeployKey(ctx, "repo2", rwKey), proto.ErrDeployKeyNotFound))
+	is.Equal(be.AccessLevelByPublicKey(ctx, "repo1", roKey), access.NoAccess)
+	_, err = be.DeployKeyByPublicKey(ctx, roKey)
+	is.True(errors.Is(err, proto.ErrDeployKeyNotFound))
+}
@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+)
+
+// CheckFreeDisk returns proto.ErrLowDiskSpace if writing need more bytes to
+// the data directory would leave less than Git.MinFreeDisk bytes free.
+func (d *Backend) CheckFreeDisk(need int64) error {
+	minFree := d.cfg.Git.MinFreeDisk
+	if minFree <= 0 {
+		return nil
+	}
+
+	free, err := freeDiskSpace(d.cfg.DataPath)
+	if errors.Is(err, errors.ErrUnsupported) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if free-need < minFree {
+		return fmt.Errorf("%w: %s free, %s required", proto.ErrLowDiskSpace,
+			humanize.IBytes(uint64(max(free, 0))), humanize.IBytes(uint64(minFree+need))) //nolint:gosec
+	}
+
+	return nil
+}
+
+// verifyFreeDisk rejects pushes whose objects would leave too little free
+// space on the data directory disk.
+func (d *Backend) verifyFreeDisk(ctx context.Context, repo string) error {
+	if d.cfg.Git.MinFreeDisk <= 0 {
+		return nil
+	}
+
+	// The pushed objects wait in the quarantine directory until the push is
+	// accepted, see "QUARANTINE ENVIRONMENT" in git-receive-pack(1). Its size
+	// estimates the space the push needs once the objects are moved and
+	// repacked.
+	var need int64
+	if qp := os.Getenv("GIT_QUARANTINE_PATH"); qp != "" {
+		if err := walkFileSizes(ctx, qp, func(_ string, n int64) {
+			need += n
+		}); err != nil {
+			d.logger.Error("error measuring pushed objects", "repo", repo, "err", err)
+		}
+	}
+
+	if err := d.CheckFreeDisk(need); err != nil {
+		d.logger.Warn("rejecting push", "repo", repo, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package backend
+
+import "golang.org/x/sys/unix"
+
+// freeDiskSpace returns the bytes available to unprivileged users on the disk
+// of path.
+func freeDiskSpace(path string) (int64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+
+	return int64(st.Bavail) * st.Bsize, nil //nolint:gosec
+}
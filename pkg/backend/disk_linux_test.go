@@ -0,0 +1,31 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/matryer/is"
+)
+
+func TestCheckFreeDisk(t *testing.T) {
+	is := is.New(t)
+	cfg := config.DefaultConfig()
+	cfg.DataPath = t.TempDir()
+	d := &Backend{cfg: cfg}
+
+	free, err := freeDiskSpace(cfg.DataPath)
+	is.NoErr(err)
+	is.True(free > 0)
+
+	// The check is disabled by default.
+	is.NoErr(d.CheckFreeDisk(free * 2))
+
+	cfg.Git.MinFreeDisk = 1
+	is.NoErr(d.CheckFreeDisk(0))
+	is.True(errors.Is(d.CheckFreeDisk(free), proto.ErrLowDiskSpace))
+
+	cfg.Git.MinFreeDisk = free * 2
+	is.True(errors.Is(d.CheckFreeDisk(0), proto.ErrLowDiskSpace))
+}
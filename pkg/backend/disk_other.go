@@ -0,0 +1,11 @@
+//go:build !linux
+
+package backend
+
+import "errors"
+
+// freeDiskSpace returns errors.ErrUnsupported, the free space is only checked
+// on Linux.
+func freeDiskSpace(string) (int64, error) {
+	return 0, errors.ErrUnsupported
+}
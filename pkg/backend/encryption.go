@@ -0,0 +1,295 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errLocked is returned by fileLock when the lock is held by another process.
+var errLocked = errors.New("file is locked")
+
+// gocryptfsType is the file system type of gocryptfs mounts.
+const gocryptfsType = "fuse.gocryptfs"
+
+// MountEncryptedStorage mounts the encrypted repositories of
+// Storage.Encryption on the repositories directory with gocryptfs. Git, and
+// everything else reading repositories, keeps using the same paths and sees
+// them in plain text, while gocryptfs writes them encrypted to the cipher
+// dir. It does nothing if encryption is disabled.
+//
+// The mount is shared by all the processes using the repositories, e.g. the
+// server, its hooks, and admin commands. The first one mounts the
+// repositories, the last one unmounts them when calling the returned
+// function. Stale mounts of crashed processes are unmounted first. Within a
+// process, operations share the mount through OpenStorage.
+//
+// Commands must not run when it fails, they would write plain text
+// repositories.
+func (d *Backend) MountEncryptedStorage(ctx context.Context) (func() error, error) {
+	enc := d.cfg.Storage.Encryption
+	if !enc.Enabled {
+		return func() error { return nil }, nil
+	}
+
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("storage encryption is only supported on linux")
+	}
+
+	fi, err := os.Stat(enc.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("storage encryption key file: %w", err)
+	}
+	if fi.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("storage encryption key file %s must only be readable by its owner", enc.KeyFile)
+	}
+
+	if _, err := os.Stat(filepath.Join(enc.CipherDir, "gocryptfs.conf")); err != nil {
+		return nil, fmt.Errorf("%s isn't a gocryptfs directory, create it with gocryptfs -init: %w", enc.CipherDir, err)
+	}
+
+	// Processes using the mount hold a shared lock on the users lock file,
+	// so the last one knows it can unmount. The mount lock file serializes
+	// mounting and unmounting.
+	mountLock := filepath.Join(d.cfg.DataPath, "encrypted-storage.lock")
+	usersLock := filepath.Join(d.cfg.DataPath, "encrypted-storage.users.lock")
+	unlock, err := fileLock(mountLock, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("lock encrypted storage: %w", err)
+	}
+
+	defer unlock()
+	release, err := fileLock(usersLock, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("lock encrypted storage: %w", err)
+	}
+
+	mnt := filepath.Join(d.cfg.DataPath, "repos")
+	if err := d.mountEncryptedStorage(ctx, mnt); err != nil {
+		release()
+		return nil, err
+	}
+
+	return func() error {
+		unlock, err := fileLock(mountLock, true, false)
+		if err != nil {
+			return fmt.Errorf("lock encrypted storage: %w", err)
+		}
+
+		defer unlock()
+		release()
+		unlockUsers, err := fileLock(usersLock, true, true)
+		if errors.Is(err, errLocked) {
+			// Other processes still use the mount.
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("lock encrypted storage: %w", err)
+		}
+
+		defer unlockUsers()
+		if err := unmountStorage(mnt, false); err != nil {
+			return fmt.Errorf("failed to unmount encrypted storage: %w", err)
+		}
+
+		d.logger.Info("unmounted encrypted storage", "mountpoint", mnt)
+		return nil
+	}, nil
+}
+
+// storageView counts the operations of the process using the decrypted view
+// of the encrypted storage.
+type storageView struct {
+	mu    sync.Mutex
+	users int
+	// gen changes every time the view is opened, idle timers of earlier
+	// operations don't unmount it.
+	gen     int
+	release func() error
+}
+
+// OpenStorage makes the decrypted view of the encrypted storage available to
+// an operation, e.g. an SSH session, a git request, or a job, until the
+// returned function is called. The first operation mounts the view, it's
+// unmounted once no operation used it for Storage.Encryption.IdleTimeout. It
+// does nothing if encryption is disabled.
+//
+// The operation must not run when it fails, it would write plain text
+// repositories.
+func (d *Backend) OpenStorage(ctx context.Context) (func(), error) {
+	if !d.cfg.Storage.Encryption.Enabled {
+		return func() {}, nil
+	}
+
+	v := &d.storage
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.release == nil {
+		release, err := d.MountEncryptedStorage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		v.release = release
+	}
+
+	v.users++
+	v.gen++
+	return sync.OnceFunc(d.closeStorageView), nil
+}
+
+// closeStorageView ends an operation using the decrypted view, and unmounts
+// it after the idle timeout if it was the last one.
+func (d *Backend) closeStorageView() {
+	v := &d.storage
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.users--
+	if v.users > 0 {
+		return
+	}
+
+	idle := d.cfg.Storage.Encryption.IdleTimeout
+	if idle <= 0 {
+		d.unmountStorageView()
+		return
+	}
+
+	gen := v.gen
+	time.AfterFunc(idle, func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		if v.users == 0 && v.gen == gen {
+			d.unmountStorageView()
+		}
+	})
+}
+
+// CloseStorage unmounts the decrypted view of the encrypted storage if no
+// operation uses it, without waiting for the idle timeout. Call it before
+// the process exits.
+func (d *Backend) CloseStorage() {
+	v := &d.storage
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.users == 0 {
+		d.unmountStorageView()
+	}
+}
+
+// unmountStorageView unmounts the decrypted view, the storage view lock must
+// be held.
+func (d *Backend) unmountStorageView() {
+	v := &d.storage
+	if v.release == nil {
+		return
+	}
+
+	if err := v.release(); err != nil {
+		d.logger.Error("error unmounting encrypted storage", "err", err)
+	}
+	v.release = nil
+}
+
+// mountEncryptedStorage mounts the encrypted storage on mnt, unless it's
+// already mounted there.
+func (d *Backend) mountEncryptedStorage(ctx context.Context, mnt string) error {
+	enc := d.cfg.Storage.Encryption
+	m, err := findMount(mnt)
+	if err != nil {
+		return fmt.Errorf("find encrypted storage mount: %w", err)
+	}
+
+	if m != nil {
+		if m.Type != gocryptfsType || filepath.Clean(m.Source) != filepath.Clean(enc.CipherDir) {
+			return fmt.Errorf("%s is already mounted from %s (%s)", mnt, m.Source, m.Type)
+		}
+
+		if _, err := os.Stat(mnt); !isStaleMount(err) {
+			d.logger.Debug("encrypted storage already mounted", "mountpoint", mnt)
+			return nil
+		}
+
+		// The gocryptfs process is gone, e.g. it crashed or was killed.
+		d.logger.Warn("unmounting stale encrypted storage", "mountpoint", mnt)
+		if err := unmountStorage(mnt, true); err != nil {
+			return fmt.Errorf("failed to unmount stale encrypted storage: %w", err)
+		}
+	}
+
+	// Mounting over existing repositories would hide them, and they would
+	// stay in plain text.
+	if err := os.MkdirAll(mnt, os.ModePerm); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(mnt)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s isn't empty, move its repositories to the encrypted storage first", mnt)
+	}
+
+	// gocryptfs returns once the file system is mounted.
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, enc.Command, "-q", "-passfile", enc.KeyFile, enc.CipherDir, mnt) // nolint: gosec
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to mount encrypted storage: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	d.logger.Info("mounted encrypted storage", "cipher_dir", enc.CipherDir, "mountpoint", mnt)
+	return nil
+}
+
+// unmountStorage unmounts the FUSE file system mounted on mnt. Lazy unmounts
+// detach it even when it's busy or its process is gone.
+func unmountStorage(mnt string, lazy bool) error {
+	name := "fusermount"
+	if _, err := exec.LookPath(name); err != nil {
+		name = "fusermount3"
+	}
+
+	flags := "-u"
+	if lazy {
+		flags = "-uz"
+	}
+
+	out, err := exec.Command(name, flags, mnt).CombinedOutput() // nolint: gosec
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// mount is a mounted file system.
+type mount struct {
+	// Point is the mount point.
+	Point string
+	// Type is the file system type, e.g. "fuse.gocryptfs".
+	Type string
+	// Source is the mounted device or directory.
+	Source string
+}
+
+// addTask adds a task to the task manager. The task keeps the decrypted view
+// of the encrypted storage open while it runs, it can outlive the operation
+// that started it.
+func (d *Backend) addTask(id string, fn func(context.Context) error) {
+	d.manager.Add(id, func(ctx context.Context) error {
+		release, err := d.OpenStorage(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer release()
+		return fn(ctx)
+	})
+}
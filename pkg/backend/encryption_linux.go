@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// fileLock locks path with flock(2), creating it if needed. Exclusive locks
+// are only held by one process, shared ones by many. With nonblock, it fails
+// with errLocked instead of waiting for the lock. The returned function
+// releases the lock.
+func fileLock(path string, exclusive, nonblock bool) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	how := unix.LOCK_SH
+	if exclusive {
+		how = unix.LOCK_EX
+	}
+	if nonblock {
+		how |= unix.LOCK_NB
+	}
+
+	if err := unix.Flock(int(f.Fd()), how); err != nil {
+		f.Close() // nolint: errcheck
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, errLocked
+		}
+		return nil, err
+	}
+
+	// Closing the file releases the lock.
+	return func() { f.Close() }, nil // nolint: errcheck
+}
+
+// findMount returns the file system mounted on mnt, or nil if there's none.
+func findMount(mnt string) (*mount, error) {
+	// Resolve symbolic links of the parent only, a stale mount point can't be
+	// stat'ed.
+	dir, err := filepath.EvalSymlinks(filepath.Dir(mnt))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close() // nolint: errcheck
+	mounts, err := parseMountInfo(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// The last mount on a mount point hides the others.
+	point := filepath.Join(dir, filepath.Base(mnt))
+	for i := len(mounts) - 1; i >= 0; i-- {
+		if mounts[i].Point == point {
+			return &mounts[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseMountInfo parses the mounts of a /proc/<pid>/mountinfo file.
+//
+// See proc_pid_mountinfo(5).
+func parseMountInfo(r io.Reader) ([]mount, error) {
+	var mounts []mount
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields, rest, ok := strings.Cut(scanner.Text(), " - ")
+		if !ok {
+			continue
+		}
+
+		f, r := strings.Fields(fields), strings.Fields(rest)
+		if len(f) < 5 || len(r) < 2 {
+			continue
+		}
+
+		mounts = append(mounts, mount{
+			Point:  unescapeMountInfo(f[4]),
+			Type:   r[0],
+			Source: unescapeMountInfo(r[1]),
+		})
+	}
+
+	return mounts, scanner.Err()
+}
+
+// unescapeMountInfo unescapes the octal escapes of spaces, tabs, newlines,
+// and backslashes of mountinfo fields.
+func unescapeMountInfo(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// isStaleMount reports whether err is the error of accessing a FUSE mount
+// whose process is gone.
+func isStaleMount(err error) bool {
+	return errors.Is(err, unix.ENOTCONN)
+}
@@ -0,0 +1,36 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	is := is.New(t)
+	mounts, err := parseMountInfo(strings.NewReader(`22 1 259:2 / / rw,relatime shared:1 - ext4 /dev/nvme0n1p2 rw
+46 22 0:42 / /data/soft\040serve/repos rw,nosuid,nodev,relatime shared:25 - fuse.gocryptfs /data/soft\040serve/repos.enc rw,user_id=1000,group_id=1000
+invalid line
+`))
+	is.NoErr(err)
+	is.Equal(mounts, []mount{
+		{Point: "/", Type: "ext4", Source: "/dev/nvme0n1p2"},
+		{Point: "/data/soft serve/repos", Type: "fuse.gocryptfs", Source: "/data/soft serve/repos.enc"},
+	})
+}
+
+func TestFindMount(t *testing.T) {
+	is := is.New(t)
+	m, err := findMount("/")
+	is.NoErr(err)
+	is.True(m != nil)
+
+	dir := filepath.Join(t.TempDir(), "repos")
+	is.NoErr(os.Mkdir(dir, 0o700))
+	m, err = findMount(dir)
+	is.NoErr(err)
+	is.Equal(m, nil)
+}
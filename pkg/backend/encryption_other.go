@@ -0,0 +1,23 @@
+//go:build !linux
+
+package backend
+
+import "errors"
+
+// fileLock returns errors.ErrUnsupported, storage encryption is only
+// supported on Linux.
+func fileLock(string, bool, bool) (func(), error) {
+	return nil, errors.ErrUnsupported
+}
+
+// findMount returns errors.ErrUnsupported, storage encryption is only
+// supported on Linux.
+func findMount(string) (*mount, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// isStaleMount always returns false, storage encryption is only supported on
+// Linux.
+func isStaleMount(error) bool {
+	return false
+}
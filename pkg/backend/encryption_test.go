@@ -0,0 +1,199 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+)
+
+func TestMountEncryptedStorageDisabled(t *testing.T) {
+	ctx, be := test.NewBackend(t)
+	unmount, err := be.MountEncryptedStorage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unmount(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMountEncryptedStorage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("storage encryption isn't supported on windows")
+	}
+
+	td := t.TempDir()
+	keyFile := filepath.Join(td, "key")
+	cipherDir := filepath.Join(td, "cipher")
+	command := filepath.Join(td, "gocryptfs")
+	if err := os.WriteFile(keyFile, []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(cipherDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(command, []byte("#!/bin/sh\necho \"bad password\" >&2\nexit 12\n"), 0o755); err != nil { // nolint: gosec
+		t.Fatal(err)
+	}
+
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Storage.Encryption.Enabled = true
+		cfg.Storage.Encryption.KeyFile = keyFile
+		cfg.Storage.Encryption.CipherDir = cipherDir
+		cfg.Storage.Encryption.Command = command
+	})
+	cfg := config.FromContext(ctx)
+	mount := func(want string) {
+		t.Helper()
+		_, err := be.MountEncryptedStorage(ctx)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected error containing %q, got %v", want, err)
+		}
+	}
+
+	mount("must only be readable by its owner")
+	if err := os.Chmod(keyFile, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mount("isn't a gocryptfs directory")
+	if err := os.WriteFile(filepath.Join(cipherDir, "gocryptfs.conf"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mount("bad password")
+
+	// Existing plain text repositories aren't hidden by the mount.
+	if err := os.MkdirAll(filepath.Join(cfg.DataPath, "repos", "repo1.git"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	mount("isn't empty")
+}
+
+// fakeEncryptedStorage installs fake gocryptfs and fusermount commands that
+// record their calls instead of mounting. It returns the file of the calls
+// and the config option enabling encryption with them.
+func fakeEncryptedStorage(t *testing.T) (string, func(*config.Config)) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("storage encryption is only supported on linux")
+	}
+
+	td := t.TempDir()
+	calls := filepath.Join(td, "calls")
+	keyFile := filepath.Join(td, "key")
+	cipherDir := filepath.Join(td, "cipher")
+	if err := os.WriteFile(keyFile, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(cipherDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cipherDir, "gocryptfs.conf"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gocryptfs", "fusermount"} {
+		script := "#!/bin/sh\necho " + name + " \"$1\" >> " + calls + "\n"
+		if err := os.WriteFile(filepath.Join(td, name), []byte(script), 0o755); err != nil { // nolint: gosec
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", td+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return calls, func(cfg *config.Config) {
+		cfg.Storage.Encryption.Enabled = true
+		cfg.Storage.Encryption.KeyFile = keyFile
+		cfg.Storage.Encryption.CipherDir = cipherDir
+		cfg.Storage.Encryption.Command = filepath.Join(td, "gocryptfs")
+	}
+}
+
+func checkCalls(t *testing.T, calls string, want string) {
+	t.Helper()
+	got, _ := os.ReadFile(calls)
+	if string(got) != want {
+		t.Errorf("expected calls %q, got %q", want, got)
+	}
+}
+
+func TestMountEncryptedStorageShared(t *testing.T) {
+	calls, opt := fakeEncryptedStorage(t)
+	ctx, be := test.NewBackend(t, opt)
+
+	unmount1, err := be.MountEncryptedStorage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmount2, err := be.MountEncryptedStorage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the last user unmounts the storage.
+	if err := unmount1(); err != nil {
+		t.Fatal(err)
+	}
+	checkCalls(t, calls, "gocryptfs -q\ngocryptfs -q\n")
+	if err := unmount2(); err != nil {
+		t.Fatal(err)
+	}
+	checkCalls(t, calls, "gocryptfs -q\ngocryptfs -q\nfusermount -u\n")
+}
+
+func TestOpenStorage(t *testing.T) {
+	calls, opt := fakeEncryptedStorage(t)
+	ctx, be := test.NewBackend(t, opt, func(cfg *config.Config) {
+		cfg.Storage.Encryption.IdleTimeout = 0
+	})
+
+	// Operations share the view, the last one unmounts it.
+	close1, err := be.OpenStorage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close2, err := be.OpenStorage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close1()
+	close1()
+	checkCalls(t, calls, "gocryptfs -q\n")
+	close2()
+	checkCalls(t, calls, "gocryptfs -q\nfusermount -u\n")
+
+	// The next operation mounts it again.
+	close3, err := be.OpenStorage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close3()
+	checkCalls(t, calls, "gocryptfs -q\nfusermount -u\ngocryptfs -q\nfusermount -u\n")
+}
+
+func TestOpenStorageIdle(t *testing.T) {
+	calls, opt := fakeEncryptedStorage(t)
+	ctx, be := test.NewBackend(t, opt, func(cfg *config.Config) {
+		cfg.Storage.Encryption.IdleTimeout = time.Hour
+	})
+
+	// The view stays mounted until the idle timeout, or until the storage
+	// is closed.
+	for range 2 {
+		closeView, err := be.OpenStorage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		closeView()
+	}
+	checkCalls(t, calls, "gocryptfs -q\n")
+	be.CloseStorage()
+	checkCalls(t, calls, "gocryptfs -q\nfusermount -u\n")
+
+	if got := be.TrashPath(); got != filepath.Join(config.FromContext(ctx).DataPath, "repos", "@trash") {
+		t.Errorf("expected the trash in the encrypted storage, got %s", got)
+	}
+}
@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"golang.org/x/crypto/ssh"
+)
+
+// EnrollUser registers an unknown public key as a new user. The user is
+// pending, without access until an admin approves them, unless self-enrolled
+// users are auto approved.
+func (d *Backend) EnrollUser(ctx context.Context, username string, pk ssh.PublicKey) (proto.User, error) {
+	if !d.cfg.Auth.AllowSelfEnroll {
+		return nil, proto.ErrSelfEnrollDisabled
+	}
+
+	if d.IsKnownPublicKey(ctx, pk) {
+		return nil, proto.ErrPublicKeyExist
+	}
+
+	pending := !d.cfg.Auth.AutoApproveSelfEnroll
+	u, err := d.createUser(ctx, username, proto.UserOptions{
+		PublicKeys: []ssh.PublicKey{pk},
+		Pending:    pending,
+	})
+	if errors.Is(err, db.ErrDuplicateKey) {
+		return nil, proto.ErrUserExist
+	} else if err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("%s enrolled with a public key", u.Username())
+	if pending {
+		summary += " and is waiting for approval"
+	}
+
+	d.logger.Info("user enrolled", "username", u.Username(), "pending", pending, "fingerprint", ssh.FingerprintSHA256(pk))
+	d.notify(ctx, notify.Notification{
+		Event:   notify.EventUserCreate,
+		Actor:   u.Username(),
+		Summary: summary,
+	})
+
+	return u, nil
+}
+
+// IsKnownPublicKey returns whether pk is an admin key, or belongs to a user
+// or a deploy key.
+func (d *Backend) IsKnownPublicKey(ctx context.Context, pk ssh.PublicKey) bool {
+	for _, k := range d.cfg.AdminKeys() {
+		if sshutils.KeysEqual(pk, k) {
+			return true
+		}
+	}
+
+	if u, _ := d.UserByPublicKey(ctx, pk); u != nil {
+		return true
+	}
+
+	_, err := d.DeployKeyByPublicKey(ctx, pk)
+	return err == nil
+}
+
+// PendingUsers returns the usernames of the self-enrolled users waiting for
+// approval.
+func (d *Backend) PendingUsers(ctx context.Context) ([]string, error) {
+	var names []string
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		ms, err := d.store.GetAllUsers(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range ms {
+			if m.Pending {
+				names = append(names, m.Username)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return names, nil
+}
+
+// ApproveUser gives a pending user access. They get the default user access.
+func (d *Backend) ApproveUser(ctx context.Context, username string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			m, err := d.store.FindUserByUsername(ctx, tx, username)
+			if err != nil {
+				return err
+			}
+			if !m.Pending {
+				return proto.ErrUserNotPending
+			}
+
+			return d.store.SetUserPendingByUsername(ctx, tx, username, false)
+		}),
+	)
+}
+
+// RejectUser deletes a pending user along with their public key.
+func (d *Backend) RejectUser(ctx context.Context, username string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			m, err := d.store.FindUserByUsername(ctx, tx, username)
+			if err != nil {
+				return err
+			}
+			if !m.Pending {
+				return proto.ErrUserNotPending
+			}
+
+			return d.store.DeleteUserByUsername(ctx, tx, username)
+		}),
+	)
+}
@@ -0,0 +1,72 @@
+package backend_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestEnrollUser(t *testing.T) {
+	is := is.New(t)
+	newKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		is.NoErr(err)
+		pk, err := ssh.NewPublicKey(pub)
+		is.NoErr(err)
+		return pk
+	}
+
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Auth.AllowSelfEnroll = true
+	})
+
+	aliceKey, bobKey := newKey(), newKey()
+	alice, err := be.EnrollUser(ctx, "alice", aliceKey)
+	is.NoErr(err)
+	is.True(alice.IsPending())
+	is.True(be.IsKnownPublicKey(ctx, aliceKey))
+	is.True(!be.IsKnownPublicKey(ctx, bobKey))
+	is.Equal(be.AccessLevelByPublicKey(ctx, "new", aliceKey), access.NoAccess)
+
+	// Keys and usernames can't be enrolled twice.
+	_, err = be.EnrollUser(ctx, "alice2", aliceKey)
+	is.True(errors.Is(err, proto.ErrPublicKeyExist))
+	_, err = be.EnrollUser(ctx, "alice", bobKey)
+	is.True(errors.Is(err, proto.ErrUserExist))
+
+	_, err = be.EnrollUser(ctx, "bob", bobKey)
+	is.NoErr(err)
+	pending, err := be.PendingUsers(ctx)
+	is.NoErr(err)
+	is.Equal(pending, []string{"alice", "bob"})
+
+	is.NoErr(be.ApproveUser(ctx, "alice"))
+	is.True(errors.Is(be.ApproveUser(ctx, "alice"), proto.ErrUserNotPending))
+	is.Equal(be.AccessLevelByPublicKey(ctx, "new", aliceKey), access.ReadWriteAccess)
+	is.True(errors.Is(be.RejectUser(ctx, "alice"), proto.ErrUserNotPending))
+
+	is.NoErr(be.RejectUser(ctx, "bob"))
+	_, err = be.UserByPublicKey(ctx, bobKey)
+	is.True(err != nil)
+
+	ctx, be = test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Auth.AllowSelfEnroll = true
+		cfg.Auth.AutoApproveSelfEnroll = true
+	})
+	carol, err := be.EnrollUser(ctx, "carol", newKey())
+	is.NoErr(err)
+	is.True(!carol.IsPending())
+	is.Equal(be.AccessLevelForUser(ctx, "new", carol), access.ReadWriteAccess)
+
+	ctx, be = test.NewBackend(t)
+	_, err = be.EnrollUser(ctx, "dave", newKey())
+	is.True(errors.Is(err, proto.ErrSelfEnrollDisabled))
+}
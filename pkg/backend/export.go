@@ -0,0 +1,177 @@
+package backend
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// ExportedRepo is the metadata of a repository in an export archive.
+type ExportedRepo struct {
+	Name          string                 `json:"name"`
+	ProjectName   string                 `json:"project_name,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	Private       bool                   `json:"private"`
+	Hidden        bool                   `json:"hidden"`
+	Mirror        bool                   `json:"mirror"`
+	Archived      bool                   `json:"archived"`
+	Collaborators []ExportedCollaborator `json:"collaborators,omitempty"`
+	// Empty is true if the repository has no commits, the archive has no
+	// bundle of it.
+	Empty bool `json:"empty"`
+}
+
+// ExportedCollaborator is a collaborator of an exported repository.
+type ExportedCollaborator struct {
+	Username    string             `json:"username"`
+	AccessLevel access.AccessLevel `json:"access_level"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty"`
+	Paths       []string           `json:"paths,omitempty"`
+}
+
+// ExportRepositories writes a tar archive of the repositories under a
+// namespace, e.g. "team" exports "team" and "team/app", to w. The archive has
+// a "<repo>.json" file with the metadata of each repository followed by a
+// "<repo>.bundle" git bundle of its branches and tags, the bundles can be
+// cloned or imported into another server.
+//
+// Repositories are locked and bundled one at a time, each bundle is written
+// to a temporary file before it's added to the archive. It returns the
+// exported repositories.
+func (d *Backend) ExportRepositories(ctx context.Context, namespace string, w io.Writer) ([]ExportedRepo, error) {
+	namespace = d.cfg.SanitizeRepo(namespace)
+	if namespace == "" {
+		return nil, errors.New("missing namespace")
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []proto.Repository
+	for _, r := range repos {
+		if r.Name() == namespace || strings.HasPrefix(r.Name(), namespace+"/") {
+			matched = append(matched, r)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: no repositories under %s", proto.ErrRepoNotFound, namespace)
+	}
+
+	tw := tar.NewWriter(w)
+	exported := make([]ExportedRepo, 0, len(matched))
+	for _, r := range matched {
+		meta, err := d.exportRepository(ctx, tw, r)
+		if err != nil {
+			return exported, fmt.Errorf("failed to export %s: %w", r.Name(), err)
+		}
+		exported = append(exported, meta)
+	}
+
+	return exported, tw.Close()
+}
+
+// exportRepository adds the metadata and the bundle of a repository to an
+// export archive.
+func (d *Backend) exportRepository(ctx context.Context, tw *tar.Writer, repo proto.Repository) (ExportedRepo, error) {
+	name := repo.Name()
+	unlock := d.repoLocks.lock(name)
+	defer unlock()
+
+	meta := ExportedRepo{
+		Name:        name,
+		ProjectName: repo.ProjectName(),
+		Description: repo.Description(),
+		Private:     repo.IsPrivate(),
+		Hidden:      repo.IsHidden(),
+		Mirror:      repo.IsMirror(),
+		Archived:    repo.IsArchived(),
+	}
+
+	collabs, err := d.CollaboratorsWithAccess(ctx, name)
+	if err != nil {
+		return meta, err
+	}
+	for _, c := range collabs {
+		ec := ExportedCollaborator{
+			Username:    c.Username,
+			AccessLevel: c.AccessLevel,
+			Paths:       c.Paths,
+		}
+		if !c.ExpiresAt.IsZero() {
+			ec.ExpiresAt = &c.ExpiresAt
+		}
+		meta.Collaborators = append(meta.Collaborators, ec)
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return meta, err
+	}
+
+	f, err := os.CreateTemp("", "soft-serve-export-*.bundle")
+	if err != nil {
+		return meta, err
+	}
+	defer os.Remove(f.Name()) // nolint: errcheck
+	defer f.Close()           // nolint: errcheck
+
+	if err := r.Bundle(ctx, f); errors.Is(err, git.ErrEmptyRepository) {
+		meta.Empty = true
+	} else if err != nil {
+		return meta, err
+	}
+
+	bts, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return meta, err
+	}
+	now := time.Now()
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name + ".json",
+		Mode:    0o644,
+		Size:    int64(len(bts)),
+		ModTime: now,
+	}); err != nil {
+		return meta, err
+	}
+	if _, err := tw.Write(bts); err != nil {
+		return meta, err
+	}
+
+	if meta.Empty {
+		return meta, nil
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return meta, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return meta, err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name + ".bundle",
+		Mode:    0o644,
+		Size:    fi.Size(),
+		ModTime: now,
+	}); err != nil {
+		return meta, err
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return meta, err
+	}
+
+	return meta, nil
+}
@@ -0,0 +1,253 @@
+package backend
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/task"
+)
+
+// FilterOptions are the files removed from the history of a repository by
+// FilterRepository.
+type FilterOptions struct {
+	// Paths are the files and directories removed from every commit.
+	Paths []string
+	// Blobs are the ids of the blobs removed from every commit, whatever
+	// their path.
+	Blobs []string
+}
+
+// FilterRepository rewrites the whole history of a repository without the
+// paths and blobs of opts, like git filter-repo would. Every ref is
+// force-updated to the rewritten commits, then reflogs are expired and the
+// repository is garbage collected so that the removed data is gone.
+//
+// A copy of the repository is backed up to the trash directory before it's
+// rewritten, the backup directory is returned. Its git data can be restored
+// with "repo import".
+//
+// Pushes are rejected with proto.ErrRepoRewriting during the rewrite, it
+// starts once the pushes in progress are done.
+func (d *Backend) FilterRepository(ctx context.Context, name string, user proto.User, opts FilterOptions) (string, error) {
+	name = d.cfg.SanitizeRepo(name)
+	if len(opts.Paths) == 0 && len(opts.Blobs) == 0 {
+		return "", errors.New("no paths or blobs to remove")
+	}
+
+	paths := make([]string, 0, len(opts.Paths))
+	for _, p := range opts.Paths {
+		cp := strings.Trim(path.Clean("/"+p), "/")
+		if cp == "" {
+			return "", fmt.Errorf("invalid path: %q", p)
+		}
+		paths = append(paths, cp)
+	}
+
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	unlock := d.repoLocks.lock(name)
+	defer unlock()
+	unlockPushes := d.pushLocks.lock(name)
+	defer unlockPushes()
+
+	rp := d.repoPath(name)
+	blobs := make(map[string]bool, len(opts.Blobs))
+	for _, b := range opts.Blobs {
+		out, err := git.NewCommand("rev-parse", "--verify", "--quiet", "--end-of-options", b+"^{blob}").
+			WithContext(ctx).RunInDir(rp)
+		if err != nil {
+			return "", fmt.Errorf("blob %q does not exist", b)
+		}
+		blobs[strings.TrimSpace(string(out))] = true
+	}
+
+	// The rewrite is a maintenance task, it doesn't run along garbage
+	// collection or fsck.
+	tid := maintenanceTaskID(name)
+	if d.manager.Exists(tid) {
+		return "", task.ErrAlreadyStarted
+	}
+
+	trash := filepath.Join(d.TrashPath(),
+		time.Now().UTC().Format("20060102T150405Z")+"-"+strings.ReplaceAll(name, "/", "_")+"-filter")
+	d.addTask(tid, func(ctx context.Context) error {
+		if err := os.MkdirAll(trash, os.ModePerm); err != nil {
+			return err
+		}
+		if _, err := git.NewCommand("clone", "--mirror", "--quiet", rp, filepath.Join(trash, "repo.git")).
+			WithContext(ctx).WithTimeout(-1).RunInDir(trash); err != nil {
+			return fmt.Errorf("failed to back up repository: %w", err)
+		}
+
+		if err := rewriteHistory(ctx, rp, paths, blobs); err != nil {
+			return fmt.Errorf("failed to rewrite history: %w", err)
+		}
+
+		for _, args := range [][]string{
+			{"reflog", "expire", "--expire=now", "--all"},
+			{"gc", "--prune=now", "--quiet"},
+		} {
+			if _, err := git.NewCommand(args...).WithContext(ctx).WithTimeout(-1).RunInDir(rp); err != nil {
+				return err
+			}
+		}
+
+		return d.InvalidateRepositoryStats(ctx, repo)
+	})
+
+	done := make(chan error, 1)
+	d.manager.Run(tid, done)
+	if err := <-done; err != nil {
+		return trash, err
+	}
+
+	var actor string
+	if user != nil {
+		actor = user.Username()
+	}
+
+	d.logger.Info("rewrote repository history", "repo", name, "actor", actor, "paths", paths, "blobs", len(blobs), "backup", trash)
+	details := make([]string, 0, len(paths)+len(blobs))
+	for _, p := range paths {
+		details = append(details, "removed "+p)
+	}
+	for b := range blobs {
+		details = append(details, "removed blob "+b)
+	}
+	d.notify(ctx, notify.Notification{
+		Event:   notify.EventHistoryRewrite,
+		Repo:    name,
+		Actor:   actor,
+		Summary: fmt.Sprintf("%s rewrote the history of %s, clone it again", actorName(actor), name),
+		Details: details,
+	})
+
+	return trash, nil
+}
+
+// BeginPush marks a push to the repository name as in progress and returns
+// the function to call once it's done. It returns proto.ErrRepoRewriting
+// while the history of the repository is being rewritten.
+func (d *Backend) BeginPush(name string) (func(), error) {
+	end, ok := d.pushLocks.tryRLock(d.cfg.SanitizeRepo(name))
+	if !ok {
+		return nil, proto.ErrRepoRewriting
+	}
+
+	return end, nil
+}
+
+// rewriteHistory pipes the history of a repository from git fast-export to
+// git fast-import, without the file changes of the given paths and blobs.
+// Blobs aren't exported, the rewritten commits refer to the existing ones.
+func rewriteHistory(ctx context.Context, rp string, paths []string, blobs map[string]bool) error {
+	exportr, exportw := io.Pipe()
+	importr, importw := io.Pipe()
+
+	errc := make(chan error, 2)
+	go func() {
+		err := git.NewCommand("fast-export", "--all", "--no-data", "--signed-tags=strip",
+			"--tag-of-filtered-object=rewrite", "--reencode=no").
+			WithContext(ctx).WithTimeout(-1).
+			RunInDirWithOptions(rp, git.RunInDirOptions{Stdout: exportw})
+		exportw.CloseWithError(err) //nolint:errcheck
+		errc <- err
+	}()
+	go func() {
+		err := filterFastExport(importw, exportr, paths, blobs)
+		exportr.CloseWithError(err) //nolint:errcheck
+		importw.CloseWithError(err) //nolint:errcheck
+		errc <- err
+	}()
+
+	err := git.NewCommand("fast-import", "--force", "--quiet").
+		WithContext(ctx).WithTimeout(-1).
+		RunInDirWithOptions(rp, git.RunInDirOptions{Stdin: importr})
+	importr.CloseWithError(err) //nolint:errcheck
+	for range 2 {
+		if e := <-errc; err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// filterFastExport copies a git fast-export stream from r to w, dropping the
+// file changes of the given paths, and of their files if they're
+// directories, and those of the given blobs.
+func filterFastExport(w io.Writer, r io.Reader, paths []string, blobs map[string]bool) error {
+	removed := func(p string) bool {
+		if strings.HasPrefix(p, `"`) {
+			if up, err := strconv.Unquote(p); err == nil {
+				p = up
+			}
+		}
+		for _, rp := range paths {
+			if p == rp || strings.HasPrefix(p, rp+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	for {
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) && line == "" {
+			break
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		cmd := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(cmd, "data "):
+			// Commit and tag messages are copied as is, whatever they
+			// contain.
+			n, err := strconv.ParseInt(strings.TrimPrefix(cmd, "data "), 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid data command: %q", cmd)
+			}
+			if _, err := bw.WriteString(line); err != nil {
+				return err
+			}
+			if _, err := io.CopyN(bw, br, n); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(cmd, "M "):
+			// M <mode> <blob id> <path>
+			fields := strings.SplitN(cmd, " ", 4)
+			if len(fields) == 4 && (blobs[fields[2]] || removed(fields[3])) {
+				continue
+			}
+		case strings.HasPrefix(cmd, "D "):
+			if removed(strings.TrimPrefix(cmd, "D ")) {
+				continue
+			}
+		}
+
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/task"
+)
+
+// Fsck checks the integrity of the objects of a repository with git fsck.
+//
+// Checks don't run during garbage collection of the repository,
+// task.ErrAlreadyStarted is returned when the repository is already being
+// collected or checked.
+func (d *Backend) Fsck(ctx context.Context, repo proto.Repository) (*git.FsckResult, error) {
+	tid := maintenanceTaskID(repo.Name())
+	if d.manager.Exists(tid) {
+		return nil, task.ErrAlreadyStarted
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	var res *git.FsckResult
+	d.addTask(tid, func(ctx context.Context) error {
+		var err error
+		res, err = r.Fsck(ctx)
+		return err
+	})
+
+	done := make(chan error, 1)
+	d.manager.Run(tid, done)
+	if err := <-done; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/task"
+)
+
+// maintenanceTaskID returns the id of the maintenance task of a repository.
+// Garbage collection and fsck share it so that only one of them runs on a
+// repository at a time.
+func maintenanceTaskID(name string) string {
+	return "maintenance:" + name
+}
+
+// CollectGarbage runs git gc on a repository and resets its push counter.
+//
+// Only one maintenance task of a repository runs at a time,
+// task.ErrAlreadyStarted is returned when the repository is already being
+// collected or checked.
+func (d *Backend) CollectGarbage(ctx context.Context, repo proto.Repository) error {
+	name := repo.Name()
+	tid := maintenanceTaskID(name)
+	if d.manager.Exists(tid) {
+		return task.ErrAlreadyStarted
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return err
+	}
+
+	d.addTask(tid, func(ctx context.Context) error {
+		cmd := git.NewCommand("gc", "--quiet").WithContext(ctx)
+		if _, err := cmd.RunInDir(r.Path); err != nil {
+			return err
+		}
+
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.ResetRepoPushesSinceGCByName(ctx, tx, name)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+
+		return d.InvalidateRepositoryStats(ctx, repo)
+	})
+
+	done := make(chan error, 1)
+	d.manager.Run(tid, done)
+	return <-done
+}
+
+// RecordPush counts a push to a repository. Once a repository has been pushed
+// to Git.GCAfterPushes times, it's garbage collected in the background so
+// that the push isn't delayed.
+func (d *Backend) RecordPush(ctx context.Context, name string) error {
+	after := d.cfg.Git.GCAfterPushes
+	if after <= 0 {
+		return nil
+	}
+
+	name = d.cfg.SanitizeRepo(name)
+	var pushes int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		pushes, err = d.store.IncrRepoPushesSinceGCByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	if pushes < int64(after) {
+		return nil
+	}
+
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		d.logger.Debug("running garbage collection", "repo", name, "pushes", pushes)
+		// The collection outlives the push, use the backend context.
+		if err := d.CollectGarbage(d.ctx, repo); err != nil && !errors.Is(err, task.ErrAlreadyStarted) {
+			d.logger.Error("error running garbage collection", "repo", name, "err", err)
+		}
+	}()
+
+	return nil
+}
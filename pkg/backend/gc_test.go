@@ -0,0 +1,53 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestRecordPush(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Git.GCAfterPushes = 2
+	})
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	repo, err := be.CreateRepository(ctx, "repo1", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+
+	is.NoErr(be.RecordPush(ctx, "repo1"))
+	is.Equal(pushesSinceGC(ctx, t), int64(1))
+
+	// Collecting resets the counter.
+	is.NoErr(be.CollectGarbage(ctx, repo))
+	is.Equal(pushesSinceGC(ctx, t), int64(0))
+
+	// Crossing the threshold collects the repository in the background.
+	is.NoErr(be.RecordPush(ctx, "repo1"))
+	is.NoErr(be.RecordPush(ctx, "repo1"))
+	deadline := time.Now().Add(10 * time.Second)
+	for pushesSinceGC(ctx, t) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("repository wasn't garbage collected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func pushesSinceGC(ctx context.Context, t *testing.T) int64 {
+	t.Helper()
+	r, err := store.FromContext(ctx).GetRepoByName(ctx, db.FromContext(ctx), "repo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.PushesSinceGC
+}
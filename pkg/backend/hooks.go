@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"sync"
@@ -15,18 +16,94 @@ import (
 
 var _ hooks.Hooks = (*Backend)(nil)
 
+// SetHooks sets the hooks called before git operations, e.g. the backend
+// embedded in a type implementing hooks.PreHooks to add custom policies.
+// Hooks that don't implement hooks.PreHooks don't veto operations.
+func (d *Backend) SetHooks(h hooks.Hooks) {
+	d.hooks = h
+}
+
+// CheckPush calls the PrePush hook, if any, before a push to repo.
+func (d *Backend) CheckPush(ctx context.Context, repo string) error {
+	if h, ok := d.hooks.(hooks.PreHooks); ok {
+		return h.PrePush(ctx, repo)
+	}
+
+	return nil
+}
+
+// CheckFetch calls the PreFetch hook, if any, before a fetch from repo.
+func (d *Backend) CheckFetch(ctx context.Context, repo string) error {
+	if h, ok := d.hooks.(hooks.PreHooks); ok {
+		return h.PreFetch(ctx, repo)
+	}
+
+	return nil
+}
+
 // PostReceive is called by the git post-receive hook.
 //
 // It implements Hooks.
-func (d *Backend) PostReceive(_ context.Context, _ io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
+func (d *Backend) PostReceive(ctx context.Context, _ io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
 	d.logger.Debug("post-receive hook called", "repo", repo, "args", args)
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", repo, "err", err)
+		return
+	}
+
+	// Anonymous pushes are notified without an actor.
+	user, err := d.hookUser(ctx)
+	if err != nil {
+		d.logger.Debug("error finding user", "err", err)
+	}
+
+	d.notifyPush(ctx, user, r, args)
+	d.queuePushEmail(ctx, user, r, args)
 }
 
 // PreReceive is called by the git pre-receive hook.
 //
 // It implements Hooks.
-func (d *Backend) PreReceive(_ context.Context, _ io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
+func (d *Backend) PreReceive(ctx context.Context, _ io.Writer, stderr io.Writer, repo string, args []hooks.HookArg) {
 	d.logger.Debug("pre-receive hook called", "repo", repo, "args", args)
+
+	d.warnBinaryLimit(ctx, stderr, repo, args)
+}
+
+// ValidatePreReceive is called by the git pre-receive hook before PreReceive.
+// A non-nil error rejects the whole push and is reported to the client.
+func (d *Backend) ValidatePreReceive(ctx context.Context, repo string, args []hooks.HookArg) error {
+	if err := d.verifyNotArchived(ctx, repo); err != nil {
+		return err
+	}
+	if err := d.verifyFreeDisk(ctx, repo); err != nil {
+		return err
+	}
+	if err := d.verifyCommitLimit(ctx, repo, args); err != nil {
+		return err
+	}
+	if err := d.verifyBranchDeletion(ctx, repo, args); err != nil {
+		return err
+	}
+	if err := d.verifyImmutableTags(ctx, repo, args); err != nil {
+		return err
+	}
+	if err := d.verifyCollabPaths(ctx, repo, args); err != nil {
+		return err
+	}
+	if err := d.verifyLinearHistory(ctx, repo, args); err != nil {
+		return err
+	}
+	if err := d.verifySignedCommits(ctx, repo, args); err != nil {
+		return err
+	}
+	if err := d.verifyBinaryLimit(ctx, repo, args); err != nil {
+		return err
+	}
+	// Checks run last, they're the slowest.
+	return d.verifyChecks(ctx, repo, args)
 }
 
 // Update is called by the git update hook.
@@ -36,28 +113,9 @@ func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo str
 	d.logger.Debug("update hook called", "repo", repo, "arg", arg)
 
 	// Find user
-	var user proto.User
-	if pubkey := os.Getenv("SOFT_SERVE_PUBLIC_KEY"); pubkey != "" {
-		pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
-		if err != nil {
-			d.logger.Error("error parsing public key", "err", err)
-			return
-		}
-
-		user, err = d.UserByPublicKey(ctx, pk)
-		if err != nil {
-			d.logger.Error("error finding user from public key", "key", pubkey, "err", err)
-			return
-		}
-	} else if username := os.Getenv("SOFT_SERVE_USERNAME"); username != "" {
-		var err error
-		user, err = d.User(ctx, username)
-		if err != nil {
-			d.logger.Error("error finding user from username", "username", username, "err", err)
-			return
-		}
-	} else {
-		d.logger.Error("error finding user")
+	user, err := d.hookUser(ctx)
+	if err != nil {
+		d.logger.Error("error finding user", "err", err)
 		return
 	}
 
@@ -104,9 +162,57 @@ func (d *Backend) PostUpdate(ctx context.Context, _ io.Writer, _ io.Writer, repo
 		}
 	}()
 
+	// Invalidate cached object statistics.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r, err := d.Repository(ctx, repo)
+		if err != nil {
+			d.logger.Error("error finding repository", "repo", repo, "err", err)
+			return
+		}
+
+		if err := d.InvalidateRepositoryStats(ctx, r); err != nil {
+			d.logger.Error("error invalidating repository stats", "repo", repo, "err", err)
+		}
+	}()
+
 	wg.Wait()
 }
 
+// hookUser returns the user that runs the git hook. The user is passed down
+// to the hook process by the public key or username environment variables.
+// Server-side ref updates running the hook checks, e.g. tag creation, set it
+// in ctx instead.
+func (d *Backend) hookUser(ctx context.Context) (proto.User, error) {
+	if user := proto.UserFromContext(ctx); user != nil {
+		return user, nil
+	}
+
+	if pubkey := os.Getenv("SOFT_SERVE_PUBLIC_KEY"); pubkey != "" {
+		pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key: %w", err)
+		}
+
+		user, err := d.UserByPublicKey(ctx, pk)
+		if err != nil {
+			return nil, fmt.Errorf("error finding user from public key %q: %w", pubkey, err)
+		}
+
+		return user, nil
+	} else if username := os.Getenv("SOFT_SERVE_USERNAME"); username != "" {
+		user, err := d.User(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("error finding user from username %q: %w", username, err)
+		}
+
+		return user, nil
+	}
+
+	return nil, proto.ErrUserNotFound
+}
+
 func populateLastModified(ctx context.Context, d *Backend, name string) error {
 	var rr *repo
 	_rr, err := d.Repository(ctx, name)
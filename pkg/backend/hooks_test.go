@@ -0,0 +1,52 @@
+package backend_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+var errNotAlice = errors.New("only alice can push")
+
+// alicePolicy vetoes pushes by users other than alice.
+type alicePolicy struct {
+	*backend.Backend
+}
+
+func (alicePolicy) PrePush(ctx context.Context, _ string) error {
+	if u := proto.UserFromContext(ctx); u == nil || u.Username() != "alice" {
+		return errNotAlice
+	}
+	return nil
+}
+
+func (alicePolicy) PreFetch(context.Context, string) error {
+	return nil
+}
+
+func TestPreHooks(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+
+	// Without hooks, nothing is vetoed.
+	is.NoErr(be.CheckPush(ctx, "repo1"))
+	is.NoErr(be.CheckFetch(ctx, "repo1"))
+
+	// Hooks without pre-operation hooks don't veto either.
+	be.SetHooks(be)
+	is.NoErr(be.CheckPush(ctx, "repo1"))
+
+	be.SetHooks(alicePolicy{be})
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	bob, err := be.CreateUser(ctx, "bob", proto.UserOptions{})
+	is.NoErr(err)
+	is.NoErr(be.CheckPush(proto.WithUserContext(ctx, alice), "repo1"))
+	is.True(errors.Is(be.CheckPush(proto.WithUserContext(ctx, bob), "repo1"), errNotAlice))
+	is.NoErr(be.CheckFetch(proto.WithUserContext(ctx, bob), "repo1"))
+}
@@ -0,0 +1,244 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/keygen"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// retiredHostKeyTimeFormat is the format of the rotation time in the file
+// names of retired host keys.
+const retiredHostKeyTimeFormat = "20060102T150405Z"
+
+// HostKey is a SSH host key of the server.
+type HostKey struct {
+	Path      string
+	PublicKey gossh.PublicKey
+	// RetiredAt is the time the key was replaced by a rotation, it's zero
+	// for the current host key.
+	RetiredAt time.Time
+	// ExpiresAt is the time a retired key stops being offered.
+	ExpiresAt time.Time
+}
+
+// Retired returns true if the key was replaced by a rotation.
+func (k HostKey) Retired() bool {
+	return !k.RetiredAt.IsZero()
+}
+
+// hostKeys caches the host keys read from disk.
+type hostKeys struct {
+	mu sync.Mutex
+	// keys are the current host key followed by the retired ones, newest
+	// first. It's nil when the keys must be read again.
+	keys []hostKey
+}
+
+type hostKey struct {
+	path      string
+	signer    gossh.Signer
+	retiredAt time.Time
+}
+
+// HostKeys returns the current host key followed by the retired host keys,
+// newest first. Retired keys past their grace period are returned until
+// they're removed by RetireHostKeys.
+func (d *Backend) HostKeys(context.Context) ([]HostKey, error) {
+	d.hostKeys.mu.Lock()
+	defer d.hostKeys.mu.Unlock()
+
+	keys, err := d.loadHostKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	hks := make([]HostKey, 0, len(keys))
+	for _, k := range keys {
+		hk := HostKey{
+			Path:      k.path,
+			PublicKey: k.signer.PublicKey(),
+			RetiredAt: k.retiredAt,
+		}
+		if hk.Retired() {
+			hk.ExpiresAt = k.retiredAt.Add(d.cfg.SSH.HostKeyGracePeriod)
+		}
+		hks = append(hks, hk)
+	}
+
+	return hks, nil
+}
+
+// HostSigners returns the signers of the host keys the SSH server offers:
+// the current host key followed by the retired keys still in their grace
+// period, newest first.
+func (d *Backend) HostSigners() ([]gossh.Signer, error) {
+	d.hostKeys.mu.Lock()
+	defer d.hostKeys.mu.Unlock()
+
+	keys, err := d.loadHostKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	signers := make([]gossh.Signer, 0, len(keys))
+	for _, k := range keys {
+		if k.retiredAt.IsZero() || now.Before(k.retiredAt.Add(d.cfg.SSH.HostKeyGracePeriod)) {
+			signers = append(signers, k.signer)
+		}
+	}
+
+	return signers, nil
+}
+
+// RotateHostKey replaces the host key with a new key of the given type. The
+// previous key is kept, and offered for SSH.HostKeyGracePeriod, until it's
+// removed by RetireHostKeys.
+func (d *Backend) RotateHostKey(_ context.Context, keyType keygen.KeyType) (HostKey, error) {
+	d.hostKeys.mu.Lock()
+	defer d.hostKeys.mu.Unlock()
+
+	path := d.cfg.SSH.KeyPath
+	tmp := path + ".new"
+	for _, p := range []string{tmp, tmp + ".pub"} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return HostKey{}, err
+		}
+	}
+
+	kp, err := keygen.New(tmp, keygen.WithKeyType(keyType), keygen.WithWrite())
+	if err != nil {
+		return HostKey{}, fmt.Errorf("generate host key: %w", err)
+	}
+
+	now := time.Now().UTC()
+	retired := fmt.Sprintf("%s.%s.retired", path, now.Format(retiredHostKeyTimeFormat))
+	if err := os.Rename(path, retired); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return HostKey{}, err
+	}
+	if err := os.Rename(path+".pub", retired+".pub"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return HostKey{}, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return HostKey{}, err
+	}
+	if err := os.Rename(tmp+".pub", path+".pub"); err != nil {
+		return HostKey{}, err
+	}
+
+	d.hostKeys.keys = nil
+	fp := gossh.FingerprintSHA256(kp.PublicKey())
+	d.logger.Info("rotated host key", "fingerprint", fp, "grace-period", d.cfg.SSH.HostKeyGracePeriod)
+
+	return HostKey{Path: path, PublicKey: kp.PublicKey()}, nil
+}
+
+// RetireHostKeys removes the retired host keys past their grace period, or
+// all of them when all is true. It returns the removed keys.
+func (d *Backend) RetireHostKeys(_ context.Context, all bool) ([]HostKey, error) {
+	d.hostKeys.mu.Lock()
+	defer d.hostKeys.mu.Unlock()
+
+	keys, err := d.loadHostKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var removed []HostKey
+	for _, k := range keys {
+		if k.retiredAt.IsZero() {
+			continue
+		}
+		expires := k.retiredAt.Add(d.cfg.SSH.HostKeyGracePeriod)
+		if !all && now.Before(expires) {
+			continue
+		}
+
+		if err := os.Remove(k.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		if err := os.Remove(k.path + ".pub"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+
+		d.hostKeys.keys = nil
+		removed = append(removed, HostKey{
+			Path:      k.path,
+			PublicKey: k.signer.PublicKey(),
+			RetiredAt: k.retiredAt,
+			ExpiresAt: expires,
+		})
+		d.logger.Info("retired host key", "fingerprint", gossh.FingerprintSHA256(k.signer.PublicKey()))
+	}
+
+	return removed, nil
+}
+
+// loadHostKeys returns the cached host keys, reading them first if needed.
+// The caller must hold d.hostKeys.mu.
+func (d *Backend) loadHostKeys() ([]hostKey, error) {
+	if d.hostKeys.keys != nil {
+		return d.hostKeys.keys, nil
+	}
+
+	path := d.cfg.SSH.KeyPath
+	signer, err := readHostKey(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := []hostKey{{path: path, signer: signer}}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Base(path) + "."
+	var retired []hostKey
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".retired") {
+			continue
+		}
+
+		at, err := time.Parse(retiredHostKeyTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".retired"))
+		if err != nil {
+			continue
+		}
+
+		p := filepath.Join(filepath.Dir(path), name)
+		signer, err := readHostKey(p)
+		if err != nil {
+			d.logger.Error("error reading retired host key", "path", p, "err", err)
+			continue
+		}
+		retired = append(retired, hostKey{path: p, signer: signer, retiredAt: at})
+	}
+
+	sort.Slice(retired, func(i, j int) bool {
+		return retired[i].retiredAt.After(retired[j].retiredAt)
+	})
+
+	d.hostKeys.keys = append(keys, retired...)
+	return d.hostKeys.keys, nil
+}
+
+func readHostKey(path string) (gossh.Signer, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return gossh.ParsePrivateKey(pem)
+}
@@ -0,0 +1,67 @@
+package backend_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/keygen"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestRotateHostKey(t *testing.T) {
+	is := is.New(t)
+	path := filepath.Join(t.TempDir(), "host_ed25519")
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.SSH.KeyPath = path
+		cfg.SSH.HostKeyGracePeriod = time.Hour
+	})
+
+	old, err := keygen.New(path, keygen.WithKeyType(keygen.Ed25519), keygen.WithWrite())
+	is.NoErr(err)
+
+	hk, err := be.RotateHostKey(ctx, keygen.ECDSA)
+	is.NoErr(err)
+	is.Equal(hk.PublicKey.Type(), ssh.KeyAlgoECDSA384)
+
+	// The previous key is still offered during the grace period.
+	signers, err := be.HostSigners()
+	is.NoErr(err)
+	is.Equal(len(signers), 2)
+	is.Equal(signers[0].PublicKey().Marshal(), hk.PublicKey.Marshal())
+	is.Equal(signers[1].PublicKey().Marshal(), old.PublicKey().Marshal())
+
+	keys, err := be.HostKeys(ctx)
+	is.NoErr(err)
+	is.Equal(len(keys), 2)
+	is.True(!keys[0].Retired())
+	is.True(keys[1].Retired())
+	is.True(keys[1].ExpiresAt.After(time.Now()))
+
+	removed, err := be.RetireHostKeys(ctx, false)
+	is.NoErr(err)
+	is.Equal(len(removed), 0)
+
+	// Once the grace period ends, it isn't offered and can be retired.
+	config.FromContext(ctx).SSH.HostKeyGracePeriod = 0
+	signers, err = be.HostSigners()
+	is.NoErr(err)
+	is.Equal(len(signers), 1)
+
+	removed, err = be.RetireHostKeys(ctx, false)
+	is.NoErr(err)
+	is.Equal(len(removed), 1)
+	is.Equal(removed[0].PublicKey.Marshal(), old.PublicKey().Marshal())
+
+	keys, err = be.HostKeys(ctx)
+	is.NoErr(err)
+	is.Equal(len(keys), 1)
+
+	// The server keeps using the rotated key after a restart.
+	kp, err := config.KeyPair(config.FromContext(ctx))
+	is.NoErr(err)
+	is.Equal(kp.PublicKey().Marshal(), hk.PublicKey.Marshal())
+}
@@ -0,0 +1,182 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// issuesFile is the name of the file, inside the repository metadata
+// directory, where issues are stored.
+const issuesFile = "issues.json"
+
+// CreateIssue creates a new open issue in a repository.
+func (d *Backend) CreateIssue(ctx context.Context, repo string, user proto.User, title string, body string) (proto.Issue, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return proto.Issue{}, fmt.Errorf("issue title cannot be empty")
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return proto.Issue{}, err
+	}
+
+	var author string
+	if user != nil {
+		author = user.Username()
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+
+	issues, err := d.readIssues(r.Name())
+	if err != nil {
+		return proto.Issue{}, err
+	}
+
+	var id int64
+	for _, i := range issues {
+		if i.ID > id {
+			id = i.ID
+		}
+	}
+
+	now := time.Now().UTC()
+	issue := proto.Issue{
+		ID:        id + 1,
+		Title:     title,
+		Body:      body,
+		Author:    author,
+		State:     proto.IssueStateOpen,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	issues = append(issues, issue)
+	if err := d.writeIssues(r.Name(), issues); err != nil {
+		return proto.Issue{}, err
+	}
+
+	return issue, nil
+}
+
+// ListIssues returns all the issues of a repository ordered by id.
+func (d *Backend) ListIssues(ctx context.Context, repo string) ([]proto.Issue, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+
+	return d.readIssues(r.Name())
+}
+
+// Issue returns an issue of a repository by id.
+func (d *Backend) Issue(ctx context.Context, repo string, id int64) (proto.Issue, error) {
+	issues, err := d.ListIssues(ctx, repo)
+	if err != nil {
+		return proto.Issue{}, err
+	}
+
+	for _, i := range issues {
+		if i.ID == id {
+			return i, nil
+		}
+	}
+
+	return proto.Issue{}, proto.ErrIssueNotFound
+}
+
+// CloseIssue closes an issue of a repository.
+func (d *Backend) CloseIssue(ctx context.Context, repo string, id int64) error {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+
+	issues, err := d.readIssues(r.Name())
+	if err != nil {
+		return err
+	}
+
+	for i := range issues {
+		if issues[i].ID == id {
+			if !issues[i].IsOpen() {
+				return nil
+			}
+
+			issues[i].State = proto.IssueStateClosed
+			issues[i].UpdatedAt = time.Now().UTC()
+			return d.writeIssues(r.Name(), issues)
+		}
+	}
+
+	return proto.ErrIssueNotFound
+}
+
+// readIssues reads the issues of a repository. It must be called with the
+// metadata lock held.
+func (d *Backend) readIssues(repo string) ([]proto.Issue, error) {
+	bts, err := os.ReadFile(d.repoMetadataPath(repo, issuesFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []proto.Issue{}, nil
+		}
+		return nil, err
+	}
+
+	var issues []proto.Issue
+	if err := json.Unmarshal(bts, &issues); err != nil {
+		return nil, fmt.Errorf("failed to decode issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// writeIssues writes the issues of a repository. It must be called with the
+// metadata lock held.
+func (d *Backend) writeIssues(repo string, issues []proto.Issue) error {
+	bts, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(d.repoMetadataPath(repo, issuesFile), bts)
+}
+
+// repoMetadataPath returns the path to a file inside the repository metadata
+// directory. The metadata directory lives inside the repository so it's
+// part of repository backups.
+func (d *Backend) repoMetadataPath(repo string, elem ...string) string {
+	repo = d.cfg.SanitizeRepo(repo)
+	return filepath.Join(append([]string{d.repoPath(repo), "soft-serve"}, elem...)...)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it to path to
+// avoid leaving partially written files behind.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil { //nolint:gosec
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
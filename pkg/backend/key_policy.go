@@ -0,0 +1,85 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"golang.org/x/crypto/ssh"
+)
+
+// KeyPolicyViolation is a user key that doesn't meet the key policy.
+type KeyPolicyViolation struct {
+	Username string
+	Key      proto.PublicKey
+	// Reason explains why the key doesn't meet the policy.
+	Reason string
+}
+
+// CheckKeyPolicy returns an error explaining why a user key doesn't meet
+// SSH.MinKeyStrength and SSH.AllowedKeyAlgorithms, or nil if it does.
+func (d *Backend) CheckKeyPolicy(pk ssh.PublicKey) error {
+	if reason := d.keyPolicyReason(pk); reason != "" {
+		return fmt.Errorf("%w: %s", proto.ErrKeyPolicy, reason)
+	}
+
+	return nil
+}
+
+// keyPolicyReason returns why a user key doesn't meet the key policy, or an
+// empty string if it does.
+func (d *Backend) keyPolicyReason(pk ssh.PublicKey) string {
+	if algos := d.cfg.SSH.AllowedKeyAlgorithms; len(algos) > 0 && !slices.Contains(algos, pk.Type()) {
+		return fmt.Sprintf("%s keys aren't allowed, use one of %s", pk.Type(), strings.Join(algos, ", "))
+	}
+
+	switch pk.Type() {
+	case ssh.KeyAlgoRSA, ssh.KeyAlgoDSA: // nolint: staticcheck
+		if min := d.cfg.SSH.MinKeyStrength; min > 0 {
+			if bits := sshutils.KeySize(pk); bits < min {
+				return fmt.Sprintf("%s keys must be at least %d bits, this key has %d bits", pk.Type(), min, bits)
+			}
+		}
+	}
+
+	return ""
+}
+
+// KeyPolicyViolations lists the user keys that don't meet the key policy, to
+// replace them before enforcing the policy at authentication.
+func (d *Backend) KeyPolicyViolations(ctx context.Context) ([]KeyPolicyViolation, error) {
+	pks, err := d.PublicKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []KeyPolicyViolation
+	usernames := map[int64]string{}
+	for _, pk := range pks {
+		reason := d.keyPolicyReason(pk.Key)
+		if reason == "" {
+			continue
+		}
+
+		username, ok := usernames[pk.UserID]
+		if !ok {
+			user, err := d.UserByID(ctx, pk.UserID)
+			if err != nil {
+				return nil, err
+			}
+			username = user.Username()
+			usernames[pk.UserID] = username
+		}
+
+		violations = append(violations, KeyPolicyViolation{
+			Username: username,
+			Key:      pk,
+			Reason:   reason,
+		})
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,48 @@
+package backend_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/keygen"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestKeyPolicy(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.SSH.MinKeyStrength = 0
+	})
+
+	weak, err := keygen.New("", keygen.WithKeyType(keygen.RSA), keygen.WithBitSize(1024))
+	is.NoErr(err)
+	strong, err := keygen.New("", keygen.WithKeyType(keygen.Ed25519))
+	is.NoErr(err)
+
+	// Keys added before the policy is tightened are reported.
+	_, err = be.CreateUser(ctx, "alice", proto.UserOptions{PublicKeys: []ssh.PublicKey{weak.PublicKey(), strong.PublicKey()}})
+	is.NoErr(err)
+	violations, err := be.KeyPolicyViolations(ctx)
+	is.NoErr(err)
+	is.Equal(len(violations), 0)
+
+	config.FromContext(ctx).SSH.MinKeyStrength = 2048
+	violations, err = be.KeyPolicyViolations(ctx)
+	is.NoErr(err)
+	is.Equal(len(violations), 1)
+	is.Equal(violations[0].Username, "alice")
+	is.Equal(violations[0].Reason, "ssh-rsa keys must be at least 2048 bits, this key has 1024 bits")
+
+	_, err = be.CreateUser(ctx, "bob", proto.UserOptions{PublicKeys: []ssh.PublicKey{weak.PublicKey()}})
+	is.True(errors.Is(err, proto.ErrKeyPolicy))
+
+	rsa, err := keygen.New("", keygen.WithKeyType(keygen.RSA), keygen.WithBitSize(2048))
+	is.NoErr(err)
+	config.FromContext(ctx).SSH.AllowedKeyAlgorithms = []string{ssh.KeyAlgoRSA}
+	is.True(errors.Is(be.AddPublicKey(ctx, "alice", strong.PublicKey()), proto.ErrKeyPolicy))
+	is.NoErr(be.AddPublicKey(ctx, "alice", rsa.PublicKey()))
+}
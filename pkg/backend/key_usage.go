@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"golang.org/x/crypto/ssh"
+)
+
+// keyUsage buffers when public keys were last used to authenticate, so
+// connections don't write to the database. The times are written in batches
+// by FlushPublicKeyUsage.
+type keyUsage struct {
+	mu      sync.Mutex
+	pending map[string]keyUse
+}
+
+type keyUse struct {
+	pk ssh.PublicKey
+	at time.Time
+}
+
+// touch records that pk was used at t, keeping the most recent use.
+func (u *keyUsage) touch(pk ssh.PublicKey, t time.Time) {
+	ak := sshutils.MarshalAuthorizedKey(pk)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.pending == nil {
+		u.pending = make(map[string]keyUse)
+	}
+	if use, ok := u.pending[ak]; !ok || use.at.Before(t) {
+		u.pending[ak] = keyUse{pk: pk, at: t}
+	}
+}
+
+// take returns the pending uses and clears them.
+func (u *keyUsage) take() map[string]keyUse {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	pending := u.pending
+	u.pending = nil
+	return pending
+}
+
+// lastUsed returns the pending use of pk, if any.
+func (u *keyUsage) lastUsed(pk ssh.PublicKey) (time.Time, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	use, ok := u.pending[sshutils.MarshalAuthorizedKey(pk)]
+	return use.at, ok
+}
+
+// TouchPublicKey records that pk was just used to authenticate. The time is
+// kept in memory until the next FlushPublicKeyUsage.
+func (d *Backend) TouchPublicKey(pk ssh.PublicKey) {
+	d.keyUsage.touch(pk, time.Now().UTC())
+}
+
+// FlushPublicKeyUsage writes the buffered last-used times of public keys to
+// the database in a single transaction. The times are kept for the next
+// flush if writing them fails.
+func (d *Backend) FlushPublicKeyUsage(ctx context.Context) error {
+	pending := d.keyUsage.take()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, use := range pending {
+			if err := d.store.SetPublicKeyLastUsed(ctx, tx, use.pk, use.at); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		for _, use := range pending {
+			d.keyUsage.touch(use.pk, use.at)
+		}
+		return db.WrapError(err)
+	}
+
+	return nil
+}
+
+// PublicKeys lists the public keys of all users including their comments
+// and when they were last used.
+func (d *Backend) PublicKeys(ctx context.Context) ([]proto.PublicKey, error) {
+	var keys []proto.PublicKey
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		ms, err := d.store.ListAllPublicKeyModels(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		keys, err = d.publicKeysFromModels(ms)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,58 @@
+package backend_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestPublicKeyUsage(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+
+	pk, _, err := sshutils.ParseAuthorizedKey("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBFb3KwQyPHlVURFNiVYc3+BjUFennJeMOq01X/cEiyj")
+	is.NoErr(err)
+	_, err = be.CreateUser(ctx, "alice", proto.UserOptions{PublicKeys: []ssh.PublicKey{pk}})
+	is.NoErr(err)
+
+	keys, err := be.UserPublicKeys(ctx, "alice")
+	is.NoErr(err)
+	is.True(keys[0].LastUsedAt.IsZero())
+
+	// Usage is buffered until it's flushed.
+	be.TouchPublicKey(pk)
+	keys, err = be.UserPublicKeys(ctx, "alice")
+	is.NoErr(err)
+	is.True(!keys[0].LastUsedAt.IsZero())
+	is.True(!storedLastUsed(ctx, t).Valid)
+
+	is.NoErr(be.FlushPublicKeyUsage(ctx))
+	used := storedLastUsed(ctx, t)
+	is.True(used.Valid)
+	is.True(used.Time.Sub(keys[0].LastUsedAt).Abs() < time.Second)
+
+	// Older uses don't overwrite newer ones.
+	is.NoErr(store.FromContext(ctx).SetPublicKeyLastUsed(ctx, db.FromContext(ctx), pk, used.Time.Add(-time.Hour)))
+	is.True(storedLastUsed(ctx, t).Time.Sub(used.Time).Abs() < time.Second)
+
+	// Nothing to flush.
+	is.NoErr(be.FlushPublicKeyUsage(ctx))
+}
+
+func storedLastUsed(ctx context.Context, t *testing.T) sql.NullTime {
+	t.Helper()
+	ms, err := store.FromContext(ctx).ListPublicKeyModelsByUsername(ctx, db.FromContext(ctx), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ms[0].LastUsedAt
+}
@@ -3,7 +3,9 @@ package backend
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -86,3 +88,32 @@ func StoreRepoMissingLFSObjects(ctx context.Context, repo proto.Repository, dbx
 
 	return nil
 }
+
+// OpenLFSObject opens the stored LFS object of a repository a pointer refers
+// to. It returns an error wrapping fs.ErrNotExist if the repository doesn't
+// have the object, or if the stored object doesn't match the pointer.
+func (d *Backend) OpenLFSObject(ctx context.Context, repo proto.Repository, p lfs.Pointer) (io.ReadCloser, error) {
+	if _, err := d.store.GetLFSObjectByOid(ctx, d.db, repo.ID(), p.Oid); errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("lfs object %s: %w", p.Oid, fs.ErrNotExist)
+	} else if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	// TODO: support S3 storage
+	repoID := strconv.FormatInt(repo.ID(), 10)
+	strg := storage.NewLocalStorage(filepath.Join(d.cfg.DataPath, "lfs", repoID))
+	obj, err := strg.Open(path.Join("objects", p.RelativePath()))
+	if err != nil {
+		return nil, err
+	}
+
+	if fi, err := obj.Stat(); err != nil {
+		obj.Close() // nolint: errcheck
+		return nil, err
+	} else if fi.Size() != p.Size {
+		obj.Close() // nolint: errcheck
+		return nil, fmt.Errorf("lfs object %s: size mismatch: %w", p.Oid, fs.ErrNotExist)
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,74 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+)
+
+// verifyLinearHistory rejects pushes adding merge commits to the default and
+// protected branches of repositories that require linear history.
+func (d *Backend) verifyLinearHistory(ctx context.Context, repo string, args []hooks.HookArg) error {
+	repo = d.cfg.SanitizeRepo(repo)
+	require, err := d.RequireLinearHistory(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if !require {
+		return nil
+	}
+
+	rp := d.repoPath(repo)
+	head, _ := git.NewCommand("symbolic-ref", git.HEAD).WithContext(ctx).RunInDir(rp)
+	defaultBranch := strings.TrimPrefix(strings.TrimSpace(string(head)), git.RefsHeads)
+	isLinear := func(branch string) bool {
+		return branch == defaultBranch || d.isProtectedBranch(branch)
+	}
+
+	// Commits already on a branch with linear history are accepted, they
+	// were checked when they were pushed.
+	out, err := git.NewCommand("for-each-ref", "--format=%(refname)", git.RefsHeads).WithContext(ctx).RunInDir(rp)
+	if err != nil {
+		return err
+	}
+	var linear []string
+	for _, ref := range strings.Fields(string(out)) {
+		if isLinear(strings.TrimPrefix(ref, git.RefsHeads)) {
+			linear = append(linear, "^"+ref)
+		}
+	}
+
+	for _, arg := range args {
+		if git.IsZeroHash(arg.NewSha) || !strings.HasPrefix(arg.RefName, git.RefsHeads) {
+			continue
+		}
+
+		branch := strings.TrimPrefix(arg.RefName, git.RefsHeads)
+		if !isLinear(branch) {
+			continue
+		}
+
+		// List the merge commits between the old and new tips.
+		revs := append([]string{"rev-list", "--min-parents=2", arg.NewSha}, linear...)
+		if !git.IsZeroHash(arg.OldSha) {
+			revs = append(revs, "^"+arg.OldSha)
+		}
+		out, err := git.NewCommand(revs...).WithContext(ctx).RunInDir(rp)
+		if err != nil {
+			return fmt.Errorf("failed to list new commits of %s: %w", arg.RefName, err)
+		}
+
+		merges := strings.Fields(string(out))
+		if len(merges) == 0 {
+			continue
+		}
+
+		return fmt.Errorf("%s requires linear history on %s, commit %s is a merge commit, rebase instead of merging",
+			repo, branch, merges[len(merges)-1])
+	}
+
+	return nil
+}
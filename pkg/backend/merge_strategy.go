@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// mergeStrategyFile is the name of the file, inside the repository metadata
+// directory, that holds the preferred merge strategy of the repository.
+const mergeStrategyFile = "merge-strategy"
+
+// MergeStrategy returns the preferred merge strategy of a repository, empty
+// if it isn't set.
+func (d *Backend) MergeStrategy(ctx context.Context, repo string) (proto.MergeStrategy, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	bts, err := os.ReadFile(d.repoMetadataPath(r.Name(), mergeStrategyFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return proto.ParseMergeStrategy(strings.TrimSpace(string(bts)))
+}
+
+// SetMergeStrategy sets the preferred merge strategy of a repository. An
+// empty strategy unsets it.
+func (d *Backend) SetMergeStrategy(ctx context.Context, repo string, strategy proto.MergeStrategy) error {
+	if strategy != "" {
+		if _, err := proto.ParseMergeStrategy(string(strategy)); err != nil {
+			return err
+		}
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	fp := d.repoMetadataPath(r.Name(), mergeStrategyFile)
+	if strategy == "" {
+		if err := os.Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	return writeFileAtomic(fp, []byte(strategy.String()+"\n"))
+}
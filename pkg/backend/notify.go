@@ -0,0 +1,114 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// maxNotifyCommits is the maximum number of commits listed in a push
+// notification.
+const maxNotifyCommits = 10
+
+// notify sends a server event notification. A failed notification is logged
+// and never fails the operation that caused it.
+func (d *Backend) notify(ctx context.Context, n notify.Notification) {
+	if d.notifier == nil {
+		return
+	}
+
+	if err := d.notifier.Notify(ctx, n); err != nil {
+		d.logger.Error("error sending notification", "event", n.Event, "err", err)
+	}
+}
+
+// notifyPush sends a push notification summarizing the pushed refs.
+func (d *Backend) notifyPush(ctx context.Context, user proto.User, repo proto.Repository, args []hooks.HookArg) {
+	if d.notifier == nil || len(args) == 0 {
+		return
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		d.logger.Error("error opening repository", "repo", repo.Name(), "err", err)
+		return
+	}
+
+	refs := make([]string, 0, len(args))
+	var details []string
+	var listed int
+	var truncated bool
+	for _, arg := range args {
+		ref := shortRefName(arg.RefName)
+		refs = append(refs, ref)
+		if git.IsZeroHash(arg.NewSha) {
+			details = append(details, "deleted "+ref)
+			continue
+		}
+
+		if truncated {
+			continue
+		}
+
+		rev := arg.NewSha
+		if !git.IsZeroHash(arg.OldSha) {
+			rev = fmt.Sprintf("%s..%s", arg.OldSha, arg.NewSha)
+		}
+
+		commits, err := r.Log(rev, gitm.LogOptions{MaxCount: maxNotifyCommits + 1})
+		if err != nil {
+			d.logger.Error("error listing pushed commits", "repo", repo.Name(), "err", err)
+			continue
+		}
+
+		for _, c := range commits {
+			if listed == maxNotifyCommits {
+				truncated = true
+				break
+			}
+			details = append(details, fmt.Sprintf("%s %s", c.ID.String()[:7], c.Summary()))
+			listed++
+		}
+	}
+
+	if truncated {
+		details = append(details, "and more commits")
+	}
+
+	var actor string
+	if user != nil {
+		actor = user.Username()
+	}
+
+	d.notify(ctx, notify.Notification{
+		Event:   notify.EventPush,
+		Repo:    repo.Name(),
+		Actor:   actor,
+		Summary: fmt.Sprintf("%s pushed to %s", actorName(actor), strings.Join(refs, ", ")),
+		Details: details,
+	})
+}
+
+// shortRefName returns the branch or tag name of ref.
+func shortRefName(ref string) string {
+	for _, prefix := range []string{git.RefsHeads, git.RefsTags} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+	return ref
+}
+
+// actorName returns the name used for actor in notifications.
+func actorName(actor string) string {
+	if actor == "" {
+		return "someone"
+	}
+	return actor
+}
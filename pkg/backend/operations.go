@@ -0,0 +1,28 @@
+package backend
+
+import (
+	"context"
+	"net"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// AcquireGitOperation waits for a slot to run a git operation, such as a
+// clone or a push, for user. Anonymous operations are scheduled by the remote
+// address they come from. The returned function must be called once the
+// operation is done.
+//
+// Slots are limited by the git max_operations setting and waiting operations
+// are admitted according to the git scheduler policy.
+func (d *Backend) AcquireGitOperation(ctx context.Context, user proto.User, remoteAddr string) (func(), error) {
+	var key string
+	if user != nil {
+		key = "user:" + user.Username()
+	} else if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		key = "addr:" + host
+	} else {
+		key = "addr:" + remoteAddr
+	}
+
+	return d.scheduler.Acquire(ctx, key)
+}
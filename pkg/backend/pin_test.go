@@ -0,0 +1,63 @@
+package backend_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestPinnedRepositories(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	bob, err := be.CreateUser(ctx, "bob", proto.UserOptions{})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+
+	for _, name := range []string{"repo1", "repo2"} {
+		_, err := be.CreateRepository(ctx, name, alice, proto.RepositoryOptions{})
+		is.NoErr(err)
+	}
+	_, err = be.CreateRepository(ctx, "secret", alice, proto.RepositoryOptions{Private: true})
+	is.NoErr(err)
+
+	is.NoErr(be.PinRepository(ctx, alice, "repo2"))
+	is.NoErr(be.PinRepository(ctx, alice, "repo1"))
+	// Pinning twice is a no-op.
+	is.NoErr(be.PinRepository(ctx, alice, "repo2"))
+
+	pinned, err := be.PinnedRepositories(ctx, alice)
+	is.NoErr(err)
+	is.Equal(pinned, []string{"repo2", "repo1"})
+
+	// Pins are per user.
+	pinned, err = be.PinnedRepositories(ctx, bob)
+	is.NoErr(err)
+	is.Equal(len(pinned), 0)
+
+	// Users can't pin repositories they can't read.
+	err = be.PinRepository(ctx, bob, "secret")
+	is.True(errors.Is(err, proto.ErrRepoNotFound))
+
+	// Pins follow renamed repositories.
+	is.NoErr(be.RenameRepository(ctx, "repo2", "renamed"))
+	pinned, err = be.PinnedRepositories(ctx, alice)
+	is.NoErr(err)
+	is.Equal(pinned, []string{"renamed", "repo1"})
+
+	is.NoErr(be.UnpinRepository(ctx, alice, "repo1"))
+	pinned, err = be.PinnedRepositories(ctx, alice)
+	is.NoErr(err)
+	is.Equal(pinned, []string{"renamed"})
+
+	// And are removed with them.
+	is.NoErr(be.DeleteRepository(ctx, "renamed"))
+	pinned, err = be.PinnedRepositories(ctx, alice)
+	is.NoErr(err)
+	is.Equal(len(pinned), 0)
+}
@@ -0,0 +1,153 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// RepoPolicy is the policy settings of a repository that presets apply.
+type RepoPolicy struct {
+	RequireSignedCommits bool
+	RequireLinearHistory bool
+	AllowBranchDeletion  bool
+}
+
+// builtinPolicyPresets are the policy presets available without
+// configuration, "open" restores the defaults of new repositories.
+func builtinPolicyPresets() map[string]config.PolicyPreset {
+	yes, no := true, false
+	return map[string]config.PolicyPreset{
+		"strict": {
+			RequireSignedCommits: &yes,
+			RequireLinearHistory: &yes,
+			AllowBranchDeletion:  &no,
+		},
+		"open": {
+			RequireSignedCommits: &no,
+			RequireLinearHistory: &no,
+			AllowBranchDeletion:  &yes,
+		},
+	}
+}
+
+// PolicyPresets returns the repository policy presets, the built-in ones and
+// the ones of Git.PolicyPresets, which replace built-in ones with the same
+// name.
+func (d *Backend) PolicyPresets() map[string]config.PolicyPreset {
+	presets := builtinPolicyPresets()
+	for name, p := range d.cfg.Git.PolicyPresets {
+		presets[name] = p
+	}
+
+	return presets
+}
+
+// PolicyPresetNames returns the sorted names of the repository policy
+// presets.
+func (d *Backend) PolicyPresetNames() []string {
+	presets := d.PolicyPresets()
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// RepoPolicy returns the policy settings of a repository.
+func (d *Backend) RepoPolicy(ctx context.Context, name string) (RepoPolicy, error) {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return RepoPolicy{}, err
+	}
+
+	var p RepoPolicy
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		if p.RequireSignedCommits, err = d.store.GetRepoRequireSignedCommitsByName(ctx, tx, r.Name()); err != nil {
+			return err
+		}
+		if p.RequireLinearHistory, err = d.store.GetRepoRequireLinearHistoryByName(ctx, tx, r.Name()); err != nil {
+			return err
+		}
+		p.AllowBranchDeletion, err = d.store.GetRepoAllowBranchDeletionByName(ctx, tx, r.Name())
+		return err
+	}); err != nil {
+		return RepoPolicy{}, db.WrapError(err)
+	}
+
+	return p, nil
+}
+
+// MatchingPolicyPresets returns the sorted names of the presets that the
+// policy of a repository matches.
+func (d *Backend) MatchingPolicyPresets(p RepoPolicy) []string {
+	var names []string
+	for name, preset := range d.PolicyPresets() {
+		if policyMatches(preset.RequireSignedCommits, p.RequireSignedCommits) &&
+			policyMatches(preset.RequireLinearHistory, p.RequireLinearHistory) &&
+			policyMatches(preset.AllowBranchDeletion, p.AllowBranchDeletion) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func policyMatches(want *bool, v bool) bool {
+	return want == nil || *want == v
+}
+
+// ApplyPolicyPreset applies the settings of a policy preset to a repository,
+// all of them or none.
+func (d *Backend) ApplyPolicyPreset(ctx context.Context, name string, preset string) error {
+	p, ok := d.PolicyPresets()[preset]
+	if !ok {
+		return fmt.Errorf("unknown policy preset %q, available presets are: %s", preset, strings.Join(d.PolicyPresetNames(), ", "))
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Delete cache
+	d.cache.Delete(r.Name())
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if p.RequireSignedCommits != nil {
+			if err := d.store.SetRepoRequireSignedCommitsByName(ctx, tx, r.Name(), *p.RequireSignedCommits); err != nil {
+				return err
+			}
+		}
+		if p.RequireLinearHistory != nil {
+			if err := d.store.SetRepoRequireLinearHistoryByName(ctx, tx, r.Name(), *p.RequireLinearHistory); err != nil {
+				return err
+			}
+		}
+		if p.AllowBranchDeletion != nil {
+			if err := d.store.SetRepoAllowBranchDeletionByName(ctx, tx, r.Name(), *p.AllowBranchDeletion); err != nil {
+				return err
+			}
+		}
+		return nil
+	})); err != nil {
+		return err
+	}
+
+	var actor string
+	if user := proto.UserFromContext(ctx); user != nil {
+		actor = user.Username()
+	}
+	d.logger.Info("applied policy preset", "repo", r.Name(), "preset", preset, "actor", actor)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package backend
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// AutoPruneBranches returns true if the merged branches of the repository
+// are pruned by the prune branches job.
+func (d *Backend) AutoPruneBranches(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var prune bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		prune, err = d.store.GetRepoPruneMergedBranchesByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return prune, nil
+}
+
+// SetAutoPruneBranches sets whether the merged branches of the repository
+// are pruned by the prune branches job.
+func (d *Backend) SetAutoPruneBranches(ctx context.Context, name string, prune bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoPruneMergedBranchesByName(ctx, tx, name, prune)
+	}))
+}
+
+// PrunableBranches returns the branches of a repository that are fully
+// merged into the default branch, whose tip was committed longer than
+// Jobs.PruneBranchesAge ago, and that aren't protected.
+func (d *Backend) PrunableBranches(_ context.Context, repo proto.Repository) ([]git.BranchTip, error) {
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	if bs, _ := r.Branches(); len(bs) == 0 {
+		return []git.BranchTip{}, nil
+	}
+
+	head, err := r.HEAD()
+	if err != nil {
+		return nil, err
+	}
+
+	tips, err := r.MergedBranchTips(head.Name().String())
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-d.cfg.Jobs.PruneBranchesAge)
+	prunable := make([]git.BranchTip, 0, len(tips))
+	for _, tip := range tips {
+		if tip.Name().String() == head.Name().String() ||
+			d.isProtectedBranch(tip.Name().Short()) ||
+			tip.When.After(cutoff) {
+			continue
+		}
+		prunable = append(prunable, tip)
+	}
+
+	return prunable, nil
+}
+
+// PruneBranches deletes the prunable branches of a repository and returns
+// them. Each deletion is logged and sent to the branch and tag webhooks of
+// the repository. User is the user pruning the branches, nil for the prune
+// branches job.
+func (d *Backend) PruneBranches(ctx context.Context, repo proto.Repository, user proto.User) ([]git.BranchTip, error) {
+	tips, err := d.PrunableBranches(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	pruned := make([]git.BranchTip, 0, len(tips))
+	for _, tip := range tips {
+		// Only delete the branch if nobody pushed to it in the meantime.
+		ref := tip.Name().String()
+		if _, err := git.NewCommand("update-ref", "-d", ref, tip.ID).WithContext(ctx).RunInDir(r.Path); err != nil {
+			d.logger.Error("error pruning merged branch", "repo", repo.Name(), "branch", tip.Name().Short(), "err", err)
+			continue
+		}
+
+		d.logger.Info("pruned merged branch", "repo", repo.Name(), "branch", tip.Name().Short(), "commit", tip.ID)
+		pruned = append(pruned, tip)
+
+		wh, err := webhook.NewBranchTagEvent(ctx, user, repo, ref, tip.ID, git.ZeroID)
+		if err != nil {
+			d.logger.Error("error creating branch_tag webhook", "err", err)
+		} else if err := webhook.SendEvent(ctx, wh); err != nil {
+			d.logger.Error("error sending branch_tag webhook", "err", err)
+		}
+	}
+
+	return pruned, nil
+}
+
+// isProtectedBranch returns true if the branch matches one of the protected
+// branch patterns.
+func (d *Backend) isProtectedBranch(branch string) bool {
+	for _, pattern := range d.cfg.Jobs.ProtectedBranches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
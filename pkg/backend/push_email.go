@@ -0,0 +1,313 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/mail"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+const (
+	// maxPushEmailCommits is the maximum number of commits listed per ref in
+	// a push email.
+	maxPushEmailCommits = 100
+
+	// maxPushEmailFiles is the maximum number of files listed per ref in the
+	// diffstat of a push email.
+	maxPushEmailFiles = 50
+
+	// maxPushEmailAttempts is the number of times delivering a push email is
+	// attempted before it's dropped.
+	maxPushEmailAttempts = 5
+
+	// pushEmailBatch is the maximum number of push emails sent at once.
+	pushEmailBatch = 50
+)
+
+// PushEmail returns the push email configuration of a repository. It returns
+// proto.ErrPushEmailNotFound if push emails aren't configured.
+func (d *Backend) PushEmail(ctx context.Context, repo string) (proto.PushEmail, error) {
+	repo = d.cfg.SanitizeRepo(repo)
+
+	var m models.RepoPushEmail
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.GetRepoPushEmailByName(ctx, tx, repo)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return proto.PushEmail{}, proto.ErrPushEmailNotFound
+		}
+		return proto.PushEmail{}, err
+	}
+
+	recipients, err := mail.ParseAddressList(m.Recipients)
+	if err != nil {
+		return proto.PushEmail{}, err
+	}
+
+	return proto.PushEmail{
+		Recipients: recipients,
+		From:       m.FromAddress,
+		SMTPURL:    m.SMTPURL,
+	}, nil
+}
+
+// SetPushEmail sends a summary email to the recipients of pe after every
+// push to a repository.
+func (d *Backend) SetPushEmail(ctx context.Context, repo string, pe proto.PushEmail) error {
+	repo = d.cfg.SanitizeRepo(repo)
+	if _, err := d.Repository(ctx, repo); err != nil {
+		return err
+	}
+
+	if len(pe.Recipients) == 0 {
+		return errors.New("no recipients")
+	}
+	recipients, err := mail.ParseAddressList(strings.Join(pe.Recipients, ","))
+	if err != nil {
+		return fmt.Errorf("invalid recipients: %w", err)
+	}
+
+	if pe.From != "" {
+		if _, err := mail.ParseAddress(pe.From); err != nil {
+			return fmt.Errorf("invalid from address: %w", err)
+		}
+	} else if d.cfg.Mail.From == "" {
+		return errors.New("missing from address, the server doesn't have a default")
+	}
+
+	if pe.SMTPURL != "" {
+		if _, err := mail.ParseURL(pe.SMTPURL); err != nil {
+			return err
+		}
+	} else if d.cfg.Mail.SMTPURL == "" {
+		return errors.New("missing smtp url, the server doesn't have a default")
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetRepoPushEmailByName(ctx, tx, repo, strings.Join(recipients, ", "), pe.From, pe.SMTPURL)
+		}),
+	)
+}
+
+// RemovePushEmail stops sending push emails for a repository, including the
+// emails that are still queued.
+func (d *Backend) RemovePushEmail(ctx context.Context, repo string) error {
+	repo = d.cfg.SanitizeRepo(repo)
+	if _, err := d.PushEmail(ctx, repo); err != nil {
+		return err
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.RemoveRepoPushEmailByName(ctx, tx, repo)
+		}),
+	)
+}
+
+// queuePushEmail queues a push email summarizing the pushed refs. The email
+// is delivered in the background by SendPushEmails so a slow or unreachable
+// SMTP server never holds up, or fails, the push.
+func (d *Backend) queuePushEmail(ctx context.Context, user proto.User, repo proto.Repository, args []hooks.HookArg) {
+	if len(args) == 0 {
+		return
+	}
+
+	if _, err := d.PushEmail(ctx, repo.Name()); err != nil {
+		if !errors.Is(err, proto.ErrPushEmailNotFound) {
+			d.logger.Error("error getting push email settings", "repo", repo.Name(), "err", err)
+		}
+		return
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		d.logger.Error("error opening repository", "repo", repo.Name(), "err", err)
+		return
+	}
+
+	var actor string
+	if user != nil {
+		actor = user.Username()
+	}
+
+	subject, body := pushEmailMessage(r, repo.Name(), actor, args)
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.EnqueuePushEmail(ctx, tx, repo.Name(), subject, body)
+	}); err != nil {
+		d.logger.Error("error queuing push email", "repo", repo.Name(), "err", db.WrapError(err))
+	}
+}
+
+// pushEmailMessage returns the subject and body of a push email. Like git's
+// post-receive-email, it lists the new commits and the diffstat of each
+// updated ref.
+func pushEmailMessage(r *git.Repository, repo string, actor string, args []hooks.HookArg) (string, string) {
+	refs := make([]string, 0, len(args))
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s pushed to %s.\n", actorName(actor), repo)
+
+	for _, arg := range args {
+		ref := shortRefName(arg.RefName)
+		refs = append(refs, ref)
+		kind := "branch"
+		if strings.HasPrefix(arg.RefName, git.RefsTags) {
+			kind = "tag"
+		} else if !strings.HasPrefix(arg.RefName, git.RefsHeads) {
+			kind = "ref"
+		}
+
+		b.WriteString("\n")
+		switch {
+		case git.IsZeroHash(arg.NewSha):
+			fmt.Fprintf(&b, "%s %s deleted (was %s)\n", kind, ref, shortHash(arg.OldSha))
+			continue
+		case git.IsZeroHash(arg.OldSha):
+			fmt.Fprintf(&b, "%s %s created at %s\n", kind, ref, shortHash(arg.NewSha))
+		default:
+			fmt.Fprintf(&b, "%s %s updated: %s..%s\n", kind, ref, shortHash(arg.OldSha), shortHash(arg.NewSha))
+		}
+
+		if kind != "branch" {
+			continue
+		}
+
+		// New branches list the commits that aren't on any other branch.
+		revs := []string{arg.NewSha, "--not", "--exclude=" + ref, "--branches"}
+		if !git.IsZeroHash(arg.OldSha) {
+			revs = []string{arg.OldSha + ".." + arg.NewSha}
+		}
+
+		out, err := git.NewCommand(append([]string{"log", "--format=%h %s (%an)", fmt.Sprintf("--max-count=%d", maxPushEmailCommits+1)}, revs...)...).RunInDir(r.Path)
+		if err != nil {
+			fmt.Fprintf(&b, "\n  (error listing commits: %v)\n", err)
+			continue
+		}
+
+		commits := strings.Split(strings.TrimSpace(string(out)), "\n")
+		if len(commits) > 0 && commits[0] != "" {
+			b.WriteString("\n")
+			for i, c := range commits {
+				if i == maxPushEmailCommits {
+					b.WriteString("  ... and more commits\n")
+					break
+				}
+				fmt.Fprintf(&b, "  %s\n", c)
+			}
+		}
+
+		if git.IsZeroHash(arg.OldSha) {
+			continue
+		}
+
+		stat, err := r.DiffStat(maxPushEmailFiles, arg.OldSha, arg.NewSha)
+		if err != nil {
+			fmt.Fprintf(&b, "\n  (error computing diffstat: %v)\n", err)
+			continue
+		}
+		if stat != "" {
+			b.WriteString("\n")
+			b.WriteString(stat)
+		}
+	}
+
+	subject := fmt.Sprintf("[%s] %s pushed to %s", repo, actorName(actor), strings.Join(refs, ", "))
+	return subject, b.String()
+}
+
+// shortHash returns the abbreviated form of a commit hash.
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
+
+// SendPushEmails delivers the queued push emails. Failed deliveries are
+// retried on the next call, up to maxPushEmailAttempts times. It returns
+// immediately if a previous call is still delivering emails.
+func (d *Backend) SendPushEmails(ctx context.Context) error {
+	if !d.pushEmailMu.TryLock() {
+		return nil
+	}
+	defer d.pushEmailMu.Unlock()
+
+	var queued []models.PushEmail
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		queued, err = d.store.ListPushEmails(ctx, tx, pushEmailBatch)
+		return err
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	for _, m := range queued {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := d.sendPushEmail(ctx, m)
+		if err == nil || m.Attempts+1 >= maxPushEmailAttempts {
+			if err != nil {
+				d.logger.Error("dropping push email", "repo", m.RepoName, "attempts", m.Attempts+1, "err", err)
+			}
+			if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+				return d.store.RemovePushEmail(ctx, tx, m.ID)
+			}); err != nil {
+				return db.WrapError(err)
+			}
+			continue
+		}
+
+		d.logger.Warn("error sending push email", "repo", m.RepoName, "attempts", m.Attempts+1, "err", err)
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.IncrementPushEmailAttempts(ctx, tx, m.ID)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+// sendPushEmail delivers a queued push email using the current settings of
+// its repository.
+func (d *Backend) sendPushEmail(ctx context.Context, m models.PushEmail) error {
+	pe, err := d.PushEmail(ctx, m.RepoName)
+	if errors.Is(err, proto.ErrPushEmailNotFound) {
+		// Push emails were disabled after the email was queued.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	from := pe.From
+	if from == "" {
+		from = d.cfg.Mail.From
+	}
+	smtpURL := pe.SMTPURL
+	if smtpURL == "" {
+		smtpURL = d.cfg.Mail.SMTPURL
+	}
+	if smtpURL == "" {
+		return errors.New("no smtp server configured")
+	}
+
+	return mail.Send(ctx, smtpURL, mail.Message{
+		From:    from,
+		To:      pe.Recipients,
+		Subject: m.Subject,
+		Body:    m.Body,
+		Date:    m.CreatedAt,
+	})
+}
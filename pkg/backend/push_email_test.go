@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+)
+
+func TestPushEmailMessage(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Alice", "-c", "user.email=alice@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "first")
+	first := run("rev-parse", "HEAD")
+	run("commit", "-q", "--allow-empty", "-m", "second")
+	run("checkout", "-q", "-b", "dev")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "README.md")
+	run("commit", "-q", "-m", "add readme")
+	dev := run("rev-parse", "HEAD")
+
+	zero := strings.Repeat("0", 40)
+	r, err := git.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, body := pushEmailMessage(r, "repo1", "alice", []hooks.HookArg{
+		{OldSha: first, NewSha: dev, RefName: "refs/heads/main"},
+		{OldSha: zero, NewSha: dev, RefName: "refs/heads/dev"},
+		{OldSha: first, NewSha: zero, RefName: "refs/tags/v1"},
+	})
+
+	if subject != "[repo1] alice pushed to main, dev, v1" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+
+	for _, want := range []string{
+		"alice pushed to repo1.\n",
+		"branch main updated: " + first[:7] + ".." + dev[:7] + "\n\n  " + dev[:7] + " add readme (Alice)\n  ",
+		" second (Alice)\n\n README.md | 1 +\n 1 file changed, 1 insertion(+)\n",
+		"branch dev created at " + dev[:7] + "\n\n  " + dev[:7] + " add readme (Alice)\n",
+		"tag v1 deleted (was " + first[:7] + ")\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	// New branches only list the commits that aren't on other branches.
+	if strings.Count(body, " second (Alice)") != 1 {
+		t.Errorf("expected one listing of the second commit, got:\n%s", body)
+	}
+}
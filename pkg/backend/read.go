@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	logr "github.com/charmbracelet/soft-serve/pkg/log"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// AuditRead records a clone or fetch of the repository by user over the
+// given transport. It does nothing if read auditing is disabled for the
+// server or the repository. A nil user is an anonymous read. Only a sample of
+// the fetches is recorded, see Log.SampleRate, clones are always recorded.
+func (d *Backend) AuditRead(ctx context.Context, repo string, user proto.User, transport string, clone bool) error {
+	if !d.cfg.Git.ReadAudit || (!clone && !logr.Sample(d.cfg.Log.SampleRate)) {
+		return nil
+	}
+
+	repo = d.cfg.SanitizeRepo(repo)
+	audit, err := d.ReadAudit(ctx, repo)
+	if err != nil || !audit {
+		return err
+	}
+
+	var userID int64
+	if user != nil {
+		userID = user.ID()
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.AddRepoRead(ctx, tx, repo, userID, transport, clone)
+		}),
+	)
+}
+
+// RepositoryReads returns the most recent audited reads of the repository,
+// newest first.
+func (d *Backend) RepositoryReads(ctx context.Context, repo string, limit int) ([]proto.RepositoryRead, error) {
+	repo = d.cfg.SanitizeRepo(repo)
+
+	var ms []models.RepoRead
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.ListRepoReadsByName(ctx, tx, repo, limit)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	reads := make([]proto.RepositoryRead, len(ms))
+	for i, m := range ms {
+		reads[i] = proto.RepositoryRead{
+			Username:  m.Username.String,
+			Transport: m.Transport,
+			Clone:     m.Clone,
+			CreatedAt: m.CreatedAt,
+		}
+	}
+
+	return reads, nil
+}
+
+// PruneRepositoryReads deletes the audited reads older than
+// Jobs.PruneReadsAge, and returns how many were deleted. It does nothing if
+// the age is 0.
+func (d *Backend) PruneRepositoryReads(ctx context.Context) (int64, error) {
+	age := d.cfg.Jobs.PruneReadsAge
+	if age <= 0 {
+		return 0, nil
+	}
+
+	var n int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		n, err = d.store.DeleteRepoReadsBefore(ctx, tx, time.Now().Add(-age))
+		return err
+	}); err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,52 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestPruneRepositoryReads(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Jobs.PruneReadsAge = 24 * time.Hour
+	})
+	cfg := config.FromContext(ctx)
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	_, err = be.CreateRepository(ctx, "repo1", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+	for i := 0; i < 3; i++ {
+		is.NoErr(be.AuditRead(ctx, "repo1", alice, "ssh", true))
+	}
+
+	// Backdate the first two reads.
+	_, err = db.FromContext(ctx).ExecContext(ctx, `UPDATE repo_reads SET created_at = datetime('now', '-2 days') WHERE id <= 2;`)
+	is.NoErr(err)
+
+	n, err := be.PruneRepositoryReads(ctx)
+	is.NoErr(err)
+	is.Equal(n, int64(2))
+	reads, err := be.RepositoryReads(ctx, "repo1", 10)
+	is.NoErr(err)
+	is.Equal(len(reads), 1)
+
+	// Nothing left to prune.
+	n, err = be.PruneRepositoryReads(ctx)
+	is.NoErr(err)
+	is.Equal(n, int64(0))
+
+	// An age of 0 keeps all reads.
+	_, err = db.FromContext(ctx).ExecContext(ctx, `UPDATE repo_reads SET created_at = datetime('now', '-2 days');`)
+	is.NoErr(err)
+	cfg.Jobs.PruneReadsAge = 0
+	n, err = be.PruneRepositoryReads(ctx)
+	is.NoErr(err)
+	is.Equal(n, int64(0))
+}
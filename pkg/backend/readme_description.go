@@ -0,0 +1,180 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// maxReadmeDescription is the maximum length, in characters, of descriptions
+// taken from a README.
+const maxReadmeDescription = 256
+
+var (
+	readmeImageRe = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	readmeLinkRe  = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+)
+
+// ReadmeDescription returns true if the description of a repository is taken
+// from its README. overridden is true if the repository overrides
+// Git.DescriptionFromReadme.
+func (d *Backend) ReadmeDescription(ctx context.Context, name string) (enabled bool, overridden bool, err error) {
+	m, err := d.repoModel(ctx, name)
+	if err != nil {
+		return false, false, err
+	}
+
+	return d.readmeDescription(m), m.ReadmeDescription.Valid, nil
+}
+
+// SetReadmeDescription overrides Git.DescriptionFromReadme for a repository. A
+// nil value uses the server default.
+func (d *Backend) SetReadmeDescription(ctx context.Context, name string, enabled *bool) error {
+	name = d.cfg.SanitizeRepo(name)
+	if _, err := d.Repository(ctx, name); err != nil {
+		return err
+	}
+
+	var v sql.NullBool
+	if enabled != nil {
+		v = sql.NullBool{Bool: *enabled, Valid: true}
+	}
+
+	d.cache.Delete(name)
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoReadmeDescriptionByName(ctx, tx, name, v)
+	}))
+}
+
+// UpdateReadmeDescription sets the description of a repository to the first
+// paragraph of its README, if enabled. Descriptions set explicitly are kept.
+// It's called after pushes.
+func (d *Backend) UpdateReadmeDescription(ctx context.Context, name string) error {
+	m, err := d.repoModel(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if !d.readmeDescription(m) || (m.Description != "" && !m.DescriptionGenerated) {
+		return nil
+	}
+
+	r, err := d.Repository(ctx, m.Name)
+	if err != nil {
+		return err
+	}
+
+	readme, _, err := Readme(r, nil)
+	if errors.Is(err, git.ErrFileNotFound) || errors.Is(err, git.ErrReferenceNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	desc := readmeParagraph(readme)
+	if desc == "" || desc == m.Description {
+		return nil
+	}
+
+	d.cache.Delete(m.Name)
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := os.WriteFile(filepath.Join(d.repoPath(m.Name), "description"), []byte(desc), fs.ModePerm); err != nil {
+			return err
+		}
+
+		return d.store.SetRepoGeneratedDescriptionByName(ctx, tx, m.Name, desc)
+	}))
+}
+
+func (d *Backend) readmeDescription(m models.Repo) bool {
+	if m.ReadmeDescription.Valid {
+		return m.ReadmeDescription.Bool
+	}
+
+	return d.cfg.Git.DescriptionFromReadme
+}
+
+func (d *Backend) repoModel(ctx context.Context, name string) (models.Repo, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var m models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.GetRepoByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return models.Repo{}, db.WrapError(err)
+	}
+
+	return m, nil
+}
+
+// readmeParagraph returns the first paragraph of a README that isn't a
+// heading, a badge, or HTML, as plain text.
+func readmeParagraph(readme string) string {
+	var para []string
+	var fenced bool
+	for _, line := range strings.Split(readme, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			if len(para) > 0 {
+				break
+			}
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+
+		if isHeadingUnderline(line) {
+			// The lines before were a heading.
+			para = nil
+			continue
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "<") ||
+			strings.HasPrefix(line, "![") || strings.HasPrefix(line, "[![") {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+
+		para = append(para, line)
+	}
+
+	s := strings.Join(para, " ")
+	s = readmeImageRe.ReplaceAllString(s, "")
+	s = readmeLinkRe.ReplaceAllString(s, "$1")
+	s = strings.NewReplacer("**", "", "__", "", "`", "").Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) > maxReadmeDescription {
+		s = string([]rune(s)[:maxReadmeDescription-1])
+		if i := strings.LastIndex(s, " "); i > 0 {
+			s = s[:i]
+		}
+		s += "…"
+	}
+
+	return s
+}
+
+// isHeadingUnderline returns true if the line underlines a heading, e.g.
+// "=====" or "-----".
+func isHeadingUnderline(line string) bool {
+	if len(line) < 3 {
+		return false
+	}
+
+	return strings.Trim(line, string(line[0])) == "" && strings.ContainsRune("=-~^*", rune(line[0]))
+}
@@ -0,0 +1,28 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestReadmeParagraph(t *testing.T) {
+	cases := map[string]string{
+		"# Project\n\nA **fast** `git` server.\nOver SSH.\n\nMore.":                                             "A fast git server. Over SSH.",
+		"Project\n=======\n\nFirst [paragraph](https://example.com).":                                           "First paragraph.",
+		"<p align=\"center\"><img src=\"logo.png\"></p>\n\n[![CI](https://ci/badge.svg)](https://ci)\n\nHello.": "Hello.",
+		"# Title\n\n```sh\nmake\n```\n\nBuild it ![logo](logo.png) yourself.":                                   "Build it yourself.",
+		"# Only a heading\n": "",
+		"":                   "",
+	}
+	for readme, want := range cases {
+		if got := readmeParagraph(readme); got != want {
+			t.Errorf("readmeParagraph(%q) = %q, want %q", readme, got, want)
+		}
+	}
+
+	long := readmeParagraph(strings.Repeat("word ", 100))
+	if n := utf8.RuneCountInString(long); n > maxReadmeDescription || !strings.HasSuffix(long, "word…") {
+		t.Errorf("expected a truncated description, got %d characters: %q", n, long)
+	}
+}
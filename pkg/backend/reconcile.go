@@ -0,0 +1,232 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ReconcileReport lists the differences between the repositories on disk and
+// their metadata.
+type ReconcileReport struct {
+	// Orphans are the repositories on disk without metadata.
+	Orphans []string
+	// Dangling are the repositories with metadata that are missing on disk.
+	Dangling []string
+}
+
+// Reconcile compares the repositories directory with the repository
+// metadata. When fix is true, default metadata is created for orphaned
+// repositories, owned by the user in ctx, or the first admin, and private
+// unless they're exported to the git daemon. The metadata of missing
+// repositories is removed along with their LFS objects. Each fix is logged
+// and sends a repository_reconcile notification.
+func (d *Backend) Reconcile(ctx context.Context, fix bool) (ReconcileReport, error) {
+	var report ReconcileReport
+	onDisk, err := d.repositoriesOnDisk()
+	if err != nil {
+		return report, err
+	}
+
+	var known []string
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		ms, err := d.store.GetAllRepos(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range ms {
+			known = append(known, m.Name)
+			if !slices.Contains(onDisk, m.Name) {
+				report.Dangling = append(report.Dangling, m.Name)
+			}
+		}
+		return nil
+	}); err != nil {
+		return report, db.WrapError(err)
+	}
+
+	for _, name := range onDisk {
+		if !slices.Contains(known, name) {
+			report.Orphans = append(report.Orphans, name)
+		}
+	}
+
+	if !fix {
+		return report, nil
+	}
+
+	// A missing or empty repositories directory, e.g. an unmounted volume,
+	// would have all the metadata removed.
+	if len(known) > 0 && len(onDisk) == 0 {
+		return report, fmt.Errorf("refusing to fix: the repositories directory %s is missing or empty",
+			filepath.Join(d.cfg.DataPath, "repos"))
+	}
+
+	var actor string
+	var ownerID int64
+	if user := proto.UserFromContext(ctx); user != nil {
+		actor = user.Username()
+		ownerID = user.ID()
+	}
+
+	for _, name := range report.Orphans {
+		if err := d.adoptRepository(ctx, name, ownerID); err != nil {
+			return report, fmt.Errorf("failed to create metadata of %s: %w", name, err)
+		}
+
+		d.logger.Info("created missing repository metadata", "repo", name, "actor", actor)
+		d.notify(ctx, notify.Notification{
+			Event:   notify.EventRepositoryReconcile,
+			Repo:    name,
+			Actor:   actor,
+			Summary: fmt.Sprintf("%s created the missing metadata of repository %s", actorName(actor), name),
+		})
+	}
+
+	dangling := report.Dangling
+	report.Dangling = nil
+	for _, name := range dangling {
+		removed, err := d.removeDanglingRepository(ctx, name)
+		if err != nil {
+			return report, fmt.Errorf("failed to remove metadata of %s: %w", name, err)
+		}
+		if !removed {
+			// The repository was created after the directory was scanned.
+			continue
+		}
+		report.Dangling = append(report.Dangling, name)
+
+		d.logger.Info("removed metadata of missing repository", "repo", name, "actor", actor)
+		d.notify(ctx, notify.Notification{
+			Event:   notify.EventRepositoryReconcile,
+			Repo:    name,
+			Actor:   actor,
+			Summary: fmt.Sprintf("%s removed the metadata of missing repository %s", actorName(actor), name),
+		})
+	}
+
+	return report, nil
+}
+
+// repositoriesOnDisk returns the names of the bare repositories in the
+// repositories directory, including nested ones.
+func (d *Backend) repositoriesOnDisk() ([]string, error) {
+	root := filepath.Join(d.cfg.DataPath, "repos")
+	var names []string
+	err := filepath.WalkDir(root, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		if e.IsDir() && p == filepath.Join(root, encryptedTrashDir) {
+			return filepath.SkipDir
+		}
+
+		if !e.IsDir() || !strings.HasSuffix(e.Name(), ".git") {
+			return nil
+		}
+
+		// Bare repositories have a HEAD file, other directories named *.git
+		// can hold nested repositories.
+		if _, err := os.Stat(filepath.Join(p, "HEAD")); err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(filepath.ToSlash(rel), ".git")
+		if err := utils.ValidateRepo(name); err != nil {
+			d.logger.Warn("skipping repository with an invalid name", "path", p, "err", err)
+		} else {
+			names = append(names, name)
+		}
+		return filepath.SkipDir
+	})
+
+	return names, err
+}
+
+// adoptRepository creates default metadata for a repository on disk. The
+// repository is owned by the first admin if ownerID is 0.
+func (d *Backend) adoptRepository(ctx context.Context, name string, ownerID int64) error {
+	defer d.cache.Delete(name)
+
+	_, err := os.Stat(filepath.Join(d.repoPath(name), "git-daemon-export-ok"))
+	private := err != nil
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.checkRepoCaseCollision(ctx, tx, name, ""); err != nil {
+			return err
+		}
+
+		if ownerID == 0 {
+			users, err := d.store.GetAllUsers(ctx, tx)
+			if err != nil {
+				return err
+			}
+			for _, u := range users {
+				if u.Admin && (ownerID == 0 || u.ID < ownerID) {
+					ownerID = u.ID
+				}
+			}
+			if ownerID == 0 {
+				return proto.ErrUserNotFound
+			}
+		}
+
+		if err := d.store.CreateRepo(ctx, tx, name, ownerID, "", "", private, false, false); err != nil {
+			return err
+		}
+
+		return hooks.GenerateHooks(ctx, d.cfg, name)
+	}))
+}
+
+// removeDanglingRepository removes the metadata and the LFS objects of a
+// repository missing on disk. The repository is checked again once the
+// metadata is locked, nothing is removed if it exists by then.
+func (d *Backend) removeDanglingRepository(ctx context.Context, name string) (bool, error) {
+	defer d.cache.Delete(name)
+
+	var removed bool
+	err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		m, err := d.store.GetRepoByName(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(d.repoPath(name)); err == nil {
+			return nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		if err := d.store.DeleteRepoByName(ctx, tx, name); err != nil {
+			return err
+		}
+
+		removed = true
+		return os.RemoveAll(filepath.Join(d.cfg.DataPath, "lfs", strconv.FormatInt(m.ID, 10)))
+	})
+
+	return removed, db.WrapError(err)
+}
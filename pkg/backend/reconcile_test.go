@@ -0,0 +1,88 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestReconcile(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+	repos := filepath.Join(config.FromContext(ctx).DataPath, "repos")
+
+	admin, err := be.User(ctx, "admin")
+	is.NoErr(err)
+	_, err = be.CreateRepository(ctx, "kept", admin, proto.RepositoryOptions{})
+	is.NoErr(err)
+	_, err = be.CreateRepository(ctx, "missing", admin, proto.RepositoryOptions{})
+	is.NoErr(err)
+	is.NoErr(os.RemoveAll(filepath.Join(repos, "missing.git")))
+	_, err = git.Init(filepath.Join(repos, "group", "orphan.git"), true)
+	is.NoErr(err)
+
+	report, err := be.Reconcile(ctx, false)
+	is.NoErr(err)
+	is.Equal(report.Orphans, []string{"group/orphan"})
+	is.Equal(report.Dangling, []string{"missing"})
+
+	// Nothing changes without fix.
+	_, err = be.Repository(ctx, "group/orphan")
+	is.Equal(err, proto.ErrRepoNotFound)
+
+	report, err = be.Reconcile(ctx, true)
+	is.NoErr(err)
+	is.Equal(len(report.Orphans), 1)
+	is.Equal(len(report.Dangling), 1)
+
+	r, err := be.Repository(ctx, "group/orphan")
+	is.NoErr(err)
+	is.True(r.IsPrivate())
+	// Without a user, orphans are owned by the first admin.
+	is.Equal(r.UserID(), admin.ID())
+	_, err = os.Stat(filepath.Join(repos, "group", "orphan.git", "hooks", "pre-receive"))
+	is.NoErr(err)
+
+	repos2, err := be.Repositories(ctx)
+	is.NoErr(err)
+	is.Equal(len(repos2), 2)
+
+	report, err = be.Reconcile(ctx, false)
+	is.NoErr(err)
+	is.Equal(len(report.Orphans)+len(report.Dangling), 0)
+}
+
+func TestReconcileMissingReposDir(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+	repos := filepath.Join(config.FromContext(ctx).DataPath, "repos")
+
+	admin, err := be.User(ctx, "admin")
+	is.NoErr(err)
+	_, err = be.CreateRepository(ctx, "repo1", admin, proto.RepositoryOptions{})
+	is.NoErr(err)
+
+	// An unmounted or missing repositories directory doesn't remove the
+	// metadata of every repository.
+	is.NoErr(os.Rename(repos, repos+".bak"))
+	report, err := be.Reconcile(ctx, false)
+	is.NoErr(err)
+	is.Equal(report.Dangling, []string{"repo1"})
+	_, err = be.Reconcile(ctx, true)
+	is.True(err != nil)
+
+	is.NoErr(os.Mkdir(repos, os.ModePerm))
+	_, err = be.Reconcile(ctx, true)
+	is.True(err != nil)
+
+	is.NoErr(os.RemoveAll(repos))
+	is.NoErr(os.Rename(repos+".bak", repos))
+	_, err = be.Repository(ctx, "repo1")
+	is.NoErr(err)
+}
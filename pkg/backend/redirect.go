@@ -0,0 +1,115 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// AddRedirect redirects requests for the repository name oldName to the
+// repository newName. Redirects are added automatically when a repository is
+// renamed.
+func (d *Backend) AddRedirect(ctx context.Context, oldName string, newName string) error {
+	oldName = d.cfg.SanitizeRepo(oldName)
+	if err := utils.ValidateRepo(oldName); err != nil {
+		return err
+	}
+
+	newName = d.cfg.SanitizeRepo(newName)
+	if _, err := d.Repository(ctx, newName); err != nil {
+		return err
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.AddRepoRedirect(ctx, tx, oldName, newName)
+		}),
+	)
+}
+
+// ResolveRedirect returns the name of the repository that requests for name
+// are redirected to. It returns proto.ErrRedirectNotFound if there is no
+// active redirect for name.
+func (d *Backend) ResolveRedirect(ctx context.Context, name string) (string, error) {
+	name = d.cfg.SanitizeRepo(name)
+
+	var m models.RepoRedirect
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.GetRepoRedirectByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return "", proto.ErrRedirectNotFound
+		}
+		return "", err
+	}
+
+	if d.redirectExpired(m) {
+		if err := d.RemoveRedirect(ctx, name); err != nil {
+			d.logger.Error("error removing expired redirect", "name", name, "err", err)
+		}
+		return "", proto.ErrRedirectNotFound
+	}
+
+	return m.RepoName, nil
+}
+
+// Redirects returns the active repository redirects.
+func (d *Backend) Redirects(ctx context.Context) ([]proto.RepositoryRedirect, error) {
+	var ms []models.RepoRedirect
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.ListRepoRedirects(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	redirects := make([]proto.RepositoryRedirect, 0, len(ms))
+	for _, m := range ms {
+		if d.redirectExpired(m) {
+			continue
+		}
+
+		r := proto.RepositoryRedirect{
+			Name:      m.Name,
+			Repo:      m.RepoName,
+			CreatedAt: m.CreatedAt,
+		}
+		if exp := d.cfg.Git.RedirectExpiry; exp > 0 {
+			r.ExpiresAt = m.CreatedAt.Add(exp)
+		}
+		redirects = append(redirects, r)
+	}
+
+	return redirects, nil
+}
+
+// RemoveRedirect removes the redirect for the repository name.
+func (d *Backend) RemoveRedirect(ctx context.Context, name string) error {
+	name = d.cfg.SanitizeRepo(name)
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			if _, err := d.store.GetRepoRedirectByName(ctx, tx, name); err != nil {
+				if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+					return proto.ErrRedirectNotFound
+				}
+				return err
+			}
+
+			return d.store.RemoveRepoRedirectByName(ctx, tx, name)
+		}),
+	)
+}
+
+func (d *Backend) redirectExpired(m models.RepoRedirect) bool {
+	exp := d.cfg.Git.RedirectExpiry
+	return exp > 0 && time.Since(m.CreatedAt) > exp
+}
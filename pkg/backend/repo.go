@@ -14,26 +14,37 @@ import (
 	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/hooks"
 	"github.com/charmbracelet/soft-serve/pkg/lfs"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/storage"
 	"github.com/charmbracelet/soft-serve/pkg/task"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"golang.org/x/crypto/ssh"
 )
 
 // CreateRepository creates a new repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.User, opts proto.RepositoryOptions) (proto.Repository, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	if err := utils.ValidateRepo(name); err != nil {
 		return nil, err
 	}
 
+	branch := opts.DefaultBranch
+	if branch == "" {
+		branch = d.cfg.Git.DefaultBranch
+	}
+	if err := utils.ValidateBranch(branch); err != nil {
+		return nil, err
+	}
+
 	rp := filepath.Join(d.repoPath(name))
 
 	var userID int64
@@ -42,6 +53,14 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 	}
 
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.checkRepoCaseCollision(ctx, tx, name, ""); err != nil {
+			return err
+		}
+
+		if err := d.checkRepoQuota(ctx, tx, user); err != nil {
+			return err
+		}
+
 		if err := d.store.CreateRepo(
 			ctx,
 			tx,
@@ -56,17 +75,42 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 			return err
 		}
 
-		_, err := git.Init(rp, true)
+		// A new repository takes over requests for its name.
+		if err := d.store.RemoveRepoRedirectByName(ctx, tx, name); err != nil {
+			return err
+		}
+
+		// Imported repositories are already cloned and keep their HEAD.
+		_, serr := os.Stat(rp)
+		exists := serr == nil
+
+		rr, err := git.Init(rp, true)
 		if err != nil {
 			d.logger.Debug("failed to create repository", "err", err)
 			return err
 		}
 
+		// HEAD of the empty repository points to the default branch until
+		// the first push.
+		if !exists {
+			if _, err := rr.SymbolicRef(git.HEAD, git.RefsHeads+branch); err != nil {
+				d.logger.Error("failed to set default branch", "repo", name, "err", err)
+				return err
+			}
+		}
+
 		if err := os.WriteFile(filepath.Join(rp, "description"), []byte(opts.Description), fs.ModePerm); err != nil {
 			d.logger.Error("failed to write description", "repo", name, "err", err)
 			return err
 		}
 
+		if kv := d.gitConfig(); len(kv) > 0 {
+			if _, err := d.reconcileRepoConfig(name, rr, kv, true); err != nil {
+				d.logger.Error("failed to apply git config", "repo", name, "err", err)
+				return err
+			}
+		}
+
 		if !opts.Private {
 			if err := os.WriteFile(filepath.Join(rp, "git-daemon-export-ok"), []byte{}, fs.ModePerm); err != nil {
 				d.logger.Error("failed to write git-daemon-export-ok", "repo", name, "err", err)
@@ -85,13 +129,95 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 		return nil, err
 	}
 
-	return d.Repository(ctx, name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var actor string
+	if user != nil {
+		actor = user.Username()
+	}
+
+	d.notify(ctx, notify.Notification{
+		Event:   notify.EventRepositoryCreate,
+		Repo:    r.Name(),
+		Actor:   actor,
+		Summary: fmt.Sprintf("%s created repository %s", actorName(actor), r.Name()),
+	})
+
+	return r, nil
+}
+
+// InitRepository creates a new repository. If template isn't empty, the
+// branches and tags of the template repository are copied to the new
+// repository, and the default branch of the template becomes its default
+// branch, renamed to opts.DefaultBranch if set.
+//
+// It implements backend.Service.
+func (d *Backend) InitRepository(ctx context.Context, name string, user proto.User, template string, opts proto.RepositoryOptions) (proto.Repository, error) {
+	if template == "" {
+		return d.CreateRepository(ctx, name, user, opts)
+	}
+
+	tr, err := d.Repository(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+
+	tgr, err := tr.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := tgr.HEAD()
+	if err != nil {
+		return nil, fmt.Errorf("template repository %s is empty", tr.Name())
+	}
+
+	tb := head.Name().Short()
+	if opts.DefaultBranch == "" {
+		opts.DefaultBranch = tb
+	}
+
+	refspecs := []string{
+		fmt.Sprintf("+%s%s:%s%s", git.RefsHeads, tb, git.RefsHeads, opts.DefaultBranch),
+		"+" + git.RefsTags + "*:" + git.RefsTags + "*",
+	}
+	branches, _ := tgr.Branches()
+	for _, b := range branches {
+		// The renamed default branch takes precedence.
+		if b == tb || b == opts.DefaultBranch {
+			continue
+		}
+		refspecs = append(refspecs, fmt.Sprintf("+%s%s:%s%s", git.RefsHeads, b, git.RefsHeads, b))
+	}
+
+	r, err := d.CreateRepository(ctx, name, user, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]string{"fetch", "--quiet", tgr.Path}, refspecs...)
+	if _, err := git.NewCommand(args...).WithContext(ctx).WithTimeout(-1).RunInDir(d.repoPath(r.Name())); err != nil {
+		d.logger.Error("failed to copy template repository", "repo", r.Name(), "template", tr.Name(), "err", err)
+		if rerr := d.DeleteRepository(ctx, r.Name()); rerr != nil {
+			d.logger.Error("failed to delete repository", "err", rerr, "name", r.Name())
+		}
+		return nil, fmt.Errorf("failed to copy template repository: %w", err)
+	}
+
+	if err := populateLastModified(ctx, d, r.Name()); err != nil {
+		d.logger.Error("error populating last-modified", "repo", r.Name(), "err", err)
+	}
+
+	return r, nil
 }
 
 // ImportRepository imports a repository from remote.
 // XXX: This a expensive operation and should be run in a goroutine.
 func (d *Backend) ImportRepository(_ context.Context, name string, user proto.User, remote string, opts proto.RepositoryOptions) (proto.Repository, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	if err := utils.ValidateRepo(name); err != nil {
 		return nil, err
 	}
@@ -110,7 +236,7 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 	done := make(chan error, 1)
 	repoc := make(chan proto.Repository, 1)
 	d.logger.Info("importing repository", "name", name, "remote", remote, "path", rp)
-	d.manager.Add(tid, func(ctx context.Context) (err error) {
+	d.addTask(tid, func(ctx context.Context) (err error) {
 		ctx = proto.WithUserContext(ctx, user)
 
 		copts := git.CloneOptions{
@@ -209,9 +335,16 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 
 // DeleteRepository deletes a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
-	name = utils.SanitizeRepo(name)
+	return d.deleteRepository(ctx, name, "")
+}
+
+// deleteRepository deletes a repository. When trash isn't empty, the git and
+// LFS data of the repository are moved to the trash directory instead of
+// being removed.
+func (d *Backend) deleteRepository(ctx context.Context, name string, trash string) error {
+	name = d.cfg.SanitizeRepo(name)
 	rp := filepath.Join(d.repoPath(name))
 
 	user := proto.UserFromContext(ctx)
@@ -245,6 +378,14 @@ func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 		}
 
 		repoID := strconv.FormatInt(repom.ID, 10)
+		if trash != "" {
+			if err := d.store.DeleteRepoByName(ctx, tx, name); err != nil {
+				return db.WrapError(err)
+			}
+
+			return moveToTrash(trash, rp, filepath.Join(d.cfg.DataPath, "lfs", repoID))
+		}
+
 		strg := storage.NewLocalStorage(filepath.Join(d.cfg.DataPath, "lfs", repoID))
 		objs, err := d.store.GetLFSObjectsByName(ctx, tx, name)
 		if err != nil {
@@ -308,14 +449,14 @@ func (d *Backend) DeleteUserRepositories(ctx context.Context, username string) e
 
 // RenameRepository renames a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName string) error {
-	oldName = utils.SanitizeRepo(oldName)
+	oldName = d.cfg.SanitizeRepo(oldName)
 	if err := utils.ValidateRepo(oldName); err != nil {
 		return err
 	}
 
-	newName = utils.SanitizeRepo(newName)
+	newName = d.cfg.SanitizeRepo(newName)
 	if err := utils.ValidateRepo(newName); err != nil {
 		return err
 	}
@@ -338,10 +479,22 @@ func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName
 		// Delete cache
 		defer d.cache.Delete(oldName)
 
+		if err := d.checkRepoCaseCollision(ctx, tx, newName, oldName); err != nil {
+			return err
+		}
+
 		if err := d.store.SetRepoNameByName(ctx, tx, oldName, newName); err != nil {
 			return err
 		}
 
+		// Redirect requests for the old name to the renamed repository.
+		if err := d.store.RemoveRepoRedirectByName(ctx, tx, newName); err != nil {
+			return err
+		}
+		if err := d.store.AddRepoRedirect(ctx, tx, oldName, newName); err != nil {
+			return err
+		}
+
 		// Make sure the new repository parent directory exists.
 		if err := os.MkdirAll(filepath.Dir(np), os.ModePerm); err != nil {
 			return err
@@ -366,9 +519,99 @@ func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName
 	return webhook.SendEvent(ctx, wh)
 }
 
+// TransferRepository transfers the ownership of a repository to the user
+// newOwner. If keepOwner is true, the previous owner stays on as a read-write
+// collaborator. Collaborators and webhooks belong to the repository and are
+// kept as they are.
+//
+// It implements backend.Service.
+func (d *Backend) TransferRepository(ctx context.Context, name string, newOwner string, keepOwner bool) error {
+	name = d.cfg.SanitizeRepo(name)
+	newOwner = strings.ToLower(newOwner)
+	if err := utils.ValidateUsername(newOwner); err != nil {
+		return err
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	var prevOwner string
+	var transferred bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		// Delete cache
+		defer d.cache.Delete(name)
+
+		owner, err := d.store.FindUserByUsername(ctx, tx, newOwner)
+		if err != nil {
+			if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+				return proto.ErrUserNotFound
+			}
+			return err
+		}
+
+		if owner.ID == r.UserID() {
+			return nil
+		}
+
+		if r.UserID() > 0 {
+			prev, err := d.store.GetUserByID(ctx, tx, r.UserID())
+			if err != nil && !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+				return err
+			}
+			prevOwner = prev.Username
+		}
+
+		if err := d.store.SetRepoUserIDByName(ctx, tx, name, owner.ID); err != nil {
+			return err
+		}
+
+		// The new owner has admin access, they don't need to be a
+		// collaborator anymore.
+		if _, err := d.store.GetCollabByUsernameAndRepo(ctx, tx, newOwner, name); err == nil {
+			if err := d.store.RemoveCollabByUsernameAndRepo(ctx, tx, newOwner, name); err != nil {
+				return err
+			}
+		}
+
+		if keepOwner && prevOwner != "" {
+			if _, err := d.store.GetCollabByUsernameAndRepo(ctx, tx, prevOwner, name); err != nil {
+				if err := d.store.AddCollabByUsernameAndRepo(ctx, tx, prevOwner, name, access.ReadWriteAccess, time.Time{}); err != nil {
+					return err
+				}
+			}
+		}
+
+		transferred = true
+		return nil
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	if !transferred {
+		return nil
+	}
+
+	d.logger.Info("repository transferred", "repo", name, "from", prevOwner, "to", newOwner)
+
+	user := proto.UserFromContext(ctx)
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	wh, err := webhook.NewRepositoryEvent(ctx, user, repo, webhook.RepositoryEventActionTransfer)
+	if err != nil {
+		return err
+	}
+
+	return webhook.SendEvent(ctx, wh)
+}
+
 // Repositories returns a list of repositories per page.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) Repositories(ctx context.Context) ([]proto.Repository, error) {
 	repos := make([]proto.Repository, 0)
 
@@ -399,12 +642,30 @@ func (d *Backend) Repositories(ctx context.Context) ([]proto.Repository, error)
 	return repos, nil
 }
 
+// VisibleRepositories returns the repositories a public key, nil for
+// anonymous users, can at least read. Hidden repositories are included.
+func (d *Backend) VisibleRepositories(ctx context.Context, pk ssh.PublicKey) ([]proto.Repository, error) {
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]proto.Repository, 0, len(repos))
+	for _, r := range repos {
+		if d.AccessLevelByPublicKey(ctx, r.Name(), pk) >= access.ReadOnlyAccess {
+			visible = append(visible, r)
+		}
+	}
+
+	return visible, nil
+}
+
 // Repository returns a repository by name.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository, error) {
 	var m models.Repo
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 
 	if r, ok := d.cache.Get(name); ok && r != nil {
 		return r, nil
@@ -443,9 +704,9 @@ func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository
 
 // Description returns the description of a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) Description(ctx context.Context, name string) (string, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	var desc string
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -460,9 +721,9 @@ func (d *Backend) Description(ctx context.Context, name string) (string, error)
 
 // IsMirror returns true if the repository is a mirror.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) IsMirror(ctx context.Context, name string) (bool, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	var mirror bool
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -476,9 +737,9 @@ func (d *Backend) IsMirror(ctx context.Context, name string) (bool, error) {
 
 // IsPrivate returns true if the repository is private.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) IsPrivate(ctx context.Context, name string) (bool, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	var private bool
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -493,9 +754,9 @@ func (d *Backend) IsPrivate(ctx context.Context, name string) (bool, error) {
 
 // IsHidden returns true if the repository is hidden.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) IsHidden(ctx context.Context, name string) (bool, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	var hidden bool
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -508,11 +769,301 @@ func (d *Backend) IsHidden(ctx context.Context, name string) (bool, error) {
 	return hidden, nil
 }
 
+// IsArchived returns true if the repository is archived.
+func (d *Backend) IsArchived(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var archived bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		archived, err = d.store.GetRepoIsArchivedByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return archived, nil
+}
+
+// SetArchived archives or unarchives a repository. Archived repositories
+// can be cloned and fetched but reject pushes.
+func (d *Backend) SetArchived(ctx context.Context, name string, archived bool) error {
+	name = d.cfg.SanitizeRepo(name)
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+	if repo.IsArchived() == archived {
+		return nil
+	}
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsArchivedByName(ctx, tx, name, archived)
+	})); err != nil {
+		return err
+	}
+
+	action := webhook.RepositoryEventActionArchive
+	if !archived {
+		action = webhook.RepositoryEventActionUnarchive
+	}
+	wh, err := webhook.NewRepositoryEvent(ctx, proto.UserFromContext(ctx), repo, action)
+	if err != nil {
+		return err
+	}
+
+	return webhook.SendEvent(ctx, wh)
+}
+
+// verifyNotArchived rejects pushes to archived repositories.
+func (d *Backend) verifyNotArchived(ctx context.Context, repo string) error {
+	archived, err := d.IsArchived(ctx, repo)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if archived {
+		return proto.ErrRepoArchived
+	}
+
+	return nil
+}
+
+// RequireSignedCommits returns true if pushes to the repository must only
+// contain signed commits.
+//
+// It implements backend.Service.
+func (d *Backend) RequireSignedCommits(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var require bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		require, err = d.store.GetRepoRequireSignedCommitsByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return require, nil
+}
+
+// RequireLinearHistory returns true if pushes must not add merge commits to
+// the default and protected branches of the repository.
+func (d *Backend) RequireLinearHistory(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var require bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		require, err = d.store.GetRepoRequireLinearHistoryByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return require, nil
+}
+
+// SetRequireLinearHistory sets whether pushes must not add merge commits to
+// the default and protected branches of the repository.
+func (d *Backend) SetRequireLinearHistory(ctx context.Context, name string, require bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoRequireLinearHistoryByName(ctx, tx, name, require)
+	}))
+}
+
+// SetRequireSignedCommits sets whether pushes to the repository must only
+// contain signed commits.
+//
+// It implements backend.Service.
+func (d *Backend) SetRequireSignedCommits(ctx context.Context, name string, require bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoRequireSignedCommitsByName(ctx, tx, name, require)
+	}))
+}
+
+// ReadAudit returns true if clones and fetches of the repository are
+// audited.
+//
+// It implements backend.Service.
+func (d *Backend) ReadAudit(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var audit bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		audit, err = d.store.GetRepoReadAuditByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return audit, nil
+}
+
+// SetReadAudit sets whether clones and fetches of the repository are
+// audited.
+//
+// It implements backend.Service.
+func (d *Backend) SetReadAudit(ctx context.Context, name string, audit bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoReadAuditByName(ctx, tx, name, audit)
+	}))
+}
+
+// SmudgeLFSArchives returns true if archives of the repository contain the
+// contents of LFS objects instead of their pointer files.
+//
+// It implements backend.Service.
+func (d *Backend) SmudgeLFSArchives(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var smudge bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		smudge, err = d.store.GetRepoSmudgeLFSArchivesByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return smudge, nil
+}
+
+// SetSmudgeLFSArchives sets whether archives of the repository contain the
+// contents of LFS objects instead of their pointer files.
+//
+// It implements backend.Service.
+func (d *Backend) SetSmudgeLFSArchives(ctx context.Context, name string, smudge bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoSmudgeLFSArchivesByName(ctx, tx, name, smudge)
+	}))
+}
+
+// AllowBranchDeletion returns true if branches of the repository can be
+// deleted by pushing.
+//
+// It implements backend.Service.
+func (d *Backend) AllowBranchDeletion(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var allow bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		allow, err = d.store.GetRepoAllowBranchDeletionByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return allow, nil
+}
+
+// SetAllowBranchDeletion sets whether branches of the repository can be
+// deleted by pushing.
+//
+// It implements backend.Service.
+func (d *Backend) SetAllowBranchDeletion(ctx context.Context, name string, allow bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoAllowBranchDeletionByName(ctx, tx, name, allow)
+	}))
+}
+
+// AllowArchives returns true if archives of the repository can be downloaded
+// with git archive --remote.
+//
+// It implements backend.Service.
+func (d *Backend) AllowArchives(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var allow bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		allow, err = d.store.GetRepoAllowArchivesByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return allow, nil
+}
+
+// SetAllowArchives sets whether archives of the repository can be downloaded
+// with git archive --remote.
+//
+// It implements backend.Service.
+func (d *Backend) SetAllowArchives(ctx context.Context, name string, allow bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoAllowArchivesByName(ctx, tx, name, allow)
+	}))
+}
+
+// ImmutableTags returns true if existing tags of the repository can't be
+// moved or deleted.
+//
+// It implements backend.Service.
+func (d *Backend) ImmutableTags(ctx context.Context, name string) (bool, error) {
+	name = d.cfg.SanitizeRepo(name)
+	var immutable bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		immutable, err = d.store.GetRepoImmutableTagsByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return immutable, nil
+}
+
+// SetImmutableTags sets whether existing tags of the repository can't be
+// moved or deleted.
+//
+// It implements backend.Service.
+func (d *Backend) SetImmutableTags(ctx context.Context, name string, immutable bool) error {
+	name = d.cfg.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoImmutableTagsByName(ctx, tx, name, immutable)
+	}))
+}
+
 // ProjectName returns the project name of a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) ProjectName(ctx context.Context, name string) (string, error) {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	var pname string
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
@@ -527,9 +1078,9 @@ func (d *Backend) ProjectName(ctx context.Context, name string) (string, error)
 
 // SetHidden sets the hidden flag of a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) SetHidden(ctx context.Context, name string, hidden bool) error {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 
 	// Delete cache
 	d.cache.Delete(name)
@@ -541,9 +1092,9 @@ func (d *Backend) SetHidden(ctx context.Context, name string, hidden bool) error
 
 // SetDescription sets the description of a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) SetDescription(ctx context.Context, name string, desc string) error {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	rp := filepath.Join(d.repoPath(name))
 
 	// Delete cache
@@ -561,9 +1112,9 @@ func (d *Backend) SetDescription(ctx context.Context, name string, desc string)
 
 // SetPrivate sets the private flag of a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) SetPrivate(ctx context.Context, name string, private bool) error {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	rp := filepath.Join(d.repoPath(name))
 
 	// Delete cache
@@ -614,9 +1165,9 @@ func (d *Backend) SetPrivate(ctx context.Context, name string, private bool) err
 
 // SetProjectName sets the project name of a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) SetProjectName(ctx context.Context, repo string, name string) error {
-	repo = utils.SanitizeRepo(repo)
+	repo = d.cfg.SanitizeRepo(repo)
 
 	// Delete cache
 	d.cache.Delete(repo)
@@ -628,9 +1179,29 @@ func (d *Backend) SetProjectName(ctx context.Context, repo string, name string)
 	)
 }
 
+// checkRepoCaseCollision returns ErrRepoCaseCollision if there is an existing
+// repository, other than ignore, whose name only differs in case from name.
+// Collisions are rejected whether or not repository names are
+// case-insensitive, so that existing repositories stay reachable when the
+// option is turned on.
+func (d *Backend) checkRepoCaseCollision(ctx context.Context, tx *db.Tx, name string, ignore string) error {
+	existing, err := d.store.GetRepoNameByNormalizedName(ctx, tx, name)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if existing != name && existing != ignore {
+		return proto.ErrRepoCaseCollision
+	}
+
+	return nil
+}
+
 // repoPath returns the path to a repository.
 func (d *Backend) repoPath(name string) string {
-	name = utils.SanitizeRepo(name)
+	name = d.cfg.SanitizeRepo(name)
 	rn := strings.ReplaceAll(name, "/", string(os.PathSeparator))
 	return filepath.Join(filepath.Join(d.cfg.DataPath, "repos"), rn+".git")
 }
@@ -662,6 +1233,17 @@ func (r *repo) UserID() int64 {
 	return 0
 }
 
+// CreatedBy returns the ID of the user who created the repository.
+// If the creator is unknown, it returns 0.
+//
+// It implements proto.Repository.
+func (r *repo) CreatedBy() int64 {
+	if r.repo.CreatedBy.Valid {
+		return r.repo.CreatedBy.Int64
+	}
+	return 0
+}
+
 // Description returns the repository's description.
 //
 // It implements backend.Repository.
@@ -711,6 +1293,13 @@ func (r *repo) IsHidden() bool {
 	return r.repo.Hidden
 }
 
+// IsArchived returns whether the repository is archived.
+//
+// It implements backend.Repository.
+func (r *repo) IsArchived() bool {
+	return r.repo.Archived
+}
+
 // CreatedAt returns the repository's creation time.
 func (r *repo) CreatedAt() time.Time {
 	return r.repo.CreatedAt
@@ -0,0 +1,197 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+// repoConfigFile is the name of the file, inside the repository metadata
+// directory, that records the baseline git config options last set on the
+// repository.
+const repoConfigFile = "repo-config.json"
+
+// RepoConfigChange is a baseline git config option that differed from the
+// repository config during a reconcile.
+type RepoConfigChange struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Previous is the value the repository had, empty if the option wasn't
+	// set.
+	Previous string `json:"previous,omitempty"`
+	// Kept is true if the repository overrides the option and the value was
+	// left unchanged.
+	Kept bool `json:"kept,omitempty"`
+}
+
+// gitConfig returns the baseline git config options of managed repositories.
+func (d *Backend) gitConfig() map[string]string {
+	kv := make(map[string]string, len(d.cfg.Git.RepoConfig)+1)
+	for k, v := range d.cfg.Git.RepoConfig {
+		kv[k] = v
+	}
+	if d.cfg.Git.DenyCurrentBranch != "" {
+		kv["receive.denyCurrentBranch"] = d.cfg.Git.DenyCurrentBranch
+	}
+	return kv
+}
+
+// ReconcileRepoConfig sets the baseline git config, Git.RepoConfig and
+// Git.DenyCurrentBranch, on a repository and returns the options that
+// differed.
+//
+// Options the repository changed since they were last set are per-repository
+// overrides and are kept, unless force is true.
+func (d *Backend) ReconcileRepoConfig(ctx context.Context, name string, force bool) ([]RepoConfigChange, error) {
+	kv := d.gitConfig()
+	if len(kv) == 0 {
+		return nil, nil
+	}
+
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.reconcileRepoConfig(repo.Name(), r, kv, force)
+}
+
+// ReconcileRepoConfigAll sets the baseline git config on all repositories,
+// keeping per-repository overrides.
+func (d *Backend) ReconcileRepoConfigAll(ctx context.Context) error {
+	if len(d.gitConfig()) == 0 {
+		return nil
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		changes, err := d.ReconcileRepoConfig(ctx, repo.Name(), false)
+		if err != nil {
+			d.logger.Error("failed to reconcile git config", "repo", repo.Name(), "err", err)
+			continue
+		}
+		for _, c := range changes {
+			if c.Kept {
+				d.logger.Debug("keeping git config override", "repo", repo.Name(), "key", c.Key, "value", c.Previous)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Backend) reconcileRepoConfig(repo string, r *git.Repository, kv map[string]string, force bool) ([]RepoConfigChange, error) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+
+	applied, err := d.readRepoConfig(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	rcfg, err := r.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var changes []RepoConfigChange
+	changed, record := false, false
+	for _, k := range keys {
+		v := kv[k]
+		// Options are "section.key" or "section.subsection.key", the
+		// subsection can contain dots.
+		first, last := strings.Index(k, "."), strings.LastIndex(k, ".")
+		section, key := k[:first], k[last+1:]
+		var subsection string
+		if first != last {
+			subsection = k[first+1 : last]
+		}
+
+		s := rcfg.Section(section)
+		cur, ok := s.Option(key), s.HasOption(key)
+		if subsection != "" {
+			ss := s.Subsection(subsection)
+			cur, ok = ss.Option(key), ss.HasOption(key)
+		}
+
+		prev, seen := applied[k]
+		switch {
+		case ok && cur == v:
+		case ok && !force && (!seen || prev != cur):
+			// The repository set its own value.
+			changes = append(changes, RepoConfigChange{Key: k, Value: v, Previous: cur, Kept: true})
+			if seen {
+				delete(applied, k)
+				record = true
+			}
+			continue
+		default:
+			rcfg.SetOption(section, subsection, key, v)
+			changes = append(changes, RepoConfigChange{Key: k, Value: v, Previous: cur})
+			changed = true
+		}
+
+		if prev != v || !seen {
+			applied[k] = v
+			record = true
+		}
+	}
+
+	if changed {
+		if err := r.SetConfig(rcfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if record {
+		bts, err := json.MarshalIndent(applied, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeFileAtomic(d.repoMetadataPath(repo, repoConfigFile), bts); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// readRepoConfig returns the baseline git config options last set on a
+// repository. It must be called with the metadata lock held.
+func (d *Backend) readRepoConfig(repo string) (map[string]string, error) {
+	applied := map[string]string{}
+	bts, err := os.ReadFile(d.repoMetadataPath(repo, repoConfigFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return applied, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bts, &applied); err != nil {
+		return nil, fmt.Errorf("failed to decode repo config: %w", err)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,80 @@
+package backend_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestReconcileRepoConfig(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Git.DenyCurrentBranch = "updateInstead"
+		cfg.Git.RepoConfig = map[string]string{
+			"core.logAllRefUpdates":              "true",
+			"url.https://example.com/.insteadOf": "git://example.com/",
+		}
+	})
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	repo, err := be.CreateRepository(ctx, "repo1", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+	r, err := repo.Open()
+	is.NoErr(err)
+	rcfg, err := r.Config()
+	is.NoErr(err)
+	is.Equal(rcfg.Section("receive").Option("denyCurrentBranch"), "updateInstead")
+	is.Equal(rcfg.Section("core").Option("logallrefupdates"), "true")
+	is.Equal(rcfg.Section("core").Option("bare"), "true")
+	is.Equal(rcfg.Section("url").Subsection("https://example.com/").Option("insteadOf"), "git://example.com/")
+
+	// Nothing to do once the repository matches the baseline.
+	changes, err := be.ReconcileRepoConfig(ctx, "repo1", false)
+	is.NoErr(err)
+	is.Equal(len(changes), 0)
+
+	// Config changes are applied to existing repositories.
+	cfg := config.FromContext(ctx)
+	cfg.Git.DenyCurrentBranch = "refuse"
+	is.NoErr(be.ReconcileRepoConfigAll(ctx))
+	rcfg, err = r.Config()
+	is.NoErr(err)
+	is.Equal(rcfg.Section("receive").Option("denyCurrentBranch"), "refuse")
+	is.Equal(len(rcfg.Section("receive").Options), 1)
+
+	// Options the repository changed are kept.
+	is.NoErr(exec.Command("git", "-C", r.Path, "config", "core.logAllRefUpdates", "always").Run())
+	cfg.Git.RepoConfig["core.logAllRefUpdates"] = "false"
+	changes, err = be.ReconcileRepoConfig(ctx, "repo1", false)
+	is.NoErr(err)
+	is.Equal(changes, []backend.RepoConfigChange{
+		{Key: "core.logAllRefUpdates", Value: "false", Previous: "always", Kept: true},
+	})
+	rcfg, err = r.Config()
+	is.NoErr(err)
+	is.Equal(rcfg.Section("core").Option("logallrefupdates"), "always")
+
+	// Unless the reconcile is forced.
+	changes, err = be.ReconcileRepoConfig(ctx, "repo1", true)
+	is.NoErr(err)
+	is.Equal(changes, []backend.RepoConfigChange{
+		{Key: "core.logAllRefUpdates", Value: "false", Previous: "always"},
+	})
+	rcfg, err = r.Config()
+	is.NoErr(err)
+	is.Equal(rcfg.Section("core").Option("logallrefupdates"), "false")
+
+	// Forced options follow later baseline changes.
+	cfg.Git.RepoConfig["core.logAllRefUpdates"] = "true"
+	changes, err = be.ReconcileRepoConfig(ctx, "repo1", false)
+	is.NoErr(err)
+	is.Equal(changes, []backend.RepoConfigChange{
+		{Key: "core.logAllRefUpdates", Value: "true", Previous: "false"},
+	})
+}
@@ -0,0 +1,107 @@
+package backend
+
+import "sync"
+
+// repoLocks serializes operations on repositories, keyed by repository
+// name.
+type repoLocks struct {
+	mu    sync.Mutex
+	locks map[string]*repoLock
+}
+
+type repoLock struct {
+	mu sync.Mutex
+	// refs is the number of holders and waiters of the lock.
+	refs int
+}
+
+// lock locks the repository name and returns the function unlocking it.
+// Locks are removed once they're not used anymore.
+func (l *repoLocks) lock(name string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*repoLock)
+	}
+	rl, ok := l.locks[name]
+	if !ok {
+		rl = &repoLock{}
+		l.locks[name] = rl
+	}
+	rl.refs++
+	l.mu.Unlock()
+
+	rl.mu.Lock()
+	return func() {
+		rl.mu.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if rl.refs--; rl.refs == 0 {
+			delete(l.locks, name)
+		}
+	}
+}
+
+// pushLocks lets pushes to a repository run concurrently, but not along
+// operations rewriting it, keyed by repository name.
+type pushLocks struct {
+	mu    sync.Mutex
+	locks map[string]*pushLock
+}
+
+type pushLock struct {
+	mu sync.RWMutex
+	// refs is the number of holders and waiters of the lock.
+	refs int
+}
+
+func (l *pushLocks) get(name string) *pushLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locks == nil {
+		l.locks = make(map[string]*pushLock)
+	}
+	pl, ok := l.locks[name]
+	if !ok {
+		pl = &pushLock{}
+		l.locks[name] = pl
+	}
+	pl.refs++
+	return pl
+}
+
+func (l *pushLocks) put(name string, pl *pushLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if pl.refs--; pl.refs == 0 {
+		delete(l.locks, name)
+	}
+}
+
+// tryRLock read locks the repository name for a push and returns the
+// function unlocking it. It returns false, without waiting, if the
+// repository is locked, or waiting to be locked, by lock.
+func (l *pushLocks) tryRLock(name string) (func(), bool) {
+	pl := l.get(name)
+	if !pl.mu.TryRLock() {
+		l.put(name, pl)
+		return nil, false
+	}
+
+	return func() {
+		pl.mu.RUnlock()
+		l.put(name, pl)
+	}, true
+}
+
+// lock locks the repository name once the pushes in progress are done and
+// returns the function unlocking it. New pushes are rejected as soon as
+// it's called.
+func (l *pushLocks) lock(name string) func() {
+	pl := l.get(name)
+	pl.mu.Lock()
+	return func() {
+		pl.mu.Unlock()
+		l.put(name, pl)
+	}
+}
@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPushLocks(t *testing.T) {
+	is := is.New(t)
+	var l pushLocks
+
+	// Pushes run concurrently.
+	end1, ok := l.tryRLock("repo1")
+	is.True(ok)
+	end2, ok := l.tryRLock("repo1")
+	is.True(ok)
+
+	// A rewrite waits for them, and new pushes are rejected meanwhile.
+	locked := make(chan func())
+	go func() { locked <- l.lock("repo1") }()
+	is.True(waitFor(func() bool {
+		end, ok := l.tryRLock("repo1")
+		if ok {
+			end()
+		}
+		return !ok
+	}))
+	select {
+	case <-locked:
+		t.Fatal("lock returned while pushes are in progress")
+	default:
+	}
+
+	// Other repositories aren't blocked.
+	end3, ok := l.tryRLock("repo2")
+	is.True(ok)
+	end3()
+
+	end1()
+	end2()
+	unlock := <-locked
+	_, ok = l.tryRLock("repo1")
+	is.True(!ok)
+
+	unlock()
+	end, ok := l.tryRLock("repo1")
+	is.True(ok)
+	end()
+	is.Equal(len(l.locks), 0)
+}
+
+func waitFor(cond func() bool) bool {
+	for range 100 {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// RepoQuota returns the number of repositories a user owns that count toward
+// Git.MaxReposPerUser, and the limit. The limit is 0 if the user isn't
+// limited, e.g. admins.
+func (d *Backend) RepoQuota(ctx context.Context, user proto.User) (used int, limit int, err error) {
+	if user == nil {
+		return 0, 0, proto.ErrUserNotFound
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		used, err = d.ownedRepos(ctx, tx, user.ID())
+		return err
+	}); err != nil {
+		return 0, 0, db.WrapError(err)
+	}
+
+	if !user.IsAdmin() {
+		limit = d.cfg.Git.MaxReposPerUser
+	}
+
+	return used, limit, nil
+}
+
+// checkRepoQuota returns proto.ErrRepoQuota if user can't own another
+// repository.
+func (d *Backend) checkRepoQuota(ctx context.Context, tx *db.Tx, user proto.User) error {
+	limit := d.cfg.Git.MaxReposPerUser
+	if limit == 0 || user == nil || user.IsAdmin() {
+		return nil
+	}
+
+	used, err := d.ownedRepos(ctx, tx, user.ID())
+	if err != nil {
+		return err
+	}
+	if used < limit {
+		return nil
+	}
+
+	d.logger.Info("repository limit reached", "user", user.Username(), "limit", limit)
+	hint := "delete"
+	if d.cfg.Git.MaxReposPerUserExcludeArchived {
+		hint = "archive or delete"
+	}
+	return fmt.Errorf("%w: %s owns %d of %d repositories, %s one to create another",
+		proto.ErrRepoQuota, user.Username(), used, limit, hint)
+}
+
+// ownedRepos returns the number of repositories owned by a user that count
+// toward Git.MaxReposPerUser.
+func (d *Backend) ownedRepos(ctx context.Context, tx *db.Tx, userID int64) (int, error) {
+	repos, err := d.store.GetUserRepos(ctx, tx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, r := range repos {
+		if r.Archived && d.cfg.Git.MaxReposPerUserExcludeArchived {
+			continue
+		}
+		n++
+	}
+
+	return n, nil
+}
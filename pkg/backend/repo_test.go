@@ -0,0 +1,138 @@
+package backend_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestTransferRepository(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	bob, err := be.CreateUser(ctx, "bob", proto.UserOptions{})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+
+	_, err = be.CreateRepository(ctx, "repo1", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+
+	err = be.TransferRepository(ctx, "repo1", "nobody", false)
+	is.True(errors.Is(err, proto.ErrUserNotFound))
+
+	is.NoErr(be.TransferRepository(ctx, "repo1", "bob", true))
+	r, err := be.Repository(ctx, "repo1")
+	is.NoErr(err)
+	is.Equal(r.UserID(), bob.ID())
+
+	collabs, err := be.Collaborators(ctx, "repo1")
+	is.NoErr(err)
+	is.Equal(collabs, []string{"alice"})
+
+	is.NoErr(be.TransferRepository(ctx, "repo1", "alice", false))
+	collabs, err = be.Collaborators(ctx, "repo1")
+	is.NoErr(err)
+	is.Equal(len(collabs), 0)
+}
+
+func TestVisibleRepositories(t *testing.T) {
+	is := is.New(t)
+	newKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		is.NoErr(err)
+		pk, err := ssh.NewPublicKey(pub)
+		is.NoErr(err)
+		return pk
+	}
+
+	adminKey := newKey()
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.InitialAdminKeys = []string{sshutils.MarshalAuthorizedKey(adminKey)}
+	})
+
+	ownerKey, collabKey, userKey := newKey(), newKey(), newKey()
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{PublicKeys: []ssh.PublicKey{ownerKey}})
+	is.NoErr(err)
+	_, err = be.CreateUser(ctx, "bob", proto.UserOptions{PublicKeys: []ssh.PublicKey{collabKey}})
+	is.NoErr(err)
+	_, err = be.CreateUser(ctx, "carol", proto.UserOptions{PublicKeys: []ssh.PublicKey{userKey}})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+
+	for name, opts := range map[string]proto.RepositoryOptions{
+		"public":   {},
+		"hidden":   {Hidden: true},
+		"private1": {Private: true},
+		"private2": {Private: true},
+	} {
+		_, err := be.CreateRepository(ctx, name, alice, opts)
+		is.NoErr(err)
+	}
+	is.NoErr(be.AddCollaborator(ctx, "private1", "bob", access.ReadOnlyAccess, time.Time{}))
+
+	visible := func(pk ssh.PublicKey) []string {
+		repos, err := be.VisibleRepositories(ctx, pk)
+		is.NoErr(err)
+		names := make([]string, 0, len(repos))
+		for _, r := range repos {
+			names = append(names, r.Name())
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	is.Equal(visible(nil), []string{"hidden", "public"})
+	is.Equal(visible(userKey), []string{"hidden", "public"})
+	is.Equal(visible(collabKey), []string{"hidden", "private1", "public"})
+	is.Equal(visible(ownerKey), []string{"hidden", "private1", "private2", "public"})
+	is.Equal(visible(adminKey), []string{"hidden", "private1", "private2", "public"})
+
+	// Anonymous users see nothing without anonymous access.
+	is.NoErr(be.SetAnonAccess(ctx, access.NoAccess))
+	is.Equal(visible(nil), []string{})
+	is.Equal(visible(userKey), []string{"hidden", "public"})
+}
+
+func TestRepositoryCaseCollisions(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+	cfg := config.FromContext(ctx)
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, alice)
+
+	// Names that only differ in case are rejected by default.
+	_, err = be.CreateRepository(ctx, "MyRepo", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+	_, err = be.CreateRepository(ctx, "myrepo", alice, proto.RepositoryOptions{})
+	is.True(errors.Is(err, proto.ErrRepoCaseCollision))
+	_, err = be.CreateRepository(ctx, "Other", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+	err = be.RenameRepository(ctx, "Other", "MYREPO")
+	is.True(errors.Is(err, proto.ErrRepoCaseCollision))
+
+	// Renaming a repository to its own name in another case is allowed.
+	is.NoErr(be.RenameRepository(ctx, "Other", "oTHER"))
+
+	// Collisions are rejected when names are case-insensitive too.
+	cfg.Git.CaseInsensitiveRepos = true
+	_, err = be.CreateRepository(ctx, "third", alice, proto.RepositoryOptions{})
+	is.NoErr(err)
+	err = be.RenameRepository(ctx, "third", "Other")
+	is.True(errors.Is(err, proto.ErrRepoCaseCollision))
+	_, err = be.CreateRepository(ctx, "other", alice, proto.RepositoryOptions{})
+	is.True(errors.Is(err, proto.ErrRepoCaseCollision))
+}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// robotsPolicyFile is the name of the file, inside the repository metadata
+// directory, that holds the robots policy of the repository.
+const robotsPolicyFile = "robots"
+
+// RobotsPolicy returns the robots policy set on a repository, empty if it
+// uses the server policy.
+func (d *Backend) RobotsPolicy(ctx context.Context, repo string) (proto.RobotsPolicy, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+
+	return d.repoRobotsPolicy(r.Name())
+}
+
+// SetRobotsPolicy sets the robots policy of a repository. An empty policy
+// unsets it, the repository uses the server policy.
+func (d *Backend) SetRobotsPolicy(ctx context.Context, repo string, policy proto.RobotsPolicy) error {
+	if policy != "" {
+		if _, err := proto.ParseRobotsPolicy(string(policy)); err != nil {
+			return err
+		}
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	fp := d.repoMetadataPath(r.Name(), robotsPolicyFile)
+	if policy == "" {
+		if err := os.Remove(fp); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	return writeFileAtomic(fp, []byte(policy.String()+"\n"))
+}
+
+// EffectiveRobotsPolicy returns whether search engines can index a
+// repository. Private repositories are never indexed, public ones use their
+// own policy, or the server policy if they don't have one.
+func (d *Backend) EffectiveRobotsPolicy(r proto.Repository) proto.RobotsPolicy {
+	if r.IsPrivate() {
+		return proto.RobotsNoindex
+	}
+
+	if p, err := d.repoRobotsPolicy(r.Name()); err != nil {
+		d.logger.Error("failed to read robots policy", "repo", r.Name(), "err", err)
+	} else if p != "" {
+		return p
+	}
+
+	if d.cfg.HTTP.RobotsPolicy == proto.RobotsNoindex.String() {
+		return proto.RobotsNoindex
+	}
+	return proto.RobotsIndex
+}
+
+// repoRobotsPolicy reads the robots policy file of a repository.
+func (d *Backend) repoRobotsPolicy(repo string) (proto.RobotsPolicy, error) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	bts, err := os.ReadFile(d.repoMetadataPath(repo, robotsPolicyFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return proto.ParseRobotsPolicy(strings.TrimSpace(string(bts)))
+}
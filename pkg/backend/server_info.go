@@ -0,0 +1,48 @@
+package backend
+
+import "context"
+
+// ServerInfo holds repository and user counts of the server.
+type ServerInfo struct {
+	// Repos is the number of repositories.
+	Repos int
+	// PublicRepos is the number of repositories that aren't private.
+	PublicRepos int
+	// Users is the number of users.
+	Users int
+}
+
+// ServerInfo returns repository and user counts of the server.
+func (d *Backend) ServerInfo(ctx context.Context) (ServerInfo, error) {
+	var info ServerInfo
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	info.Repos = len(repos)
+	for _, r := range repos {
+		if !r.IsPrivate() {
+			info.PublicRepos++
+		}
+	}
+
+	users, err := d.Users(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	info.Users = len(users)
+	return info, nil
+}
+
+// DiskUsage returns the size in bytes of the files in the server data
+// directory.
+func (d *Backend) DiskUsage(ctx context.Context) (int64, error) {
+	var size int64
+	err := walkFileSizes(ctx, d.cfg.DataPath, func(_ string, n int64) {
+		size += n
+	})
+
+	return size, err
+}
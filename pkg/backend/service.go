@@ -0,0 +1,282 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/keygen"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/lfs"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+// Service is the interface of the Soft Serve backend. The SSH and HTTP
+// servers and their commands only use the backend through it, see
+// FromContext.
+//
+// Backend implements it. It keeps its metadata in the configured database,
+// an in-memory one for tests and ephemeral deployments, see
+// config.DBConfig.AllowInMemory.
+type Service interface {
+	RepositoryBackend
+	RepositoryPolicyBackend
+	GitBackend
+	CollaboratorBackend
+	DeployKeyBackend
+	IssueBackend
+	WebhookBackend
+	ReadBackend
+	UserBackend
+	AccessBackend
+	AccessTokenBackend
+	SettingsBackend
+	ServerBackend
+	HooksBackend
+}
+
+var _ Service = (*Backend)(nil)
+
+// RepositoryBackend is an interface for managing repositories.
+type RepositoryBackend interface {
+	AutoCreateRepository(ctx context.Context, name string, user proto.User) (r proto.Repository, created bool, err error)
+	DeleteAutoCreatedRepository(ctx context.Context, name string) error
+	ExportRepositories(ctx context.Context, namespace string, w io.Writer) ([]ExportedRepo, error)
+	AddRedirect(ctx context.Context, oldName string, newName string) error
+	ResolveRedirect(ctx context.Context, name string) (string, error)
+	Redirects(ctx context.Context) ([]proto.RepositoryRedirect, error)
+	RemoveRedirect(ctx context.Context, name string) error
+	CreateRepository(ctx context.Context, name string, user proto.User, opts proto.RepositoryOptions) (proto.Repository, error)
+	InitRepository(ctx context.Context, name string, user proto.User, template string, opts proto.RepositoryOptions) (proto.Repository, error)
+	ImportRepository(ctx context.Context, name string, user proto.User, remote string, opts proto.RepositoryOptions) (proto.Repository, error)
+	DeleteRepository(ctx context.Context, name string) error
+	DeleteUserRepositories(ctx context.Context, username string) error
+	RenameRepository(ctx context.Context, oldName string, newName string) error
+	TransferRepository(ctx context.Context, name string, newOwner string, keepOwner bool) error
+	Repositories(ctx context.Context) ([]proto.Repository, error)
+	VisibleRepositories(ctx context.Context, pk ssh.PublicKey) ([]proto.Repository, error)
+	Repository(ctx context.Context, name string) (proto.Repository, error)
+	Description(ctx context.Context, name string) (string, error)
+	IsMirror(ctx context.Context, name string) (bool, error)
+	IsPrivate(ctx context.Context, name string) (bool, error)
+	IsHidden(ctx context.Context, name string) (bool, error)
+	IsArchived(ctx context.Context, name string) (bool, error)
+	SetArchived(ctx context.Context, name string, archived bool) error
+	RequireSignedCommits(ctx context.Context, name string) (bool, error)
+	RequireLinearHistory(ctx context.Context, name string) (bool, error)
+	SetRequireLinearHistory(ctx context.Context, name string, require bool) error
+	SetRequireSignedCommits(ctx context.Context, name string, require bool) error
+	ReadAudit(ctx context.Context, name string) (bool, error)
+	SetReadAudit(ctx context.Context, name string, audit bool) error
+	SmudgeLFSArchives(ctx context.Context, name string) (bool, error)
+	SetSmudgeLFSArchives(ctx context.Context, name string, smudge bool) error
+	AllowBranchDeletion(ctx context.Context, name string) (bool, error)
+	SetAllowBranchDeletion(ctx context.Context, name string, allow bool) error
+	AllowArchives(ctx context.Context, name string) (bool, error)
+	SetAllowArchives(ctx context.Context, name string, allow bool) error
+	ImmutableTags(ctx context.Context, name string) (bool, error)
+	SetImmutableTags(ctx context.Context, name string, immutable bool) error
+	ProjectName(ctx context.Context, name string) (string, error)
+	SetHidden(ctx context.Context, name string, hidden bool) error
+	SetDescription(ctx context.Context, name string, desc string) error
+	SetPrivate(ctx context.Context, name string, private bool) error
+	SetProjectName(ctx context.Context, repo string, name string) error
+	RepoQuota(ctx context.Context, user proto.User) (used int, limit int, err error)
+	StaleRepositories(ctx context.Context, before time.Time, emptyOnly bool) ([]StaleRepository, error)
+	TrashRepository(ctx context.Context, name string) (string, error)
+	UserRepos(ctx context.Context, user proto.User) ([]UserRepo, error)
+}
+
+// RepositoryPolicyBackend is an interface for managing the policies and
+// settings of repositories.
+type RepositoryPolicyBackend interface {
+	BinaryLimit(ctx context.Context, repo string) (BinaryLimit, error)
+	SetBinaryLimit(ctx context.Context, repo string, limit BinaryLimit) error
+	RepoChecks(ctx context.Context, repo string) ([]string, error)
+	AddRepoCheck(ctx context.Context, repo string, command string) error
+	RemoveRepoCheck(ctx context.Context, repo string, index int) error
+	MergeStrategy(ctx context.Context, repo string) (proto.MergeStrategy, error)
+	SetMergeStrategy(ctx context.Context, repo string, strategy proto.MergeStrategy) error
+	PolicyPresets() map[string]config.PolicyPreset
+	PolicyPresetNames() []string
+	RepoPolicy(ctx context.Context, name string) (RepoPolicy, error)
+	MatchingPolicyPresets(p RepoPolicy) []string
+	ApplyPolicyPreset(ctx context.Context, name string, preset string) error
+	AutoPruneBranches(ctx context.Context, name string) (bool, error)
+	SetAutoPruneBranches(ctx context.Context, name string, prune bool) error
+	PushEmail(ctx context.Context, repo string) (proto.PushEmail, error)
+	SetPushEmail(ctx context.Context, repo string, pe proto.PushEmail) error
+	RemovePushEmail(ctx context.Context, repo string) error
+	ReadmeDescription(ctx context.Context, name string) (enabled bool, overridden bool, err error)
+	SetReadmeDescription(ctx context.Context, name string, enabled *bool) error
+	UpdateReadmeDescription(ctx context.Context, name string) error
+	ReconcileRepoConfig(ctx context.Context, name string, force bool) ([]RepoConfigChange, error)
+	RobotsPolicy(ctx context.Context, repo string) (proto.RobotsPolicy, error)
+	SetRobotsPolicy(ctx context.Context, repo string, policy proto.RobotsPolicy) error
+	EffectiveRobotsPolicy(r proto.Repository) proto.RobotsPolicy
+}
+
+// GitBackend is an interface for git operations on repositories.
+type GitBackend interface {
+	CommitActivity(ctx context.Context, repo proto.Repository, ref string, since time.Time, interval git.ActivityInterval) ([]git.ActivityBucket, error)
+	RenameBranch(ctx context.Context, repo proto.Repository, user proto.User, oldName, newName string) error
+	LastCommit(ctx context.Context, repo proto.Repository) (*proto.CommitSummary, error)
+	Contributors(ctx context.Context, repo proto.Repository, ref string) ([]git.Contributor, error)
+	CheckFreeDisk(need int64) error
+	FilterRepository(ctx context.Context, name string, user proto.User, opts FilterOptions) (string, error)
+	BeginPush(name string) (func(), error)
+	Fsck(ctx context.Context, repo proto.Repository) (*git.FsckResult, error)
+	CollectGarbage(ctx context.Context, repo proto.Repository) error
+	OpenLFSObject(ctx context.Context, repo proto.Repository, p lfs.Pointer) (io.ReadCloser, error)
+	AcquireGitOperation(ctx context.Context, user proto.User, remoteAddr string) (func(), error)
+	PrunableBranches(ctx context.Context, repo proto.Repository) ([]git.BranchTip, error)
+	PruneBranches(ctx context.Context, repo proto.Repository, user proto.User) ([]git.BranchTip, error)
+	RepositorySizeDetailed(ctx context.Context, name string) (*RepositorySize, error)
+	RepositoryStats(ctx context.Context, repo proto.Repository) (*git.ObjectStats, error)
+	InvalidateRepositoryStats(ctx context.Context, repo proto.Repository) error
+	CreateTag(ctx context.Context, repo proto.Repository, user proto.User, name, ref, message string, force bool) error
+}
+
+// CollaboratorBackend is an interface for managing repository collaborators.
+type CollaboratorBackend interface {
+	AddCollaborator(ctx context.Context, repo string, username string, level access.AccessLevel, expiresAt time.Time) error
+	Collaborators(ctx context.Context, repo string) ([]string, error)
+	CollaboratorsWithAccess(ctx context.Context, repo string) ([]proto.Collaborator, error)
+	IsCollaborator(ctx context.Context, repo string, username string) (access.AccessLevel, bool, error)
+	RemoveCollaborator(ctx context.Context, repo string, username string) error
+	SetCollaboratorPaths(ctx context.Context, repo string, username string, patterns []string) error
+}
+
+// DeployKeyBackend is an interface for managing repository deploy keys.
+type DeployKeyBackend interface {
+	AddDeployKey(ctx context.Context, repo string, pk ssh.PublicKey, comment string, level access.AccessLevel) error
+	RemoveDeployKey(ctx context.Context, repo string, pk ssh.PublicKey) error
+	DeployKeys(ctx context.Context, repo string) ([]proto.DeployKey, error)
+	DeployKeyByPublicKey(ctx context.Context, pk ssh.PublicKey) (proto.DeployKey, error)
+}
+
+// IssueBackend is an interface for managing repository issues.
+type IssueBackend interface {
+	CreateIssue(ctx context.Context, repo string, user proto.User, title string, body string) (proto.Issue, error)
+	ListIssues(ctx context.Context, repo string) ([]proto.Issue, error)
+	Issue(ctx context.Context, repo string, id int64) (proto.Issue, error)
+	CloseIssue(ctx context.Context, repo string, id int64) error
+}
+
+// WebhookBackend is an interface for managing repository webhooks.
+type WebhookBackend interface {
+	CreateWebhook(ctx context.Context, repo proto.Repository, url string, contentType webhook.ContentType, secret string, events []webhook.Event, active bool) error
+	Webhook(ctx context.Context, repo proto.Repository, id int64) (webhook.Hook, error)
+	ListWebhooks(ctx context.Context, repo proto.Repository) ([]webhook.Hook, error)
+	UpdateWebhook(ctx context.Context, repo proto.Repository, id int64, url string, contentType webhook.ContentType, secret string, updatedEvents []webhook.Event, active bool) error
+	SetWebhookTemplate(ctx context.Context, repo proto.Repository, id int64, tmpl string) error
+	DeleteWebhook(ctx context.Context, repo proto.Repository, id int64) error
+	ListWebhookDeliveries(ctx context.Context, repo proto.Repository, id int64) ([]webhook.Delivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, repo proto.Repository, id int64, delID uuid.UUID) error
+	RedeliverRepositoryWebhookDelivery(ctx context.Context, repo proto.Repository, delID uuid.UUID) error
+	WebhookDelivery(ctx context.Context, repo proto.Repository, webhookID int64, id uuid.UUID) (webhook.Delivery, error)
+}
+
+// ReadBackend is an interface for auditing repository reads.
+type ReadBackend interface {
+	AuditRead(ctx context.Context, repo string, user proto.User, transport string, clone bool) error
+	RepositoryReads(ctx context.Context, repo string, limit int) ([]proto.RepositoryRead, error)
+}
+
+// UserBackend is an interface for managing users and their public keys.
+type UserBackend interface {
+	EnrollUser(ctx context.Context, username string, pk ssh.PublicKey) (proto.User, error)
+	IsKnownPublicKey(ctx context.Context, pk ssh.PublicKey) bool
+	PendingUsers(ctx context.Context) ([]string, error)
+	ApproveUser(ctx context.Context, username string) error
+	RejectUser(ctx context.Context, username string) error
+	CheckKeyPolicy(pk ssh.PublicKey) error
+	KeyPolicyViolations(ctx context.Context) ([]KeyPolicyViolation, error)
+	TouchPublicKey(pk ssh.PublicKey)
+	PublicKeys(ctx context.Context) ([]proto.PublicKey, error)
+	User(ctx context.Context, username string) (proto.User, error)
+	UserByID(ctx context.Context, id int64) (proto.User, error)
+	UserByPublicKey(ctx context.Context, pk ssh.PublicKey) (proto.User, error)
+	UserByAccessToken(ctx context.Context, token string) (proto.User, error)
+	Users(ctx context.Context) ([]string, error)
+	AddPublicKey(ctx context.Context, username string, pk ssh.PublicKey) error
+	AddPublicKeyWithComment(ctx context.Context, username string, pk ssh.PublicKey, comment string) error
+	CreateUser(ctx context.Context, username string, opts proto.UserOptions) (proto.User, error)
+	DeleteUser(ctx context.Context, username string) error
+	RemovePublicKey(ctx context.Context, username string, pk ssh.PublicKey) error
+	ListPublicKeys(ctx context.Context, username string) ([]ssh.PublicKey, error)
+	UserPublicKeys(ctx context.Context, username string) ([]proto.PublicKey, error)
+	RemovePublicKeyByFingerprint(ctx context.Context, fingerprint string) (proto.PublicKey, error)
+	PublicKeyByFingerprint(ctx context.Context, fingerprint string) (proto.PublicKey, error)
+	SetPublicKeyGitOnly(ctx context.Context, fingerprint string, gitOnly bool) (proto.PublicKey, error)
+	IsGitOnlyPublicKey(ctx context.Context, pk ssh.PublicKey) bool
+	SetUserAccessLevel(ctx context.Context, username string, level access.AccessLevel) error
+	SetUsername(ctx context.Context, username string, newUsername string) error
+	SetAdmin(ctx context.Context, username string, admin bool) error
+	SetPassword(ctx context.Context, username string, rawPassword string) error
+	SetTheme(ctx context.Context, username string, theme string) error
+	SetTimeFormat(ctx context.Context, username string, format string) error
+	PinRepository(ctx context.Context, user proto.User, repo string) error
+	UnpinRepository(ctx context.Context, user proto.User, repo string) error
+	PinnedRepositories(ctx context.Context, user proto.User) ([]string, error)
+}
+
+// AccessBackend is an interface for resolving access levels.
+type AccessBackend interface {
+	AccessLevel(ctx context.Context, repo string, username string) access.AccessLevel
+	AccessLevelByPublicKey(ctx context.Context, repo string, pk ssh.PublicKey) access.AccessLevel
+	AccessLevelByPublicKeyWithReason(ctx context.Context, repo string, pk ssh.PublicKey) (access.AccessLevel, access.Reason)
+	AccessLevelForUser(ctx context.Context, repo string, user proto.User) access.AccessLevel
+	AccessLevelForUserWithReason(ctx context.Context, repo string, user proto.User) (access.AccessLevel, access.Reason)
+}
+
+// AccessTokenBackend is an interface for managing user access tokens.
+type AccessTokenBackend interface {
+	CreateAccessToken(ctx context.Context, user proto.User, name string, expiresAt time.Time) (string, error)
+	DeleteAccessToken(ctx context.Context, user proto.User, id int64) error
+	ListAccessTokens(ctx context.Context, user proto.User) ([]proto.AccessToken, error)
+}
+
+// SettingsBackend is an interface for managing server settings.
+type SettingsBackend interface {
+	AllowKeyless(ctx context.Context) bool
+	AnonymousRepos() bool
+	IsAnonymousRepo(repo string) bool
+	SetAllowKeyless(ctx context.Context, allow bool) error
+	AnonAccess(ctx context.Context) access.AccessLevel
+	SetAnonAccess(ctx context.Context, level access.AccessLevel) error
+}
+
+// ServerBackend is an interface for server-wide operations, e.g. storage,
+// host keys, and background jobs.
+type ServerBackend interface {
+	OpenStorage(ctx context.Context) (func(), error)
+	CloseStorage()
+	HostKeys(ctx context.Context) ([]HostKey, error)
+	HostSigners() ([]ssh.Signer, error)
+	RotateHostKey(ctx context.Context, keyType keygen.KeyType) (HostKey, error)
+	RetireHostKeys(ctx context.Context, all bool) ([]HostKey, error)
+	FlushPublicKeyUsage(ctx context.Context) error
+	SendPushEmails(ctx context.Context) error
+	PruneRepositoryReads(ctx context.Context) (int64, error)
+	Reconcile(ctx context.Context, fix bool) (ReconcileReport, error)
+	ReconcileRepoConfigAll(ctx context.Context) error
+	ServerInfo(ctx context.Context) (ServerInfo, error)
+	DiskUsage(ctx context.Context) (int64, error)
+}
+
+// HooksBackend is an interface for the git hooks and the checks before git
+// operations.
+type HooksBackend interface {
+	hooks.Hooks
+	RecordPush(ctx context.Context, name string) error
+	CheckPush(ctx context.Context, repo string) error
+	CheckFetch(ctx context.Context, repo string) error
+	ValidatePreReceive(ctx context.Context, repo string, args []hooks.HookArg) error
+}
@@ -9,7 +9,7 @@ import (
 
 // AllowKeyless returns whether or not keyless access is allowed.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (b *Backend) AllowKeyless(ctx context.Context) bool {
 	var allow bool
 	if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
@@ -23,9 +23,27 @@ func (b *Backend) AllowKeyless(ctx context.Context) bool {
 	return allow
 }
 
+// AnonymousRepos returns true if any repository can be read anonymously,
+// see IsAnonymousRepo.
+func (b *Backend) AnonymousRepos() bool {
+	return len(b.cfg.Git.AnonymousRepos) > 0
+}
+
+// IsAnonymousRepo returns true if the repository is one of the git
+// anonymous_repos anonymous users can read.
+func (b *Backend) IsAnonymousRepo(repo string) bool {
+	repo = b.cfg.SanitizeRepo(repo)
+	for _, r := range b.cfg.Git.AnonymousRepos {
+		if b.cfg.SanitizeRepo(r) == repo {
+			return true
+		}
+	}
+	return false
+}
+
 // SetAllowKeyless sets whether or not keyless access is allowed.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (b *Backend) SetAllowKeyless(ctx context.Context, allow bool) error {
 	return b.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		return b.store.SetAllowKeylessAccess(ctx, tx, allow)
@@ -34,7 +52,7 @@ func (b *Backend) SetAllowKeyless(ctx context.Context, allow bool) error {
 
 // AnonAccess returns the level of anonymous access.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (b *Backend) AnonAccess(ctx context.Context) access.AccessLevel {
 	var level access.AccessLevel
 	if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
@@ -50,7 +68,7 @@ func (b *Backend) AnonAccess(ctx context.Context) access.AccessLevel {
 
 // SetAnonAccess sets the level of anonymous access.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (b *Backend) SetAnonAccess(ctx context.Context, level access.AccessLevel) error {
 	return b.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		return b.store.SetAnonAccess(ctx, tx, level)
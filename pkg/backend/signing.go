@@ -0,0 +1,93 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+)
+
+// maxUnverifiedCommits is the maximum number of unverified commits listed in
+// the error returned to the client.
+const maxUnverifiedCommits = 10
+
+// goodPushCert returns true if the push has a good push certificate with the
+// nonce issued by the server, see "GIT_PUSH_CERT_STATUS" and
+// "GIT_PUSH_CERT_NONCE_STATUS" in githooks(5). A certificate with a bad,
+// missing, or stale nonce could be replayed from another push.
+func goodPushCert() bool {
+	return os.Getenv("GIT_PUSH_CERT_STATUS") == "G" && os.Getenv("GIT_PUSH_CERT_NONCE_STATUS") == "OK"
+}
+
+// verifySignedCommits makes sure every new commit pushed to a repository that
+// requires signed commits has a valid signature. A push with a good push
+// certificate (git push --signed) is accepted as a whole, see goodPushCert.
+func (d *Backend) verifySignedCommits(ctx context.Context, repo string, args []hooks.HookArg) error {
+	repo = d.cfg.SanitizeRepo(repo)
+	require, err := d.RequireSignedCommits(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if !require {
+		return nil
+	}
+
+	if goodPushCert() {
+		d.logger.Debug("accepting signed push", "repo", repo, "signer", os.Getenv("GIT_PUSH_CERT_SIGNER"))
+		return nil
+	}
+
+	rp := d.repoPath(repo)
+	var unverified []string
+	for _, arg := range args {
+		if git.IsZeroHash(arg.NewSha) {
+			// Deleting a ref doesn't add any commits.
+			continue
+		}
+
+		// List the commits that aren't reachable from any existing ref.
+		out, err := git.NewCommand("rev-list", arg.NewSha, "--not", "--all").
+			WithContext(ctx).RunInDir(rp)
+		if err != nil {
+			return fmt.Errorf("failed to list new commits of %s: %w", arg.RefName, err)
+		}
+
+		for _, sha := range strings.Fields(string(out)) {
+			if d.verifyCommit(ctx, rp, sha) {
+				continue
+			}
+
+			unverified = append(unverified, fmt.Sprintf("  %s (%s)", sha, arg.RefName))
+		}
+	}
+
+	if len(unverified) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s requires signed commits, the following commits are not signed by an allowed signer:", repo)
+	if len(unverified) > maxUnverifiedCommits {
+		more := len(unverified) - maxUnverifiedCommits
+		unverified = append(unverified[:maxUnverifiedCommits], fmt.Sprintf("  ... and %d more", more))
+	}
+
+	return fmt.Errorf("%s\n%s", msg, strings.Join(unverified, "\n"))
+}
+
+// verifyCommit returns true if the commit has a valid signature.
+func (d *Backend) verifyCommit(ctx context.Context, repoPath, sha string) bool {
+	args := []string{}
+	if d.cfg.Git.AllowedSignersFile != "" {
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+d.cfg.Git.AllowedSignersFile)
+	}
+	args = append(args, "verify-commit", sha)
+	if _, err := git.NewCommand(args...).WithContext(ctx).RunInDir(repoPath); err != nil {
+		d.logger.Debug("commit signature verification failed", "commit", sha, "err", err)
+		return false
+	}
+
+	return true
+}
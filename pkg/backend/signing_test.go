@@ -0,0 +1,27 @@
+package backend
+
+import "testing"
+
+func TestGoodPushCert(t *testing.T) {
+	cases := []struct {
+		status, nonce string
+		want          bool
+	}{
+		{"G", "OK", true},
+		{"G", "BAD", false},
+		{"G", "MISSING", false},
+		{"G", "SLOP", false},
+		{"G", "UNSOLICITED", false},
+		{"G", "", false},
+		{"B", "OK", false},
+		{"N", "OK", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		t.Setenv("GIT_PUSH_CERT_STATUS", c.status)
+		t.Setenv("GIT_PUSH_CERT_NONCE_STATUS", c.nonce)
+		if got := goodPushCert(); got != c.want {
+			t.Errorf("goodPushCert() with status %q and nonce status %q = %t, want %t", c.status, c.nonce, got, c.want)
+		}
+	}
+}
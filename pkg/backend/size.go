@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RepositorySize is the disk usage of a repository, in bytes.
+type RepositorySize struct {
+	// Packs is the size of the pack files and their indexes.
+	Packs int64 `json:"packs"`
+	// Loose is the size of the loose objects.
+	Loose int64 `json:"loose"`
+	// Refs is the size of the loose and packed refs.
+	Refs int64 `json:"refs"`
+	// Other is the size of the other files, e.g. the config and hooks.
+	Other int64 `json:"other"`
+	// LFS is the size of the Git LFS objects of the repository. It's zero if
+	// LFS is disabled.
+	LFS int64 `json:"lfs"`
+}
+
+// Total returns the total disk usage of the repository.
+func (s RepositorySize) Total() int64 {
+	return s.Packs + s.Loose + s.Refs + s.Other + s.LFS
+}
+
+// RepositorySizeDetailed returns the disk usage of a repository broken down
+// by what uses the space.
+func (d *Backend) RepositorySizeDetailed(ctx context.Context, name string) (*RepositorySize, error) {
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var size RepositorySize
+	rp := d.repoPath(repo.Name())
+	if err := walkFileSizes(ctx, rp, func(p string, n int64) {
+		rel, _ := filepath.Rel(rp, p)
+		rel = filepath.ToSlash(rel)
+		switch dir := path0(rel); {
+		case strings.HasPrefix(rel, "objects/pack/"):
+			size.Packs += n
+		case dir == "objects" && isLooseObjectPath(rel):
+			size.Loose += n
+		case dir == "refs" || rel == "packed-refs":
+			size.Refs += n
+		default:
+			size.Other += n
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if d.cfg.LFS.Enabled {
+		lfsPath := filepath.Join(d.cfg.DataPath, "lfs", strconv.FormatInt(repo.ID(), 10))
+		if err := walkFileSizes(ctx, lfsPath, func(_ string, n int64) {
+			size.LFS += n
+		}); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return &size, nil
+}
+
+// walkFileSizes calls fn with the path and size of every regular file under
+// root.
+func walkFileSizes(ctx context.Context, root string, fn func(string, int64)) error {
+	return filepath.WalkDir(root, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !e.Type().IsRegular() {
+			return nil
+		}
+
+		fi, err := e.Info()
+		if err != nil {
+			return err
+		}
+
+		fn(p, fi.Size())
+		return nil
+	})
+}
+
+// path0 returns the first element of a slash separated path.
+func path0(p string) string {
+	dir, _, _ := strings.Cut(p, "/")
+	return dir
+}
+
+// isLooseObjectPath returns true if a path relative to the repository is a
+// loose object, i.e. objects/xx/yyyy....
+func isLooseObjectPath(rel string) bool {
+	parts := strings.Split(rel, "/")
+	if len(parts) != 3 || len(parts[1]) != 2 {
+		return false
+	}
+	_, err := strconv.ParseUint(parts[1], 16, 8)
+	return err == nil
+}
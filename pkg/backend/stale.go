@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"context"
+	"time"
+)
+
+// StaleRepository is a repository without recent pushes or without any
+// commits.
+type StaleRepository struct {
+	Name string `json:"name"`
+	// LastActivity is the time of the latest commit of the repository, or
+	// its last update if it's empty.
+	LastActivity time.Time `json:"last_activity"`
+	// Empty is true if the repository has no objects.
+	Empty bool `json:"empty"`
+}
+
+// StaleRepositories returns the repositories without activity since before,
+// and the repositories without any objects. Only empty repositories are
+// returned when emptyOnly is true.
+func (d *Backend) StaleRepositories(ctx context.Context, before time.Time, emptyOnly bool) ([]StaleRepository, error) {
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []StaleRepository
+	for _, r := range repos {
+		stats, err := d.RepositoryStats(ctx, r)
+		if err != nil {
+			d.logger.Error("error counting repository objects", "repo", r.Name(), "err", err)
+			continue
+		}
+
+		s := StaleRepository{
+			Name:         r.Name(),
+			LastActivity: r.UpdatedAt(),
+			Empty:        stats.Objects() == 0,
+		}
+		if s.Empty || (!emptyOnly && s.LastActivity.Before(before)) {
+			stale = append(stale, s)
+		}
+	}
+
+	return stale, nil
+}
@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// statsFile is the name of the file, relative to the repository info
+// directory, where object statistics are cached. The file lives on disk so
+// that the git hooks, which run in a separate process, can invalidate it.
+const statsFile = "stats.json"
+
+// RepositoryStats returns the object database statistics of a repository.
+//
+// Statistics are cached in the repository and invalidated on push and after
+// garbage collection.
+func (d *Backend) RepositoryStats(_ context.Context, repo proto.Repository) (*git.ObjectStats, error) {
+	r, err := repo.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	fp := filepath.Join(r.Path, "info", statsFile)
+	if bts, err := os.ReadFile(fp); err == nil {
+		var stats git.ObjectStats
+		if err := json.Unmarshal(bts, &stats); err == nil {
+			return &stats, nil
+		}
+	}
+
+	stats, err := r.CountObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	bts, err := json.Marshal(stats)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fp), 0o755); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(fp, bts, 0o644); err != nil { //nolint:gosec
+		d.logger.Error("error caching repository stats", "repo", repo.Name(), "err", err)
+	}
+
+	return stats, nil
+}
+
+// InvalidateRepositoryStats removes the cached object statistics of a
+// repository.
+func (d *Backend) InvalidateRepositoryStats(_ context.Context, repo proto.Repository) error {
+	r, err := repo.Open()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(r.Path, "info", statsFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// CreateTag creates the tag name pointing to the commit ref resolves to. The
+// tag is annotated, tagged by user, when message isn't empty. Existing tags
+// are only replaced when force is set, by repository admins, unless tags of
+// the repository are immutable.
+//
+// The tag is validated like a push of it, with ValidatePreReceive. The custom
+// git hooks of the repository don't run.
+func (d *Backend) CreateTag(ctx context.Context, repo proto.Repository, user proto.User, name, ref, message string, force bool) error {
+	end, err := d.BeginPush(repo.Name())
+	if err != nil {
+		return err
+	}
+
+	defer end()
+	r, err := repo.Open()
+	if err != nil {
+		return err
+	}
+
+	id, err := r.ResolveCommit(ref)
+	if err != nil {
+		return err
+	}
+
+	oldID, err := r.ShowRefVerify(git.RefsTags + name)
+	if err == nil {
+		if !force {
+			return proto.ErrTagExist
+		}
+		if d.AccessLevelForUser(ctx, repo.Name(), user) < access.AdminAccess {
+			return fmt.Errorf("only admins can replace tag %q of %s", name, repo.Name())
+		}
+	} else {
+		oldID = git.ZeroID
+	}
+
+	// The pre-receive checks find the user in the context, instead of the
+	// environment of the hook.
+	ctx = proto.WithUserContext(ctx, user)
+	args := []hooks.HookArg{{OldSha: oldID, NewSha: id, RefName: git.RefsTags + name}}
+	if err := d.ValidatePreReceive(ctx, repo.Name(), args); err != nil {
+		return err
+	}
+
+	var tagger *gitm.Signature
+	if message != "" {
+		tagger = d.tagger(user)
+	}
+
+	if err := r.CreateTag(ctx, name, id, message, tagger, force); err != nil {
+		return err
+	}
+
+	newID, err := r.ShowRefVerify(git.RefsTags + name)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("created tag", "repo", repo.Name(), "tag", name, "commit", id, "annotated", message != "", "replaced", oldID != git.ZeroID)
+
+	wh, err := webhook.NewBranchTagEvent(ctx, user, repo, git.RefsTags+name, oldID, newID)
+	if err != nil {
+		d.logger.Error("error creating branch_tag webhook", "err", err)
+	} else if err := webhook.SendEvent(ctx, wh); err != nil {
+		d.logger.Error("error sending branch_tag webhook", "err", err)
+	}
+
+	return nil
+}
+
+// verifyImmutableTags rejects pushes moving or deleting existing tags of a
+// repository with immutable tags. New tags can still be pushed.
+func (d *Backend) verifyImmutableTags(ctx context.Context, repo string, args []hooks.HookArg) error {
+	var tag string
+	for _, arg := range args {
+		if strings.HasPrefix(arg.RefName, git.RefsTags) && !git.IsZeroHash(arg.OldSha) {
+			tag = strings.TrimPrefix(arg.RefName, git.RefsTags)
+			break
+		}
+	}
+	if tag == "" {
+		return nil
+	}
+
+	immutable, err := d.ImmutableTags(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if !immutable {
+		return nil
+	}
+
+	return fmt.Errorf("%w in %s, tag %q can't be moved or deleted", proto.ErrTagImmutable, d.cfg.SanitizeRepo(repo), tag)
+}
+
+// tagger returns the identity of the annotated tags created by user. The
+// email address uses the server SSH hostname, users don't have one.
+func (d *Backend) tagger(user proto.User) *gitm.Signature {
+	name, login := d.cfg.Name, "soft-serve"
+	if user != nil {
+		name, login = user.Username(), user.Username()
+	}
+
+	host := "localhost"
+	if u, err := url.Parse(d.cfg.SSH.PublicURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	return &gitm.Signature{
+		Name:  name,
+		Email: login + "@" + host,
+		When:  time.Now(),
+	}
+}
@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// encryptedTrashDir is the trash directory inside the repositories directory
+// when storage encryption is enabled. It can't be the name of a repository.
+const encryptedTrashDir = "@trash"
+
+// TrashPath returns the directory trashed repositories are moved to. With
+// storage encryption, it's inside the encrypted repositories directory so
+// that trashed repositories and backups stay encrypted.
+func (d *Backend) TrashPath() string {
+	if d.cfg.Storage.Encryption.Enabled {
+		return filepath.Join(d.cfg.DataPath, "repos", encryptedTrashDir)
+	}
+
+	return filepath.Join(d.cfg.DataPath, "trash")
+}
+
+// TrashRepository deletes a repository like DeleteRepository, but moves its
+// git and LFS data to the trash directory instead of removing them. It
+// returns the trash directory of the repository, its git data can be
+// restored with "repo import".
+func (d *Backend) TrashRepository(ctx context.Context, name string) (string, error) {
+	name = d.cfg.SanitizeRepo(name)
+	trash := filepath.Join(d.TrashPath(),
+		time.Now().UTC().Format("20060102T150405Z")+"-"+strings.ReplaceAll(name, "/", "_"))
+	if err := d.deleteRepository(ctx, name, trash); err != nil {
+		return "", err
+	}
+
+	return trash, nil
+}
+
+// moveToTrash moves the git data of a repository, and its LFS objects if any,
+// to the trash directory.
+func moveToTrash(trash string, repoPath string, lfsPath string) error {
+	if err := os.MkdirAll(trash, os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := os.Rename(repoPath, filepath.Join(trash, "repo.git")); err != nil {
+		return err
+	}
+
+	if err := moveDir(lfsPath, filepath.Join(trash, "lfs")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// moveDir moves a directory, copying it when it's moved to another file
+// system, e.g. LFS objects moved to the encrypted trash.
+func moveDir(src string, dst string) error {
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := os.CopyFS(dst, os.DirFS(src)); err != nil {
+		os.RemoveAll(dst) // nolint: errcheck
+		return err
+	}
+
+	return os.RemoveAll(src)
+}
@@ -3,21 +3,25 @@ package backend
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/notify"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"golang.org/x/crypto/ssh"
 )
 
 // AccessLevel returns the access level of a user for a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) AccessLevel(ctx context.Context, repo string, username string) access.AccessLevel {
 	user, _ := d.User(ctx, username)
 	return d.AccessLevelForUser(ctx, repo, user)
@@ -25,34 +29,71 @@ func (d *Backend) AccessLevel(ctx context.Context, repo string, username string)
 
 // AccessLevelByPublicKey returns the access level of a user's public key for a repository.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) AccessLevelByPublicKey(ctx context.Context, repo string, pk ssh.PublicKey) access.AccessLevel {
+	level, _ := d.AccessLevelByPublicKeyWithReason(ctx, repo, pk)
+	return level
+}
+
+// AccessLevelByPublicKeyWithReason returns the access level of a user's
+// public key for a repository along with the reason it was granted.
+func (d *Backend) AccessLevelByPublicKeyWithReason(ctx context.Context, repo string, pk ssh.PublicKey) (access.AccessLevel, access.Reason) {
 	for _, k := range d.cfg.AdminKeys() {
 		if sshutils.KeysEqual(pk, k) {
-			return access.AdminAccess
+			return access.AdminAccess, access.ReasonAdminKey
 		}
 	}
 
 	user, _ := d.UserByPublicKey(ctx, pk)
-	if user != nil {
-		return d.AccessLevel(ctx, repo, user.Username())
-	}
-
-	return d.AccessLevel(ctx, repo, "")
+	return d.accessLevel(ctx, repo, user, pk)
 }
 
 // AccessLevelForUser returns the access level of a user for a repository.
-// TODO: user repository ownership
 func (d *Backend) AccessLevelForUser(ctx context.Context, repo string, user proto.User) access.AccessLevel {
+	level, _ := d.AccessLevelForUserWithReason(ctx, repo, user)
+	return level
+}
+
+// AccessLevelForUserWithReason returns the access level of a user for a
+// repository along with the reason, i.e. the rule, that granted it.
+func (d *Backend) AccessLevelForUserWithReason(ctx context.Context, repo string, user proto.User) (access.AccessLevel, access.Reason) {
+	return d.accessLevel(ctx, repo, user, contextPublicKey(ctx, user))
+}
+
+// accessLevel returns the access level of a user, authenticated with pk if
+// not nil, for a repository along with the reason that granted it.
+// TODO: user repository ownership
+func (d *Backend) accessLevel(ctx context.Context, repo string, user proto.User, pk ssh.PublicKey) (access.AccessLevel, access.Reason) {
 	var username string
 	anon := d.AnonAccess(ctx)
 	if user != nil {
 		username = user.Username()
 	}
 
+	// Pending users have no access until an admin approves them.
+	if user != nil && user.IsPending() {
+		return access.NoAccess, access.ReasonPending
+	}
+
 	// If the user is an admin, they have admin access.
 	if user != nil && user.IsAdmin() {
-		return access.AdminAccess
+		return access.AdminAccess, access.ReasonAdmin
+	}
+
+	// The auth exec hook decides the access level of public keys unless it
+	// defers to the rules below.
+	if level, ok := d.authHookAccessLevel(ctx, repo, user, pk); ok {
+		return level, access.ReasonExecHook
+	}
+
+	// Deploy keys only have access to their repository.
+	if user == nil && pk != nil {
+		if dk, err := d.DeployKeyByPublicKey(ctx, pk); err == nil {
+			if dk.Repo != d.cfg.SanitizeRepo(repo) {
+				return access.NoAccess, access.ReasonDeployKey
+			}
+			return dk.AccessLevel, access.ReasonDeployKey
+		}
 	}
 
 	// If the repository exists, check if the user is a collaborator.
@@ -61,11 +102,28 @@ func (d *Backend) AccessLevelForUser(ctx context.Context, repo string, user prot
 		r, _ = d.Repository(ctx, repo)
 	}
 
+	if user == nil {
+		// Anonymous repositories can be read by anyone.
+		if r != nil && d.IsAnonymousRepo(repo) {
+			if anon > access.ReadOnlyAccess && !r.IsPrivate() {
+				return anon, access.ReasonAnon
+			}
+			return access.ReadOnlyAccess, access.ReasonAnonymousRepo
+		}
+
+		// Connections without a public key, e.g. over HTTP or keyboard
+		// interactive SSH, are limited to anonymous repositories unless
+		// keyless access is allowed.
+		if pk == nil && !d.AllowKeyless(ctx) {
+			return access.NoAccess, access.ReasonKeyless
+		}
+	}
+
 	if r != nil {
 		if user != nil {
 			// If the user is the owner, they have admin access.
 			if r.UserID() == user.ID() {
-				return access.AdminAccess
+				return access.AdminAccess, access.ReasonOwner
 			}
 		}
 
@@ -73,40 +131,47 @@ func (d *Backend) AccessLevelForUser(ctx context.Context, repo string, user prot
 		collabAccess, isCollab, _ := d.IsCollaborator(ctx, repo, username)
 		if isCollab {
 			if anon > collabAccess {
-				return anon
+				return anon, access.ReasonAnon
 			}
-			return collabAccess
+			return collabAccess, access.ReasonCollaborator
 		}
 
 		// If the repository is private, the user has no access.
 		if r.IsPrivate() {
-			return access.NoAccess
+			return access.NoAccess, access.ReasonPrivate
 		}
 
-		// Otherwise, the user has read-only access.
+		// Otherwise, the user has read-only access, unless their baseline
+		// access is lower. Users don't get less than anonymous users.
 		if user == nil {
-			return anon
+			return anon, access.ReasonAnon
 		}
 
-		return access.ReadOnlyAccess
+		level := min(user.AccessLevel(), access.ReadOnlyAccess)
+		if anon > level {
+			return anon, access.ReasonAnon
+		}
+
+		return level, access.ReasonUser
 	}
 
 	if user != nil {
-		// If the repository doesn't exist, the user has read/write access.
-		if anon > access.ReadWriteAccess {
-			return anon
+		// If the repository doesn't exist, the user can create it with
+		// read/write baseline access.
+		if anon > user.AccessLevel() {
+			return anon, access.ReasonAnon
 		}
 
-		return access.ReadWriteAccess
+		return user.AccessLevel(), access.ReasonRepoNotFound
 	}
 
 	// If the user doesn't exist, give them the anonymous access level.
-	return anon
+	return anon, access.ReasonAnon
 }
 
 // User finds a user by username.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) User(ctx context.Context, username string) (proto.User, error) {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
@@ -169,7 +234,7 @@ func (d *Backend) UserByID(ctx context.Context, id int64) (proto.User, error) {
 
 // UserByPublicKey finds a user by public key.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) UserByPublicKey(ctx context.Context, pk ssh.PublicKey) (proto.User, error) {
 	var m models.User
 	var pks []ssh.PublicKey
@@ -238,7 +303,7 @@ func (d *Backend) UserByAccessToken(ctx context.Context, token string) (proto.Us
 
 // Users returns all users.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) Users(ctx context.Context) ([]string, error) {
 	var users []string
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
@@ -261,31 +326,104 @@ func (d *Backend) Users(ctx context.Context) ([]string, error) {
 
 // AddPublicKey adds a public key to a user.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) AddPublicKey(ctx context.Context, username string, pk ssh.PublicKey) error {
+	return d.AddPublicKeyWithComment(ctx, username, pk, "")
+}
+
+// AddPublicKeyWithComment adds a public key with a comment to a user.
+//
+// It implements backend.Service.
+func (d *Backend) AddPublicKeyWithComment(ctx context.Context, username string, pk ssh.PublicKey, comment string) error {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
 		return err
 	}
 
+	if err := d.CheckKeyPolicy(pk); err != nil {
+		return err
+	}
+
 	return db.WrapError(
 		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-			return d.store.AddPublicKeyByUsername(ctx, tx, username, pk)
+			if err := d.store.AddPublicKeyByUsername(ctx, tx, username, pk); err != nil {
+				return err
+			}
+
+			if comment = strings.TrimSpace(comment); comment == "" {
+				return nil
+			}
+
+			return d.store.SetPublicKeyComment(ctx, tx, pk, comment)
 		}),
 	)
 }
 
 // CreateUser creates a new user.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) CreateUser(ctx context.Context, username string, opts proto.UserOptions) (proto.User, error) {
+	u, err := d.createUser(ctx, username, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var actor string
+	if au := proto.UserFromContext(ctx); au != nil {
+		actor = au.Username()
+	}
+
+	d.notify(ctx, notify.Notification{
+		Event:   notify.EventUserCreate,
+		Actor:   actor,
+		Summary: fmt.Sprintf("%s created user %s", actorName(actor), u.Username()),
+	})
+
+	return u, nil
+}
+
+// createUser creates a user without sending a notification.
+func (d *Backend) createUser(ctx context.Context, username string, opts proto.UserOptions) (proto.User, error) {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
 		return nil, err
 	}
 
+	for _, pk := range opts.PublicKeys {
+		if err := d.CheckKeyPolicy(pk); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		return d.store.CreateUser(ctx, tx, username, opts.Admin, opts.PublicKeys)
+		if err := d.store.CreateUser(ctx, tx, username, opts.Admin, opts.PublicKeys); err != nil {
+			return err
+		}
+
+		level := access.ReadWriteAccess
+		if d.cfg.Auth.DefaultUserAccess != "" {
+			level = access.ParseAccessLevel(d.cfg.Auth.DefaultUserAccess)
+		}
+		if err := d.store.SetUserAccessLevelByUsername(ctx, tx, username, level); err != nil {
+			return err
+		}
+
+		if opts.Pending {
+			if err := d.store.SetUserPendingByUsername(ctx, tx, username, true); err != nil {
+				return err
+			}
+		}
+
+		for i, comment := range opts.PublicKeyComments {
+			if i >= len(opts.PublicKeys) || comment == "" {
+				continue
+			}
+			if err := d.store.SetPublicKeyComment(ctx, tx, opts.PublicKeys[i], strings.TrimSpace(comment)); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}); err != nil {
 		return nil, db.WrapError(err)
 	}
@@ -295,7 +433,7 @@ func (d *Backend) CreateUser(ctx context.Context, username string, opts proto.Us
 
 // DeleteUser deletes a user.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) DeleteUser(ctx context.Context, username string) error {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
@@ -313,7 +451,7 @@ func (d *Backend) DeleteUser(ctx context.Context, username string) error {
 
 // RemovePublicKey removes a public key from a user.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) RemovePublicKey(ctx context.Context, username string, pk ssh.PublicKey) error {
 	return db.WrapError(
 		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
@@ -341,9 +479,193 @@ func (d *Backend) ListPublicKeys(ctx context.Context, username string) ([]ssh.Pu
 	return keys, nil
 }
 
+// UserPublicKeys lists the public keys of a user including their comments.
+//
+// It implements backend.Service.
+func (d *Backend) UserPublicKeys(ctx context.Context, username string) ([]proto.PublicKey, error) {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	var ms []models.PublicKey
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.ListPublicKeyModelsByUsername(ctx, tx, username)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return d.publicKeysFromModels(ms)
+}
+
+// RemovePublicKeyByFingerprint removes the public key matching the given
+// fingerprint. The fingerprint can be abbreviated and the "SHA256:" prefix is
+// optional.
+//
+// It implements backend.Service.
+func (d *Backend) RemovePublicKeyByFingerprint(ctx context.Context, fingerprint string) (proto.PublicKey, error) {
+	var key proto.PublicKey
+	err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		key, err = d.publicKeyByFingerprint(ctx, tx, fingerprint)
+		if err != nil {
+			return err
+		}
+
+		return d.store.RemovePublicKeyByID(ctx, tx, key.ID)
+	})
+
+	return key, db.WrapError(err)
+}
+
+// PublicKeyByFingerprint returns the public key matching the given
+// fingerprint. The fingerprint can be abbreviated and the "SHA256:" prefix is
+// optional.
+//
+// It implements backend.Service.
+func (d *Backend) PublicKeyByFingerprint(ctx context.Context, fingerprint string) (proto.PublicKey, error) {
+	var key proto.PublicKey
+	err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		key, err = d.publicKeyByFingerprint(ctx, tx, fingerprint)
+		return err
+	})
+
+	return key, db.WrapError(err)
+}
+
+// SetPublicKeyGitOnly sets whether the public key matching the given
+// fingerprint can only be used for git commands.
+//
+// It implements backend.Service.
+func (d *Backend) SetPublicKeyGitOnly(ctx context.Context, fingerprint string, gitOnly bool) (proto.PublicKey, error) {
+	var key proto.PublicKey
+	err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		key, err = d.publicKeyByFingerprint(ctx, tx, fingerprint)
+		if err != nil {
+			return err
+		}
+
+		key.GitOnly = gitOnly
+		return d.store.SetPublicKeyGitOnlyByID(ctx, tx, key.ID, gitOnly)
+	})
+
+	return key, db.WrapError(err)
+}
+
+// IsGitOnlyPublicKey returns true if the user public key can only be used for
+// git commands. Keys that don't belong to a user aren't git-only.
+//
+// It implements backend.Service.
+func (d *Backend) IsGitOnlyPublicKey(ctx context.Context, pk ssh.PublicKey) bool {
+	if pk == nil {
+		return false
+	}
+
+	var gitOnly bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		gitOnly, err = d.store.IsPublicKeyGitOnly(ctx, tx, pk)
+		return err
+	}); err != nil {
+		return false
+	}
+
+	return gitOnly
+}
+
+// publicKeyByFingerprint returns the user public key matching the
+// abbreviated fingerprint.
+func (d *Backend) publicKeyByFingerprint(ctx context.Context, tx *db.Tx, fingerprint string) (proto.PublicKey, error) {
+	fingerprint = strings.TrimPrefix(strings.TrimSpace(fingerprint), "SHA256:")
+	if fingerprint == "" {
+		return proto.PublicKey{}, proto.ErrPublicKeyNotFound
+	}
+
+	ms, err := d.store.ListAllPublicKeyModels(ctx, tx)
+	if err != nil {
+		return proto.PublicKey{}, err
+	}
+
+	keys, err := d.publicKeysFromModels(ms)
+	if err != nil {
+		return proto.PublicKey{}, err
+	}
+
+	var matches []proto.PublicKey
+	for _, k := range keys {
+		if strings.HasPrefix(strings.TrimPrefix(k.Fingerprint(), "SHA256:"), fingerprint) {
+			matches = append(matches, k)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return proto.PublicKey{}, proto.ErrPublicKeyNotFound
+	case 1:
+		return matches[0], nil
+	default:
+		return proto.PublicKey{}, proto.ErrPublicKeyAmbiguous
+	}
+}
+
+// publicKeysFromModels converts key models, taking the buffered key usage
+// into account.
+func (d *Backend) publicKeysFromModels(ms []models.PublicKey) ([]proto.PublicKey, error) {
+	keys := make([]proto.PublicKey, len(ms))
+	for i, m := range ms {
+		pk, _, err := sshutils.ParseAuthorizedKey(m.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = proto.PublicKey{
+			ID:      m.ID,
+			UserID:  m.UserID,
+			Key:     pk,
+			Comment: m.Comment,
+			GitOnly: m.GitOnly,
+		}
+		if m.LastUsedAt.Valid {
+			keys[i].LastUsedAt = m.LastUsedAt.Time
+		}
+		if t, ok := d.keyUsage.lastUsed(pk); ok && t.After(keys[i].LastUsedAt) {
+			keys[i].LastUsedAt = t
+		}
+	}
+
+	return keys, nil
+}
+
+// SetUserAccessLevel sets the baseline access level of a user, their access to
+// the public repositories they don't collaborate on and to creating
+// repositories. It's either access.NoAccess, access.ReadOnlyAccess, or
+// access.ReadWriteAccess.
+func (d *Backend) SetUserAccessLevel(ctx context.Context, username string, level access.AccessLevel) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	if level < access.NoAccess || level > access.ReadWriteAccess {
+		return access.ErrInvalidAccessLevel
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			if _, err := d.store.FindUserByUsername(ctx, tx, username); err != nil {
+				return err
+			}
+			return d.store.SetUserAccessLevelByUsername(ctx, tx, username, level)
+		}),
+	)
+}
+
 // SetUsername sets the username of a user.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) SetUsername(ctx context.Context, username string, newUsername string) error {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
@@ -359,7 +681,7 @@ func (d *Backend) SetUsername(ctx context.Context, username string, newUsername
 
 // SetAdmin sets the admin flag of a user.
 //
-// It implements backend.Backend.
+// It implements backend.Service.
 func (d *Backend) SetAdmin(ctx context.Context, username string, admin bool) error {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
@@ -392,6 +714,102 @@ func (d *Backend) SetPassword(ctx context.Context, username string, rawPassword
 	)
 }
 
+// SetTheme sets the UI theme of a user. An empty theme resets the user to the
+// server default.
+func (d *Backend) SetTheme(ctx context.Context, username string, theme string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	theme = strings.ToLower(strings.TrimSpace(theme))
+	if _, ok := styles.LookupTheme(theme); theme != "" && !ok {
+		return fmt.Errorf("invalid theme %q, must be one of %s", theme, strings.Join(styles.ThemeNames(), ", "))
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetUserThemeByUsername(ctx, tx, username, theme)
+		}),
+	)
+}
+
+// SetTimeFormat sets the UI timestamp format of a user, either
+// config.TimeFormatRelative or config.TimeFormatAbsolute. An empty format
+// resets the user to the server default.
+func (d *Backend) SetTimeFormat(ctx context.Context, username string, format string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	switch format {
+	case "", config.TimeFormatRelative, config.TimeFormatAbsolute:
+	default:
+		return fmt.Errorf("invalid time format %q, must be %s or %s", format, config.TimeFormatRelative, config.TimeFormatAbsolute)
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetUserTimeFormatByUsername(ctx, tx, username, format)
+		}),
+	)
+}
+
+// PinRepository pins a repository for a user, pinned repositories are listed
+// first in the UI. Pins are private to the user and follow the repository
+// when it's renamed.
+func (d *Backend) PinRepository(ctx context.Context, user proto.User, repo string) error {
+	return d.setPinned(ctx, user, repo, true)
+}
+
+// UnpinRepository unpins a repository for a user.
+func (d *Backend) UnpinRepository(ctx context.Context, user proto.User, repo string) error {
+	return d.setPinned(ctx, user, repo, false)
+}
+
+func (d *Backend) setPinned(ctx context.Context, user proto.User, repo string, pinned bool) error {
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+		return proto.ErrRepoNotFound
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			if pinned {
+				return d.store.AddUserPinnedRepo(ctx, tx, user.ID(), r.Name())
+			}
+			return d.store.RemoveUserPinnedRepo(ctx, tx, user.ID(), r.Name())
+		}),
+	)
+}
+
+// PinnedRepositories returns the names of the repositories pinned by a user,
+// in the order they were pinned.
+func (d *Backend) PinnedRepositories(ctx context.Context, user proto.User) ([]string, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	var names []string
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		names, err = d.store.ListUserPinnedRepos(ctx, tx, user.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return names, nil
+}
+
 type user struct {
 	user       models.User
 	publicKeys []ssh.PublicKey
@@ -427,3 +845,27 @@ func (u *user) Password() string {
 
 	return ""
 }
+
+// Theme implements proto.User.
+func (u *user) Theme() string {
+	return u.user.Theme
+}
+
+// TimeFormat implements proto.User.
+func (u *user) TimeFormat() string {
+	return u.user.TimeFormat
+}
+
+// AccessLevel implements proto.User.
+func (u *user) AccessLevel() access.AccessLevel {
+	if l := access.ParseAccessLevel(u.user.AccessLevel); l >= access.NoAccess && l <= access.ReadWriteAccess {
+		return l
+	}
+
+	return access.NoAccess
+}
+
+// IsPending implements proto.User.
+func (u *user) IsPending() bool {
+	return u.user.Pending
+}
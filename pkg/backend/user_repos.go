@@ -0,0 +1,72 @@
+package backend
+
+import (
+	"context"
+	"sort"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// UserRepo is a repository a user owns or collaborates on.
+type UserRepo struct {
+	Repo string
+	// AccessLevel is the effective access level of the user, and Reason the
+	// rule that granted it.
+	AccessLevel access.AccessLevel
+	Reason      access.Reason
+	Owner       bool
+	// Collaborator is the collaborator access level of the user, -1 if the
+	// user isn't a collaborator or the grant expired.
+	Collaborator access.AccessLevel
+}
+
+// UserRepos returns the repositories a user owns or collaborates on, sorted
+// by name. Deploy keys can't belong to users, they don't grant users access.
+func (d *Backend) UserRepos(ctx context.Context, user proto.User) ([]UserRepo, error) {
+	var owned, collabs []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		owned, err = d.store.GetUserRepos(ctx, tx, user.ID())
+		if err != nil {
+			return err
+		}
+		collabs, err = d.store.GetCollabRepos(ctx, tx, user.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	repos := map[string]*UserRepo{}
+	for _, r := range owned {
+		repos[r.Name] = &UserRepo{Repo: r.Name, Owner: true, Collaborator: -1}
+	}
+	for _, r := range collabs {
+		ur, ok := repos[r.Name]
+		if !ok {
+			ur = &UserRepo{Repo: r.Name, Collaborator: -1}
+			repos[r.Name] = ur
+		}
+		level, isCollab, err := d.IsCollaborator(ctx, r.Name, user.Username())
+		if err != nil {
+			return nil, err
+		}
+		if isCollab {
+			ur.Collaborator = level
+		}
+	}
+
+	list := make([]UserRepo, 0, len(repos))
+	for _, ur := range repos {
+		ur.AccessLevel, ur.Reason = d.AccessLevelForUserWithReason(ctx, ur.Repo, user)
+		list = append(list, *ur)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Repo < list[j].Repo
+	})
+
+	return list, nil
+}
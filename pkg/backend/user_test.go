@@ -0,0 +1,61 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/matryer/is"
+)
+
+func TestDefaultUserAccess(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t, func(cfg *config.Config) {
+		cfg.Auth.DefaultUserAccess = "read-only"
+	})
+
+	admin, err := be.User(ctx, "admin")
+	is.NoErr(err)
+	ctx = proto.WithUserContext(ctx, admin)
+	_, err = be.CreateRepository(ctx, "public", admin, proto.RepositoryOptions{})
+	is.NoErr(err)
+
+	alice, err := be.CreateUser(ctx, "alice", proto.UserOptions{})
+	is.NoErr(err)
+	is.Equal(alice.AccessLevel(), access.ReadOnlyAccess)
+
+	// Read-only users can read public repositories but not create ones.
+	is.Equal(be.AccessLevelForUser(ctx, "public", alice), access.ReadOnlyAccess)
+	is.Equal(be.AccessLevelForUser(ctx, "new", alice), access.ReadOnlyAccess)
+
+	// Collaborators still get their access.
+	is.NoErr(be.AddCollaborator(ctx, "public", "alice", access.ReadWriteAccess, time.Time{}))
+	is.Equal(be.AccessLevelForUser(ctx, "public", alice), access.ReadWriteAccess)
+
+	is.NoErr(be.SetUserAccessLevel(ctx, "alice", access.NoAccess))
+	alice, err = be.User(ctx, "alice")
+	is.NoErr(err)
+	is.NoErr(be.SetAnonAccess(ctx, access.NoAccess))
+	is.Equal(be.AccessLevelForUser(ctx, "new", alice), access.NoAccess)
+	is.NoErr(be.RemoveCollaborator(ctx, "public", "alice"))
+	is.Equal(be.AccessLevelForUser(ctx, "public", alice), access.NoAccess)
+
+	// Users don't get less than anonymous users.
+	is.NoErr(be.SetAnonAccess(ctx, access.ReadWriteAccess))
+	is.Equal(be.AccessLevelForUser(ctx, "public", alice), access.ReadWriteAccess)
+	is.Equal(be.AccessLevelForUser(ctx, "new", alice), access.ReadWriteAccess)
+	is.NoErr(be.SetAnonAccess(ctx, access.ReadOnlyAccess))
+	is.Equal(be.AccessLevelForUser(ctx, "public", alice), access.ReadOnlyAccess)
+
+	is.NoErr(be.SetUserAccessLevel(ctx, "alice", access.ReadWriteAccess))
+	alice, err = be.User(ctx, "alice")
+	is.NoErr(err)
+	is.Equal(be.AccessLevelForUser(ctx, "public", alice), access.ReadOnlyAccess)
+	is.Equal(be.AccessLevelForUser(ctx, "new", alice), access.ReadWriteAccess)
+
+	is.True(be.SetUserAccessLevel(ctx, "alice", access.AdminAccess) != nil)
+	is.True(be.SetUserAccessLevel(ctx, "nobody", access.ReadOnlyAccess) != nil)
+}
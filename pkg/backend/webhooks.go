@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/pkg/db"
@@ -119,6 +120,18 @@ func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id i
 	datastore := store.FromContext(ctx)
 
 	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		h, err := datastore.GetWebhookByID(ctx, tx, repo.ID(), id)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		// The template must still work with the updated events.
+		if h.Template != "" {
+			if err := webhook.ValidateTemplate(h.Template, updatedEvents); err != nil {
+				return err
+			}
+		}
+
 		if err := datastore.UpdateWebhookByID(ctx, tx, repo.ID(), id, url, secret, int(contentType), active); err != nil {
 			return db.WrapError(err)
 		}
@@ -170,6 +183,28 @@ func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id i
 	})
 }
 
+// SetWebhookTemplate sets the payload template of a webhook, it's validated
+// against the webhook events. An empty template restores the default payload.
+func (b *Backend) SetWebhookTemplate(ctx context.Context, repo proto.Repository, id int64, tmpl string) error {
+	wh, err := b.Webhook(ctx, repo, id)
+	if err != nil {
+		return err
+	}
+
+	if tmpl != "" {
+		if err := webhook.ValidateTemplate(tmpl, wh.Events); err != nil {
+			return err
+		}
+	}
+
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	return db.WrapError(dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		return datastore.SetWebhookTemplateByID(ctx, tx, repo.ID(), id, tmpl)
+	}))
+}
+
 // DeleteWebhook deletes a webhook for a repository.
 func (b *Backend) DeleteWebhook(ctx context.Context, repo proto.Repository, id int64) error {
 	dbx := db.FromContext(ctx)
@@ -188,13 +223,21 @@ func (b *Backend) DeleteWebhook(ctx context.Context, repo proto.Repository, id i
 	})
 }
 
-// ListWebhookDeliveries lists webhook deliveries for a webhook.
-func (b *Backend) ListWebhookDeliveries(ctx context.Context, id int64) ([]webhook.Delivery, error) {
+// ListWebhookDeliveries lists the deliveries of a repository webhook, latest
+// first. Only the first 200 characters of the response bodies are returned.
+func (b *Backend) ListWebhookDeliveries(ctx context.Context, repo proto.Repository, id int64) ([]webhook.Delivery, error) {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 
 	var deliveries []models.WebhookDelivery
 	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		// The webhook must belong to the repository.
+		if _, err := datastore.GetWebhookByID(ctx, tx, repo.ID(), id); errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return proto.ErrWebhookNotFound
+		} else if err != nil {
+			return db.WrapError(err)
+		}
+
 		var err error
 		deliveries, err = datastore.ListWebhookDeliveriesByWebhookID(ctx, tx, id)
 		if err != nil {
@@ -244,6 +287,12 @@ func (b *Backend) RedeliverWebhookDelivery(ctx context.Context, repo proto.Repos
 
 	log.Infof("redelivering webhook delivery %s for webhook %d\n\n%s\n\n", delID, id, delivery.RequestBody)
 
+	// Templated bodies are sent again as they were rendered.
+	if wh.Template != "" {
+		wh.Template = "{{ . }}"
+		return webhook.SendWebhook(ctx, wh, webhook.Event(delivery.Event), delivery.RequestBody)
+	}
+
 	var payload json.RawMessage
 	if err := json.Unmarshal([]byte(delivery.RequestBody), &payload); err != nil {
 		log.Errorf("error unmarshaling webhook payload: %v", err)
@@ -253,15 +302,44 @@ func (b *Backend) RedeliverWebhookDelivery(ctx context.Context, repo proto.Repos
 	return webhook.SendWebhook(ctx, wh, webhook.Event(delivery.Event), payload)
 }
 
-// WebhookDelivery returns a webhook delivery.
-func (b *Backend) WebhookDelivery(ctx context.Context, webhookID int64, id uuid.UUID) (webhook.Delivery, error) {
+// RedeliverRepositoryWebhookDelivery redelivers a delivery of any webhook of
+// a repository.
+func (b *Backend) RedeliverRepositoryWebhookDelivery(ctx context.Context, repo proto.Repository, delID uuid.UUID) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	var delivery models.WebhookDelivery
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		delivery, err = datastore.GetWebhookDeliveryByRepoID(ctx, tx, repo.ID(), delID)
+		return err
+	}); errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+		return proto.ErrWebhookDeliveryNotFound
+	} else if err != nil {
+		return db.WrapError(err)
+	}
+
+	return b.RedeliverWebhookDelivery(ctx, repo, delivery.WebhookID, delID)
+}
+
+// WebhookDelivery returns a delivery of a repository webhook.
+func (b *Backend) WebhookDelivery(ctx context.Context, repo proto.Repository, webhookID int64, id uuid.UUID) (webhook.Delivery, error) {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 
 	var delivery webhook.Delivery
 	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		// The webhook must belong to the repository.
+		if _, err := datastore.GetWebhookByID(ctx, tx, repo.ID(), webhookID); errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return proto.ErrWebhookNotFound
+		} else if err != nil {
+			return db.WrapError(err)
+		}
+
 		d, err := datastore.GetWebhookDeliveryByID(ctx, tx, webhookID, id)
-		if err != nil {
+		if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return proto.ErrWebhookDeliveryNotFound
+		} else if err != nil {
 			return db.WrapError(err)
 		}
 
@@ -3,7 +3,9 @@ package daemon
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"path/filepath"
 	"strings"
@@ -16,7 +18,8 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/git"
-	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/lfs"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/go-git/go-git/v5/plumbing/format/pktline"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -48,7 +51,7 @@ type GitDaemon struct {
 	finished chan struct{}
 	conns    connections
 	cfg      *config.Config
-	be       *backend.Backend
+	be       backend.Service
 	wg       sync.WaitGroup
 	once     sync.Once
 	logger   *log.Logger
@@ -239,12 +242,28 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 		}
 
 		be := d.be
-		if !be.AllowKeyless(ctx) {
+		name := d.cfg.SanitizeRepo(string(opts[0]))
+		if !be.AllowKeyless(ctx) && !be.IsAnonymousRepo(name) {
 			d.fatal(c, git.ErrNotAuthed)
 			return
 		}
 
-		name := utils.SanitizeRepo(string(opts[0]))
+		closeStorage, err := be.OpenStorage(ctx)
+		if err != nil {
+			d.logger.Errorf("git: error mounting encrypted storage: %v", err)
+			d.fatal(c, git.ErrSystemMalfunction)
+			return
+		}
+		defer closeStorage()
+
+		// Follow the redirect of a renamed repository.
+		if _, err := be.Repository(ctx, name); errors.Is(err, proto.ErrRepoNotFound) {
+			if target, err := be.ResolveRedirect(ctx, name); err == nil {
+				d.logger.Debugf("git: redirecting %s to %s", name, target)
+				name = target
+			}
+		}
+
 		d.logger.Debugf("git: connect %s %s %s", c.RemoteAddr(), service, name)
 		defer d.logger.Debugf("git: disconnect %s %s %s", c.RemoteAddr(), service, name)
 
@@ -258,8 +277,9 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			return
 		}
 
-		if _, err := d.be.Repository(ctx, repo); err != nil {
-			d.fatal(c, git.ErrInvalidRepo)
+		r, err := d.be.Repository(ctx, repo)
+		if err != nil {
+			d.fatal(c, git.ErrRepoNotFound)
 			return
 		}
 
@@ -269,6 +289,22 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			return
 		}
 
+		if err := be.CheckFetch(ctx, name); err != nil {
+			d.fatal(c, err)
+			return
+		}
+
+		if service == git.UploadArchiveService {
+			if allow, err := be.AllowArchives(ctx, name); err != nil {
+				d.logger.Error("git: error getting archives setting", "repo", name, "err", err)
+				d.fatal(c, git.ErrSystemMalfunction)
+				return
+			} else if !allow {
+				d.fatal(c, git.ErrArchivesDisabled)
+				return
+			}
+		}
+
 		// Environment variables to pass down to git hooks.
 		envs := []string{
 			"SOFT_SERVE_REPO_NAME=" + name,
@@ -297,14 +333,74 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 			Stderr: c,
 			Env:    envs,
 			Dir:    filepath.Join(reposDir, repo),
+
+			BufferSize: d.cfg.Git.TransferBufferSize,
+			Limits:     git.ServiceLimits(d.cfg),
 		}
 
-		if err := service.Handler(ctx, cmd); err != nil {
+		release, err := d.be.AcquireGitOperation(ctx, nil, c.RemoteAddr().String())
+		if err != nil {
+			d.logger.Debugf("git: error waiting for operation: %v", err)
+			d.fatal(c, git.ErrTimeout)
+			return
+		}
+		defer release()
+
+		var fetch *git.FetchTracker
+		var limiter *git.NegotiationLimiter
+		if service == git.UploadPackService {
+			fetch = git.TrackFetch(&cmd)
+			if n := d.cfg.Git.MaxNegotiationRounds; n > 0 {
+				limiter = git.LimitNegotiation(&cmd, n)
+			}
+		}
+
+		var archiveLimit *git.ArchiveLimiter
+		var archive *git.ArchiveSmudger
+		if service == git.UploadArchiveService {
+			// Limit the archive with LFS objects smudged.
+			if n := d.cfg.Git.MaxArchiveSize; n > 0 {
+				archiveLimit = git.LimitArchive(&cmd, n)
+			}
+			if smudge, err := d.be.SmudgeLFSArchives(ctx, name); err != nil {
+				d.logger.Error("git: error getting lfs archives setting", "repo", name, "err", err)
+			} else if smudge {
+				archive = git.SmudgeArchive(&cmd, func(p lfs.Pointer) (io.ReadCloser, error) {
+					return d.be.OpenLFSObject(ctx, r, p)
+				})
+			}
+		}
+
+		err = service.Handler(ctx, cmd)
+		if limiter != nil && limiter.Exceeded() {
+			d.logger.Warn("git: aborted session", "repo", name, "err", git.ErrTooManyNegotiationRounds)
+			d.fatal(c, git.ErrTooManyNegotiationRounds)
+			return
+		} else if archiveLimit != nil && archiveLimit.Exceeded() {
+			// The client already got the error on the side-band.
+			d.logger.Warn("git: aborted session", "repo", name, "err", git.ErrArchiveTooLarge)
+			c.Close() // nolint: errcheck
+			return
+		} else if git.IsResourceLimit(err) {
+			d.logger.Warn("git: aborted session", "repo", name, "err", err)
+			d.fatal(c, err)
+			return
+		} else if err != nil {
 			d.logger.Debugf("git: error handling request: %v", err)
 			d.fatal(c, err)
 			return
 		}
 
+		if archive != nil && archive.Err() != nil {
+			d.logger.Error("git: error smudging lfs archive", "repo", name, "err", archive.Err())
+		}
+
+		if fetch != nil && fetch.Fetched() {
+			if err := d.be.AuditRead(ctx, name, nil, "git", fetch.Clone()); err != nil {
+				d.logger.Error("git: error auditing repository read", "repo", name, "err", err)
+			}
+		}
+
 		counter.WithLabelValues(name)
 	}
 }
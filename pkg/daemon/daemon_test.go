@@ -105,8 +105,8 @@ func TestInvalidRepo(t *testing.T) {
 		t.Fatalf("expected nil, got error: %v", err)
 	}
 	_, err = readPktline(c)
-	if err != nil && err.Error() != git.ErrInvalidRepo.Error() {
-		t.Errorf("expected %q error, got %q", git.ErrInvalidRepo, err)
+	if err != nil && err.Error() != git.ErrRepoNotFound.Error() {
+		t.Errorf("expected %q error, got %q", git.ErrRepoNotFound, err)
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/pkg/config"
@@ -17,10 +18,20 @@ import (
 type DB struct {
 	*sqlx.DB
 	logger *log.Logger
+
+	// conn keeps in-memory databases alive until the database is closed.
+	conn *sql.Conn
 }
 
 // Open opens a database connection.
+//
+// The SQLite data source MemoryDataSource opens a new in-memory database.
 func Open(ctx context.Context, driverName string, dsn string) (*DB, error) {
+	memory := strings.HasPrefix(driverName, "sqlite") && dsn == MemoryDataSource
+	if memory {
+		dsn = newMemoryDataSource()
+	}
+
 	db, err := sqlx.ConnectContext(ctx, driverName, dsn)
 	if err != nil {
 		return nil, err
@@ -30,6 +41,16 @@ func Open(ctx context.Context, driverName string, dsn string) (*DB, error) {
 		DB: db,
 	}
 
+	if memory {
+		// An in-memory database is deleted when its last connection is
+		// closed.
+		d.conn, err = db.Conn(ctx)
+		if err != nil {
+			db.Close() // nolint: errcheck
+			return nil, err
+		}
+	}
+
 	if config.IsVerbose() {
 		logger := log.FromContext(ctx).WithPrefix("db")
 		d.logger = logger
@@ -40,6 +61,9 @@ func Open(ctx context.Context, driverName string, dsn string) (*DB, error) {
 
 // Close implements db.DB.
 func (d *DB) Close() error {
+	if d.conn != nil {
+		d.conn.Close() // nolint: errcheck
+	}
 	return d.DB.Close()
 }
 
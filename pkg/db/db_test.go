@@ -15,3 +15,39 @@ func TestOpenUnknownDriver(t *testing.T) {
 		t.Errorf("Open(invalid) => %v, want error containing 'unknown driver'", err)
 	}
 }
+
+func TestOpenMemory(t *testing.T) {
+	ctx := context.TODO()
+	d1, err := Open(ctx, "sqlite", MemoryDataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d1.Close() // nolint: errcheck
+	d2, err := Open(ctx, "sqlite", MemoryDataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d2.Close() // nolint: errcheck
+
+	if _, err := d1.ExecContext(ctx, "CREATE TABLE t (id INTEGER);"); err != nil {
+		t.Fatal(err)
+	}
+
+	// All the connections of a database share it.
+	d1.SetMaxIdleConns(0)
+	if err := d1.TransactionContext(ctx, func(tx *Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO t VALUES (1);")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := d1.GetContext(ctx, &n, "SELECT COUNT(*) FROM t;"); err != nil || n != 1 {
+		t.Errorf("count => %d, %v, want 1", n, err)
+	}
+
+	// Databases are separate.
+	if _, err := d2.ExecContext(ctx, "SELECT * FROM t;"); err == nil {
+		t.Error("d2 has table t, want separate databases")
+	}
+}
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// MemoryDataSource is the SQLite data source of an in-memory database.
+//
+// In-memory databases only live as long as the process that opened them. Git
+// hooks run in their own process and can't see them, so the servers using one
+// reject pushes, see config.DBConfig.AllowInMemory.
+const MemoryDataSource = ":memory:"
+
+var memoryDatabases atomic.Int64
+
+// newMemoryDataSource returns the data source of a new in-memory database.
+// It uses the memdb VFS so that all the connections of the pool share the
+// same database.
+func newMemoryDataSource() string {
+	n := memoryDatabases.Add(1)
+	return fmt.Sprintf("file:/soft-serve-%d?vfs=memdb&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", n)
+}
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	publicKeyCommentsName    = "public_key_comments"
+	publicKeyCommentsVersion = 4
+)
+
+var publicKeyComments = Migration{
+	Name:    publicKeyCommentsName,
+	Version: publicKeyCommentsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, publicKeyCommentsVersion, publicKeyCommentsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, publicKeyCommentsVersion, publicKeyCommentsName)
+	},
+}
@@ -0,0 +1,2 @@
+-- Existing keys get an empty comment.
+ALTER TABLE public_keys ADD COLUMN comment TEXT NOT NULL DEFAULT '';
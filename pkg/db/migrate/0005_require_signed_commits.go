@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	requireSignedCommitsName    = "require_signed_commits"
+	requireSignedCommitsVersion = 5
+)
+
+var requireSignedCommits = Migration{
+	Name:    requireSignedCommitsName,
+	Version: requireSignedCommitsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, requireSignedCommitsVersion, requireSignedCommitsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, requireSignedCommitsVersion, requireSignedCommitsName)
+	},
+}
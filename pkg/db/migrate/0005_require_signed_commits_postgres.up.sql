@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN require_signed_commits BOOLEAN NOT NULL DEFAULT false;
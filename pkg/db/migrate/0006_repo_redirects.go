@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoRedirectsName    = "repo_redirects"
+	repoRedirectsVersion = 6
+)
+
+var repoRedirects = Migration{
+	Name:    repoRedirectsName,
+	Version: repoRedirectsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoRedirectsVersion, repoRedirectsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoRedirectsVersion, repoRedirectsName)
+	},
+}
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoReadsName    = "repo_reads"
+	repoReadsVersion = 7
+)
+
+var repoReads = Migration{
+	Name:    repoReadsName,
+	Version: repoReadsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoReadsVersion, repoReadsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoReadsVersion, repoReadsName)
+	},
+}
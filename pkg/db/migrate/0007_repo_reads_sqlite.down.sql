@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_reads;
+ALTER TABLE repos DROP COLUMN read_audit;
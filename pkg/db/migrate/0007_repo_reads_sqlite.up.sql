@@ -0,0 +1,20 @@
+ALTER TABLE repos ADD COLUMN read_audit BOOLEAN NOT NULL DEFAULT true;
+
+CREATE TABLE IF NOT EXISTS repo_reads (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  repo_id INTEGER NOT NULL,
+  user_id INTEGER,
+  transport TEXT NOT NULL,
+  clone BOOLEAN NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE SET NULL
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS repo_reads_repo_id_idx ON repo_reads(repo_id, created_at);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	webhookTemplatesName    = "webhook_templates"
+	webhookTemplatesVersion = 8
+)
+
+var webhookTemplates = Migration{
+	Name:    webhookTemplatesName,
+	Version: webhookTemplatesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, webhookTemplatesVersion, webhookTemplatesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, webhookTemplatesVersion, webhookTemplatesName)
+	},
+}
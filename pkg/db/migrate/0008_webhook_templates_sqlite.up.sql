@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN template TEXT NOT NULL DEFAULT '';
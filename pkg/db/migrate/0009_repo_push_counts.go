@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoPushCountsName    = "repo_push_counts"
+	repoPushCountsVersion = 9
+)
+
+var repoPushCounts = Migration{
+	Name:    repoPushCountsName,
+	Version: repoPushCountsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoPushCountsVersion, repoPushCountsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoPushCountsVersion, repoPushCountsName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE repos DROP COLUMN pushes_since_gc;
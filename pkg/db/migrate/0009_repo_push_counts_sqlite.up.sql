@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN pushes_since_gc INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoLFSArchivesName    = "repo_lfs_archives"
+	repoLFSArchivesVersion = 10
+)
+
+var repoLFSArchives = Migration{
+	Name:    repoLFSArchivesName,
+	Version: repoLFSArchivesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoLFSArchivesVersion, repoLFSArchivesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoLFSArchivesVersion, repoLFSArchivesName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN smudge_lfs_archives BOOLEAN NOT NULL DEFAULT false;
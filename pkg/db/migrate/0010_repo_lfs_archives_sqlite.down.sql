@@ -0,0 +1 @@
+ALTER TABLE repos DROP COLUMN smudge_lfs_archives;
@@ -0,0 +1,61 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoCreatorsName    = "repo_creators"
+	repoCreatorsVersion = 11
+)
+
+// Record who created repositories, and backfill the creation time of
+// repositories that existed on disk before they were added to the database.
+var repoCreators = Migration{
+	Name:    repoCreatorsName,
+	Version: repoCreatorsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		if err := migrateUp(ctx, tx, repoCreatorsVersion, repoCreatorsName); err != nil {
+			return err
+		}
+
+		cfg := config.FromContext(ctx)
+		logger := log.FromContext(ctx).WithPrefix("migrate_repo_creators")
+
+		var repos []struct {
+			ID        int64     `db:"id"`
+			Name      string    `db:"name"`
+			CreatedAt time.Time `db:"created_at"`
+		}
+		if err := tx.SelectContext(ctx, &repos, "SELECT id, name, created_at FROM repos"); err != nil {
+			return err
+		}
+		for _, r := range repos {
+			rp := filepath.Join(cfg.DataPath, "repos", filepath.FromSlash(r.Name)+".git")
+			fi, err := os.Stat(rp)
+			if err != nil {
+				logger.Warn("stat repository", "repo", r.Name, "err", err)
+				continue
+			}
+
+			// The directory is only a better guess if it's older than the
+			// database record.
+			if mt := fi.ModTime().UTC(); mt.Before(r.CreatedAt) {
+				if _, err := tx.ExecContext(ctx, tx.Rebind("UPDATE repos SET created_at = ? WHERE id = ?"), mt, r.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoCreatorsVersion, repoCreatorsName)
+	},
+}
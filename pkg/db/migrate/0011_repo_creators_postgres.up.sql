@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN created_by INTEGER;
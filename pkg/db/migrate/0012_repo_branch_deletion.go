@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoBranchDeletionName    = "repo_branch_deletion"
+	repoBranchDeletionVersion = 12
+)
+
+var repoBranchDeletion = Migration{
+	Name:    repoBranchDeletionName,
+	Version: repoBranchDeletionVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoBranchDeletionVersion, repoBranchDeletionName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoBranchDeletionVersion, repoBranchDeletionName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN allow_branch_deletion BOOLEAN NOT NULL DEFAULT true;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	publicKeyLastUsedName    = "public_key_last_used"
+	publicKeyLastUsedVersion = 13
+)
+
+var publicKeyLastUsed = Migration{
+	Name:    publicKeyLastUsedName,
+	Version: publicKeyLastUsedVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, publicKeyLastUsedVersion, publicKeyLastUsedName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, publicKeyLastUsedVersion, publicKeyLastUsedName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE public_keys ADD COLUMN last_used_at TIMESTAMP;
@@ -0,0 +1 @@
+ALTER TABLE public_keys DROP COLUMN last_used_at;
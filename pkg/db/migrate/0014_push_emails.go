@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	pushEmailsName    = "push_emails"
+	pushEmailsVersion = 14
+)
+
+var pushEmails = Migration{
+	Name:    pushEmailsName,
+	Version: pushEmailsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, pushEmailsVersion, pushEmailsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, pushEmailsVersion, pushEmailsName)
+	},
+}
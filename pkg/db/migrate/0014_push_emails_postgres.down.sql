@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS push_email_queue;
+DROP TABLE IF EXISTS repo_push_emails;
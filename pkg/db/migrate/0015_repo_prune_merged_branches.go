@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoPruneMergedBranchesName    = "repo_prune_merged_branches"
+	repoPruneMergedBranchesVersion = 15
+)
+
+var repoPruneMergedBranches = Migration{
+	Name:    repoPruneMergedBranchesName,
+	Version: repoPruneMergedBranchesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoPruneMergedBranchesVersion, repoPruneMergedBranchesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoPruneMergedBranchesVersion, repoPruneMergedBranchesName)
+	},
+}
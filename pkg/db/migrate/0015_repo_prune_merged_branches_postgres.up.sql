@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN prune_merged_branches BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	collabExpiryName    = "collab_expiry"
+	collabExpiryVersion = 16
+)
+
+var collabExpiry = Migration{
+	Name:    collabExpiryName,
+	Version: collabExpiryVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, collabExpiryVersion, collabExpiryName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, collabExpiryVersion, collabExpiryName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE collabs ADD COLUMN expires_at TIMESTAMP;
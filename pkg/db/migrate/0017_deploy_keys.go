@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	deployKeysName    = "deploy_keys"
+	deployKeysVersion = 17
+)
+
+var deployKeys = Migration{
+	Name:    deployKeysName,
+	Version: deployKeysVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, deployKeysVersion, deployKeysName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, deployKeysVersion, deployKeysName)
+	},
+}
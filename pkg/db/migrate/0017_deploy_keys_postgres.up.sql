@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS deploy_keys (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  public_key TEXT NOT NULL UNIQUE,
+  comment TEXT NOT NULL DEFAULT '',
+  access_level INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	userThemesName    = "user_themes"
+	userThemesVersion = 18
+)
+
+var userThemes = Migration{
+	Name:    userThemesName,
+	Version: userThemesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, userThemesVersion, userThemesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, userThemesVersion, userThemesName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN theme TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	collabPathsName    = "collab_paths"
+	collabPathsVersion = 19
+)
+
+var collabPaths = Migration{
+	Name:    collabPathsName,
+	Version: collabPathsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, collabPathsVersion, collabPathsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, collabPathsVersion, collabPathsName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE collabs ADD COLUMN paths TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE collabs DROP COLUMN paths;
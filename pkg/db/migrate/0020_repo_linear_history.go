@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoLinearHistoryName    = "repo_linear_history"
+	repoLinearHistoryVersion = 20
+)
+
+var repoLinearHistory = Migration{
+	Name:    repoLinearHistoryName,
+	Version: repoLinearHistoryVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoLinearHistoryVersion, repoLinearHistoryName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoLinearHistoryVersion, repoLinearHistoryName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN require_linear_history BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoReadmeDescriptionName    = "repo_readme_description"
+	repoReadmeDescriptionVersion = 22
+)
+
+var repoReadmeDescription = Migration{
+	Name:    repoReadmeDescriptionName,
+	Version: repoReadmeDescriptionVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoReadmeDescriptionVersion, repoReadmeDescriptionName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoReadmeDescriptionVersion, repoReadmeDescriptionName)
+	},
+}
@@ -0,0 +1,2 @@
+ALTER TABLE repos DROP COLUMN description_generated;
+ALTER TABLE repos DROP COLUMN readme_description;
@@ -0,0 +1,2 @@
+ALTER TABLE repos ADD COLUMN readme_description BOOLEAN;
+ALTER TABLE repos ADD COLUMN description_generated BOOLEAN NOT NULL DEFAULT false;
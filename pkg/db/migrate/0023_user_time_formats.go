@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	userTimeFormatsName    = "user_time_formats"
+	userTimeFormatsVersion = 23
+)
+
+var userTimeFormats = Migration{
+	Name:    userTimeFormatsName,
+	Version: userTimeFormatsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, userTimeFormatsVersion, userTimeFormatsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, userTimeFormatsVersion, userTimeFormatsName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN time_format TEXT NOT NULL DEFAULT '';
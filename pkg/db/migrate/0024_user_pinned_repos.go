@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	userPinnedReposName    = "user_pinned_repos"
+	userPinnedReposVersion = 24
+)
+
+var userPinnedRepos = Migration{
+	Name:    userPinnedReposName,
+	Version: userPinnedReposVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, userPinnedReposVersion, userPinnedReposName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, userPinnedReposVersion, userPinnedReposName)
+	},
+}
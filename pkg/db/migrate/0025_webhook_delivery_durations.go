@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	webhookDeliveryDurationsName    = "webhook_delivery_durations"
+	webhookDeliveryDurationsVersion = 25
+)
+
+var webhookDeliveryDurations = Migration{
+	Name:    webhookDeliveryDurationsName,
+	Version: webhookDeliveryDurationsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, webhookDeliveryDurationsVersion, webhookDeliveryDurationsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, webhookDeliveryDurationsVersion, webhookDeliveryDurationsName)
+	},
+}
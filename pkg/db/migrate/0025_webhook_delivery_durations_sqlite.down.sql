@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS webhook_deliveries_webhook_id_created_at_idx;
+ALTER TABLE webhook_deliveries DROP COLUMN duration_ms;
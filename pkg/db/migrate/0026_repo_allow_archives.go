@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoAllowArchivesName    = "repo_allow_archives"
+	repoAllowArchivesVersion = 26
+)
+
+var repoAllowArchives = Migration{
+	Name:    repoAllowArchivesName,
+	Version: repoAllowArchivesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoAllowArchivesVersion, repoAllowArchivesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoAllowArchivesVersion, repoAllowArchivesName)
+	},
+}
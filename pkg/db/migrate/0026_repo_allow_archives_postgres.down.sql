@@ -0,0 +1 @@
+ALTER TABLE repos DROP COLUMN allow_archives;
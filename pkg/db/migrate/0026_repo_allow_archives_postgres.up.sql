@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN allow_archives BOOLEAN NOT NULL DEFAULT true;
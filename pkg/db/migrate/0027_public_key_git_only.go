@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	publicKeyGitOnlyName    = "public_key_git_only"
+	publicKeyGitOnlyVersion = 27
+)
+
+var publicKeyGitOnly = Migration{
+	Name:    publicKeyGitOnlyName,
+	Version: publicKeyGitOnlyVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, publicKeyGitOnlyVersion, publicKeyGitOnlyName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, publicKeyGitOnlyVersion, publicKeyGitOnlyName)
+	},
+}
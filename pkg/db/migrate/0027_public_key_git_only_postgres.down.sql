@@ -0,0 +1 @@
+ALTER TABLE public_keys DROP COLUMN git_only;
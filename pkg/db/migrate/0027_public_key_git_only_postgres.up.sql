@@ -0,0 +1 @@
+ALTER TABLE public_keys ADD COLUMN git_only BOOLEAN NOT NULL DEFAULT false;
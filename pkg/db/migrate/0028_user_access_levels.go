@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	userAccessLevelsName    = "user_access_levels"
+	userAccessLevelsVersion = 28
+)
+
+var userAccessLevels = Migration{
+	Name:    userAccessLevelsName,
+	Version: userAccessLevelsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, userAccessLevelsVersion, userAccessLevelsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, userAccessLevelsVersion, userAccessLevelsName)
+	},
+}
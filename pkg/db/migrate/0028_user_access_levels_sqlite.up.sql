@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN access_level TEXT NOT NULL DEFAULT 'read-write';
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	userPendingName    = "user_pending"
+	userPendingVersion = 29
+)
+
+var userPending = Migration{
+	Name:    userPendingName,
+	Version: userPendingVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, userPendingVersion, userPendingName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, userPendingVersion, userPendingName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN pending BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,55 @@
+package migrate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoNormalizedNamesName    = "repo_normalized_names"
+	repoNormalizedNamesVersion = 30
+)
+
+// Fill the lowercased repository names used to find case-only collisions.
+// The names of the repositories colliding with an older one are left empty
+// and reported.
+var repoNormalizedNames = Migration{
+	Name:    repoNormalizedNamesName,
+	Version: repoNormalizedNamesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		if err := migrateUp(ctx, tx, repoNormalizedNamesVersion, repoNormalizedNamesName); err != nil {
+			return err
+		}
+
+		logger := log.FromContext(ctx).WithPrefix("migrate")
+		var repos []struct {
+			ID   int64  `db:"id"`
+			Name string `db:"name"`
+		}
+		if err := tx.SelectContext(ctx, &repos, "SELECT id, name FROM repos ORDER BY id;"); err != nil {
+			return err
+		}
+
+		seen := make(map[string]string, len(repos))
+		for _, r := range repos {
+			normalized := strings.ToLower(r.Name)
+			if first, ok := seen[normalized]; ok {
+				logger.Warn("repository name only differs in case from an existing repository, rename it to use case-insensitive repository names", "repo", r.Name, "existing", first)
+				continue
+			}
+			seen[normalized] = r.Name
+
+			if _, err := tx.ExecContext(ctx, tx.Rebind("UPDATE repos SET normalized_name = ? WHERE id = ?;"), normalized, r.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoNormalizedNamesVersion, repoNormalizedNamesName)
+	},
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS repos_normalized_name_idx;
+ALTER TABLE repos DROP COLUMN normalized_name;
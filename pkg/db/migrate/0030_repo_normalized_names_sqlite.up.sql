@@ -0,0 +1,2 @@
+ALTER TABLE repos ADD COLUMN normalized_name TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS repos_normalized_name_idx ON repos (normalized_name);
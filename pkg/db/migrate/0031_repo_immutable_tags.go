@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoImmutableTagsName    = "repo_immutable_tags"
+	repoImmutableTagsVersion = 31
+)
+
+var repoImmutableTags = Migration{
+	Name:    repoImmutableTagsName,
+	Version: repoImmutableTagsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoImmutableTagsVersion, repoImmutableTagsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoImmutableTagsVersion, repoImmutableTagsName)
+	},
+}
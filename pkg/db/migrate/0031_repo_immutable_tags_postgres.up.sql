@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN immutable_tags BOOLEAN NOT NULL DEFAULT false;
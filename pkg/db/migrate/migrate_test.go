@@ -2,9 +2,14 @@ package migrate
 
 import (
 	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/internal/test"
 )
 
@@ -20,3 +25,86 @@ func TestMigrate(t *testing.T) {
 		t.Errorf("Migrate() => %v, want nil error", err)
 	}
 }
+
+func TestMigrateRepoCreators(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataPath = t.TempDir()
+	ctx := config.WithContext(context.TODO(), cfg)
+	dbx, err := test.OpenSqlite(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A repository that existed on disk long before it was added to the
+	// database.
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rp := filepath.Join(cfg.DataPath, "repos", "repo1.git")
+	if err := os.MkdirAll(rp, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(rp, created, created); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, m := range migrations[:repoCreatorsVersion-1] {
+			if err := m.Migrate(ctx, tx); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO repos (name, project_name, description, private, mirror, hidden, updated_at, user_id)
+			VALUES ('repo1', '', '', false, false, false, CURRENT_TIMESTAMP, 1)`); err != nil {
+			return err
+		}
+		return repoCreators.Migrate(ctx, tx)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got time.Time
+	if err := dbx.Get(&got, "SELECT created_at FROM repos WHERE name = 'repo1'"); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(created) {
+		t.Errorf("expected created_at %v, got %v", created, got)
+	}
+}
+
+func TestMigrateRepoNormalizedNames(t *testing.T) {
+	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
+	dbx, err := test.OpenSqlite(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, m := range migrations[:repoNormalizedNamesVersion-1] {
+			if err := m.Migrate(ctx, tx); err != nil {
+				return err
+			}
+		}
+		for _, name := range []string{"MyRepo", "other", "myrepo"} {
+			if _, err := tx.Exec(`INSERT INTO repos (name, project_name, description, private, mirror, hidden, updated_at, user_id)
+				VALUES (?, '', '', false, false, false, CURRENT_TIMESTAMP, 1)`, name); err != nil {
+				return err
+			}
+		}
+		return repoNormalizedNames.Migrate(ctx, tx)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []struct {
+		Name       string         `db:"name"`
+		Normalized sql.NullString `db:"normalized_name"`
+	}
+	if err := dbx.Select(&got, "SELECT name, normalized_name FROM repos ORDER BY id"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"myrepo", "other", ""}
+	for i, r := range got {
+		if r.Normalized.String != want[i] {
+			t.Errorf("expected normalized name of %s %q, got %q", r.Name, want[i], r.Normalized.String)
+		}
+	}
+}
@@ -18,6 +18,34 @@ var migrations = []Migration{
 	createTables,
 	webhooks,
 	migrateLfsObjects,
+	publicKeyComments,
+	requireSignedCommits,
+	repoRedirects,
+	repoReads,
+	webhookTemplates,
+	repoPushCounts,
+	repoLFSArchives,
+	repoCreators,
+	repoBranchDeletion,
+	publicKeyLastUsed,
+	pushEmails,
+	repoPruneMergedBranches,
+	collabExpiry,
+	deployKeys,
+	userThemes,
+	collabPaths,
+	repoLinearHistory,
+	repoArchived,
+	repoReadmeDescription,
+	userTimeFormats,
+	userPinnedRepos,
+	webhookDeliveryDurations,
+	repoAllowArchives,
+	publicKeyGitOnly,
+	userAccessLevels,
+	userPending,
+	repoNormalizedNames,
+	repoImmutableTags,
 }
 
 func execMigration(ctx context.Context, tx *db.Tx, version int, name string, down bool) error {
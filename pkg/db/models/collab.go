@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
@@ -14,4 +15,8 @@ type Collab struct {
 	AccessLevel access.AccessLevel `db:"access_level"`
 	CreatedAt   time.Time          `db:"created_at"`
 	UpdatedAt   time.Time          `db:"updated_at"`
+	ExpiresAt   sql.NullTime       `db:"expires_at"`
+	// Paths are the newline separated path patterns the collaborator can
+	// push changes to, empty for the whole repository.
+	Paths string `db:"paths"`
 }
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+)
+
+// DeployKey represents a public key with access to a single repository.
+type DeployKey struct {
+	ID          int64              `db:"id"`
+	RepoID      int64              `db:"repo_id"`
+	RepoName    string             `db:"repo_name"`
+	PublicKey   string             `db:"public_key"`
+	Comment     string             `db:"comment"`
+	AccessLevel access.AccessLevel `db:"access_level"`
+	CreatedAt   time.Time          `db:"created_at"`
+	UpdatedAt   time.Time          `db:"updated_at"`
+}
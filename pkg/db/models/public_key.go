@@ -1,10 +1,15 @@
 package models
 
+import "database/sql"
+
 // PublicKey represents a public key.
 type PublicKey struct {
-	ID        int64  `db:"id"`
-	UserID    int64  `db:"user_id"`
-	PublicKey string `db:"public_key"`
-	CreatedAt string `db:"created_at"`
-	UpdatedAt string `db:"updated_at"`
+	ID         int64        `db:"id"`
+	UserID     int64        `db:"user_id"`
+	PublicKey  string       `db:"public_key"`
+	Comment    string       `db:"comment"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	GitOnly    bool         `db:"git_only"`
+	CreatedAt  string       `db:"created_at"`
+	UpdatedAt  string       `db:"updated_at"`
 }
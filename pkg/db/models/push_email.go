@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// RepoPushEmail represents the push email settings of a repository.
+type RepoPushEmail struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// Recipients is a comma separated list of email addresses.
+	Recipients  string    `db:"recipients"`
+	FromAddress string    `db:"from_address"`
+	SMTPURL     string    `db:"smtp_url"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// PushEmail represents a push email waiting to be delivered.
+type PushEmail struct {
+	ID        int64     `db:"id"`
+	RepoID    int64     `db:"repo_id"`
+	RepoName  string    `db:"repo_name"`
+	Subject   string    `db:"subject"`
+	Body      string    `db:"body"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RepoRead is a database model for an audited repository read, a clone or a
+// fetch.
+type RepoRead struct {
+	ID        int64          `db:"id"`
+	RepoID    int64          `db:"repo_id"`
+	UserID    sql.NullInt64  `db:"user_id"`
+	Username  sql.NullString `db:"username"`
+	Transport string         `db:"transport"`
+	Clone     bool           `db:"clone"`
+	CreatedAt time.Time      `db:"created_at"`
+}
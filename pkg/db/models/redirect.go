@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RepoRedirect represents a redirect from a previous name of a repository.
+type RepoRedirect struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	RepoID    int64     `db:"repo_id"`
+	RepoName  string    `db:"repo_name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
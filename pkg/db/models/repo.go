@@ -7,14 +7,28 @@ import (
 
 // Repo is a database model for a repository.
 type Repo struct {
-	ID          int64         `db:"id"`
-	Name        string        `db:"name"`
-	ProjectName string        `db:"project_name"`
-	Description string        `db:"description"`
-	Private     bool          `db:"private"`
-	Mirror      bool          `db:"mirror"`
-	Hidden      bool          `db:"hidden"`
-	UserID      sql.NullInt64 `db:"user_id"`
-	CreatedAt   time.Time     `db:"created_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
+	ID                   int64          `db:"id"`
+	Name                 string         `db:"name"`
+	NormalizedName       sql.NullString `db:"normalized_name"`
+	ProjectName          string         `db:"project_name"`
+	Description          string         `db:"description"`
+	Private              bool           `db:"private"`
+	Mirror               bool           `db:"mirror"`
+	Hidden               bool           `db:"hidden"`
+	Archived             bool           `db:"archived"`
+	RequireSignedCommits bool           `db:"require_signed_commits"`
+	ReadAudit            bool           `db:"read_audit"`
+	PushesSinceGC        int64          `db:"pushes_since_gc"`
+	SmudgeLFSArchives    bool           `db:"smudge_lfs_archives"`
+	AllowBranchDeletion  bool           `db:"allow_branch_deletion"`
+	PruneMergedBranches  bool           `db:"prune_merged_branches"`
+	RequireLinearHistory bool           `db:"require_linear_history"`
+	ReadmeDescription    sql.NullBool   `db:"readme_description"`
+	DescriptionGenerated bool           `db:"description_generated"`
+	AllowArchives        bool           `db:"allow_archives"`
+	ImmutableTags        bool           `db:"immutable_tags"`
+	UserID               sql.NullInt64  `db:"user_id"`
+	CreatedBy            sql.NullInt64  `db:"created_by"`
+	CreatedAt            time.Time      `db:"created_at"`
+	UpdatedAt            time.Time      `db:"updated_at"`
 }
@@ -7,10 +7,14 @@ import (
 
 // User represents a user.
 type User struct {
-	ID        int64          `db:"id"`
-	Username  string         `db:"username"`
-	Admin     bool           `db:"admin"`
-	Password  sql.NullString `db:"password"`
-	CreatedAt time.Time      `db:"created_at"`
-	UpdatedAt time.Time      `db:"updated_at"`
+	ID          int64          `db:"id"`
+	Username    string         `db:"username"`
+	Admin       bool           `db:"admin"`
+	Password    sql.NullString `db:"password"`
+	Theme       string         `db:"theme"`
+	TimeFormat  string         `db:"time_format"`
+	AccessLevel string         `db:"access_level"`
+	Pending     bool           `db:"pending"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
 }
@@ -15,6 +15,7 @@ type Webhook struct {
 	Secret      string    `db:"secret"`
 	ContentType int       `db:"content_type"`
 	Active      bool      `db:"active"`
+	Template    string    `db:"template"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
@@ -40,5 +41,6 @@ type WebhookDelivery struct {
 	ResponseStatus  int            `db:"response_status"`
 	ResponseHeaders string         `db:"response_headers"`
 	ResponseBody    string         `db:"response_body"`
+	DurationMS      int64          `db:"duration_ms"`
 	CreatedAt       time.Time      `db:"created_at"`
 }
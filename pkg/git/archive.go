@@ -0,0 +1,331 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/soft-serve/pkg/lfs"
+)
+
+// maxSidebandData is the maximum size of the data of a side-band packet, a
+// pkt-line without its length prefix and band byte.
+const maxSidebandData = maxPktlineLen - 5
+
+// maxLFSPointerSize is the size above which blobs can't be LFS pointers.
+const maxLFSPointerSize = 1024
+
+// LFSObjectOpener opens the LFS object a pointer refers to. It returns an
+// error wrapping fs.ErrNotExist when the object isn't available, the pointer
+// is then kept as is.
+type LFSObjectOpener func(p lfs.Pointer) (io.ReadCloser, error)
+
+// ArchiveSmudger replaces LFS pointer files with the contents of their
+// objects in the tar archives of an upload-archive session.
+//
+// Clients send the archive arguments before the server acknowledges them,
+// the archive is then sent multiplexed on side-band 1 and terminated by a
+// flush packet. Other archive formats, e.g. zip or compressed tarballs, are
+// sent as is.
+type ArchiveSmudger struct {
+	open LFSObjectOpener
+
+	mu       sync.Mutex
+	in       pktlineScanner
+	format   string
+	nextArg  bool
+	smudged  int
+	err      error
+	out      pktlineScanner
+	state    archiveState
+	pw       *io.PipeWriter
+	done     chan struct{}
+	wmu      sync.Mutex
+	w        io.Writer
+	writeErr error
+}
+
+type archiveState int
+
+const (
+	archiveAck archiveState = iota
+	archiveData
+	archivePassthrough
+	archiveFailed
+)
+
+// SmudgeArchive wraps the streams of cmd with a new ArchiveSmudger that opens
+// LFS objects with open.
+func SmudgeArchive(cmd *ServiceCommand, open LFSObjectOpener) *ArchiveSmudger {
+	s := &ArchiveSmudger{open: open, w: cmd.Stdout}
+	if cmd.Stdin != nil {
+		cmd.Stdin = &archiveSmudgerReader{s: s, r: cmd.Stdin}
+	}
+	if cmd.Stdout != nil {
+		cmd.Stdout = &archiveSmudgerWriter{s: s}
+	}
+	return s
+}
+
+// Smudged returns the number of pointer files replaced in the archive.
+func (s *ArchiveSmudger) Smudged() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.smudged
+}
+
+// Err returns the error that aborted the archive, if any.
+func (s *ArchiveSmudger) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// scanInput parses the archive arguments sent by the client looking for the
+// archive format.
+func (s *ArchiveSmudger) scanInput(p []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.in.Feed(p, func(n int, payload []byte) bool {
+		if n == 0 {
+			return false
+		}
+
+		arg, ok := bytes.CutPrefix(bytes.TrimSuffix(payload, []byte("\n")), []byte("argument "))
+		if !ok {
+			return true
+		}
+
+		switch {
+		case s.nextArg:
+			s.format = string(arg)
+			s.nextArg = false
+		case bytes.Equal(arg, []byte("--format")):
+			s.nextArg = true
+		case bytes.HasPrefix(arg, []byte("--format=")):
+			s.format = strings.TrimPrefix(string(arg), "--format=")
+		}
+		return true
+	})
+}
+
+// writeOutput parses the packets written by upload-archive, and feeds the
+// archive data to the tar rewriter.
+func (s *ArchiveSmudger) writeOutput(p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.Feed(p, func(n int, payload []byte) bool {
+		switch s.state {
+		case archiveAck:
+			// The acknowledgment is followed by a flush packet.
+			if n == 0 {
+				if s.format == "" || s.format == "tar" {
+					s.startTar()
+				} else {
+					s.state = archivePassthrough
+				}
+			} else if bytes.HasPrefix(payload, []byte("NACK")) {
+				s.state = archivePassthrough
+			}
+			s.writePacket(n, payload)
+		case archiveData:
+			switch {
+			case n == 0:
+				s.pw.Close() // nolint: errcheck
+				s.mu.Unlock()
+				<-s.done
+				s.mu.Lock()
+				if s.state != archiveFailed {
+					s.state = archivePassthrough
+					s.writePacket(n, payload)
+				}
+			case len(payload) > 0 && payload[0] == 1:
+				// The rewriter drains the pipe on errors, writes don't block.
+				s.mu.Unlock()
+				s.pw.Write(payload[1:]) // nolint: errcheck
+				s.mu.Lock()
+			default:
+				s.writePacket(n, payload)
+			}
+		case archivePassthrough:
+			s.writePacket(n, payload)
+		case archiveFailed:
+			// Stop the rewriter from waiting for the rest of the archive.
+			s.pw.Close() // nolint: errcheck
+			return false
+		}
+		return true
+	})
+
+	s.wmu.Lock()
+	err := s.writeErr
+	s.wmu.Unlock()
+	if err != nil && s.pw != nil {
+		s.pw.CloseWithError(err) // nolint: errcheck
+	}
+	return err
+}
+
+// startTar starts rewriting the archive data.
+func (s *ArchiveSmudger) startTar() {
+	pr, pw := io.Pipe()
+	s.pw = pw
+	s.done = make(chan struct{})
+	s.state = archiveData
+	go func() {
+		defer close(s.done)
+		if err := s.rewriteTar(pr); err != nil {
+			s.mu.Lock()
+			s.err = err
+			s.state = archiveFailed
+			s.mu.Unlock()
+
+			s.wmu.Lock()
+			WriteSidebandErr(s.w, err) // nolint: errcheck
+			s.wmu.Unlock()
+		}
+		// Drain the rest of the archive, e.g. the padding of the last
+		// record.
+		io.Copy(io.Discard, pr) // nolint: errcheck
+	}()
+}
+
+// rewriteTar copies the tar archive read from r to the client, replacing LFS
+// pointer files with their objects.
+func (s *ArchiveSmudger) rewriteTar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(&sidebandWriter{s: s})
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("error reading archive: %w", err)
+		}
+
+		var content io.Reader = tr
+		var obj io.ReadCloser
+		if hdr.Typeflag == tar.TypeReg && hdr.Size < maxLFSPointerSize {
+			buf, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("error reading archive: %w", err)
+			}
+			content = bytes.NewReader(buf)
+
+			if p, err := lfs.ReadPointerFromBuffer(buf); err == nil {
+				obj, err = s.open(p)
+				switch {
+				case errors.Is(err, fs.ErrNotExist):
+				case err != nil:
+					return fmt.Errorf("error opening lfs object %s: %w", p.Oid, err)
+				default:
+					hdr.Size = p.Size
+					content = io.LimitReader(obj, p.Size)
+					s.mu.Lock()
+					s.smudged++
+					s.mu.Unlock()
+				}
+			}
+		}
+
+		if err := s.writeEntry(tw, hdr, content, obj); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("error writing archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeEntry writes an archive entry, and closes the LFS object it was read
+// from if any.
+func (s *ArchiveSmudger) writeEntry(tw *tar.Writer, hdr *tar.Header, content io.Reader, obj io.Closer) error {
+	if obj != nil {
+		defer obj.Close() // nolint: errcheck
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("error writing archive: %w", err)
+	}
+	if _, err := io.Copy(tw, content); err != nil {
+		return fmt.Errorf("error writing archive: %w", err)
+	}
+
+	return nil
+}
+
+// writePacket writes a packet to the client as is.
+func (s *ArchiveSmudger) writePacket(n int, payload []byte) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	if s.writeErr != nil {
+		return
+	}
+
+	if n < 4 {
+		_, s.writeErr = fmt.Fprintf(s.w, "%04x", n)
+		return
+	}
+	if _, s.writeErr = fmt.Fprintf(s.w, "%04x", len(payload)+4); s.writeErr == nil {
+		_, s.writeErr = s.w.Write(payload)
+	}
+}
+
+// sidebandWriter writes the rewritten archive on side-band 1.
+type sidebandWriter struct {
+	s *ArchiveSmudger
+}
+
+func (w *sidebandWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > maxSidebandData {
+			chunk = chunk[:maxSidebandData]
+		}
+
+		w.s.writePacket(len(chunk)+5, append([]byte{1}, chunk...))
+		w.s.wmu.Lock()
+		err := w.s.writeErr
+		w.s.wmu.Unlock()
+		if err != nil {
+			return written, err
+		}
+
+		written += len(chunk)
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+type archiveSmudgerReader struct {
+	s *ArchiveSmudger
+	r io.Reader
+}
+
+func (r *archiveSmudgerReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.s.scanInput(p[:n])
+	}
+	return n, err
+}
+
+type archiveSmudgerWriter struct {
+	s *ArchiveSmudger
+}
+
+func (w *archiveSmudgerWriter) Write(p []byte) (int, error) {
+	if err := w.s.writeOutput(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
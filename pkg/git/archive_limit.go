@@ -0,0 +1,101 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ArchiveLimiter aborts upload-archive sessions once the archive sent to the
+// client goes over a size. The archive is the data sent on side-band 1 after
+// the acknowledgment, progress and error messages don't count.
+//
+// Packets are only written once they're complete, so that the client gets a
+// side-band error, instead of a truncated packet, when the session is
+// aborted.
+type ArchiveLimiter struct {
+	max int64
+
+	mu       sync.Mutex
+	scanner  pktlineScanner
+	acked    bool
+	size     int64
+	exceeded bool
+	w        io.Writer
+	writeErr error
+}
+
+// LimitArchive wraps the stdout of cmd with a new ArchiveLimiter allowing
+// archives of up to size bytes.
+//
+// To limit archives with LFS objects smudged, call it before SmudgeArchive.
+func LimitArchive(cmd *ServiceCommand, size int64) *ArchiveLimiter {
+	l := &ArchiveLimiter{max: size, w: cmd.Stdout}
+	if cmd.Stdout != nil {
+		cmd.Stdout = &archiveLimitWriter{l: l}
+	}
+	return l
+}
+
+// Exceeded returns true if the session was aborted because the archive went
+// over the size limit.
+func (l *ArchiveLimiter) Exceeded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.exceeded
+}
+
+// write passes the complete packets of p to the client, and returns
+// ErrArchiveTooLarge once the archive goes over the limit.
+func (l *ArchiveLimiter) write(p []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.exceeded {
+		return ErrArchiveTooLarge
+	}
+
+	l.scanner.Feed(p, func(n int, payload []byte) bool {
+		switch {
+		case n == 0 && !l.acked:
+			// The acknowledgment is followed by a flush packet.
+			l.acked = true
+		case l.acked && len(payload) > 0 && payload[0] == 1:
+			l.size += int64(len(payload) - 1)
+			if l.size > l.max {
+				l.exceeded = true
+				return false
+			}
+		}
+
+		l.writePacket(n, payload)
+		return l.writeErr == nil
+	})
+
+	if l.exceeded {
+		WriteSidebandErr(l.w, fmt.Errorf("%w of %d bytes", ErrArchiveTooLarge, l.max)) // nolint: errcheck
+		return ErrArchiveTooLarge
+	}
+	return l.writeErr
+}
+
+// writePacket writes a packet to the client as is.
+func (l *ArchiveLimiter) writePacket(n int, payload []byte) {
+	if n < 4 {
+		_, l.writeErr = fmt.Fprintf(l.w, "%04x", n)
+		return
+	}
+	if _, l.writeErr = fmt.Fprintf(l.w, "%04x", len(payload)+4); l.writeErr == nil {
+		_, l.writeErr = l.w.Write(payload)
+	}
+}
+
+type archiveLimitWriter struct {
+	l *ArchiveLimiter
+}
+
+func (w *archiveLimitWriter) Write(p []byte) (int, error) {
+	if err := w.l.write(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestArchiveLimiter(t *testing.T) {
+	data := strings.Repeat("a", 600)
+	out := pkt("ACK\n") + "0000" +
+		pkt("\x01"+data[:300]) + pkt("\x02progress\n") + pkt("\x01"+data[300:]) + "0000"
+
+	cases := []struct {
+		name     string
+		max      int64
+		exceeded bool
+	}{
+		{"under limit", 1000, false},
+		{"at limit", 600, false},
+		{"over limit", 500, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := ServiceCommand{Stdout: &buf}
+			l := LimitArchive(&cmd, c.max)
+
+			// Write the output in small chunks to split packets.
+			var err error
+			for i := 0; i < len(out) && err == nil; i += 7 {
+				end := min(i+7, len(out))
+				_, err = cmd.Stdout.Write([]byte(out[i:end]))
+			}
+
+			if l.Exceeded() != c.exceeded {
+				t.Fatalf("expected exceeded to be %t", c.exceeded)
+			}
+			if !c.exceeded {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if buf.String() != out {
+					t.Errorf("expected output to be unchanged")
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrArchiveTooLarge) {
+				t.Fatalf("expected ErrArchiveTooLarge, got %v", err)
+			}
+			// The first data packet is sent, the second one is replaced by
+			// an error.
+			expected := pkt("ACK\n") + "0000" + pkt("\x01"+data[:300]) + pkt("\x02progress\n") +
+				pkt("\x03archive exceeds the maximum size of 500 bytes\n") + "0000"
+			if buf.String() != expected {
+				t.Errorf("expected output %q, got %q", expected, buf.String())
+			}
+		})
+	}
+}
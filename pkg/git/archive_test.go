@@ -0,0 +1,176 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/lfs"
+)
+
+func TestArchiveSmudger(t *testing.T) {
+	const object = "large file contents\n"
+	p, err := lfs.GeneratePointer(strings.NewReader(object))
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := lfs.Pointer{Oid: strings.Repeat("a", 64), Size: 3}
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for _, f := range []struct{ name, body string }{
+		{"README.md", "# Project\n"},
+		{"large.bin", p.String()},
+		{"missing.bin", missing.String()},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(tw, f.body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Split the archive between a couple of side-band packets with progress
+	// in between.
+	data := archive.String()
+	out := pkt("ACK\n") + "0000" +
+		pkt("\x01"+data[:700]) + pkt("\x02progress\n") + pkt("\x01"+data[700:]) + "0000"
+
+	open := func(ptr lfs.Pointer) (io.ReadCloser, error) {
+		if ptr.Oid != p.Oid {
+			return nil, fs.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader(object)), nil
+	}
+
+	cases := []struct {
+		name    string
+		args    []string
+		smudged int
+	}{
+		{"default format", []string{"HEAD"}, 1},
+		{"tar format", []string{"--format=tar", "HEAD"}, 1},
+		{"separate format argument", []string{"--format", "tar", "HEAD"}, 1},
+		{"zip format", []string{"--format=zip", "HEAD"}, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var in string
+			for _, arg := range c.args {
+				in += pkt("argument " + arg + "\n")
+			}
+			in += "0000"
+
+			var buf bytes.Buffer
+			cmd := ServiceCommand{Stdin: strings.NewReader(in), Stdout: &buf}
+			s := SmudgeArchive(&cmd, open)
+			if _, err := io.ReadAll(cmd.Stdin); err != nil {
+				t.Fatal(err)
+			}
+
+			// Write the output in small chunks to split packets.
+			for i := 0; i < len(out); i += 7 {
+				end := min(i+7, len(out))
+				if _, err := cmd.Stdout.Write([]byte(out[i:end])); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if s.Err() != nil {
+				t.Fatalf("unexpected error: %v", s.Err())
+			}
+			if s.Smudged() != c.smudged {
+				t.Errorf("expected %d smudged files, got %d", c.smudged, s.Smudged())
+			}
+			if c.smudged == 0 {
+				if buf.String() != out {
+					t.Errorf("expected output to be unchanged")
+				}
+				return
+			}
+
+			files, progress := readArchiveOutput(t, buf.Bytes())
+			if progress != "progress\n" {
+				t.Errorf("expected progress to be kept, got %q", progress)
+			}
+			expected := map[string]string{
+				"README.md":   "# Project\n",
+				"large.bin":   object,
+				"missing.bin": missing.String(),
+			}
+			for name, body := range expected {
+				if files[name] != body {
+					t.Errorf("expected %s to be %q, got %q", name, body, files[name])
+				}
+			}
+		})
+	}
+}
+
+// readArchiveOutput demultiplexes an upload-archive output and returns the
+// files of the archive and the progress messages.
+func readArchiveOutput(t *testing.T, out []byte) (map[string]string, string) {
+	t.Helper()
+
+	var data, progress bytes.Buffer
+	flushes := 0
+	for len(out) > 0 {
+		n, err := strconv.ParseUint(string(out[:4]), 16, 16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			flushes++
+			out = out[4:]
+			continue
+		}
+
+		payload := out[4:n]
+		out = out[n:]
+		if flushes == 0 {
+			continue
+		}
+		switch payload[0] {
+		case 1:
+			data.Write(payload[1:])
+		case 2:
+			progress.Write(payload[1:])
+		default:
+			t.Fatalf("unexpected band %d: %s", payload[0], payload[1:])
+		}
+	}
+	if flushes != 2 {
+		t.Fatalf("expected 2 flush packets, got %d", flushes)
+	}
+
+	files := map[string]string{}
+	tr := tar.NewReader(&data)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("error reading archive: %v", err)
+		}
+
+		body, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Size != int64(len(body)) {
+			t.Errorf("expected %s size %d, got %d", hdr.Name, len(body), hdr.Size)
+		}
+		files[hdr.Name] = string(body)
+	}
+
+	return files, progress.String()
+}
@@ -9,15 +9,47 @@ var (
 	// ErrSystemMalfunction represents a general system error returned to clients.
 	ErrSystemMalfunction = errors.New("something went wrong")
 
-	// ErrInvalidRepo represents an attempt to access a non-existent repo.
+	// ErrInvalidRepo represents an attempt to access an invalid repo path.
 	ErrInvalidRepo = errors.New("invalid repo")
 
+	// ErrRepoNotFound represents an attempt to access a non-existent repo.
+	// Existing repositories without any refs are valid and can be cloned.
+	ErrRepoNotFound = errors.New("repository not found")
+
 	// ErrInvalidRequest represents an invalid request.
 	ErrInvalidRequest = errors.New("invalid request")
 
 	// ErrMaxConnections represents a maximum connection limit being reached.
 	ErrMaxConnections = errors.New("too many connections, try again later")
 
+	// ErrTooManyNegotiationRounds is returned when a client exceeds the
+	// maximum number of upload-pack negotiation rounds.
+	ErrTooManyNegotiationRounds = errors.New("too many negotiation rounds")
+
+	// ErrShallowPush is returned when a client pushes from a shallow clone
+	// and shallow pushes are rejected.
+	ErrShallowPush = errors.New("pushing from a shallow clone is not allowed, run \"git fetch --unshallow\" and push again")
+
+	// ErrCPUTimeLimit is returned when a git process is killed for going
+	// over its CPU time limit.
+	ErrCPUTimeLimit = errors.New("git process exceeded the cpu time limit")
+
+	// ErrMemoryLimit is returned when a git process fails for going over its
+	// memory limit.
+	ErrMemoryLimit = errors.New("git process exceeded the memory limit")
+
+	// ErrArchiveTooLarge is returned when an archive goes over the maximum
+	// archive size.
+	ErrArchiveTooLarge = errors.New("archive exceeds the maximum size")
+
+	// ErrArchivesDisabled is returned when requesting an archive of a
+	// repository that doesn't serve archives.
+	ErrArchivesDisabled = errors.New("archives are disabled for this repository")
+
+	// ErrUnsupportedGitCommand is returned when a client runs a git command
+	// that isn't served over SSH.
+	ErrUnsupportedGitCommand = errors.New("unsupported git command")
+
 	// ErrTimeout is returned when the maximum read timeout is exceeded.
 	ErrTimeout = errors.New("I/O timeout reached")
 )
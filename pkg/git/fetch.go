@@ -0,0 +1,145 @@
+package git
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// packSignature is the start of a pack file header, followed by the pack
+// version.
+var packSignature = []byte("PACK\x00\x00\x00")
+
+// FetchTracker inspects the streams of an upload-pack session to tell
+// whether a pack was sent to the client, and whether the client already had
+// some objects, i.e. a fetch rather than a clone.
+type FetchTracker struct {
+	mu    sync.Mutex
+	in    pktlineScanner
+	haves bool
+	out   []byte
+	pack  bool
+}
+
+// TrackFetch wraps the streams of cmd with a new FetchTracker.
+func TrackFetch(cmd *ServiceCommand) *FetchTracker {
+	t := &FetchTracker{}
+	if cmd.Stdin != nil {
+		cmd.Stdin = &fetchTrackerReader{t: t, r: cmd.Stdin}
+	}
+	if cmd.Stdout != nil {
+		cmd.Stdout = &fetchTrackerWriter{t: t, w: cmd.Stdout}
+	}
+	return t
+}
+
+// Fetched returns true if a pack was sent to the client.
+func (t *FetchTracker) Fetched() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.pack
+}
+
+// Clone returns true if the client didn't have any objects, i.e. it didn't
+// send any "have" lines.
+func (t *FetchTracker) Clone() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.haves
+}
+
+// scanInput parses the pkt-lines sent by the client looking for "have" lines.
+func (t *FetchTracker) scanInput(p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.in.Feed(p, func(_ int, payload []byte) bool {
+		if bytes.HasPrefix(payload, []byte("have ")) {
+			t.haves = true
+			return false
+		}
+		return true
+	})
+}
+
+// scanOutput looks for a pack header in the server output.
+func (t *FetchTracker) scanOutput(p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pack {
+		return
+	}
+
+	if bytes.Contains(p, packSignature) {
+		t.pack = true
+		t.out = nil
+		return
+	}
+
+	// The header might be split between writes, keep the tail of the output
+	// and look for it in the tail followed by the start of the next write.
+	keep := len(packSignature) - 1
+	head := p
+	if len(head) > keep {
+		head = head[:keep]
+	}
+	if bytes.Contains(append(t.out, head...), packSignature) {
+		t.pack = true
+		t.out = nil
+		return
+	}
+
+	if len(p) >= keep {
+		t.out = append(t.out[:0], p[len(p)-keep:]...)
+	} else {
+		t.out = append(t.out, p...)
+		if len(t.out) > keep {
+			t.out = t.out[len(t.out)-keep:]
+		}
+	}
+}
+
+type fetchTrackerReader struct {
+	t *FetchTracker
+	r io.Reader
+}
+
+func (r *fetchTrackerReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.t.scanInput(p[:n])
+	}
+	return n, err
+}
+
+type fetchTrackerWriter struct {
+	t *FetchTracker
+	w io.Writer
+}
+
+func (w *fetchTrackerWriter) Write(p []byte) (int, error) {
+	w.t.scanOutput(p)
+	return w.w.Write(p)
+}
+
+// ReadFrom keeps the io.ReaderFrom implementation of the underlying writer,
+// if any.
+func (w *fetchTrackerWriter) ReadFrom(r io.Reader) (int64, error) {
+	if rf, ok := w.w.(io.ReaderFrom); ok {
+		return rf.ReadFrom(&fetchTrackerOutput{t: w.t, r: r})
+	}
+	return io.Copy(struct{ io.Writer }{w}, r)
+}
+
+// fetchTrackerOutput scans the server output read by an io.ReaderFrom.
+type fetchTrackerOutput struct {
+	t *FetchTracker
+	r io.Reader
+}
+
+func (r *fetchTrackerOutput) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.t.scanOutput(p[:n])
+	}
+	return n, err
+}
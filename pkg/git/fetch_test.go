@@ -0,0 +1,61 @@
+package git
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func pkt(s string) string {
+	const hex = "0123456789abcdef"
+	n := len(s) + 4
+	return string([]byte{hex[n>>12&0xf], hex[n>>8&0xf], hex[n>>4&0xf], hex[n&0xf]}) + s
+}
+
+func TestFetchTracker(t *testing.T) {
+	const want = "want 0123456789012345678901234567890123456789\n"
+	const have = "have 0123456789012345678901234567890123456789\n"
+	pack := []byte("\x01PACK\x00\x00\x00\x02\x00\x00\x00\x03")
+	cases := []struct {
+		name    string
+		in      string
+		out     []byte
+		fetched bool
+		clone   bool
+	}{
+		{"clone", pkt(want) + "0000" + pkt("done\n"), pack, true, true},
+		{"fetch", pkt(want) + "0000" + pkt(have) + pkt("done\n"), pack, true, false},
+		{"v2 fetch", pkt("command=fetch\n") + "0001" + pkt(want) + pkt(have) + "0000", pack, true, false},
+		{"up to date", "0000", []byte(pkt("NAK\n")), false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := ServiceCommand{
+				// Read one byte at a time to split the pkt-lines.
+				Stdin:  iotest.OneByteReader(bytes.NewBufferString(c.in)),
+				Stdout: &out,
+			}
+			tr := TrackFetch(&cmd)
+			if _, err := io.ReadAll(cmd.Stdin); err != nil {
+				t.Fatal(err)
+			}
+
+			// Write one byte at a time to split the pack header.
+			for _, b := range append([]byte(pkt("NAK\n")), c.out...) {
+				if _, err := cmd.Stdout.Write([]byte{b}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tr.Fetched() != c.fetched {
+				t.Errorf("expected fetched %t, got %t", c.fetched, tr.Fetched())
+			}
+			if tr.Clone() != c.clone {
+				t.Errorf("expected clone %t, got %t", c.clone, tr.Clone())
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	gitm "github.com/aymanbagabas/git-module"
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/go-git/go-git/v5/plumbing/format/pktline"
 )
 
@@ -36,6 +37,20 @@ func WritePktlineErr(w io.Writer, err error) error {
 	return WritePktline(w, "ERR", err.Error())
 }
 
+// WriteSidebandErr writes an error to the given writer on the side-band error
+// channel, for clients that asked for a side-band.
+func WriteSidebandErr(w io.Writer, err error) error {
+	pkt := pktline.NewEncoder(w)
+	if err := pkt.Encode(append([]byte{3}, err.Error()+"\n"...)); err != nil {
+		return fmt.Errorf("git: error writing side-band message: %w", err)
+	}
+	if err := pkt.Flush(); err != nil {
+		return fmt.Errorf("git: error flushing side-band message: %w", err)
+	}
+
+	return nil
+}
+
 // EnsureWithin ensures the given repo is within the repos directory.
 func EnsureWithin(reposDir string, repo string) error {
 	repoDir := filepath.Join(reposDir, repo)
@@ -60,7 +75,8 @@ func EnsureWithin(reposDir string, repo string) error {
 }
 
 // EnsureDefaultBranch ensures the repo has a default branch.
-// It will prefer choosing "main" or "master" if available.
+// It will prefer choosing the configured default branch, "main", or "master"
+// if available.
 func EnsureDefaultBranch(ctx context.Context, repoPath string) error {
 	r, err := git.Open(repoPath)
 	if err != nil {
@@ -77,11 +93,17 @@ func EnsureDefaultBranch(ctx context.Context, repoPath string) error {
 	_, err = r.HEAD()
 	if err == git.ErrReferenceNotExist {
 		branch := brs[0]
-		// Prefer "main" or "master" as the default branch
-		for _, b := range brs {
-			if b == "main" || b == "master" {
-				branch = b
-				break
+		preferred := []string{"main", "master"}
+		if cfg := config.FromContext(ctx); cfg != nil && cfg.Git.DefaultBranch != "" {
+			preferred = append([]string{cfg.Git.DefaultBranch}, preferred...)
+		}
+	prefer:
+		for _, p := range preferred {
+			for _, b := range brs {
+				if b == p {
+					branch = b
+					break prefer
+				}
 			}
 		}
 
@@ -36,7 +36,7 @@ type lfsTransfer struct {
 var _ transfer.Backend = &lfsTransfer{}
 
 // LFSTransfer is a Git LFS transfer service handler.
-// ctx is expected to have proto.User, *backend.Backend, *log.Logger,
+// ctx is expected to have proto.User, backend.Service, *log.Logger,
 // *config.Config, *db.DB, and store.Store.
 // The first arg in cmd.Args should be the repo path.
 // The second arg in cmd.Args should be the LFS operation (download or upload).
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+// ResourceLimits are the resource limits of a git service process. They're
+// set before git starts, and the processes it spawns, e.g. git pack-objects
+// or the server hooks, inherit them. Each process is limited on its own, the
+// limits don't bound the resources of the whole operation. Limits are only
+// enforced on Linux, using rlimits. A zero value means no limit.
+type ResourceLimits struct {
+	// CPUTime is the maximum CPU time of each process.
+	CPUTime time.Duration
+	// Memory is the maximum data segment size in bytes of each process.
+	Memory int64
+}
+
+// ServiceLimits returns the resource limits of git service processes.
+func ServiceLimits(cfg *config.Config) ResourceLimits {
+	return ResourceLimits{
+		CPUTime: time.Duration(cfg.Git.MaxCPUTime) * time.Second,
+		Memory:  cfg.Git.MaxMemory,
+	}
+}
+
+// IsResourceLimit returns true if err is caused by a git process going over
+// its resource limits.
+func IsResourceLimit(err error) bool {
+	return errors.Is(err, ErrCPUTimeLimit) || errors.Is(err, ErrMemoryLimit)
+}
+
+// outOfMemoryMessages are the messages git prints when it fails to allocate
+// memory.
+var outOfMemoryMessages = [][]byte{
+	[]byte("Out of memory"),
+	[]byte("Cannot allocate memory"),
+}
+
+// oomDetector is an io.Writer looking for out of memory errors in the stderr
+// output of git.
+type oomDetector struct {
+	mu   sync.Mutex
+	tail []byte
+	oom  bool
+}
+
+// Write implements io.Writer.
+func (d *oomDetector) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.oom {
+		return len(p), nil
+	}
+
+	// Keep the end of the previous write to find messages split between
+	// writes.
+	buf := append(append([]byte{}, d.tail...), p...)
+	for _, m := range outOfMemoryMessages {
+		if bytes.Contains(buf, m) {
+			d.oom = true
+			return len(p), nil
+		}
+	}
+
+	const keep = 32
+	if len(buf) > keep {
+		buf = buf[len(buf)-keep:]
+	}
+	d.tail = buf
+	return len(p), nil
+}
+
+// OutOfMemory returns true if git reported failing to allocate memory.
+func (d *oomDetector) OutOfMemory() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.oom
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// limitsArg0 is the name re-executed processes get to exec a command with
+// resource limits, see wrap. The limits are in the limitsEnv environment
+// variable.
+const (
+	limitsArg0 = "soft-serve-limits"
+	limitsEnv  = "SOFT_SERVE_GIT_RESOURCE_LIMITS"
+)
+
+func init() {
+	if len(os.Args) < 3 || os.Args[0] != limitsArg0 {
+		return
+	}
+	v, ok := os.LookupEnv(limitsEnv)
+	if !ok {
+		return
+	}
+
+	if err := execLimited(v, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: failed to set resource limits: %v\n", err)
+		os.Exit(128)
+	}
+}
+
+// wrap makes cmd set the resource limits before it runs. The current
+// executable is run in its place, it sets the limits of its process and execs
+// the command. The command can't run, or spawn processes, before the limits
+// are set.
+func (l ResourceLimits) wrap(cmd *exec.Cmd) {
+	if l.CPUTime <= 0 && l.Memory <= 0 {
+		return
+	}
+
+	secs := int64((l.CPUTime + time.Second - 1) / time.Second)
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env, fmt.Sprintf("%s=%d,%d", limitsEnv, secs, l.Memory))
+	cmd.Args = append([]string{limitsArg0, cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+}
+
+// execLimited sets the resource limits v, as set by wrap, of the current
+// process and replaces it with path.
+func execLimited(v string, path string, args []string) error {
+	var secs, mem uint64
+	if _, err := fmt.Sscanf(v, "%d,%d", &secs, &mem); err != nil {
+		return fmt.Errorf("invalid resource limits %q", v)
+	}
+
+	if secs > 0 {
+		// The process receives SIGXCPU at the soft limit, and SIGKILL at the
+		// hard limit if it keeps running.
+		if err := unix.Setrlimit(unix.RLIMIT_CPU, &unix.Rlimit{Cur: secs, Max: secs + 1}); err != nil {
+			return err
+		}
+	}
+
+	if mem > 0 {
+		if err := unix.Setrlimit(unix.RLIMIT_DATA, &unix.Rlimit{Cur: mem, Max: mem}); err != nil {
+			return err
+		}
+	}
+
+	env := make([]string, 0, len(os.Environ()))
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, limitsEnv+"=") {
+			env = append(env, e)
+		}
+	}
+
+	return unix.Exec(path, args, env)
+}
+
+// exceeded returns the limit a failed process went over, or nil. The CPU
+// time includes the processes git waited for.
+func (l ResourceLimits) exceeded(state *os.ProcessState, oom *oomDetector) error {
+	if state == nil || state.Success() {
+		return nil
+	}
+
+	if l.CPUTime > 0 {
+		if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() && ws.Signal() == syscall.SIGXCPU {
+			return ErrCPUTimeLimit
+		}
+		if state.UserTime()+state.SystemTime() >= l.CPUTime {
+			return ErrCPUTimeLimit
+		}
+	}
+
+	if l.Memory > 0 && oom != nil && oom.OutOfMemory() {
+		return ErrMemoryLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// shellServiceCommand returns a service command running script instead of
+// git.
+func shellServiceCommand(t *testing.T, script string, limits ResourceLimits) ServiceCommand {
+	t.Helper()
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not found")
+	}
+
+	return ServiceCommand{
+		Dir:    t.TempDir(),
+		Limits: limits,
+		CmdFunc: func(cmd *exec.Cmd) {
+			cmd.Path = sh
+			cmd.Args = []string{"sh", "-c", script}
+		},
+	}
+}
+
+func TestResourceLimits(t *testing.T) {
+	t.Run("cpu time", func(t *testing.T) {
+		scmd := shellServiceCommand(t, "while :; do :; done", ResourceLimits{CPUTime: time.Second})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := UploadPack(ctx, scmd); !errors.Is(err, ErrCPUTimeLimit) {
+			t.Fatalf("expected cpu time limit error, got %v", err)
+		}
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		scmd := shellServiceCommand(t, "echo 'fatal: Out of memory, malloc failed' >&2; exit 128", ResourceLimits{Memory: 1 << 30})
+		if err := UploadPack(context.Background(), scmd); !errors.Is(err, ErrMemoryLimit) {
+			t.Fatalf("expected memory limit error, got %v", err)
+		}
+	})
+
+	t.Run("set before the command runs", func(t *testing.T) {
+		var out bytes.Buffer
+		scmd := shellServiceCommand(t, "ulimit -t; ulimit -d; sh -c 'ulimit -t'", ResourceLimits{CPUTime: 30 * time.Second, Memory: 1 << 30})
+		scmd.Stdout = &out
+		if err := UploadPack(context.Background(), scmd); err != nil {
+			t.Fatal(err)
+		}
+		// ulimit -d is in KiB.
+		if got, want := out.String(), "30\n1048576\n30\n"; got != want {
+			t.Errorf("expected limits %q, got %q", want, got)
+		}
+	})
+
+	t.Run("other failures", func(t *testing.T) {
+		scmd := shellServiceCommand(t, "exit 1", ResourceLimits{CPUTime: time.Minute, Memory: 1 << 30})
+		if err := UploadPack(context.Background(), scmd); err == nil || IsResourceLimit(err) {
+			t.Fatalf("expected a plain error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package git
+
+import (
+	"os"
+	"os/exec"
+)
+
+// wrap is a no-op, resource limits are only enforced on Linux.
+func (l ResourceLimits) wrap(*exec.Cmd) {}
+
+// exceeded always returns nil, resource limits are only enforced on Linux.
+func (l ResourceLimits) exceeded(*os.ProcessState, *oomDetector) error {
+	return nil
+}
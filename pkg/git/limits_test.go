@@ -0,0 +1,31 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestOOMDetector(t *testing.T) {
+	cases := []struct {
+		name   string
+		writes []string
+		oom    bool
+	}{
+		{"no error", []string{"remote: Counting objects: 3, done.\n"}, false},
+		{"malloc", []string{"fatal: Out of memory, malloc failed (tried to allocate 1024 bytes)\n"}, true},
+		{"mmap split between writes", []string{"fatal: mmap failed: Cannot all", "ocate memory\n"}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var d oomDetector
+			for _, w := range c.writes {
+				if _, err := d.Write([]byte(w)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if d.OutOfMemory() != c.oom {
+				t.Errorf("expected out of memory %t", c.oom)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// NegotiationLimiter aborts upload-pack sessions once the client exceeds a
+// number of negotiation rounds. A round is a batch of "have" lines ended by
+// a flush packet. Clones don't send any "have" lines and never reach the
+// limit.
+//
+// Over HTTP, every negotiation round is a separate request, so the limit
+// applies per request.
+type NegotiationLimiter struct {
+	max int
+
+	mu       sync.Mutex
+	scanner  pktlineScanner
+	rounds   int
+	haves    bool
+	exceeded bool
+}
+
+// LimitNegotiation wraps the stdin of cmd with a new NegotiationLimiter
+// allowing up to rounds negotiation rounds.
+func LimitNegotiation(cmd *ServiceCommand, rounds int) *NegotiationLimiter {
+	l := &NegotiationLimiter{max: rounds}
+	if cmd.Stdin != nil {
+		cmd.Stdin = &negotiationLimitReader{l: l, r: cmd.Stdin}
+	}
+	return l
+}
+
+// Exceeded returns true if the session was aborted because the client
+// exceeded the negotiation rounds limit.
+func (l *NegotiationLimiter) Exceeded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.exceeded
+}
+
+// scan counts the negotiation rounds in p and returns false once the limit
+// is exceeded.
+func (l *NegotiationLimiter) scan(p []byte) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.exceeded {
+		return false
+	}
+
+	l.scanner.Feed(p, func(n int, payload []byte) bool {
+		switch {
+		case n == 0 && l.haves:
+			l.haves = false
+			l.rounds++
+			if l.rounds > l.max {
+				l.exceeded = true
+				return false
+			}
+		case bytes.HasPrefix(payload, []byte("have ")):
+			l.haves = true
+		}
+		return true
+	})
+
+	return !l.exceeded
+}
+
+type negotiationLimitReader struct {
+	l *NegotiationLimiter
+	r io.Reader
+}
+
+func (r *negotiationLimitReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && !r.l.scan(p[:n]) {
+		// Don't pass the offending data down, close git's stdin instead.
+		return 0, ErrTooManyNegotiationRounds
+	}
+	return n, err
+}
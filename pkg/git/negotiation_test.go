@@ -0,0 +1,38 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNegotiationLimiter(t *testing.T) {
+	const want = "want 0123456789012345678901234567890123456789\n"
+	const have = "have 0123456789012345678901234567890123456789\n"
+	round := pkt(have) + pkt(have) + "0000"
+	cases := []struct {
+		name     string
+		in       string
+		exceeded bool
+	}{
+		{"clone", pkt(want) + "0000" + pkt("done\n"), false},
+		{"within limit", pkt(want) + "0000" + strings.Repeat(round, 3) + pkt("done\n"), false},
+		{"over limit", pkt(want) + "0000" + strings.Repeat(round, 4) + pkt("done\n"), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmd := ServiceCommand{Stdin: bytes.NewBufferString(c.in)}
+			l := LimitNegotiation(&cmd, 3)
+			_, err := io.ReadAll(cmd.Stdin)
+			if c.exceeded != errors.Is(err, ErrTooManyNegotiationRounds) {
+				t.Errorf("expected error %t, got %v", c.exceeded, err)
+			}
+			if l.Exceeded() != c.exceeded {
+				t.Errorf("expected exceeded %t, got %t", c.exceeded, l.Exceeded())
+			}
+		})
+	}
+}
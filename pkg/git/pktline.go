@@ -0,0 +1,52 @@
+package git
+
+import "strconv"
+
+// maxPktlineLen is the maximum length of a pkt-line, including the length
+// prefix.
+const maxPktlineLen = 65520
+
+// pktlineScanner incrementally splits a pkt-line stream into packets as it's
+// read, without consuming it.
+type pktlineScanner struct {
+	buf  []byte
+	done bool
+}
+
+// Feed adds p to the stream and calls fn for every complete packet with its
+// length and payload. Flush, delimiter, and response end packets have a
+// length below 4 and no payload. Scanning stops once fn returns false or the
+// stream isn't a valid pkt-line stream.
+func (s *pktlineScanner) Feed(p []byte, fn func(n int, payload []byte) bool) {
+	if s.done {
+		return
+	}
+
+	s.buf = append(s.buf, p...)
+	for len(s.buf) >= 4 {
+		n, err := strconv.ParseUint(string(s.buf[:4]), 16, 16)
+		if err != nil || n == 3 || n > maxPktlineLen {
+			s.stop()
+			return
+		}
+
+		size := int(n)
+		if size < 4 {
+			size = 4
+		}
+		if len(s.buf) < size {
+			return
+		}
+
+		if !fn(int(n), s.buf[4:size]) {
+			s.stop()
+			return
+		}
+		s.buf = s.buf[size:]
+	}
+}
+
+func (s *pktlineScanner) stop() {
+	s.done = true
+	s.buf = nil
+}
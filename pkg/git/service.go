@@ -83,6 +83,7 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 	if scmd.CmdFunc != nil {
 		scmd.CmdFunc(cmd)
 	}
+	scmd.Limits.wrap(cmd)
 
 	var (
 		err    error
@@ -91,6 +92,18 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 		stderr io.ReadCloser
 	)
 
+	// git reports failed allocations on stderr.
+	errOut := scmd.Stderr
+	var oom *oomDetector
+	if scmd.Limits.Memory > 0 {
+		oom = &oomDetector{}
+		if errOut != nil {
+			errOut = io.MultiWriter(errOut, oom)
+		} else {
+			errOut = oom
+		}
+	}
+
 	if scmd.Stdin != nil {
 		stdin, err = cmd.StdinPipe()
 		if err != nil {
@@ -105,7 +118,7 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 		}
 	}
 
-	if scmd.Stderr != nil {
+	if errOut != nil {
 		stderr, err = cmd.StderrPipe()
 		if err != nil {
 			return err
@@ -125,7 +138,7 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 	if scmd.Stdin != nil {
 		go func() {
 			defer stdin.Close() // nolint: errcheck
-			if _, err := io.Copy(stdin, scmd.Stdin); err != nil {
+			if _, err := copyBuffer(stdin, scmd.Stdin, scmd.BufferSize); err != nil {
 				log.Errorf("gitServiceHandler: failed to copy stdin: %v", err)
 			}
 		}()
@@ -136,18 +149,21 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if _, err := io.Copy(scmd.Stdout, stdout); err != nil {
+			if _, err := copyBuffer(scmd.Stdout, stdout, scmd.BufferSize); err != nil {
 				log.Errorf("gitServiceHandler: failed to copy stdout: %v", err)
+				// Don't leave git blocked writing to a pipe nobody reads,
+				// it exits on the broken pipe instead.
+				stdout.Close() // nolint: errcheck
 			}
 		}()
 	}
 
 	// stderr
-	if scmd.Stderr != nil {
+	if errOut != nil {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if _, erro := io.Copy(scmd.Stderr, stderr); err != nil {
+			if _, erro := io.Copy(errOut, stderr); err != nil {
 				log.Errorf("gitServiceHandler: failed to copy stderr: %v", erro)
 			}
 		}()
@@ -159,7 +175,9 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 	wg.Wait()
 
 	err = cmd.Wait()
-	if err != nil && errors.Is(err, os.ErrNotExist) {
+	if lerr := scmd.Limits.exceeded(cmd.ProcessState, oom); lerr != nil {
+		return lerr
+	} else if err != nil && errors.Is(err, os.ErrNotExist) {
 		return ErrInvalidRepo
 	} else if err != nil {
 		var exitErr *exec.ExitError
@@ -173,6 +191,18 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 	return nil
 }
 
+// copyBuffer copies from src to dst using a buffer of the given size. If size
+// is 0, it uses io.Copy.
+func copyBuffer(dst io.Writer, src io.Reader, size int) (int64, error) {
+	if size <= 0 {
+		return io.Copy(dst, src)
+	}
+
+	// Hide io.ReaderFrom and io.WriterTo so that io.CopyBuffer actually uses
+	// our buffer. Pipes implement io.ReaderFrom with a fixed-size buffer.
+	return io.CopyBuffer(struct{ io.Writer }{dst}, struct{ io.Reader }{src}, make([]byte, size))
+}
+
 // ServiceCommand is used to run a git service command.
 type ServiceCommand struct {
 	Stdin  io.Reader
@@ -182,6 +212,13 @@ type ServiceCommand struct {
 	Env    []string
 	Args   []string
 
+	// BufferSize is the size of the buffer used to copy stdin and stdout.
+	// A value of 0 uses the io.Copy default.
+	BufferSize int
+
+	// Limits are the resource limits of the git process.
+	Limits ResourceLimits
+
 	// Modifier functions
 	CmdFunc func(*exec.Cmd)
 }
@@ -192,6 +229,8 @@ func UploadPack(ctx context.Context, cmd ServiceCommand) error {
 }
 
 // UploadArchive runs the git upload-archive protocol against the provided repo.
+// Repositories are bare, git archive reads the export-ignore and
+// export-subst attributes from the .gitattributes files of the archived tree.
 func UploadArchive(ctx context.Context, cmd ServiceCommand) error {
 	return gitServiceHandler(ctx, UploadArchiveService, cmd)
 }
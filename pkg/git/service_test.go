@@ -0,0 +1,50 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestCopyBuffer(t *testing.T) {
+	data := bytes.Repeat([]byte("soft-serve"), 100000)
+	for _, size := range []int{0, 1, 4096, 64 * 1024} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			var out bytes.Buffer
+			n, err := copyBuffer(&out, bytes.NewReader(data), size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(len(data)) || !bytes.Equal(out.Bytes(), data) {
+				t.Fatalf("copied %d bytes, expected %d", n, len(data))
+			}
+		})
+	}
+}
+
+// BenchmarkCopyBuffer copies data through an OS pipe, like the git process
+// stdio, using different buffer sizes.
+func BenchmarkCopyBuffer(b *testing.B) {
+	data := bytes.Repeat([]byte{'x'}, 16*1024*1024)
+	for _, size := range []int{0, 4 * 1024, 32 * 1024, 64 * 1024, 256 * 1024, 1024 * 1024} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				r, w, err := os.Pipe()
+				if err != nil {
+					b.Fatal(err)
+				}
+				go func() {
+					w.Write(data) // nolint: errcheck
+					w.Close()     // nolint: errcheck
+				}()
+				if _, err := copyBuffer(io.Discard, r, size); err != nil {
+					b.Fatal(err)
+				}
+				r.Close() // nolint: errcheck
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// ShallowPushGuard aborts receive-pack sessions pushed from shallow clones.
+// Clients push their shallow boundaries as "shallow" lines before the
+// commands, the first command carries the client capabilities.
+type ShallowPushGuard struct {
+	mu       sync.Mutex
+	scanner  pktlineScanner
+	shallow  bool
+	rejected bool
+	sideband bool
+}
+
+// RejectShallowPush wraps the stdin of cmd with a new ShallowPushGuard.
+func RejectShallowPush(cmd *ServiceCommand) *ShallowPushGuard {
+	g := &ShallowPushGuard{}
+	if cmd.Stdin != nil {
+		cmd.Stdin = &shallowPushReader{g: g, r: cmd.Stdin}
+	}
+	return g
+}
+
+// Rejected returns true if the session was aborted because it was pushed
+// from a shallow clone.
+func (g *ShallowPushGuard) Rejected() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rejected
+}
+
+// SideBand returns true if the client of a rejected session asked for a
+// side-band, errors sent in the protocol stream must then use band 3.
+func (g *ShallowPushGuard) SideBand() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.sideband
+}
+
+// scan looks for "shallow" lines in p and returns false once the first
+// command following them is found. The rest of the commands and the pack
+// data aren't scanned.
+func (g *ShallowPushGuard) scan(p []byte) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.rejected {
+		return false
+	}
+
+	g.scanner.Feed(p, func(n int, payload []byte) bool {
+		switch {
+		case n == 0:
+			return false
+		case bytes.HasPrefix(payload, []byte("shallow ")):
+			g.shallow = true
+			return true
+		case g.shallow:
+			_, caps, _ := bytes.Cut(payload, []byte{0})
+			g.rejected = true
+			g.sideband = bytes.Contains(caps, []byte("side-band"))
+		}
+		return false
+	})
+
+	return !g.rejected
+}
+
+type shallowPushReader struct {
+	g *ShallowPushGuard
+	r io.Reader
+}
+
+func (r *shallowPushReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && !r.g.scan(p[:n]) {
+		// Don't pass the push down, close git's stdin instead.
+		return 0, ErrShallowPush
+	}
+	return n, err
+}
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestShallowPushGuard(t *testing.T) {
+	const command = "0000000000000000000000000000000000000000 0123456789012345678901234567890123456789 refs/heads/main\x00report-status\n"
+	const shallow = "shallow 0123456789012345678901234567890123456789\n"
+	cases := []struct {
+		name     string
+		in       string
+		rejected bool
+		sideband bool
+	}{
+		{"full clone", pkt(command) + "0000" + "PACK", false, false},
+		{"shallow clone", pkt(shallow) + pkt(command) + "0000" + "PACK", true, false},
+		{"shallow clone with side-band", pkt(shallow) + pkt(command[:len(command)-1]+" side-band-64k\n") + "0000" + "PACK", true, true},
+		{"pack data", pkt(command) + "0000" + "PACK" + pkt(shallow), false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmd := ServiceCommand{Stdin: bytes.NewBufferString(c.in)}
+			g := RejectShallowPush(&cmd)
+			_, err := io.ReadAll(cmd.Stdin)
+			if c.rejected != errors.Is(err, ErrShallowPush) {
+				t.Errorf("expected error %t, got %v", c.rejected, err)
+			}
+			if g.Rejected() != c.rejected {
+				t.Errorf("expected rejected %t, got %t", c.rejected, g.Rejected())
+			}
+			if g.SideBand() != c.sideband {
+				t.Errorf("expected side-band %t, got %t", c.sideband, g.SideBand())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+// ConfigEnv returns environment variables that pass the given git config
+// key-value pairs to a git process.
+//
+// See GIT_CONFIG_COUNT in git(1).
+func ConfigEnv(kv map[string]string) []string {
+	envs := make([]string, 0, len(kv)*2+1)
+	i := 0
+	for k, v := range kv {
+		envs = append(envs,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, k),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, v),
+		)
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+
+	return append(envs, fmt.Sprintf("GIT_CONFIG_COUNT=%d", i))
+}
+
+// SigningEnv returns the environment variables needed by git receive-pack to
+// verify signed pushes and commit signatures.
+func SigningEnv(cfg *config.Config) []string {
+	kv := map[string]string{}
+	if cfg.Git.AllowedSignersFile != "" {
+		kv["gpg.ssh.allowedSignersFile"] = cfg.Git.AllowedSignersFile
+	}
+	if cfg.Git.PushCertNonceSeed != "" {
+		kv["receive.certNonceSeed"] = cfg.Git.PushCertNonceSeed
+		// Allow some slack for stateless (HTTP) pushes, where the nonce is
+		// issued by a different process than the one receiving the push.
+		kv["receive.certNonceSlop"] = "300"
+	}
+
+	return ConfigEnv(kv)
+}
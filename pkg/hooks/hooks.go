@@ -19,3 +19,15 @@ type Hooks interface {
 	PostReceive(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args []HookArg)
 	PostUpdate(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args ...string)
 }
+
+// PreHooks extends Hooks with hooks called before pushes and fetches run.
+// Returning an error vetoes the operation, the error is reported to the
+// client. The context holds the user, if any, see proto.UserFromContext.
+//
+// Over HTTP, they're called for the ref advertisement and again for the
+// pack request of the same operation.
+type PreHooks interface {
+	Hooks
+	PrePush(ctx context.Context, repo string) error
+	PreFetch(ctx context.Context, repo string) error
+}
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/sync"
+	"github.com/charmbracelet/soft-serve/pkg/task"
+)
+
+func init() {
+	Register("gc", gc{})
+}
+
+type gc struct{}
+
+// Spec derives the spec used for garbage collection and implements Runner.
+// The job is disabled by default.
+func (g gc) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	return cfg.Jobs.GC
+}
+
+// Func runs the garbage collection job task and implements Runner.
+//
+// Repositories are only collected when their loose object or pack count
+// exceeds the configured thresholds. Repositories already being collected,
+// e.g. after Git.GCAfterPushes pushes, are skipped.
+func (g gc) Func(ctx context.Context) func() {
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx).WithPrefix("jobs.gc")
+	b := backend.FromContext(ctx)
+	return func() {
+		repos, err := b.Repositories(ctx)
+		if err != nil {
+			logger.Error("error getting repositories", "err", err)
+			return
+		}
+
+		// Divide the work up among the number of CPUs.
+		wq := sync.NewWorkPool(ctx, runtime.GOMAXPROCS(0),
+			sync.WithWorkPoolLogger(logger.Errorf),
+		)
+
+		logger.Debug("checking repos for garbage collection")
+		for _, repo := range repos {
+			stats, err := b.RepositoryStats(ctx, repo)
+			if err != nil {
+				logger.Error("error getting repository stats", "repo", repo.Name(), "err", err)
+				continue
+			}
+
+			if !needsGC(cfg, stats) {
+				continue
+			}
+
+			name := repo.Name()
+			wq.Add(name, func() {
+				logger.Debug("running garbage collection", "repo", name, "loose", stats.Count, "packs", stats.Packs)
+				if err := b.CollectGarbage(ctx, repo); errors.Is(err, task.ErrAlreadyStarted) {
+					logger.Debug("garbage collection already running", "repo", name)
+				} else if err != nil {
+					logger.Error("error running git gc", "repo", name, "err", err)
+				}
+			})
+		}
+
+		wq.Run()
+	}
+}
+
+// needsGC returns whether a repository with the given stats exceeds the
+// garbage collection thresholds. A zero threshold disables that check.
+func needsGC(cfg *config.Config, stats *git.ObjectStats) bool {
+	if cfg.Jobs.GCLooseObjects > 0 && stats.Count > cfg.Jobs.GCLooseObjects {
+		return true
+	}
+	if cfg.Jobs.GCPacks > 0 && stats.Packs > cfg.Jobs.GCPacks {
+		return true
+	}
+	return false
+}
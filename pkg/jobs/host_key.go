@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+)
+
+func init() {
+	Register("host-key", hostKey{})
+}
+
+type hostKey struct{}
+
+// Spec returns the spec used to retire the previous host keys and implements
+// Runner.
+func (h hostKey) Spec(context.Context) string {
+	return "@every 1h"
+}
+
+// Func removes the previous host keys past their grace period and implements
+// Runner.
+func (h hostKey) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.host-key")
+	b := backend.FromContext(ctx)
+	return func() {
+		if _, err := b.RetireHostKeys(ctx, false); err != nil {
+			logger.Error("error retiring host keys", "err", err)
+		}
+	}
+}
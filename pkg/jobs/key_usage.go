@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+)
+
+func init() {
+	Register("key-usage", keyUsage{})
+}
+
+type keyUsage struct{}
+
+// Spec returns the spec used to write the buffered key usage and implements
+// Runner.
+func (k keyUsage) Spec(context.Context) string {
+	return "@every 1m"
+}
+
+// Func writes the buffered last-used times of public keys and implements
+// Runner.
+func (k keyUsage) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.key-usage")
+	b := backend.FromContext(ctx)
+	return func() {
+		if err := b.FlushPublicKeyUsage(ctx); err != nil {
+			logger.Error("error writing public key usage", "err", err)
+		}
+	}
+}
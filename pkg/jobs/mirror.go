@@ -84,6 +84,10 @@ func (m mirrorPull) Func(ctx context.Context) func() {
 						}
 					}
 
+					if err := b.InvalidateRepositoryStats(ctx, repo); err != nil {
+						logger.Error("error invalidating repository stats", "repo", name, "err", err)
+					}
+
 					if cfg.LFS.Enabled {
 						rcfg, err := r.Config()
 						if err != nil {
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("prune-branches", pruneBranches{})
+}
+
+type pruneBranches struct{}
+
+// Spec derives the spec used for pruning merged branches and implements
+// Runner.
+func (p pruneBranches) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.PruneBranches != "" {
+		return cfg.Jobs.PruneBranches
+	}
+	return "@every 24h"
+}
+
+// Func runs the prune branches job task and implements Runner.
+//
+// Only repositories that enabled auto-pruning are pruned.
+func (p pruneBranches) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.prune-branches")
+	b := backend.FromContext(ctx)
+	return func() {
+		repos, err := b.Repositories(ctx)
+		if err != nil {
+			logger.Error("error getting repositories", "err", err)
+			return
+		}
+
+		for _, repo := range repos {
+			prune, err := b.AutoPruneBranches(ctx, repo.Name())
+			if err != nil {
+				logger.Error("error getting auto-prune setting", "repo", repo.Name(), "err", err)
+				continue
+			}
+			if !prune || repo.IsMirror() {
+				continue
+			}
+
+			pruned, err := b.PruneBranches(ctx, repo, nil)
+			if err != nil {
+				logger.Error("error pruning merged branches", "repo", repo.Name(), "err", err)
+				continue
+			}
+			if len(pruned) > 0 {
+				logger.Debug("pruned merged branches", "repo", repo.Name(), "count", len(pruned))
+			}
+		}
+	}
+}
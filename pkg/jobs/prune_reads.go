@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("prune-reads", pruneReads{})
+}
+
+type pruneReads struct{}
+
+// Spec derives the spec used for pruning audited reads and implements
+// Runner.
+func (p pruneReads) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.PruneReads != "" {
+		return cfg.Jobs.PruneReads
+	}
+	return "@every 24h"
+}
+
+// Func runs the prune reads job task and implements Runner.
+func (p pruneReads) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.prune-reads")
+	b := backend.FromContext(ctx)
+	return func() {
+		n, err := b.PruneRepositoryReads(ctx)
+		if err != nil {
+			logger.Error("error pruning repository reads", "err", err)
+			return
+		}
+
+		if n > 0 {
+			logger.Info("pruned repository reads", "count", n)
+		}
+	}
+}
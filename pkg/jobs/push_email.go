@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+)
+
+func init() {
+	Register("push-email", pushEmail{})
+}
+
+type pushEmail struct{}
+
+// Spec returns the spec used to deliver queued push emails and implements
+// Runner.
+func (p pushEmail) Spec(context.Context) string {
+	return "@every 30s"
+}
+
+// Func delivers queued push emails and implements Runner.
+func (p pushEmail) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.push-email")
+	b := backend.FromContext(ctx)
+	return func() {
+		if err := b.SendPushEmails(ctx); err != nil {
+			logger.Error("error sending push emails", "err", err)
+		}
+	}
+}
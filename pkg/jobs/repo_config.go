@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("repo-config", repoConfig{})
+}
+
+type repoConfig struct{}
+
+// Spec derives the spec used for reconciling the git config of repositories
+// and implements Runner.
+func (r repoConfig) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.RepoConfig != "" {
+		return cfg.Jobs.RepoConfig
+	}
+	return "@every 1h"
+}
+
+// Func runs the repo config job task and implements Runner.
+//
+// Per-repository overrides are kept.
+func (r repoConfig) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.repo-config")
+	b := backend.FromContext(ctx)
+	return func() {
+		if err := b.ReconcileRepoConfigAll(ctx); err != nil {
+			logger.Error("error reconciling repo config", "err", err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"math/rand/v2"
 	"os"
 	"strings"
 	"time"
@@ -50,3 +51,10 @@ func NewLogger(cfg *config.Config) (*log.Logger, *os.File, error) {
 
 	return logger, f, nil
 }
+
+// Sample reports whether a routine event is logged, rate is the fraction of
+// events logged. Events are always logged with a rate of 1 or more, and never
+// with a rate of 0 or less.
+func Sample(rate float64) bool {
+	return rate >= 1 || rand.Float64() < rate
+}
@@ -41,3 +41,24 @@ func TestBadNewLogger(t *testing.T) {
 		}
 	}
 }
+
+func TestSample(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if !Sample(1) {
+			t.Fatal("expected events to be logged with a rate of 1")
+		}
+		if Sample(0) {
+			t.Fatal("expected events not to be logged with a rate of 0")
+		}
+	}
+
+	var n int
+	for i := 0; i < 10000; i++ {
+		if Sample(0.5) {
+			n++
+		}
+	}
+	if n < 4000 || n > 6000 {
+		t.Errorf("expected about half of the events to be logged, got %d of 10000", n)
+	}
+}
@@ -0,0 +1,193 @@
+// Package mail sends plain text emails through an SMTP server.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// timeout is the maximum time spent delivering a single message.
+const timeout = 30 * time.Second
+
+// Message is a plain text email message.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	// Date is the date of the message. The current time is used when it's
+	// zero.
+	Date time.Time
+}
+
+// Bytes returns the message formatted as an RFC 5322 message.
+func (m Message) Bytes() []byte {
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// ParseURL parses the URL of an SMTP server. "smtp" URLs upgrade the
+// connection with STARTTLS when the server supports it, "smtps" URLs use
+// implicit TLS. The user and password of the URL are used to authenticate.
+func ParseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "smtp", "smtps":
+	default:
+		return nil, fmt.Errorf("invalid smtp url scheme: %q", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, errors.New("missing smtp server host")
+	}
+
+	return u, nil
+}
+
+// ParseAddress parses an email address and returns the bare address.
+func ParseAddress(s string) (string, error) {
+	a, err := mail.ParseAddress(s)
+	if err != nil {
+		return "", err
+	}
+	return a.Address, nil
+}
+
+// ParseAddressList parses a comma separated list of email addresses and
+// returns the bare addresses.
+func ParseAddressList(s string) ([]string, error) {
+	list, err := mail.ParseAddressList(s)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(list))
+	for _, a := range list {
+		addrs = append(addrs, a.Address)
+	}
+	return addrs, nil
+}
+
+// Send delivers msg through the SMTP server at serverURL.
+func Send(ctx context.Context, serverURL string, msg Message) error {
+	u, err := ParseURL(serverURL)
+	if err != nil {
+		return err
+	}
+
+	from, err := ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	if len(msg.To) == 0 {
+		return errors.New("no recipients")
+	}
+
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		port = "587"
+		if u.Scheme == "smtps" {
+			port = "465"
+		}
+	}
+
+	addr := net.JoinHostPort(host, port)
+	tlsCfg := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if u.Scheme == "smtps" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsCfg}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close() // nolint: errcheck
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close() // nolint: errcheck
+		return err
+	}
+	defer c.Close() // nolint: errcheck
+
+	if u.Scheme == "smtp" {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsCfg); err != nil {
+				return err
+			}
+		}
+	}
+
+	if u.User != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp server doesn't support authentication")
+		}
+		// PlainAuth refuses to send credentials over unencrypted
+		// connections to remote servers.
+		password, _ := u.User.Password()
+		if err := c.Auth(smtp.PlainAuth("", u.User.Username(), password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
@@ -0,0 +1,120 @@
+package mail
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// session is what a fake SMTP server received.
+type session struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// testServer starts a fake SMTP server accepting a single session. It
+// advertises AUTH PLAIN but not STARTTLS.
+func testServer(t *testing.T) (string, <-chan session) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() }) // nolint: errcheck
+
+	sessions := make(chan session, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close() // nolint: errcheck
+
+		tp := textproto.NewConn(conn)
+		var s session
+		tp.PrintfLine("220 localhost ESMTP") // nolint: errcheck
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO":
+				tp.PrintfLine("250-localhost")  // nolint: errcheck
+				tp.PrintfLine("250 AUTH PLAIN") // nolint: errcheck
+			case "AUTH":
+				cred, _ := base64.StdEncoding.DecodeString(strings.Fields(line)[2])
+				s.auth = string(cred)
+				tp.PrintfLine("235 ok") // nolint: errcheck
+			case "MAIL":
+				s.from = line
+				tp.PrintfLine("250 ok") // nolint: errcheck
+			case "RCPT":
+				s.to = append(s.to, line)
+				tp.PrintfLine("250 ok") // nolint: errcheck
+			case "DATA":
+				tp.PrintfLine("354 go ahead") // nolint: errcheck
+				data, _ := tp.ReadDotBytes()
+				s.data = string(data)
+				tp.PrintfLine("250 queued") // nolint: errcheck
+			case "QUIT":
+				tp.PrintfLine("221 bye") // nolint: errcheck
+				sessions <- s
+				return
+			default:
+				tp.PrintfLine("502 unknown command") // nolint: errcheck
+			}
+		}
+	}()
+
+	return l.Addr().String(), sessions
+}
+
+func TestSend(t *testing.T) {
+	is := is.New(t)
+	addr, sessions := testServer(t)
+
+	err := Send(context.Background(), "smtp://alice:secret@"+addr, Message{
+		From:    "Soft Serve <soft@example.com>",
+		To:      []string{"dev@example.com", "ops@example.com"},
+		Subject: "[repo1] alice pushed to main",
+		Body:    "first line\n.dotted line\n",
+	})
+	is.NoErr(err)
+
+	s := <-sessions
+	is.Equal(s.auth, "\x00alice\x00secret")
+	is.Equal(s.from, "MAIL FROM:<soft@example.com>")
+	is.Equal(s.to, []string{"RCPT TO:<dev@example.com>", "RCPT TO:<ops@example.com>"})
+	is.True(strings.Contains(s.data, "Subject: [repo1] alice pushed to main\n"))
+	is.True(strings.Contains(s.data, "To: dev@example.com, ops@example.com\n"))
+	is.True(strings.HasSuffix(s.data, "\nfirst line\n.dotted line\n"))
+}
+
+func TestSendErrors(t *testing.T) {
+	is := is.New(t)
+	msg := Message{From: "soft@example.com", To: []string{"dev@example.com"}}
+
+	is.True(Send(context.Background(), "http://localhost", msg) != nil)
+
+	msg.From = "not an address"
+	is.True(Send(context.Background(), "smtp://localhost", msg) != nil)
+}
+
+func TestParseAddressList(t *testing.T) {
+	is := is.New(t)
+	addrs, err := ParseAddressList("dev@example.com, Ops <ops@example.com>")
+	is.NoErr(err)
+	is.Equal(addrs, []string{"dev@example.com", "ops@example.com"})
+
+	_, err = ParseAddressList("dev@example.com, nope")
+	is.True(err != nil)
+}
@@ -0,0 +1,175 @@
+// Package notify sends server event notifications to chat services such as
+// Slack and Discord.
+//
+// Notifications are configured once for the whole server, they're independent
+// of repository webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/version"
+)
+
+// Event is a server event that can be notified about.
+type Event string
+
+const (
+	// EventPush is sent when refs are pushed to a repository.
+	EventPush Event = "push"
+	// EventRepositoryCreate is sent when a repository is created.
+	EventRepositoryCreate Event = "repository_create"
+	// EventUserCreate is sent when a user is created.
+	EventUserCreate Event = "user_create"
+	// EventHistoryRewrite is sent when the history of a repository is
+	// rewritten, collaborators have to clone it again.
+	EventHistoryRewrite Event = "history_rewrite"
+	// EventRepositoryReconcile is sent when the metadata of a repository is
+	// created or removed to match the repositories on disk.
+	EventRepositoryReconcile Event = "repository_reconcile"
+)
+
+// ErrInvalidProvider is returned when the notification provider is unknown.
+var ErrInvalidProvider = errors.New("invalid notification provider")
+
+// Notification is a server event notification.
+type Notification struct {
+	// Event is the event that happened.
+	Event Event
+	// Repo is the name of the repository the event happened in, if any.
+	Repo string
+	// Actor is the username of the user that caused the event, if any.
+	Actor string
+	// Summary is a one line summary of the event.
+	Summary string
+	// Details are additional lines, such as the pushed commits.
+	Details []string
+}
+
+// Text returns the notification as plain text.
+func (n Notification) Text() string {
+	var sb strings.Builder
+	if n.Repo != "" {
+		fmt.Fprintf(&sb, "[%s] ", n.Repo)
+	}
+	sb.WriteString(n.Summary)
+	for _, d := range n.Details {
+		sb.WriteString("\n• ")
+		sb.WriteString(d)
+	}
+	return sb.String()
+}
+
+// Notifier sends notifications.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// New returns the notifier configured in cfg. It returns nil if
+// notifications are disabled.
+func New(cfg config.NotifyConfig) (Notifier, error) {
+	var n Notifier
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "slack":
+		n = Slack{URL: cfg.URL}
+	case "discord":
+		n = Discord{URL: cfg.URL}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProvider, cfg.Provider)
+	}
+
+	if len(cfg.Events) == 0 {
+		return n, nil
+	}
+
+	events := make([]Event, len(cfg.Events))
+	for i, e := range cfg.Events {
+		events[i] = Event(e)
+	}
+
+	return Filter(n, events...), nil
+}
+
+// Filter returns a notifier that only sends notifications for the given
+// events to n.
+func Filter(n Notifier, events ...Event) Notifier {
+	return filter{n: n, events: events}
+}
+
+type filter struct {
+	n      Notifier
+	events []Event
+}
+
+// Notify implements Notifier.
+func (f filter) Notify(ctx context.Context, n Notification) error {
+	if !slices.Contains(f.events, n.Event) {
+		return nil
+	}
+	return f.n.Notify(ctx, n)
+}
+
+// Slack sends notifications to a Slack incoming webhook.
+type Slack struct {
+	URL string
+}
+
+// Notify implements Notifier.
+func (s Slack) Notify(ctx context.Context, n Notification) error {
+	return post(ctx, s.URL, struct {
+		Text string `json:"text"`
+	}{n.Text()})
+}
+
+// Discord sends notifications to a Discord webhook.
+type Discord struct {
+	URL string
+}
+
+// Notify implements Notifier.
+func (d Discord) Notify(ctx context.Context, n Notification) error {
+	return post(ctx, d.URL, struct {
+		Content string `json:"content"`
+	}{n.Text()})
+}
+
+// client is the HTTP client used to send notifications. A slow chat service
+// must not hold up the operation that caused the event for long.
+var client = &http.Client{Timeout: 10 * time.Second}
+
+func post(ctx context.Context, url string, payload interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(payload); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SoftServe/"+version.Version)
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("notification failed: %s", res.Status)
+	}
+
+	return nil
+}
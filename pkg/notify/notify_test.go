@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/matryer/is"
+)
+
+func testServer(t *testing.T) (*httptest.Server, <-chan map[string]string) {
+	t.Helper()
+	payloads := make(chan map[string]string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		payloads <- p
+	}))
+	t.Cleanup(srv.Close)
+	return srv, payloads
+}
+
+var testNotification = Notification{
+	Event:   EventPush,
+	Repo:    "repo1",
+	Actor:   "alice",
+	Summary: "alice pushed 2 commits to main",
+	Details: []string{"abc1234 first commit", "def5678 second commit"},
+}
+
+func TestNotificationText(t *testing.T) {
+	is := is.New(t)
+	is.Equal(testNotification.Text(), "[repo1] alice pushed 2 commits to main\n• abc1234 first commit\n• def5678 second commit")
+	is.Equal(Notification{Summary: "bob was created"}.Text(), "bob was created")
+}
+
+func TestSlack(t *testing.T) {
+	is := is.New(t)
+	srv, payloads := testServer(t)
+	n, err := New(config.NotifyConfig{Provider: "slack", URL: srv.URL})
+	is.NoErr(err)
+	is.NoErr(n.Notify(context.Background(), testNotification))
+	is.Equal(<-payloads, map[string]string{"text": testNotification.Text()})
+}
+
+func TestDiscord(t *testing.T) {
+	is := is.New(t)
+	srv, payloads := testServer(t)
+	n, err := New(config.NotifyConfig{Provider: "discord", URL: srv.URL})
+	is.NoErr(err)
+	is.NoErr(n.Notify(context.Background(), testNotification))
+	is.Equal(<-payloads, map[string]string{"content": testNotification.Text()})
+}
+
+func TestFilter(t *testing.T) {
+	is := is.New(t)
+	srv, payloads := testServer(t)
+	n, err := New(config.NotifyConfig{Provider: "slack", URL: srv.URL, Events: []string{"user_create"}})
+	is.NoErr(err)
+	is.NoErr(n.Notify(context.Background(), testNotification))
+	is.NoErr(n.Notify(context.Background(), Notification{Event: EventUserCreate, Summary: "bob was created"}))
+	is.Equal(<-payloads, map[string]string{"text": "bob was created"})
+	is.Equal(len(payloads), 0)
+}
+
+func TestNew(t *testing.T) {
+	is := is.New(t)
+	n, err := New(config.NotifyConfig{})
+	is.NoErr(err)
+	is.True(n == nil)
+	_, err = New(config.NotifyConfig{Provider: "irc"})
+	is.True(err != nil)
+}
+
+func TestNotifyError(t *testing.T) {
+	is := is.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	is.True(Slack{URL: srv.URL}.Notify(context.Background(), testNotification) != nil)
+}
@@ -0,0 +1,26 @@
+package proto
+
+import (
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+)
+
+// Collaborator represents a collaborator of a repository.
+type Collaborator struct {
+	Username    string
+	AccessLevel access.AccessLevel
+	// ExpiresAt is the time the collaborator loses access to the repository.
+	// It's zero if the access doesn't expire.
+	ExpiresAt time.Time
+	// Paths are the path patterns the collaborator can push changes to. The
+	// collaborator can change the whole repository if it's empty.
+	Paths     []string
+	CreatedAt time.Time
+}
+
+// Expired returns true if the access of the collaborator has expired.
+// Expired collaborators are kept until they're removed.
+func (c Collaborator) Expired() bool {
+	return !c.ExpiresAt.IsZero() && !time.Now().Before(c.ExpiresAt)
+}
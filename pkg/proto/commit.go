@@ -0,0 +1,23 @@
+package proto
+
+import "time"
+
+// CommitSummary is a short summary of a commit.
+type CommitSummary struct {
+	// Hash is the commit hash.
+	Hash string `json:"hash"`
+	// Summary is the first line of the commit message.
+	Summary string `json:"summary"`
+	// Author is the name of the commit author.
+	Author string `json:"author"`
+	// When is the time the commit was authored.
+	When time.Time `json:"when"`
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c CommitSummary) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
@@ -0,0 +1,18 @@
+package proto
+
+import (
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"golang.org/x/crypto/ssh"
+)
+
+// DeployKey represents a public key with read-only or read-write access to
+// a single repository. Deploy keys don't belong to a user.
+type DeployKey struct {
+	Repo        string
+	PublicKey   ssh.PublicKey
+	Comment     string
+	AccessLevel access.AccessLevel
+	CreatedAt   time.Time
+}
@@ -13,14 +13,82 @@ var (
 	ErrRepoNotFound = errors.New("repository not found")
 	// ErrRepoExist is returned when a repository already exists.
 	ErrRepoExist = errors.New("repository already exists")
+	// ErrLowDiskSpace is returned when the data directory disk is almost
+	// full.
+	ErrLowDiskSpace = errors.New("not enough free disk space")
+	// ErrBranchExist is returned when a branch already exists.
+	ErrBranchExist = errors.New("branch already exists")
+	// ErrTagExist is returned when a tag already exists.
+	ErrTagExist = errors.New("tag already exists")
+	// ErrTagImmutable is returned when moving or deleting a tag of a
+	// repository with immutable tags.
+	ErrTagImmutable = errors.New("tags are immutable")
+	// ErrRepoArchived is returned when pushing to an archived repository.
+	ErrRepoArchived = errors.New("repository is archived")
+	// ErrRepoRewriting is returned when pushing to a repository whose history
+	// is being rewritten.
+	ErrRepoRewriting = errors.New("repository history is being rewritten, try again later")
+	// ErrRepoCaseCollision is returned when a repository name only differs in
+	// case from an existing repository.
+	ErrRepoCaseCollision = errors.New("repository name conflicts with an existing repository that differs only in case")
+	// ErrRedirectNotFound is returned when a repository redirect is not found.
+	ErrRedirectNotFound = errors.New("redirect not found")
+	// ErrPushEmailNotFound is returned when a repository doesn't have push
+	// emails configured.
+	ErrPushEmailNotFound = errors.New("push emails aren't configured")
 	// ErrUserNotFound is returned when a user is not found.
 	ErrUserNotFound = errors.New("user not found")
 	// ErrTokenNotFound is returned when a token is not found.
 	ErrTokenNotFound = errors.New("token not found")
 	// ErrTokenExpired is returned when a token is expired.
 	ErrTokenExpired = errors.New("token expired")
+	// ErrPublicKeyNotFound is returned when a public key is not found.
+	ErrPublicKeyNotFound = errors.New("public key not found")
+	// ErrPublicKeyAmbiguous is returned when a fingerprint matches more than
+	// one public key.
+	ErrPublicKeyAmbiguous = errors.New("fingerprint matches more than one public key")
+	// ErrKeyPolicy is returned when a user key doesn't meet the key policy of
+	// the server.
+	ErrKeyPolicy = errors.New("public key doesn't meet the key policy")
+	// ErrIssueNotFound is returned when an issue is not found.
+	ErrIssueNotFound = errors.New("issue not found")
 	// ErrCollaboratorNotFound is returned when a collaborator is not found.
 	ErrCollaboratorNotFound = errors.New("collaborator not found")
 	// ErrCollaboratorExist is returned when a collaborator already exists.
 	ErrCollaboratorExist = errors.New("collaborator already exists")
+	// ErrWebhookNotFound is returned when a webhook is not found.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when a webhook delivery is not
+	// found.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrDeployKeyNotFound is returned when a deploy key is not found.
+	ErrDeployKeyNotFound = errors.New("deploy key not found")
+	// ErrDeployKeyExist is returned when a public key is already a deploy
+	// key.
+	ErrDeployKeyExist = errors.New("public key is already a deploy key")
+	// ErrDeployKeyIsUserKey is returned when a public key can't be a deploy
+	// key because it belongs to a user or is an admin key.
+	ErrDeployKeyIsUserKey = errors.New("public key belongs to a user")
+	// ErrAutoCreateLimit is returned when a user created too many
+	// repositories by pushing to them.
+	ErrAutoCreateLimit = errors.New("too many new repositories created by pushing, try again later")
+	// ErrRepoQuota is returned when a user owns too many repositories to
+	// create another one.
+	ErrRepoQuota = errors.New("repository limit reached")
+	// ErrReservedName is returned when pushing to a nonexistent repository
+	// whose name is reserved by Git.ReservedNames.
+	ErrReservedName = errors.New("reserved name")
+	// ErrCheckNotFound is returned when a push check is not found.
+	ErrCheckNotFound = errors.New("check not found")
+	// ErrUserExist is returned when a username is already taken.
+	ErrUserExist = errors.New("user already exists")
+	// ErrUserNotPending is returned when approving or rejecting a user that
+	// isn't waiting for approval.
+	ErrUserNotPending = errors.New("user isn't pending approval")
+	// ErrSelfEnrollDisabled is returned when an unknown public key tries to
+	// enroll and self enrollment is disabled.
+	ErrSelfEnrollDisabled = errors.New("self enrollment is disabled")
+	// ErrPublicKeyExist is returned when enrolling with a public key that
+	// belongs to a user or is a deploy key.
+	ErrPublicKeyExist = errors.New("public key is already registered")
 )
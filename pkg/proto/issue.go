@@ -0,0 +1,34 @@
+package proto
+
+import "time"
+
+// IssueState is the state of an issue.
+type IssueState string
+
+const (
+	// IssueStateOpen is the state of an open issue.
+	IssueStateOpen IssueState = "open"
+	// IssueStateClosed is the state of a closed issue.
+	IssueStateClosed IssueState = "closed"
+)
+
+// String returns the string representation of the issue state.
+func (s IssueState) String() string {
+	return string(s)
+}
+
+// Issue represents a repository issue.
+type Issue struct {
+	ID        int64      `json:"id"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Author    string     `json:"author"`
+	State     IssueState `json:"state"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// IsOpen returns whether the issue is open.
+func (i Issue) IsOpen() bool {
+	return i.State == IssueStateOpen
+}
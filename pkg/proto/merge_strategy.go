@@ -0,0 +1,38 @@
+package proto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeStrategy is the preferred way to land changes on a repository. It's
+// advisory, pushes aren't checked against it.
+type MergeStrategy string
+
+const (
+	// MergeStrategyMerge prefers merge commits.
+	MergeStrategyMerge MergeStrategy = "merge"
+	// MergeStrategySquash prefers squashing changes into a single commit.
+	MergeStrategySquash MergeStrategy = "squash"
+	// MergeStrategyRebase prefers rebasing changes onto the target branch.
+	MergeStrategyRebase MergeStrategy = "rebase"
+)
+
+// MergeStrategies are the valid merge strategies.
+var MergeStrategies = []MergeStrategy{MergeStrategyMerge, MergeStrategySquash, MergeStrategyRebase}
+
+// String returns the string representation of the merge strategy.
+func (s MergeStrategy) String() string {
+	return string(s)
+}
+
+// ParseMergeStrategy parses a merge strategy, case-insensitively.
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	for _, ms := range MergeStrategies {
+		if strings.EqualFold(s, string(ms)) {
+			return ms, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid merge strategy %q, must be one of merge, squash, or rebase", s)
+}
@@ -22,9 +22,15 @@ type Repository interface {
 	IsMirror() bool
 	// IsHidden returns whether the repository is hidden.
 	IsHidden() bool
+	// IsArchived returns whether the repository is archived, i.e. read-only.
+	IsArchived() bool
 	// UserID returns the ID of the user who owns the repository.
 	// It returns 0 if the repository is not owned by a user.
 	UserID() int64
+	// CreatedBy returns the ID of the user who created the repository.
+	// Unlike the owner, it doesn't change when the repository is
+	// transferred. It returns 0 if the creator is unknown.
+	CreatedBy() int64
 	// CreatedAt returns the time the repository was created.
 	CreatedAt() time.Time
 	// UpdatedAt returns the time the repository was last updated.
@@ -43,6 +49,9 @@ type RepositoryOptions struct {
 	Hidden      bool
 	LFS         bool
 	LFSEndpoint string
+	// DefaultBranch is the branch HEAD points to. It defaults to the
+	// configured default branch.
+	DefaultBranch string
 }
 
 // RepositoryDefaultBranch returns the default branch of a repository.
@@ -59,3 +68,41 @@ func RepositoryDefaultBranch(repo Repository) (string, error) {
 
 	return ref.Name().Short(), nil
 }
+
+// RepositoryRedirect redirects requests for a previous name of a repository
+// to the repository.
+type RepositoryRedirect struct {
+	// Name is the previous name of the repository.
+	Name string
+	// Repo is the current name of the repository.
+	Repo string
+	// CreatedAt is when the redirect was created.
+	CreatedAt time.Time
+	// ExpiresAt is when the redirect expires. It's zero if the redirect
+	// never expires.
+	ExpiresAt time.Time
+}
+
+// PushEmail is the push email configuration of a repository.
+type PushEmail struct {
+	// Recipients are the addresses push emails are sent to.
+	Recipients []string
+	// From is the sender address. The server default is used when empty.
+	From string
+	// SMTPURL is the URL of the SMTP server. The server default is used
+	// when empty.
+	SMTPURL string
+}
+
+// RepositoryRead is an audited read of a repository, a clone or a fetch.
+type RepositoryRead struct {
+	// Username is the user that read the repository. It's empty for
+	// anonymous reads.
+	Username string `json:"username"`
+	// Transport is the transport used, "ssh", "http", or "git".
+	Transport string `json:"transport"`
+	// Clone is true if the client didn't have any objects yet.
+	Clone bool `json:"clone"`
+	// CreatedAt is when the repository was read.
+	CreatedAt time.Time `json:"created_at"`
+}
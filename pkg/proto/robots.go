@@ -0,0 +1,32 @@
+package proto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RobotsPolicy is whether search engines can index a repository.
+type RobotsPolicy string
+
+const (
+	// RobotsIndex lets search engines index the repository.
+	RobotsIndex RobotsPolicy = "index"
+	// RobotsNoindex keeps the repository out of search indexes.
+	RobotsNoindex RobotsPolicy = "noindex"
+)
+
+// String returns the string representation of the robots policy.
+func (p RobotsPolicy) String() string {
+	return string(p)
+}
+
+// ParseRobotsPolicy parses a robots policy, case-insensitively.
+func ParseRobotsPolicy(s string) (RobotsPolicy, error) {
+	for _, p := range []RobotsPolicy{RobotsIndex, RobotsNoindex} {
+		if strings.EqualFold(s, string(p)) {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid robots policy %q, must be index or noindex", s)
+}
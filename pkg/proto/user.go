@@ -1,6 +1,13 @@
 package proto
 
-import "golang.org/x/crypto/ssh"
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+
+	"golang.org/x/crypto/ssh"
+)
 
 // User is an interface representing a user.
 type User interface {
@@ -14,6 +21,19 @@ type User interface {
 	PublicKeys() []ssh.PublicKey
 	// Password returns the user's password hash.
 	Password() string
+	// Theme returns the name of the user's UI theme, empty to use the server
+	// default.
+	Theme() string
+	// TimeFormat returns the user's UI timestamp format, empty to use the
+	// server default.
+	TimeFormat() string
+	// AccessLevel returns the user's baseline access level, i.e. their
+	// access to the public repositories they don't collaborate on and to
+	// creating repositories.
+	AccessLevel() access.AccessLevel
+	// IsPending returns whether the user enrolled themselves and is waiting
+	// for an admin to approve them. Pending users have no access.
+	IsPending() bool
 }
 
 // UserOptions are options for creating a user.
@@ -22,4 +42,43 @@ type UserOptions struct {
 	Admin bool
 	// PublicKeys are the user's public keys.
 	PublicKeys []ssh.PublicKey
+	// PublicKeyComments are the comments of the user's public keys, in the
+	// same order as PublicKeys.
+	PublicKeyComments []string
+	// Pending is whether the user needs to be approved by an admin before
+	// they get access.
+	Pending bool
+}
+
+// PublicKey is a user's public key.
+type PublicKey struct {
+	// ID is the public key's ID.
+	ID int64
+	// UserID is the ID of the user who owns the key.
+	UserID int64
+	// Key is the public key.
+	Key ssh.PublicKey
+	// Comment is the key comment, usually the origin email or host.
+	Comment string
+	// LastUsedAt is when the key was last used to authenticate. It's zero if
+	// the key was never used.
+	LastUsedAt time.Time
+	// GitOnly is true if the key can only be used for git commands, it can't
+	// open the TUI or run other commands.
+	GitOnly bool
+}
+
+// Fingerprint returns the SHA256 fingerprint of the key.
+func (k PublicKey) Fingerprint() string {
+	return ssh.FingerprintSHA256(k.Key)
+}
+
+// ShortFingerprint returns an abbreviated fingerprint of the key without the
+// hash prefix.
+func (k PublicKey) ShortFingerprint() string {
+	fp := strings.TrimPrefix(k.Fingerprint(), "SHA256:")
+	if len(fp) > 12 {
+		fp = fp[:12]
+	}
+	return fp
 }
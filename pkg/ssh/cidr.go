@@ -0,0 +1,80 @@
+package ssh
+
+import (
+	"fmt"
+	"net"
+)
+
+// sourceFilter decides whether connections are accepted based on the network
+// they come from.
+type sourceFilter struct {
+	allowed []*net.IPNet
+	denied  []*net.IPNet
+}
+
+// newSourceFilter returns a filter accepting connections from the allowed
+// networks, or from anywhere if there are none, except the denied ones.
+func newSourceFilter(allowed, denied []string) (*sourceFilter, error) {
+	f := &sourceFilter{}
+	for _, list := range []struct {
+		cidrs []string
+		nets  *[]*net.IPNet
+	}{
+		{allowed, &f.allowed},
+		{denied, &f.denied},
+	} {
+		for _, cidr := range list.cidrs {
+			_, n, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cidr %q: %w", cidr, err)
+			}
+			*list.nets = append(*list.nets, n)
+		}
+	}
+
+	return f, nil
+}
+
+// Empty returns true if the filter accepts every connection.
+func (f *sourceFilter) Empty() bool {
+	return len(f.allowed) == 0 && len(f.denied) == 0
+}
+
+// Allowed returns whether connections from ip are accepted.
+func (f *sourceFilter) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return f.Empty()
+	}
+
+	for _, n := range f.denied {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(f.allowed) == 0 {
+		return true
+	}
+
+	for _, n := range f.allowed {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addrIP returns the IP address of a network address, or nil if it has none.
+func addrIP(addr net.Addr) net.IP {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+
+	return net.ParseIP(host)
+}
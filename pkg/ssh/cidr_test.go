@@ -0,0 +1,42 @@
+package ssh
+
+import (
+	"net"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSourceFilter(t *testing.T) {
+	is := is.New(t)
+
+	f, err := newSourceFilter(nil, nil)
+	is.NoErr(err)
+	is.True(f.Empty())
+	is.True(f.Allowed(net.ParseIP("192.0.2.1")))
+	is.True(f.Allowed(nil))
+
+	f, err = newSourceFilter([]string{"10.0.0.0/8", "fd00::/8"}, []string{"10.0.1.0/24"})
+	is.NoErr(err)
+	is.True(!f.Empty())
+	is.True(f.Allowed(net.ParseIP("10.1.2.3")))
+	is.True(f.Allowed(net.ParseIP("::ffff:10.1.2.3")))
+	is.True(f.Allowed(net.ParseIP("fd00::1")))
+	is.True(!f.Allowed(net.ParseIP("10.0.1.5")))
+	is.True(!f.Allowed(net.ParseIP("192.0.2.1")))
+	is.True(!f.Allowed(nil))
+
+	f, err = newSourceFilter(nil, []string{"127.0.0.0/8"})
+	is.NoErr(err)
+	is.True(!f.Allowed(net.ParseIP("127.0.0.1")))
+	is.True(f.Allowed(net.ParseIP("192.0.2.1")))
+
+	_, err = newSourceFilter([]string{"10.0.0.1"}, nil)
+	is.True(err != nil)
+}
+
+func TestAddrIP(t *testing.T) {
+	is := is.New(t)
+	is.Equal(addrIP(&net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 22}).String(), "192.0.2.1")
+	is.Equal(addrIP(&net.UnixAddr{Name: "/tmp/sock", Net: "unix"}), net.IP(nil))
+}
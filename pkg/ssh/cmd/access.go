@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/ssh"
+	"github.com/spf13/cobra"
+)
+
+// AccessCommand returns a command that inspects access levels.
+func AccessCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "access",
+		Short:             "Inspect access levels",
+		PersistentPreRunE: checkIfServerAdmin,
+	}
+
+	cmd.AddCommand(accessTestCommand())
+
+	return cmd
+}
+
+type accessTestResult struct {
+	User        string             `json:"user,omitempty"`
+	Repo        string             `json:"repo"`
+	AccessLevel access.AccessLevel `json:"access_level"`
+	Reason      access.Reason      `json:"reason"`
+	Description string             `json:"description"`
+}
+
+func accessTestCommand() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "test [KEY_OR_USERNAME] REPOSITORY",
+		Short: "Show the access level of a user or public key for a repository",
+		Long: "Show the access level of a user or public key for a repository, " +
+			"and the rule that granted it. Omit the user, or use an empty string, for anonymous access.",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg := config.FromContext(ctx)
+			be := backend.FromContext(ctx)
+			if len(args) == 1 {
+				args = []string{"", args[0]}
+			}
+			rn := cfg.SanitizeRepo(args[1])
+
+			var res accessTestResult
+			if pk, _, err := sshutils.ParseAuthorizedKey(args[0]); err == nil {
+				res.AccessLevel, res.Reason = be.AccessLevelByPublicKeyWithReason(ctx, rn, pk)
+				if user, _ := be.UserByPublicKey(ctx, pk); user != nil {
+					res.User = user.Username()
+				}
+			} else {
+				var user proto.User
+				if args[0] != "" {
+					user, err = be.User(ctx, args[0])
+					if err != nil {
+						return err
+					}
+					res.User = user.Username()
+				} else {
+					// Anonymous access doesn't use the public key of the
+					// admin running the command.
+					ctx = context.WithValue(ctx, ssh.ContextKeyPublicKey, nil)
+				}
+				res.AccessLevel, res.Reason = be.AccessLevelForUserWithReason(ctx, rn, user)
+			}
+
+			res.Repo = rn
+			res.Description = res.Reason.Description()
+
+			if asJSON {
+				bts, err := json.Marshal(res)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+				return nil
+			}
+
+			user := res.User
+			if user == "" {
+				user = "anonymous"
+			}
+
+			cmd.Printf("User: %s\n", user)
+			cmd.Printf("Repository: %s\n", res.Repo)
+			cmd.Printf("Access level: %s\n", res.AccessLevel)
+			cmd.Printf("Reason: %s (%s)\n", res.Reason, res.Description)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON")
+
+	return cmd
+}
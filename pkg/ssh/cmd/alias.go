@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/anmitsu/go-shlex"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// commandGroupID is the help group used for built-in commands once
+	// aliases are listed.
+	commandGroupID = "commands"
+
+	// aliasGroupID is the help group used for command aliases.
+	aliasGroupID = "aliases"
+)
+
+// ExpandAlias expands the first argument using the given command aliases.
+//
+// Only the command name is expanded, and only once, so an alias can't refer
+// to another alias. Aliases never shadow the commands of root. The expanded
+// command runs through the same access checks as if it was typed out,
+// aliases can't grant any extra access.
+func ExpandAlias(root *cobra.Command, aliases map[string]string, args []string) ([]string, error) {
+	if len(args) == 0 || len(aliases) == 0 {
+		return args, nil
+	}
+
+	expansion, ok := aliases[args[0]]
+	if !ok || isCommand(root, args[0]) {
+		return args, nil
+	}
+
+	fields, err := shlex.Split(expansion, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias %q: %w", args[0], err)
+	}
+
+	return append(fields, args[1:]...), nil
+}
+
+// AddAliasCommands lists the given command aliases in the help output of
+// root. The commands are placeholders, aliases are expanded before the
+// command is dispatched.
+func AddAliasCommands(root *cobra.Command, aliases map[string]string) {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		if !isCommand(root, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	sort.Strings(names)
+	for _, c := range root.Commands() {
+		if c.GroupID == "" {
+			c.GroupID = commandGroupID
+		}
+	}
+	root.SetHelpCommandGroupID(commandGroupID)
+	root.AddGroup(
+		&cobra.Group{ID: commandGroupID, Title: "Available Commands:"},
+		&cobra.Group{ID: aliasGroupID, Title: "Command Aliases:"},
+	)
+	for _, name := range names {
+		expansion := aliases[name]
+		root.AddCommand(&cobra.Command{
+			Use:                name,
+			Short:              fmt.Sprintf("Alias for %q", expansion),
+			GroupID:            aliasGroupID,
+			DisableFlagParsing: true,
+			RunE: func(*cobra.Command, []string) error {
+				return fmt.Errorf("alias %q was not expanded", name)
+			},
+		})
+	}
+}
+
+func isCommand(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
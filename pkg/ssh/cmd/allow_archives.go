@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func allowArchivesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "allow-archives REPOSITORY [true|false]",
+		Short:             "Set or get whether archives of the repository can be downloaded",
+		Long:              "Set or get whether archives of the repository can be downloaded with \"git archive --remote\". Clones and fetches are not affected.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				allow, err := be.AllowArchives(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(allow)
+			case 2:
+				allow, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetAllowArchives(ctx, rn, allow); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
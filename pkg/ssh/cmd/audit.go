@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func auditCommand() *cobra.Command {
+	var limit int
+	cmd := &cobra.Command{
+		Use:               "audit REPOSITORY",
+		Short:             "List recent clones and fetches of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			reads, err := be.RepositoryReads(ctx, repo.Name(), limit)
+			if err != nil {
+				return err
+			}
+
+			table := table.New().Headers("User", "Type", "Transport", "Time")
+			for _, r := range reads {
+				user := r.Username
+				if user == "" {
+					user = "anonymous"
+				}
+
+				kind := "fetch"
+				if r.Clone {
+					kind = "clone"
+				}
+
+				table = table.Row(user, kind, r.Transport, humanize.Time(r.CreatedAt))
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "maximum number of reads to list")
+
+	return cmd
+}
+
+func readAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "read-audit REPOSITORY [true|false]",
+		Short:             "Set or get whether clones and fetches are audited",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				audit, err := be.ReadAudit(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(audit)
+			case 2:
+				audit, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetReadAudit(ctx, rn, audit); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func autoPruneBranchesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "auto-prune-branches REPOSITORY [true|false]",
+		Short:             "Set or get whether merged branches are pruned automatically",
+		Long:              "Set or get whether branches fully merged into the default branch are deleted by the prune branches job once they're old enough. Run \"repo branch prune --dry-run\" first to see which branches would be deleted.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				prune, err := be.AutoPruneBranches(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(prune)
+			case 2:
+				prune, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetAutoPruneBranches(ctx, rn, prune); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
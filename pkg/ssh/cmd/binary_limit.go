@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func binaryLimitCommand() *cobra.Command {
+	var reject, unset bool
+	cmd := &cobra.Command{
+		Use:   "binary-limit REPOSITORY [SIZE]",
+		Short: "Set or get the size of binary files a push can add",
+		Long: "Set or get the size of binary files a push can add to a repository, e.g. 10MB, before suggesting Git LFS. " +
+			"Pushes over the limit print a warning, or are rejected with --reject. Files are binary if git diff says so.\n\n" +
+			"Use --unset to remove the limit.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			if !unset && len(args) == 1 {
+				limit, err := be.BinaryLimit(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				if limit.Threshold == 0 {
+					cmd.Println("No binary limit")
+					return nil
+				}
+				action := "warn"
+				if limit.Reject {
+					action = "reject"
+				}
+				cmd.Printf("%s (%s)\n", humanize.IBytes(uint64(limit.Threshold)), action) //nolint:gosec
+				return nil
+			}
+
+			if err := checkIfAdmin(cmd, args); err != nil {
+				return err
+			}
+
+			var limit backend.BinaryLimit
+			if !unset {
+				size, err := humanize.ParseBytes(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid size %q: %w", args[1], err)
+				}
+				if size == 0 {
+					return fmt.Errorf("size must be positive, use --unset to remove the limit")
+				}
+				limit.Threshold = int64(size) //nolint:gosec
+				limit.Reject = reject
+			}
+
+			return be.SetBinaryLimit(ctx, rn, limit)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&reject, "reject", "r", false, "reject pushes over the limit instead of warning")
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "remove the binary limit")
+
+	return cmd
+}
@@ -20,7 +20,7 @@ func blobCommand(renderer *lipgloss.Renderer) *cobra.Command {
 	styles := styles.DefaultStyles(renderer)
 	cmd := &cobra.Command{
 		Use:               "blob REPOSITORY [REFERENCE] [PATH]",
-		Aliases:           []string{"cat", "show"},
+		Aliases:           []string{"cat"},
 		Short:             "Print out the contents of file at path",
 		Args:              cobra.RangeArgs(1, 3),
 		PersistentPreRunE: checkIfReadable,
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,8 @@ func branchCommand() *cobra.Command {
 		branchListCommand(),
 		branchDefaultCommand(),
 		branchDeleteCommand(),
+		branchRenameCommand(),
+		branchPruneCommand(),
 	)
 
 	return cmd
@@ -205,3 +208,72 @@ func branchDeleteCommand() *cobra.Command {
 
 	return cmd
 }
+
+func branchRenameCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "rename REPOSITORY OLD NEW",
+		Aliases:           []string{"mv"},
+		Short:             "Rename a branch",
+		Long:              "Rename a branch. The default branch follows the rename.",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			rr, err := be.Repository(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			return be.RenameBranch(ctx, rr, proto.UserFromContext(ctx), args[1], args[2])
+		},
+	}
+
+	return cmd
+}
+
+func branchPruneCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:               "prune REPOSITORY",
+		Short:             "Delete merged branches",
+		Long:              "Delete the branches fully merged into the default branch whose last commit is older than the prune branches age. Protected branches are kept.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			rr, err := be.Repository(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			var tips []git.BranchTip
+			if dryRun {
+				tips, err = be.PrunableBranches(ctx, rr)
+			} else {
+				tips, err = be.PruneBranches(ctx, rr, proto.UserFromContext(ctx))
+			}
+			if err != nil {
+				return err
+			}
+
+			verb := "Deleted"
+			if dryRun {
+				verb = "Would delete"
+			}
+			for _, tip := range tips {
+				cmd.Printf("%s %s (%s, %s)\n", verb, tip.Name().Short(), tip.ID[:7], humanize.Time(tip.When))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "only list the branches that would be deleted")
+
+	return cmd
+}
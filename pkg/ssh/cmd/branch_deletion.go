@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func allowBranchDeletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "allow-branch-deletion REPOSITORY [true|false]",
+		Short:             "Set or get whether branches can be deleted by pushing",
+		Long:              "Set or get whether pushes can delete branches. When disabled, branches can only be deleted with \"repo branch delete\". Tags are not affected.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				allow, err := be.AllowBranchDeletion(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(allow)
+			case 2:
+				allow, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetAllowBranchDeletion(ctx, rn, allow); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func bundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "bundle REPOSITORY",
+		Short:             "Download a repository as a git bundle",
+		Long:              "Write a git bundle of every branch and tag of a repository to stdout, e.g. ssh host repo bundle repo1 > repo1.bundle. The bundle can be cloned offline with git clone repo1.bundle.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+			rn := args[0]
+
+			repo, err := be.Repository(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			r, err := repo.Open()
+			if err != nil {
+				return err
+			}
+
+			release, err := acquireGitOperation(ctx, be, user)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			if err := r.Bundle(ctx, cmd.OutOrStdout()); err != nil {
+				return err
+			}
+
+			// A bundle contains the whole repository, audit it as a clone.
+			if err := be.AuditRead(ctx, repo.Name(), user, "ssh", true); err != nil {
+				log.FromContext(ctx).Error("failed to audit repository read", "err", err, "repo", repo.Name())
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
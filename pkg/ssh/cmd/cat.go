@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+// CatCommand returns a command that streams the raw contents of a file at a
+// reference, like the HTTP raw endpoint.
+func CatCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "cat REPOSITORY REFERENCE PATH",
+		Short:             "Print the raw contents of a file at a reference",
+		Long:              "Print the raw contents of a file at a reference. Binary files are printed unchanged.",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			ref := args[1]
+			fp := cleanFilePath(args[2])
+			// Don't let references be parsed as git options.
+			if ref == "" || strings.HasPrefix(ref, "-") {
+				return git.ErrRevisionNotExist
+			}
+			if fp == "" {
+				return git.ErrFileNotFound
+			}
+
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			r, err := repo.Open()
+			if err != nil {
+				return err
+			}
+
+			tree, err := r.LsTree(ref)
+			if err != nil {
+				return err
+			}
+
+			te, err := tree.TreeEntry(fp)
+			if err != nil {
+				return err
+			}
+
+			if te.Type() != "blob" {
+				return git.ErrFileNotFound
+			}
+
+			// Stream the blob, large files aren't read into memory.
+			return git.NewCommand("cat-file", "blob", te.ID().String()).
+				WithContext(ctx).WithTimeout(-1).
+				RunInDirWithOptions(r.Path, git.RunInDirOptions{
+					Stdout: cmd.OutOrStdout(),
+				})
+		},
+	}
+
+	return cmd
+}
+
+// cleanFilePath returns the path of a file relative to the root of a tree.
+// Paths can't escape the tree, leading ".." elements are dropped.
+func cleanFilePath(fp string) string {
+	fp = path.Clean("/" + fp)
+	return strings.TrimPrefix(fp, "/")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func checkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "check",
+		Aliases: []string{"checks"},
+		Short:   "Manage repository push checks",
+		Long: "Manage repository push checks. Checks are shell commands run in a checkout of every pushed ref before the push is accepted, " +
+			"a non-zero exit status rejects the push and its output is sent to the client. " +
+			"SOFT_SERVE_CHECK_REF and SOFT_SERVE_CHECK_COMMIT are set to the pushed ref and commit.",
+	}
+
+	cmd.AddCommand(
+		checkAddCommand(),
+		checkRemoveCommand(),
+		checkListCommand(),
+	)
+
+	return cmd
+}
+
+func checkAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add REPOSITORY COMMAND",
+		Short:             "Add a push check to a repository",
+		Long:              "Add a push check to a repository. Checks run on the server, only server admins can change them.",
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.AddRepoCheck(ctx, args[0], strings.Join(args[1:], " "))
+		},
+	}
+
+	return cmd
+}
+
+func checkRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY NUMBER",
+		Short:             "Remove a push check from a repository",
+		Long:              "Remove a push check from a repository by its number in \"repo check list\".",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.RemoveRepoCheck(ctx, args[0], n)
+		},
+	}
+
+	return cmd
+}
+
+func checkListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List the push checks of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			checks, err := be.RepoChecks(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			for i, c := range checks {
+				cmd.Printf("%d %s\n", i+1, c)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
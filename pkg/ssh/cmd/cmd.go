@@ -12,7 +12,6 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
-	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/charmbracelet/ssh"
 	"github.com/spf13/cobra"
 )
@@ -116,8 +115,9 @@ func checkIfReadable(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := cmd.Context()
+	cfg := config.FromContext(ctx)
 	be := backend.FromContext(ctx)
-	rn := utils.SanitizeRepo(repo)
+	rn := cfg.SanitizeRepo(repo)
 	user := proto.UserFromContext(ctx)
 	auth := be.AccessLevelForUser(cmd.Context(), rn, user)
 	if auth < access.ReadOnlyAccess {
@@ -146,7 +146,7 @@ func checkIfAdmin(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	cfg := config.FromContext(ctx)
 	be := backend.FromContext(ctx)
-	rn := utils.SanitizeRepo(repo)
+	rn := cfg.SanitizeRepo(repo)
 	pk := sshutils.PublicKeyFromContext(ctx)
 	if IsPublicKeyAdmin(cfg, pk) {
 		return nil
@@ -169,6 +169,13 @@ func checkIfAdmin(cmd *cobra.Command, args []string) error {
 	return proto.ErrUnauthorized
 }
 
+// checkIfServerAdmin checks if the user is a server admin. Unlike
+// checkIfAdmin, it doesn't treat the first argument as a repository, so
+// owning a repository doesn't grant access.
+func checkIfServerAdmin(cmd *cobra.Command, _ []string) error {
+	return checkIfAdmin(cmd, nil)
+}
+
 func checkIfCollab(cmd *cobra.Command, args []string) error {
 	var repo string
 	if len(args) > 0 {
@@ -176,8 +183,9 @@ func checkIfCollab(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := cmd.Context()
+	cfg := config.FromContext(ctx)
 	be := backend.FromContext(ctx)
-	rn := utils.SanitizeRepo(repo)
+	rn := cfg.SanitizeRepo(repo)
 	user := proto.UserFromContext(ctx)
 	auth := be.AccessLevelForUser(cmd.Context(), rn, user)
 	if auth < access.ReadWriteAccess {
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"strings"
+	"time"
+
+	"github.com/caarlos0/duration"
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/spf13/cobra"
 )
 
@@ -17,16 +22,18 @@ func collabCommand() *cobra.Command {
 		collabAddCommand(),
 		collabRemoveCommand(),
 		collabListCommand(),
+		collabPathsCommand(),
 	)
 
 	return cmd
 }
 
 func collabAddCommand() *cobra.Command {
+	var expiresIn string
 	cmd := &cobra.Command{
 		Use:               "add REPOSITORY USERNAME [LEVEL]",
 		Short:             "Add a collaborator to a repo",
-		Long:              "Add a collaborator to a repo. LEVEL can be one of: no-access, read-only, read-write, or admin-access. Defaults to read-write.",
+		Long:              "Add a collaborator to a repo. LEVEL can be one of: no-access, read-only, read-write, or admin-access. Defaults to read-write. Use --expires-in to give time-boxed access, expired collaborators are listed until they're removed.",
 		Args:              cobra.RangeArgs(2, 3),
 		PersistentPreRunE: checkIfReadableAndCollab,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -42,10 +49,21 @@ func collabAddCommand() *cobra.Command {
 				}
 			}
 
-			return be.AddCollaborator(ctx, repo, username, level)
+			var expiresAt time.Time
+			if expiresIn != "" {
+				d, err := duration.Parse(expiresIn)
+				if err != nil {
+					return err
+				}
+				expiresAt = time.Now().Add(d)
+			}
+
+			return be.AddCollaborator(ctx, repo, username, level, expiresAt)
 		},
 	}
 
+	cmd.Flags().StringVar(&expiresIn, "expires-in", "", "Access expiration time (e.g. 1y, 3mo, 2w, 5d4h, 1h30m)")
+
 	return cmd
 }
 
@@ -78,13 +96,20 @@ func collabListCommand() *cobra.Command {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
 			repo := args[0]
-			collabs, err := be.Collaborators(ctx, repo)
+			collabs, err := be.CollaboratorsWithAccess(ctx, repo)
 			if err != nil {
 				return err
 			}
 
 			for _, c := range collabs {
-				cmd.Println(c)
+				switch {
+				case c.ExpiresAt.IsZero():
+					cmd.Println(c.Username)
+				case c.Expired():
+					cmd.Printf("%s (expired %s)\n", c.Username, c.ExpiresAt.UTC().Format(time.RFC3339))
+				default:
+					cmd.Printf("%s (expires %s)\n", c.Username, c.ExpiresAt.UTC().Format(time.RFC3339))
+				}
 			}
 
 			return nil
@@ -93,3 +118,48 @@ func collabListCommand() *cobra.Command {
 
 	return cmd
 }
+
+func collabPathsCommand() *cobra.Command {
+	var unset bool
+	cmd := &cobra.Command{
+		Use:               "paths REPOSITORY USERNAME [PATTERN...]",
+		Short:             "Set or get the paths a collaborator can push to",
+		Long:              "Set or get the paths a collaborator can push to. Pushes changing files outside of them are rejected. A PATTERN is a glob, e.g. docs or services/*, and allows changing everything under the directories it matches. Use --unset to allow changing the whole repo.",
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			username := args[1]
+			switch {
+			case unset:
+				return be.SetCollaboratorPaths(ctx, repo, username, nil)
+			case len(args) > 2:
+				return be.SetCollaboratorPaths(ctx, repo, username, args[2:])
+			}
+
+			collabs, err := be.CollaboratorsWithAccess(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			for _, c := range collabs {
+				if c.Username != strings.ToLower(username) {
+					continue
+				}
+
+				for _, p := range c.Paths {
+					cmd.Println(p)
+				}
+				return nil
+			}
+
+			return proto.ErrCollaboratorNotFound
+		},
+	}
+
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "allow changing the whole repo")
+
+	return cmd
+}
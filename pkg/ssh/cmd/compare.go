@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+// compareCommit is a commit listed in a comparison.
+type compareCommit struct {
+	ID      string    `json:"id"`
+	Summary string    `json:"summary"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+// compareResult is the JSON output of the compare command.
+type compareResult struct {
+	Base      string          `json:"base"`
+	Head      string          `json:"head"`
+	MergeBase string          `json:"merge_base"`
+	Ahead     int64           `json:"ahead"`
+	Behind    int64           `json:"behind"`
+	Diverged  bool            `json:"diverged"`
+	Commits   []compareCommit `json:"commits"`
+	DiffStat  string          `json:"diffstat"`
+	// MergeStrategy is the preferred merge strategy of the repository.
+	MergeStrategy proto.MergeStrategy `json:"merge_strategy,omitempty"`
+}
+
+func compareCommand() *cobra.Command {
+	var asJSON bool
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:               "compare REPOSITORY BASE HEAD",
+		Short:             "Compare two refs",
+		Long:              "Compare two refs. Lists the commits of HEAD that aren't in BASE, like git log BASE..HEAD, and the changes of HEAD since it diverged from BASE, like git diff --stat BASE...HEAD.",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			base, head := args[1], args[2]
+			rr, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			r, err := rr.Open()
+			if err != nil {
+				return err
+			}
+
+			c, err := r.Compare(base, head, limit, 0)
+			if err != nil {
+				return err
+			}
+
+			ms, err := be.MergeStrategy(ctx, rr.Name())
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				res := compareResult{
+					Base:          c.Base,
+					Head:          c.Head,
+					MergeBase:     c.MergeBase,
+					Ahead:         c.Ahead,
+					Behind:        c.Behind,
+					Diverged:      c.Diverged(),
+					Commits:       make([]compareCommit, 0, len(c.Commits)),
+					DiffStat:      c.DiffStat,
+					MergeStrategy: ms,
+				}
+				for _, commit := range c.Commits {
+					res.Commits = append(res.Commits, compareCommit{
+						ID:      commit.ID.String(),
+						Summary: commit.Summary(),
+						Author:  commit.Author.Name,
+						Date:    commit.Author.When,
+					})
+				}
+				bts, err := json.Marshal(res)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+				return nil
+			}
+
+			cmd.Println(c.Summary(base, head))
+			if ms != "" {
+				cmd.Printf("Preferred merge strategy: %s\n", ms)
+			}
+			if len(c.Commits) > 0 {
+				cmd.Println()
+				for _, commit := range c.Commits {
+					cmd.Printf("%s %s (%s, %s)\n", commit.ID.String()[:7], commit.Summary(), commit.Author.Name, humanize.Time(commit.Author.When))
+				}
+				if more := c.Ahead - int64(len(c.Commits)); more > 0 {
+					cmd.Printf("... and %d more\n", more)
+				}
+			}
+			if c.DiffStat != "" {
+				cmd.Println()
+				cmd.Print(c.DiffStat)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 100, "list at most this many commits, 0 lists all of them")
+
+	return cmd
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func contributorsCommand() *cobra.Command {
+	var asJSON bool
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:               "contributors REPOSITORY [REF]",
+		Short:             "List repository contributors by commit count",
+		Long:              "List repository contributors by commit count. REF defaults to the default branch.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rr, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			var ref string
+			if len(args) > 1 {
+				ref = args[1]
+			}
+
+			contributors, err := be.Contributors(ctx, rr, ref)
+			if err != nil {
+				return err
+			}
+
+			if limit > 0 && len(contributors) > limit {
+				contributors = contributors[:limit]
+			}
+
+			if asJSON {
+				if contributors == nil {
+					contributors = []git.Contributor{}
+				}
+				bts, err := json.Marshal(contributors)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+				return nil
+			}
+
+			for _, c := range contributors {
+				cmd.Printf("%6d\t%s <%s>\n", c.Commits, c.Name, c.Email)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, "show at most this many contributors")
+
+	return cmd
+}
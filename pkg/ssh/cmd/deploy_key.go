@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/spf13/cobra"
+)
+
+func deployKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deploy-key",
+		Aliases: []string{"deploy-keys"},
+		Short:   "Manage repository deploy keys",
+		Long:    "Manage repository deploy keys. Deploy keys can only fetch, or push with --write, their repository. They can't use the TUI or the other commands.",
+	}
+
+	cmd.AddCommand(
+		deployKeyAddCommand(),
+		deployKeyRemoveCommand(),
+		deployKeyListCommand(),
+	)
+
+	return cmd
+}
+
+func deployKeyAddCommand() *cobra.Command {
+	var write bool
+	cmd := &cobra.Command{
+		Use:               "add REPOSITORY AUTHORIZED_KEY",
+		Short:             "Add a deploy key to a repository",
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			pk, comment, err := sshutils.ParseAuthorizedKey(strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+
+			level := access.ReadOnlyAccess
+			if write {
+				level = access.ReadWriteAccess
+			}
+
+			return be.AddDeployKey(ctx, args[0], pk, comment, level)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&write, "write", "w", false, "allow the key to push to the repository")
+
+	return cmd
+}
+
+func deployKeyRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY AUTHORIZED_KEY",
+		Short:             "Remove a deploy key from a repository",
+		Args:              cobra.MinimumNArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			pk, _, err := sshutils.ParseAuthorizedKey(strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+
+			return be.RemoveDeployKey(ctx, args[0], pk)
+		},
+	}
+
+	return cmd
+}
+
+func deployKeyListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List the deploy keys of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			keys, err := be.DeployKeys(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			for _, k := range keys {
+				ak := sshutils.MarshalAuthorizedKey(k.PublicKey)
+				if k.Comment != "" {
+					ak += " " + k.Comment
+				}
+				cmd.Printf("%s %s\n", k.AccessLevel, ak)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func exportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export NAMESPACE DEST",
+		Short: "Export the repositories of a namespace as one archive",
+		Long: "Export every repository under a namespace as a tar archive holding a git bundle and the metadata of each repository, e.g. for migrating them to another server.\n\n" +
+			"Use - as DEST to write the archive to stdout, e.g. ssh host repo export team - > team.tar. Otherwise the archive is written to DEST in the exports directory of the server.",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			cfg := config.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+			ns, dest := args[0], args[1]
+
+			var w io.Writer = cmd.OutOrStdout()
+			var fp string
+			if dest != "-" {
+				if !filepath.IsLocal(dest) {
+					return fmt.Errorf("invalid destination %q, it must be a relative path in the exports directory", dest)
+				}
+
+				fp = filepath.Join(cfg.DataPath, "exports", dest)
+				if err := os.MkdirAll(filepath.Dir(fp), os.ModePerm); err != nil {
+					return err
+				}
+
+				f, err := os.OpenFile(fp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+				if errors.Is(err, os.ErrExist) {
+					return fmt.Errorf("%s already exists", dest)
+				} else if err != nil {
+					return err
+				}
+				defer f.Close() // nolint: errcheck
+				w = f
+			}
+
+			release, err := acquireGitOperation(ctx, be, user)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			repos, err := be.ExportRepositories(ctx, ns, w)
+			if err != nil {
+				if fp != "" {
+					os.Remove(fp) // nolint: errcheck
+				}
+				return err
+			}
+
+			// The bundles contain the whole repositories, audit them as
+			// clones.
+			for _, r := range repos {
+				if err := be.AuditRead(ctx, r.Name, user, "ssh", true); err != nil {
+					log.FromContext(ctx).Error("failed to audit repository read", "err", err, "repo", r.Name)
+				}
+			}
+
+			if fp != "" {
+				cmd.Printf("Exported %d repositories to %s\n", len(repos), fp)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func filterCommand() *cobra.Command {
+	var opts backend.FilterOptions
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "filter REPOSITORY",
+		Short: "Remove files from the whole history of a repository",
+		Long: "Remove paths or blobs, such as pushed secrets, from every commit of a repository. " +
+			"All refs are rewritten and the old objects are garbage collected, collaborators must clone the repository again.\n\n" +
+			"A mirror clone of the repository is backed up to the trash first, and pushes are rejected during the rewrite. " +
+			"The history is only rewritten once --yes is given.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+			if len(opts.Paths) == 0 && len(opts.Blobs) == 0 {
+				return errors.New("specify at least one --path or --blob")
+			}
+
+			if _, err := be.Repository(ctx, rn); err != nil {
+				return err
+			}
+
+			if !yes {
+				for _, p := range opts.Paths {
+					cmd.Println(p)
+				}
+				for _, b := range opts.Blobs {
+					cmd.Println("blob " + b)
+				}
+				cmd.PrintErrf("Run again with --yes to remove them from the history of %s, this can't be undone.\n", rn)
+				return nil
+			}
+
+			trash, err := be.FilterRepository(ctx, rn, proto.UserFromContext(ctx), opts)
+			if err != nil {
+				if trash != "" {
+					cmd.PrintErrf("A backup of %s is in %s\n", rn, trash)
+				}
+				return err
+			}
+
+			cmd.Printf("Backed up %s to %s\n", rn, trash)
+			cmd.Printf("Rewrote the history of %s, removed %s\n", rn, strings.Join(append(opts.Paths, opts.Blobs...), ", "))
+			cmd.Println("Collaborators must clone the repository again.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.Paths, "path", "p", nil, "path of a file or directory to remove, can be repeated")
+	cmd.Flags().StringArrayVarP(&opts.Blobs, "blob", "b", nil, "id of a blob to remove, can be repeated")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "confirm rewriting the history")
+
+	return cmd
+}
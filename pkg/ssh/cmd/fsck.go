@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+// fsckResult is the result of checking a repository.
+type fsckResult struct {
+	Repository string `json:"repository"`
+	// Busy is true if the repository was skipped because it's being
+	// collected or checked.
+	Busy bool `json:"busy"`
+	*git.FsckResult
+}
+
+func fsckCommand() *cobra.Command {
+	var all bool
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:               "fsck [REPOSITORY]",
+		Short:             "Check the integrity of repositories",
+		Long:              "Check the integrity of a repository, or of every repository with --all, using git fsck. Broken and missing objects are reported as problems.",
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			var repos []proto.Repository
+			switch {
+			case all && len(args) == 0:
+				var err error
+				repos, err = be.Repositories(ctx)
+				if err != nil {
+					return err
+				}
+			case !all && len(args) == 1:
+				repo, err := be.Repository(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				repos = append(repos, repo)
+			default:
+				return errors.New("specify either a repository or --all")
+			}
+
+			results := make([]fsckResult, 0, len(repos))
+			var failed int
+			for _, repo := range repos {
+				res, err := be.Fsck(ctx, repo)
+				if errors.Is(err, task.ErrAlreadyStarted) {
+					results = append(results, fsckResult{Repository: repo.Name(), Busy: true})
+					continue
+				} else if err != nil {
+					return fmt.Errorf("%s: %w", repo.Name(), err)
+				}
+
+				if !res.OK() {
+					failed++
+				}
+				results = append(results, fsckResult{Repository: repo.Name(), FsckResult: res})
+			}
+
+			if asJSON {
+				bts, err := json.Marshal(results)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+			} else {
+				for _, r := range results {
+					printFsckResult(cmd, r)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("found problems in %d repositories", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "check every repository")
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON")
+
+	return cmd
+}
+
+func printFsckResult(cmd *cobra.Command, r fsckResult) {
+	switch {
+	case r.Busy:
+		cmd.Printf("%s: skipped, maintenance in progress\n", r.Repository)
+		return
+	case !r.OK():
+		cmd.Printf("%s: %d problems\n", r.Repository, len(r.Problems))
+	default:
+		cmd.Printf("%s: ok\n", r.Repository)
+	}
+
+	for _, lines := range [][]string{r.Problems, r.Warnings, r.Dangling} {
+		for _, l := range lines {
+			cmd.Printf("  %s\n", l)
+		}
+	}
+}
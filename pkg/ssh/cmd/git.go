@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 	"time"
@@ -14,7 +17,6 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/lfs"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
-	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/spf13/cobra"
@@ -172,7 +174,16 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 	start := time.Now()
 
 	// repo should be in the form of "repo.git"
-	name := utils.SanitizeRepo(args[0])
+	name := cfg.SanitizeRepo(args[0])
+
+	// Follow the redirect of a renamed repository.
+	if _, err := be.Repository(ctx, name); errors.Is(err, proto.ErrRepoNotFound) {
+		if target, err := be.ResolveRedirect(ctx, name); err == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: repository %q has been renamed to %q, please update your remote\n", name, target) //nolint:errcheck
+			name = target
+		}
+	}
+
 	pk := sshutils.PublicKeyFromContext(ctx)
 	ak := sshutils.MarshalAuthorizedKey(pk)
 	user := proto.UserFromContext(ctx)
@@ -204,6 +215,7 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 	}
 
 	envs = append(envs, cfg.Environ()...)
+	envs = append(envs, git.SigningEnv(cfg)...)
 
 	// Add GIT_PROTOCOL from session.
 	if sess := sshutils.SessionFromContext(ctx); sess != nil {
@@ -226,6 +238,9 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		Stderr: stderr,
 		Env:    envs,
 		Dir:    repoPath,
+
+		BufferSize: cfg.Git.TransferBufferSize,
+		Limits:     git.ServiceLimits(cfg),
 	}
 
 	switch service {
@@ -237,23 +252,63 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		if accessLevel < access.ReadWriteAccess {
 			return git.ErrNotAuthed
 		}
+		if repo != nil && repo.IsArchived() {
+			return proto.ErrRepoArchived
+		}
+		if err := be.CheckPush(ctx, name); err != nil {
+			return err
+		}
+		endPush, err := be.BeginPush(name)
+		if err != nil {
+			return err
+		}
+		defer endPush()
+		// Don't start receiving objects on an almost full disk.
+		if err := be.CheckFreeDisk(0); errors.Is(err, proto.ErrLowDiskSpace) {
+			return err
+		} else if err != nil {
+			log.Errorf("failed to check free disk space: %s", err)
+		}
+		var created bool
 		if repo == nil {
-			if _, err := be.CreateRepository(ctx, name, user, proto.RepositoryOptions{Private: false}); err != nil {
+			var err error
+			if _, created, err = be.AutoCreateRepository(ctx, name, user); err != nil {
 				log.Errorf("failed to create repo: %s", err)
 				return err
 			}
-			createRepoCounter.WithLabelValues(name).Inc()
+			if created {
+				createRepoCounter.WithLabelValues(name).Inc()
+			}
+		}
+
+		release, err := acquireGitOperation(ctx, be, user)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		var shallow *git.ShallowPushGuard
+		if cfg.Git.RejectShallowPush {
+			shallow = git.RejectShallowPush(&scmd)
 		}
 
 		if err := service.Handler(ctx, scmd); err != nil {
-			logger.Error("failed to handle git service", "service", service, "err", err, "repo", name)
 			defer func() {
-				if repo == nil {
+				if created {
 					// If the repo was created, but the request failed, delete it.
-					be.DeleteRepository(ctx, name) // nolint: errcheck
+					be.DeleteAutoCreatedRepository(ctx, name) // nolint: errcheck
 				}
 			}()
 
+			if shallow != nil && shallow.Rejected() {
+				logger.Warn("rejected shallow push", "repo", name)
+				return git.ErrShallowPush
+			} else if git.IsResourceLimit(err) {
+				logger.Warn("aborted git session", "service", service, "err", err, "repo", name)
+				return err
+			}
+
+			logger.Error("failed to handle git service", "service", service, "err", err, "repo", name)
 			return git.ErrSystemMalfunction
 		}
 
@@ -262,6 +317,14 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 			return git.ErrSystemMalfunction
 		}
 
+		if err := be.RecordPush(ctx, name); err != nil {
+			logger.Error("failed to record push", "err", err, "repo", name)
+		}
+
+		if err := be.UpdateReadmeDescription(ctx, name); err != nil {
+			logger.Error("failed to update description from readme", "err", err, "repo", name)
+		}
+
 		receivePackCounter.WithLabelValues(name).Inc()
 
 		return nil
@@ -271,11 +334,21 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		}
 
 		if repo == nil {
-			return git.ErrInvalidRepo
+			return git.ErrRepoNotFound
+		}
+
+		if err := be.CheckFetch(ctx, name); err != nil {
+			return err
 		}
 
 		switch service {
 		case git.UploadArchiveService:
+			if allow, err := be.AllowArchives(ctx, name); err != nil {
+				logger.Error("failed to get archives setting", "err", err, "repo", name)
+				return git.ErrSystemMalfunction
+			} else if !allow {
+				return git.ErrArchivesDisabled
+			}
 			uploadArchiveCounter.WithLabelValues(name).Inc()
 			defer func() {
 				uploadArchiveSeconds.WithLabelValues(name).Add(time.Since(start).Seconds())
@@ -287,14 +360,64 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 			}()
 		}
 
-		err := service.Handler(ctx, scmd)
-		if errors.Is(err, git.ErrInvalidRepo) {
+		release, err := acquireGitOperation(ctx, be, user)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		var fetch *git.FetchTracker
+		var limiter *git.NegotiationLimiter
+		if service == git.UploadPackService {
+			fetch = git.TrackFetch(&scmd)
+			if n := cfg.Git.MaxNegotiationRounds; n > 0 {
+				limiter = git.LimitNegotiation(&scmd, n)
+			}
+		}
+
+		var archiveLimit *git.ArchiveLimiter
+		var archive *git.ArchiveSmudger
+		if service == git.UploadArchiveService {
+			// Limit the archive with LFS objects smudged.
+			if n := cfg.Git.MaxArchiveSize; n > 0 {
+				archiveLimit = git.LimitArchive(&scmd, n)
+			}
+			if smudge, err := be.SmudgeLFSArchives(ctx, name); err != nil {
+				logger.Error("failed to get lfs archives setting", "err", err, "repo", name)
+			} else if smudge {
+				archive = git.SmudgeArchive(&scmd, func(p lfs.Pointer) (io.ReadCloser, error) {
+					return be.OpenLFSObject(ctx, repo, p)
+				})
+			}
+		}
+
+		err = service.Handler(ctx, scmd)
+		if limiter != nil && limiter.Exceeded() {
+			logger.Warn("aborted git session", "err", git.ErrTooManyNegotiationRounds, "repo", name)
+			return git.ErrTooManyNegotiationRounds
+		} else if archiveLimit != nil && archiveLimit.Exceeded() {
+			logger.Warn("aborted git session", "err", git.ErrArchiveTooLarge, "repo", name)
+			return git.ErrArchiveTooLarge
+		} else if errors.Is(err, git.ErrInvalidRepo) {
 			return git.ErrInvalidRepo
+		} else if git.IsResourceLimit(err) {
+			logger.Warn("aborted git session", "service", service, "err", err, "repo", name)
+			return err
 		} else if err != nil {
 			logger.Error("failed to handle git service", "service", service, "err", err, "repo", name)
 			return git.ErrSystemMalfunction
 		}
 
+		if archive != nil && archive.Err() != nil {
+			logger.Error("failed to smudge lfs archive", "err", archive.Err(), "repo", name)
+		}
+
+		if fetch != nil && fetch.Fetched() {
+			if err := be.AuditRead(ctx, name, user, "ssh", fetch.Clone()); err != nil {
+				logger.Error("failed to audit repository read", "err", err, "repo", name)
+			}
+		}
+
 		return nil
 	case git.LFSTransferService, git.LFSAuthenticateService:
 		operation := args[1]
@@ -312,7 +435,7 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		}
 
 		if repo == nil {
-			return git.ErrInvalidRepo
+			return git.ErrRepoNotFound
 		}
 
 		scmd.Args = []string{
@@ -343,3 +466,14 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 
 	return errors.New("unsupported git service")
 }
+
+// acquireGitOperation waits for the backend to admit a git operation for the
+// session user.
+func acquireGitOperation(ctx context.Context, be backend.Service, user proto.User) (func(), error) {
+	var addr string
+	if sess := sshutils.SessionFromContext(ctx); sess != nil {
+		addr = sess.RemoteAddr().String()
+	}
+
+	return be.AcquireGitOperation(ctx, user, addr)
+}
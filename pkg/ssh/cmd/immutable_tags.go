@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func immutableTagsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "immutable-tags REPOSITORY [true|false]",
+		Short:             "Set or get whether existing tags can't be moved or deleted",
+		Long:              "Set or get whether existing tags of the repository can't be moved or deleted, by pushing or with \"repo tag\". New tags can still be created.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				immutable, err := be.ImmutableTags(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(immutable)
+			case 2:
+				immutable, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetImmutableTags(ctx, rn, immutable); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// initCommand is the command for creating a new repository with options set
+// up front.
+func initCommand() *cobra.Command {
+	var private bool
+	var description string
+	var projectName string
+	var hidden bool
+	var defaultBranch string
+	var template string
+
+	cmd := &cobra.Command{
+		Use:               "init REPOSITORY",
+		Short:             "Create and set up a new repository",
+		Long:              "Create a new repository with its metadata and default branch, optionally copying the branches and tags of a template repository. LFS objects of the template aren't copied.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg := config.FromContext(ctx)
+			be := backend.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+			name := args[0]
+
+			if template != "" && be.AccessLevelForUser(ctx, template, user) < access.ReadOnlyAccess {
+				return proto.ErrRepoNotFound
+			}
+
+			r, err := be.InitRepository(ctx, name, user, template, proto.RepositoryOptions{
+				Private:       private,
+				Description:   description,
+				ProjectName:   projectName,
+				Hidden:        hidden,
+				DefaultBranch: defaultBranch,
+			})
+			if err != nil {
+				return err
+			}
+
+			cloneurl := fmt.Sprintf("%s/%s.git", cfg.SSH.PublicURL, r.Name())
+			cmd.PrintErrf("Initialized repository %s\n", r.Name())
+			cmd.Println(cloneurl)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&private, "private", "p", false, "make the repository private")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "set the repository description")
+	cmd.Flags().StringVarP(&projectName, "name", "n", "", "set the project name")
+	cmd.Flags().BoolVarP(&hidden, "hidden", "H", false, "hide the repository from the UI")
+	cmd.Flags().StringVarP(&defaultBranch, "default-branch", "b", "", "set the default branch")
+	cmd.Flags().StringVarP(&template, "template", "t", "", "copy the branches and tags of a template repository")
+
+	return cmd
+}
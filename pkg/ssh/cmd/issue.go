@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func issueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "issue",
+		Aliases: []string{"issues"},
+		Short:   "Manage repository issues",
+	}
+
+	cmd.AddCommand(
+		issueListCommand(),
+		issueCreateCommand(),
+		issueShowCommand(),
+		issueCloseCommand(),
+	)
+
+	return cmd
+}
+
+func issueListCommand() *cobra.Command {
+	var all bool
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List repository issues",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			issues, err := be.ListIssues(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			table := table.New().Headers("ID", "Title", "State", "Author", "Created At")
+			for _, i := range issues {
+				if !all && !i.IsOpen() {
+					continue
+				}
+
+				table = table.Row(
+					strconv.FormatInt(i.ID, 10),
+					i.Title,
+					i.State.String(),
+					i.Author,
+					humanize.Time(i.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "include closed issues")
+
+	return cmd
+}
+
+func issueCreateCommand() *cobra.Command {
+	var body string
+	cmd := &cobra.Command{
+		Use:               "create REPOSITORY TITLE",
+		Short:             "Create a repository issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUnauthorized
+			}
+
+			issue, err := be.CreateIssue(ctx, args[0], user, args[1], body)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Created issue #%d\n", issue.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&body, "body", "b", "", "issue description (markdown)")
+
+	return cmd
+}
+
+func issueShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY ID",
+		Short:             "Show a repository issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := parseIssueID(args[1])
+			if err != nil {
+				return err
+			}
+
+			issue, err := be.Issue(ctx, args[0], id)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("#%d %s\n", issue.ID, issue.Title)
+			cmd.Println("State:", issue.State)
+			if issue.Author != "" {
+				cmd.Println("Author:", issue.Author)
+			}
+			cmd.Println("Created:", humanize.Time(issue.CreatedAt))
+			if body := strings.TrimSpace(issue.Body); body != "" {
+				cmd.Println()
+				cmd.Println(body)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueCloseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "close REPOSITORY ID",
+		Short:             "Close a repository issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			id, err := parseIssueID(args[1])
+			if err != nil {
+				return err
+			}
+
+			return be.CloseIssue(ctx, args[0], id)
+		},
+	}
+
+	return cmd
+}
+
+func parseIssueID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, "#"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid issue id: %s", s)
+	}
+	return id, nil
+}
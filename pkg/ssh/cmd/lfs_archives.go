@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func lfsArchivesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "lfs-archives REPOSITORY [true|false]",
+		Short:             "Set or get whether archives contain LFS object contents",
+		Long:              "Set or get whether archives contain the contents of LFS objects instead of their pointer files. Only tar archives are affected.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				smudge, err := be.SmudgeLFSArchives(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(smudge)
+			case 2:
+				smudge, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetSmudgeLFSArchives(ctx, rn, smudge); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func requireLinearHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "require-linear-history REPOSITORY [true|false]",
+		Short:             "Set or get whether pushes must not add merge commits",
+		Long:              "Set or get whether pushes must not add merge commits to the default branch and the protected branches.",
+		Aliases:           []string{"linear-history"},
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				require, err := be.RequireLinearHistory(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(require)
+			case 2:
+				require, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetRequireLinearHistory(ctx, rn, require); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
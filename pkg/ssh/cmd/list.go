@@ -1,15 +1,31 @@
 package cmd
 
 import (
-	"github.com/charmbracelet/soft-serve/pkg/access"
+	"encoding/json"
+	"time"
+
 	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"github.com/spf13/cobra"
 )
 
+type listRepoResult struct {
+	Name        string               `json:"name"`
+	ProjectName string               `json:"project_name,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Private     bool                 `json:"private"`
+	Hidden      bool                 `json:"hidden"`
+	Mirror      bool                 `json:"mirror"`
+	Archived    bool                 `json:"archived"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	LastCommit  *proto.CommitSummary `json:"last_commit"`
+}
+
 // listCommand returns a command that list file or directory at path.
 func listCommand() *cobra.Command {
 	var all bool
+	var asJSON bool
 
 	listCmd := &cobra.Command{
 		Use:     "list",
@@ -20,22 +36,55 @@ func listCommand() *cobra.Command {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
 			pk := sshutils.PublicKeyFromContext(ctx)
-			repos, err := be.Repositories(ctx)
+			repos, err := be.VisibleRepositories(ctx, pk)
 			if err != nil {
 				return err
 			}
+			results := make([]listRepoResult, 0, len(repos))
 			for _, r := range repos {
-				if be.AccessLevelByPublicKey(ctx, r.Name(), pk) >= access.ReadOnlyAccess {
-					if !r.IsHidden() || all {
+				if r.IsHidden() && !all {
+					continue
+				}
+
+				if !asJSON {
+					if r.IsArchived() {
+						cmd.Println(r.Name(), "(archived)")
+					} else {
 						cmd.Println(r.Name())
 					}
+					continue
+				}
+
+				commit, err := be.LastCommit(ctx, r)
+				if err != nil {
+					return err
+				}
+
+				results = append(results, listRepoResult{
+					Name:        r.Name(),
+					ProjectName: r.ProjectName(),
+					Description: r.Description(),
+					Private:     r.IsPrivate(),
+					Hidden:      r.IsHidden(),
+					Mirror:      r.IsMirror(),
+					Archived:    r.IsArchived(),
+					UpdatedAt:   r.UpdatedAt(),
+					LastCommit:  commit,
+				})
+			}
+			if asJSON {
+				bts, err := json.Marshal(results)
+				if err != nil {
+					return err
 				}
+				cmd.Println(string(bts))
 			}
 			return nil
 		},
 	}
 
 	listCmd.Flags().BoolVarP(&all, "all", "a", false, "List all repositories")
+	listCmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON, including the last commit of each repository")
 
 	return listCmd
 }
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func mergeStrategyCommand() *cobra.Command {
+	var unset bool
+	cmd := &cobra.Command{
+		Use:   "merge-strategy REPOSITORY [merge|squash|rebase]",
+		Short: "Set or get the preferred merge strategy of a repository",
+		Long: "Set or get the preferred merge strategy of a repository, shown to contributors comparing refs.\n\n" +
+			"The strategy is advisory, pushes aren't checked against it. Use --unset to remove it.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			if !unset && len(args) == 1 {
+				ms, err := be.MergeStrategy(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(ms)
+				return nil
+			}
+
+			if err := checkIfCollab(cmd, args); err != nil {
+				return err
+			}
+
+			var ms proto.MergeStrategy
+			if !unset {
+				var err error
+				ms, err = proto.ParseMergeStrategy(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			return be.SetMergeStrategy(ctx, rn, ms)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "remove the preferred merge strategy")
+
+	return cmd
+}
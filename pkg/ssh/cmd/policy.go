@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func policyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy REPOSITORY [PRESET]",
+		Short: "Set or get the policy preset of a repository",
+		Long: "Set or get the policy preset of a repository. Presets bundle the signed commits, linear history, and branch deletion settings, and apply all of them at once. " +
+			"\"strict\" requires signed commits and linear history and forbids deleting branches by pushing, \"open\" restores the defaults. Servers can define their own presets.\n\n" +
+			"Without a preset, it prints the settings of the repository, the presets they match, and the available presets.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			if len(args) == 2 {
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				return be.ApplyPolicyPreset(ctx, rn, args[1])
+			}
+
+			p, err := be.RepoPolicy(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			matching := be.MatchingPolicyPresets(p)
+			if len(matching) == 0 {
+				matching = []string{"none"}
+			}
+
+			cmd.Printf("Preset: %s\n", strings.Join(matching, ", "))
+			cmd.Printf("Require signed commits: %t\n", p.RequireSignedCommits)
+			cmd.Printf("Require linear history: %t\n", p.RequireLinearHistory)
+			cmd.Printf("Allow branch deletion: %t\n", p.AllowBranchDeletion)
+			cmd.Printf("Available presets: %s\n", strings.Join(be.PolicyPresetNames(), ", "))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -29,12 +29,12 @@ func PubkeyCommand() *cobra.Command {
 				return err
 			}
 
-			apk, _, err := sshutils.ParseAuthorizedKey(strings.Join(args, " "))
+			apk, comment, err := sshutils.ParseAuthorizedKey(strings.Join(args, " "))
 			if err != nil {
 				return err
 			}
 
-			return be.AddPublicKey(ctx, user.Username(), apk)
+			return be.AddPublicKeyWithComment(ctx, user.Username(), apk, comment)
 		},
 	}
 
@@ -74,9 +74,13 @@ func PubkeyCommand() *cobra.Command {
 				return err
 			}
 
-			pks := user.PublicKeys()
+			pks, err := be.UserPublicKeys(ctx, user.Username())
+			if err != nil {
+				return err
+			}
+
 			for _, pk := range pks {
-				cmd.Println(sshutils.MarshalAuthorizedKey(pk))
+				cmd.Println(authorizedKeyWithComment(pk))
 			}
 
 			return nil
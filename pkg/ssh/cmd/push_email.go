@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func pushEmailCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push-email",
+		Short: "Manage the summary emails sent after pushes",
+	}
+
+	cmd.AddCommand(
+		pushEmailShowCommand(),
+		pushEmailSetCommand(),
+		pushEmailRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func pushEmailShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY",
+		Short:             "Show the push email settings of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			pe, err := be.PushEmail(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			from, smtpURL := pe.From, "(server default)"
+			if from == "" {
+				from = "(server default)"
+			}
+			if pe.SMTPURL != "" {
+				// Don't print the SMTP password.
+				u, err := url.Parse(pe.SMTPURL)
+				if err != nil {
+					return err
+				}
+				smtpURL = u.Redacted()
+			}
+
+			cmd.Printf("To: %s\n", strings.Join(pe.Recipients, ", "))
+			cmd.Printf("From: %s\n", from)
+			cmd.Printf("SMTP URL: %s\n", smtpURL)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func pushEmailSetCommand() *cobra.Command {
+	var to []string
+	var from string
+	var smtpURL string
+
+	cmd := &cobra.Command{
+		Use:               "set REPOSITORY",
+		Short:             "Send a summary email to a list of addresses after every push",
+		Long:              "Send a summary email of the pushed refs, commits, and diffstat to a list of addresses after every push. Emails are delivered in the background and never fail the push. Overriding the SMTP server requires admin access.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			// The server connects to the SMTP server on behalf of the
+			// repository, only admins can point it somewhere else.
+			if smtpURL != "" {
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+			}
+
+			pe, err := be.PushEmail(ctx, args[0])
+			if err == nil && !cmd.Flags().Changed("smtp-url") {
+				// Keep the SMTP server set by an admin.
+				smtpURL = pe.SMTPURL
+			}
+
+			pe.Recipients = to
+			pe.From = from
+			pe.SMTPURL = smtpURL
+			return be.SetPushEmail(ctx, args[0], pe)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&to, "to", "t", nil, "addresses to send push emails to")
+	cmd.Flags().StringVarP(&from, "from", "f", "", "sender address, defaults to the server sender")
+	cmd.Flags().StringVar(&smtpURL, "smtp-url", "", "SMTP server URL, defaults to the server SMTP server")
+
+	return cmd
+}
+
+func pushEmailRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY",
+		Aliases:           []string{"rm"},
+		Short:             "Stop sending push emails for a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			return be.RemovePushEmail(ctx, args[0])
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func descriptionFromReadmeCommand() *cobra.Command {
+	var unset bool
+	cmd := &cobra.Command{
+		Use:   "description-from-readme REPOSITORY [true|false]",
+		Short: "Set or get whether the description is taken from the README",
+		Long: "Set or get whether the description of a repository is set to the first paragraph of its README on push.\n\n" +
+			"Descriptions set explicitly are kept. Use --unset to follow the server default.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+
+			if !unset && len(args) == 1 {
+				enabled, _, err := be.ReadmeDescription(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(enabled)
+				return nil
+			}
+
+			if err := checkIfCollab(cmd, args); err != nil {
+				return err
+			}
+
+			var enabled *bool
+			if !unset {
+				v, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				enabled = &v
+			}
+
+			return be.SetReadmeDescription(ctx, rn, enabled)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "use the server default")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func reconfigureCommand() *cobra.Command {
+	var all bool
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:               "reconfigure [REPOSITORY]",
+		Short:             "Reconcile the git config of repositories",
+		Long:              "Set the baseline git config from the server configuration on a repository, or on every repository with --all. Options a repository changed are kept unless --force is used.",
+		Args:              cobra.MaximumNArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			var repos []proto.Repository
+			switch {
+			case all && len(args) == 0:
+				var err error
+				repos, err = be.Repositories(ctx)
+				if err != nil {
+					return err
+				}
+			case !all && len(args) == 1:
+				repo, err := be.Repository(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				repos = append(repos, repo)
+			default:
+				return errors.New("specify either a repository or --all")
+			}
+
+			for _, repo := range repos {
+				changes, err := be.ReconcileRepoConfig(ctx, repo.Name(), force)
+				if err != nil {
+					return fmt.Errorf("%s: %w", repo.Name(), err)
+				}
+
+				for _, c := range changes {
+					if c.Kept {
+						cmd.Printf("%s: kept %s=%s\n", repo.Name(), c.Key, c.Previous)
+					} else {
+						cmd.Printf("%s: set %s=%s\n", repo.Name(), c.Key, c.Value)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "reconcile every repository")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "overwrite options changed on the repository")
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func redirectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "redirect",
+		Aliases: []string{"redirects"},
+		Short:   "Manage redirects from previous repository names",
+	}
+
+	cmd.AddCommand(
+		redirectListCommand(),
+		redirectRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func redirectListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List the previous names redirecting to a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			redirects, err := be.Redirects(ctx)
+			if err != nil {
+				return err
+			}
+
+			table := table.New().Headers("Name", "Created At", "Expires At")
+			for _, r := range redirects {
+				if r.Repo != repo.Name() {
+					continue
+				}
+
+				expiresAt := "-"
+				if !r.ExpiresAt.IsZero() {
+					expiresAt = humanize.Time(r.ExpiresAt)
+				}
+
+				table = table.Row(r.Name, humanize.Time(r.CreatedAt), expiresAt)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func redirectRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY NAME",
+		Aliases:           []string{"rm", "delete"},
+		Short:             "Remove a redirect from a previous repository name",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cfg := config.FromContext(ctx)
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			// Only allow removing redirects to this repository.
+			name := cfg.SanitizeRepo(args[1])
+			target, err := be.ResolveRedirect(ctx, name)
+			if err != nil {
+				return err
+			}
+			if target != repo.Name() {
+				return proto.ErrRedirectNotFound
+			}
+
+			return be.RemoveRedirect(ctx, name)
+		},
+	}
+
+	return cmd
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
@@ -19,22 +20,56 @@ func RepoCommand(renderer *lipgloss.Renderer) *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		allowArchivesCommand(),
+		allowBranchDeletionCommand(),
+		archiveCommand(),
+		auditCommand(),
+		autoPruneBranchesCommand(),
+		binaryLimitCommand(),
 		blobCommand(renderer),
 		branchCommand(),
+		bundleCommand(),
+		checkCommand(),
 		collabCommand(),
 		commitCommand(renderer),
+		compareCommand(),
+		contributorsCommand(),
 		createCommand(),
 		deleteCommand(),
+		deployKeyCommand(),
 		descriptionCommand(),
+		descriptionFromReadmeCommand(),
+		exportCommand(),
+		filterCommand(),
+		fsckCommand(),
 		hiddenCommand(),
+		immutableTagsCommand(),
 		importCommand(),
+		initCommand(),
+		issueCommand(),
+		lfsArchivesCommand(),
 		listCommand(),
+		mergeStrategyCommand(),
 		mirrorCommand(),
+		policyCommand(),
 		privateCommand(),
 		projectName(),
+		pushEmailCommand(),
+		readAuditCommand(),
+		reconfigureCommand(),
+		redirectCommand(),
 		renameCommand(),
+		requireLinearHistoryCommand(),
+		requireSignedCommitsCommand(),
+		robotsCommand(),
+		showCommand(),
+		sizeCommand(),
+		staleCommand(),
+		statsCommand(),
 		tagCommand(),
+		transferCommand(),
 		treeCommand(),
+		unarchiveCommand(),
 		webhookCommand(),
 	)
 
@@ -71,6 +106,12 @@ func RepoCommand(renderer *lipgloss.Renderer) *cobra.Command {
 					}
 				}
 
+				// The creator might have been deleted since.
+				var creator proto.User
+				if rr.CreatedBy() > 0 {
+					creator, _ = be.UserByID(ctx, rr.CreatedBy())
+				}
+
 				branches, _ := r.Branches()
 				tags, _ := r.Tags()
 
@@ -82,9 +123,16 @@ func RepoCommand(renderer *lipgloss.Renderer) *cobra.Command {
 				cmd.Println("Private:", rr.IsPrivate())
 				cmd.Println("Hidden:", rr.IsHidden())
 				cmd.Println("Mirror:", rr.IsMirror())
+				if rr.IsArchived() {
+					cmd.Println("Archived:", true)
+				}
 				if owner != nil {
 					cmd.Println(strings.TrimSpace(fmt.Sprint("Owner: ", owner.Username())))
 				}
+				cmd.Println("Created At:", rr.CreatedAt().UTC().Format(time.RFC3339))
+				if creator != nil {
+					cmd.Println("Created By:", creator.Username())
+				}
 				cmd.Println("Default Branch:", head.Name().Short())
 				if len(branches) > 0 {
 					cmd.Println("Branches:")
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func robotsCommand() *cobra.Command {
+	var unset bool
+	cmd := &cobra.Command{
+		Use:   "robots REPOSITORY [index|noindex]",
+		Short: "Set or get whether search engines can index a repository",
+		Long: "Set or get whether search engines can index a repository over HTTP. Getting it prints the policy in effect, " +
+			"private repositories are never indexed.\n\n" +
+			"Use --unset to follow the server policy.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			if !unset && len(args) == 1 {
+				r, err := be.Repository(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(be.EffectiveRobotsPolicy(r))
+				return nil
+			}
+
+			if err := checkIfAdmin(cmd, args); err != nil {
+				return err
+			}
+
+			var policy proto.RobotsPolicy
+			if !unset {
+				var err error
+				policy, err = proto.ParseRobotsPolicy(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			return be.SetRobotsPolicy(ctx, rn, policy)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "follow the server robots policy")
+
+	return cmd
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/keygen"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ServerCommand returns the command to manage the server.
+func ServerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "server",
+		Short:             "Manage the server",
+		PersistentPreRunE: checkIfServerAdmin,
+	}
+
+	cmd.AddCommand(
+		hostKeyCommand(),
+		reconcileCommand(),
+	)
+
+	return cmd
+}
+
+func reconcileCommand() *cobra.Command {
+	var fix bool
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Check that the repositories on disk match their metadata",
+		Long: "Check that the repositories on disk match their metadata, listing the repositories without metadata " +
+			"and the metadata of missing repositories.\n\n" +
+			"With --fix, default metadata is created for the repositories without metadata, they're private unless " +
+			"they have a git-daemon-export-ok file, and the metadata and LFS objects of missing repositories are removed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			report, err := be.Reconcile(ctx, fix)
+			if err != nil {
+				return err
+			}
+
+			if len(report.Orphans) == 0 && len(report.Dangling) == 0 {
+				cmd.Println("Repositories match their metadata")
+				return nil
+			}
+
+			orphan, dangling := "missing metadata", "missing on disk"
+			if fix {
+				orphan, dangling = "metadata created", "metadata removed"
+			}
+
+			table := table.New().Headers("Repository", "Status")
+			for _, name := range report.Orphans {
+				table = table.Row(name, orphan)
+			}
+			for _, name := range report.Dangling {
+				table = table.Row(name, dangling)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "create default metadata for orphaned repositories and remove the metadata of missing ones")
+
+	return cmd
+}
+
+func hostKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "hostkey",
+		Aliases: []string{"host-key"},
+		Short:   "Manage the SSH host keys",
+	}
+
+	var keyType string
+	rotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the SSH host key with a new one",
+		Long: "Replace the SSH host key with a new one. The previous key is still offered for the host key grace period, " +
+			"so clients can update their known_hosts.\n\n" +
+			"SSH offers a single key per algorithm: when the new key has the same type as the previous one, " +
+			"the previous key is offered until the grace period ends, add the new key to known_hosts in the meantime.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var kt keygen.KeyType
+			switch keyType {
+			case "ed25519":
+				kt = keygen.Ed25519
+			case "ecdsa":
+				kt = keygen.ECDSA
+			case "rsa":
+				kt = keygen.RSA
+			default:
+				return fmt.Errorf("invalid key type: %q", keyType)
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			hk, err := be.RotateHostKey(ctx, kt)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("New host key: %s\n", gossh.FingerprintSHA256(hk.PublicKey))
+			cmd.Print(string(gossh.MarshalAuthorizedKey(hk.PublicKey)))
+			return nil
+		},
+	}
+	rotateCmd.Flags().StringVarP(&keyType, "type", "t", "ed25519", "type of the new key: ed25519, ecdsa, or rsa")
+
+	var all bool
+	retireCmd := &cobra.Command{
+		Use:   "retire",
+		Short: "Remove the previous host keys past their grace period",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			removed, err := be.RetireHostKeys(ctx, all)
+			if err != nil {
+				return err
+			}
+
+			for _, hk := range removed {
+				cmd.Printf("Retired host key %s\n", gossh.FingerprintSHA256(hk.PublicKey))
+			}
+			return nil
+		},
+	}
+	retireCmd.Flags().BoolVarP(&all, "all", "a", false, "also remove the keys still in their grace period")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:     "list",
+			Aliases: []string{"ls"},
+			Short:   "List the SSH host keys",
+			Args:    cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ctx := cmd.Context()
+				be := backend.FromContext(ctx)
+				keys, err := be.HostKeys(ctx)
+				if err != nil {
+					return err
+				}
+
+				table := table.New().Headers("Fingerprint", "Type", "Status", "Expires")
+				for _, hk := range keys {
+					status, expires := "current", "-"
+					if hk.Retired() {
+						status, expires = "retired", humanize.Time(hk.ExpiresAt)
+					}
+					table = table.Row(gossh.FingerprintSHA256(hk.PublicKey), hk.PublicKey.Type(), status, expires)
+				}
+				cmd.Println(table)
+				return nil
+			},
+		},
+		rotateCmd,
+		retireCmd,
+	)
+
+	return cmd
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/version"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ServerInfoCommand returns a command that shows information about the
+// server.
+func ServerInfoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "server-info",
+		Short: "Show server information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			cfg := config.FromContext(ctx)
+
+			info, err := be.ServerInfo(ctx)
+			if err != nil {
+				return err
+			}
+
+			v := version.Version
+			if v == "" {
+				v = "unknown"
+			}
+
+			cmd.Printf("Name: %s\n", cfg.Name)
+			cmd.Printf("Version: %s\n", v)
+			if kp, err := config.KeyPair(cfg); err == nil {
+				cmd.Printf("Host key: %s\n", gossh.FingerprintSHA256(kp.PublicKey()))
+			}
+			cmd.Printf("Public repositories: %d\n", info.PublicRepos)
+			cmd.Printf("Protocols:\n")
+			if cfg.SSH.Enabled {
+				cmd.Printf("  ssh: %s\n", cfg.SSH.PublicURL)
+			}
+			if cfg.HTTP.Enabled {
+				cmd.Printf("  http: %s\n", cfg.HTTP.PublicURL)
+			}
+			if cfg.Git.Enabled {
+				cmd.Printf("  git: %s\n", cfg.Git.PublicURL)
+			}
+
+			// Only admins see the totals.
+			if err := checkIfAdmin(cmd, nil); err != nil {
+				return nil //nolint:nilerr
+			}
+
+			size, err := be.DiskUsage(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Repositories: %d\n", info.Repos)
+			cmd.Printf("Users: %d\n", info.Users)
+			cmd.Printf("Disk usage: %s\n", humanize.IBytes(uint64(size))) //nolint:gosec
+			return nil
+		},
+	}
+
+	return cmd
+}
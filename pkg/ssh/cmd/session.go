@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/spf13/cobra"
+)
+
+// SessionCommand returns a command that manages active SSH sessions.
+func SessionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "session",
+		Aliases:           []string{"sessions"},
+		Short:             "Manage active sessions",
+		PersistentPreRunE: checkIfServerAdmin,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List active sessions",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				sessions := sshutils.SessionsFromContext(cmd.Context())
+				if sessions == nil {
+					return fmt.Errorf("session registry not available")
+				}
+
+				table := table.New().Headers("ID", "User", "Remote Address", "Operation", "Duration")
+				for _, s := range sessions.List() {
+					table = table.Row(
+						strconv.FormatInt(s.ID, 10),
+						s.User,
+						s.RemoteAddr,
+						s.Operation,
+						s.Duration().Truncate(time.Second).String(),
+					)
+				}
+				cmd.Println(table)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "kill ID",
+			Short: "Forcibly terminate an active session",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				sessions := sshutils.SessionsFromContext(cmd.Context())
+				if sessions == nil {
+					return fmt.Errorf("session registry not available")
+				}
+
+				id, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid session id: %s", args[0])
+				}
+
+				if !sessions.Kill(id) {
+					return fmt.Errorf("session not found: %d", id)
+				}
+
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func showCommand() *cobra.Command {
+	var stat, nameOnly bool
+
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY REVISION",
+		Short:             "Show a commit and its changes",
+		Long:              "Show the metadata and the diff of a commit, like git show.",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			r, err := repo.Open()
+			if err != nil {
+				return err
+			}
+
+			sha, err := r.ResolveCommit(args[1])
+			if err != nil {
+				return err
+			}
+
+			showArgs := []string{"show", "--no-color"}
+			switch {
+			case nameOnly:
+				showArgs = append(showArgs, "--name-only")
+			case stat:
+				showArgs = append(showArgs, "--stat")
+			}
+			showArgs = append(showArgs, sha, "--")
+
+			// Stream the output, large diffs aren't read into memory.
+			return git.NewCommand(showArgs...).
+				WithContext(ctx).WithTimeout(-1).
+				RunInDirWithOptions(r.Path, git.RunInDirOptions{
+					Stdout: cmd.OutOrStdout(),
+				})
+		},
+	}
+
+	cmd.Flags().BoolVar(&stat, "stat", false, "show a diffstat instead of the patch")
+	cmd.Flags().BoolVar(&nameOnly, "name-only", false, "show only the names of the changed files")
+	cmd.MarkFlagsMutuallyExclusive("stat", "name-only")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func requireSignedCommitsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "require-signed-commits REPOSITORY [true|false]",
+		Short:             "Set or get whether pushes must only contain signed commits",
+		Aliases:           []string{"require-signed"},
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := args[0]
+
+			switch len(args) {
+			case 1:
+				require, err := be.RequireSignedCommits(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(require)
+			case 2:
+				require, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetRequireSignedCommits(ctx, rn, require); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
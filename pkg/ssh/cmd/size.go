@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func sizeCommand() *cobra.Command {
+	var detailed, asJSON bool
+
+	cmd := &cobra.Command{
+		Use:               "size REPOSITORY",
+		Short:             "Show repository disk usage",
+		Long:              "Show repository disk usage. Use --detailed to break it down into pack files, loose objects, refs, other files, and Git LFS objects.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			size, err := be.RepositorySizeDetailed(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				bts, err := json.Marshal(size)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+				return nil
+			}
+
+			cmd.Println(humanize.IBytes(uint64(size.Total()))) //nolint:gosec
+			if !detailed {
+				return nil
+			}
+
+			for _, s := range []struct {
+				name string
+				size int64
+			}{
+				{"Packs", size.Packs},
+				{"Loose objects", size.Loose},
+				{"Refs", size.Refs},
+				{"Other", size.Other},
+				{"LFS", size.LFS},
+			} {
+				cmd.Printf("  %s: %s\n", s.name, humanize.IBytes(uint64(s.size))) //nolint:gosec
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&detailed, "detailed", "d", false, "break down the disk usage")
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output the breakdown as JSON")
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func staleCommand() *cobra.Command {
+	var days int
+	var empty, del, yes bool
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "List repositories without recent pushes or without commits",
+		Long: "List repositories without pushes in the last days, and repositories without any commits.\n\n" +
+			"With --delete, the listed repositories are moved to the trash once --yes is given.",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if days < 0 {
+				return fmt.Errorf("invalid number of days: %d", days)
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			before := time.Now().AddDate(0, 0, -days)
+			repos, err := be.StaleRepositories(ctx, before, empty)
+			if err != nil {
+				return err
+			}
+
+			if !del {
+				table := table.New().Headers("Repository", "Last Activity", "Empty")
+				for _, r := range repos {
+					table = table.Row(r.Name, humanize.Time(r.LastActivity), fmt.Sprint(r.Empty))
+				}
+				cmd.Println(table)
+				return nil
+			}
+
+			if !yes {
+				for _, r := range repos {
+					cmd.Println(r.Name)
+				}
+				cmd.PrintErrf("Run again with --yes to move %d repositories to the trash.\n", len(repos))
+				return nil
+			}
+
+			for _, r := range repos {
+				trash, err := be.TrashRepository(ctx, r.Name)
+				if err != nil {
+					return fmt.Errorf("failed to delete %s: %w", r.Name, err)
+				}
+				cmd.Printf("Moved %s to %s\n", r.Name, trash)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&days, "days", "d", 180, "list repositories without pushes in this many days")
+	cmd.Flags().BoolVarP(&empty, "empty", "e", false, "only list repositories without commits")
+	cmd.Flags().BoolVar(&del, "delete", false, "move the listed repositories to the trash")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "confirm --delete")
+
+	return cmd
+}
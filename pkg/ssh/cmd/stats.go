@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func statsCommand() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:               "stats REPOSITORY",
+		Short:             "Show repository object statistics",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rr, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			stats, err := be.RepositoryStats(ctx, rr)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				bts, err := json.Marshal(stats)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+				return nil
+			}
+
+			cmd.Println("Objects:", stats.Objects())
+			cmd.Printf("Loose objects: %d (%.1f%%)\n", stats.Count, stats.LooseRatio()*100)
+			cmd.Println("Packed objects:", stats.InPack)
+			cmd.Println("Packs:", stats.Packs)
+			cmd.Println("Prune packable:", stats.PrunePackable)
+			cmd.Println("Garbage:", stats.Garbage)
+			cmd.Println("Size:", humanize.IBytes(uint64(stats.TotalSize()))) //nolint:gosec
+			cmd.Printf("  Loose: %s, Packed: %s, Garbage: %s\n",
+				humanize.IBytes(uint64(stats.Size)),        //nolint:gosec
+				humanize.IBytes(uint64(stats.SizePack)),    //nolint:gosec
+				humanize.IBytes(uint64(stats.SizeGarbage)), //nolint:gosec
+			)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON")
+
+	return cmd
+}
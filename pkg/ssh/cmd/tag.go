@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/log"
@@ -19,6 +20,7 @@ func tagCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		tagListCommand(),
+		tagCreateCommand(),
 		tagDeleteCommand(),
 	)
 
@@ -58,6 +60,36 @@ func tagListCommand() *cobra.Command {
 	return cmd
 }
 
+func tagCreateCommand() *cobra.Command {
+	var message string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "create REPOSITORY TAG REF",
+		Short: "Create a tag",
+		Long: "Create a tag pointing to a branch, tag, or commit. The tag is annotated when a message is given. Only repository admins can replace an existing tag, unless tags are immutable.\n\n" +
+			"The tag goes through the same checks as pushing it, e.g. immutable tags, archived repositories, and repository checks. Custom git hooks don't run.",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+			rr, err := be.Repository(ctx, rn)
+			if err != nil {
+				return err
+			}
+
+			return be.CreateTag(ctx, rr, proto.UserFromContext(ctx), args[1], args[2], message, force)
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "create an annotated tag with this message")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "replace an existing tag")
+
+	return cmd
+}
+
 func tagDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "delete REPOSITORY TAG",
@@ -95,6 +127,12 @@ func tagDeleteCommand() *cobra.Command {
 				return git.ErrReferenceNotExist
 			}
 
+			if immutable, err := be.ImmutableTags(ctx, rn); err != nil {
+				return err
+			} else if immutable {
+				return fmt.Errorf("%w in %s, tag %q can't be moved or deleted", proto.ErrTagImmutable, rn, tag)
+			}
+
 			tagCommit, err := r.TagCommit(tag)
 			if err != nil {
 				log.Errorf("failed to get tag commit: %s", err)
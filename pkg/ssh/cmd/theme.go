@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
+	"github.com/spf13/cobra"
+)
+
+// ThemeCommand returns a command that shows or sets the user's UI theme.
+func ThemeCommand() *cobra.Command {
+	var unset, list bool
+	cmd := &cobra.Command{
+		Use:   "theme [THEME]",
+		Short: "Show or set your UI theme",
+		Long: "Show or set your UI theme.\n\nThemes: " +
+			strings.Join(styles.ThemeNames(), ", ") + ".",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list {
+				for _, name := range styles.ThemeNames() {
+					cmd.Println(name)
+				}
+				return nil
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			cfg := config.FromContext(ctx)
+			pk := sshutils.PublicKeyFromContext(ctx)
+			user, err := be.UserByPublicKey(ctx, pk)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case unset:
+				return be.SetTheme(ctx, user.Username(), "")
+			case len(args) == 1:
+				return be.SetTheme(ctx, user.Username(), args[0])
+			}
+
+			theme := user.Theme()
+			if theme == "" {
+				theme = cfg.UI.Theme
+			}
+			cmd.Println(theme)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&unset, "unset", "u", false, "use the server default theme")
+	cmd.Flags().BoolVarP(&list, "list", "l", false, "list the available themes")
+
+	return cmd
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/spf13/cobra"
+)
+
+func transferCommand() *cobra.Command {
+	var keepOwner bool
+
+	cmd := &cobra.Command{
+		Use:               "transfer REPOSITORY USERNAME",
+		Short:             "Transfer the ownership of a repository to another user",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfOwner,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			username := args[1]
+
+			return be.TransferRepository(ctx, repo, username, keepOwner)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&keepOwner, "keep-collaborator", "k", false, "keep the current owner as a collaborator")
+
+	return cmd
+}
+
+// checkIfOwner checks that the user is a server admin or the owner of the
+// repository.
+func checkIfOwner(cmd *cobra.Command, args []string) error {
+	if err := checkIfReadable(cmd, args); err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cfg := config.FromContext(ctx)
+	be := backend.FromContext(ctx)
+	pk := sshutils.PublicKeyFromContext(ctx)
+	if IsPublicKeyAdmin(cfg, pk) {
+		return nil
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUnauthorized
+	}
+
+	if user.IsAdmin() {
+		return nil
+	}
+
+	r, err := be.Repository(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if r.UserID() != user.ID() {
+		return proto.ErrUnauthorized
+	}
+
+	return nil
+}
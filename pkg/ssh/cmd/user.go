@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/caarlos0/duration"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
 )
@@ -28,21 +35,24 @@ func UserCommand() *cobra.Command {
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var pubkeys []ssh.PublicKey
+			var comments []string
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
 			username := args[0]
 			if key != "" {
-				pk, _, err := sshutils.ParseAuthorizedKey(key)
+				pk, comment, err := sshutils.ParseAuthorizedKey(key)
 				if err != nil {
 					return err
 				}
 
 				pubkeys = []ssh.PublicKey{pk}
+				comments = []string{comment}
 			}
 
 			opts := proto.UserOptions{
-				Admin:      admin,
-				PublicKeys: pubkeys,
+				Admin:             admin,
+				PublicKeys:        pubkeys,
+				PublicKeyComments: comments,
 			}
 
 			_, err := be.CreateUser(ctx, username, opts)
@@ -100,12 +110,12 @@ func UserCommand() *cobra.Command {
 			be := backend.FromContext(ctx)
 			username := args[0]
 			pubkey := strings.Join(args[1:], " ")
-			pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
+			pk, comment, err := sshutils.ParseAuthorizedKey(pubkey)
 			if err != nil {
 				return err
 			}
 
-			return be.AddPublicKey(ctx, username, pk)
+			return be.AddPublicKeyWithComment(ctx, username, pk, comment)
 		},
 	}
 
@@ -157,19 +167,113 @@ func UserCommand() *cobra.Command {
 				return err
 			}
 
+			pks, err := be.UserPublicKeys(ctx, user.Username())
+			if err != nil {
+				return err
+			}
+
 			isAdmin := user.IsAdmin()
+			used, limit, err := be.RepoQuota(ctx, user)
+			if err != nil {
+				return err
+			}
 
 			cmd.Printf("Username: %s\n", user.Username())
 			cmd.Printf("Admin: %t\n", isAdmin)
+			if user.IsPending() {
+				cmd.Printf("Pending: true\n")
+			}
+			cmd.Printf("Repositories: %s\n", formatRepoQuota(used, limit))
 			cmd.Printf("Public keys:\n")
-			for _, pk := range user.PublicKeys() {
-				cmd.Printf("  %s\n", sshutils.MarshalAuthorizedKey(pk))
+			for _, pk := range pks {
+				cmd.Printf("  %s\n", authorizedKeyWithComment(pk))
 			}
 
 			return nil
 		},
 	}
 
+	userAccessCommand := &cobra.Command{
+		Use:   "access USERNAME [no-access|read-only|read-write]",
+		Short: "Set or get the baseline access level of a user",
+		Long: "Set or get the baseline access level of a user. It applies to the public repositories the user doesn't " +
+			"collaborate on and to creating repositories: read-write users can read public repositories and create " +
+			"repositories, read-only users can only read public repositories, and no-access users can't do either.",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			username := args[0]
+
+			switch len(args) {
+			case 1:
+				user, err := be.User(ctx, username)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(user.AccessLevel())
+			case 2:
+				level := access.ParseAccessLevel(args[1])
+				if level < 0 {
+					return access.ErrInvalidAccessLevel
+				}
+
+				return be.SetUserAccessLevel(ctx, username, level)
+			}
+			return nil
+		},
+	}
+
+	userPendingCommand := &cobra.Command{
+		Use:               "pending",
+		Short:             "List self-enrolled users waiting for approval",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			users, err := be.PendingUsers(ctx)
+			if err != nil {
+				return err
+			}
+
+			sort.Strings(users)
+			for _, u := range users {
+				cmd.Println(u)
+			}
+
+			return nil
+		},
+	}
+
+	userApproveCommand := &cobra.Command{
+		Use:               "approve USERNAME",
+		Short:             "Approve a self-enrolled user",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.ApproveUser(ctx, args[0])
+		},
+	}
+
+	userRejectCommand := &cobra.Command{
+		Use:               "reject USERNAME",
+		Short:             "Reject a self-enrolled user and delete them",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfServerAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.RejectUser(ctx, args[0])
+		},
+	}
+
 	userSetUsernameCommand := &cobra.Command{
 		Use:               "set-username USERNAME NEW_USERNAME",
 		Short:             "Change a user's username",
@@ -186,11 +290,17 @@ func UserCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		userAccessCommand,
+		userApproveCommand,
 		userCreateCommand,
 		userAddPubkeyCommand,
 		userInfoCommand,
+		userKeyCommand(),
 		userListCommand,
 		userDeleteCommand,
+		userPendingCommand,
+		userRejectCommand,
+		userReposCommand(),
 		userRemovePubkeyCommand,
 		userSetAdminCommand,
 		userSetUsernameCommand,
@@ -198,3 +308,275 @@ func UserCommand() *cobra.Command {
 
 	return cmd
 }
+
+func userKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "key",
+		Aliases:           []string{"keys"},
+		Short:             "Manage user public keys",
+		PersistentPreRunE: checkIfServerAdmin,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:     "list USERNAME",
+			Aliases: []string{"ls"},
+			Short:   "List the public keys of a user",
+			Args:    cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := cmd.Context()
+				be := backend.FromContext(ctx)
+				pks, err := be.UserPublicKeys(ctx, args[0])
+				if err != nil {
+					return err
+				}
+
+				table := table.New().Headers("Fingerprint", "Type", "Comment", "Last Used", "Git Only")
+				for _, pk := range pks {
+					table = table.Row(
+						pk.ShortFingerprint(),
+						pk.Key.Type(),
+						pk.Comment,
+						keyLastUsed(pk),
+						strconv.FormatBool(pk.GitOnly),
+					)
+				}
+				cmd.Println(table)
+				return nil
+			},
+		},
+		userKeyAuditCommand(),
+		&cobra.Command{
+			Use:   "git-only FINGERPRINT [true|false]",
+			Short: "Set or get whether a public key can only be used for git commands",
+			Long:  "Set or get whether a public key can only be used for git commands, e.g. for service accounts. Git-only keys can't open the TUI or run other commands. The fingerprint can be abbreviated and the SHA256: prefix is optional.",
+			Args:  cobra.RangeArgs(1, 2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := cmd.Context()
+				be := backend.FromContext(ctx)
+
+				switch len(args) {
+				case 1:
+					pk, err := be.PublicKeyByFingerprint(ctx, args[0])
+					if err != nil {
+						return err
+					}
+
+					cmd.Println(pk.GitOnly)
+				case 2:
+					gitOnly, err := strconv.ParseBool(args[1])
+					if err != nil {
+						return err
+					}
+					if _, err := be.SetPublicKeyGitOnly(ctx, args[0], gitOnly); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "policy",
+			Short: "List public keys that don't meet the key policy",
+			Long:  "List the public keys of all users that don't meet the minimum key strength or allowed key algorithms, to replace them before enforcing the key policy at authentication.",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ctx := cmd.Context()
+				be := backend.FromContext(ctx)
+				violations, err := be.KeyPolicyViolations(ctx)
+				if err != nil {
+					return err
+				}
+
+				table := table.New().Headers("User", "Fingerprint", "Type", "Bits", "Reason")
+				for _, v := range violations {
+					table = table.Row(
+						v.Username,
+						v.Key.ShortFingerprint(),
+						v.Key.Key.Type(),
+						strconv.Itoa(sshutils.KeySize(v.Key.Key)),
+						v.Reason,
+					)
+				}
+				cmd.Println(table)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "remove FINGERPRINT",
+			Short: "Remove a public key by its fingerprint",
+			Long:  "Remove a public key by its fingerprint. The fingerprint can be abbreviated and the SHA256: prefix is optional.",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := cmd.Context()
+				be := backend.FromContext(ctx)
+				pk, err := be.RemovePublicKeyByFingerprint(ctx, args[0])
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("Removed public key %s\n", pk.Fingerprint())
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
+
+func userKeyAuditCommand() *cobra.Command {
+	var unusedFor string
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "List public keys that weren't used recently",
+		Long:  "List the public keys of all users that weren't used to authenticate for longer than --unused-for, including keys that were never used.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			d, err := duration.Parse(unusedFor)
+			if err != nil {
+				return err
+			}
+
+			pks, err := be.PublicKeys(ctx)
+			if err != nil {
+				return err
+			}
+
+			usernames := map[int64]string{}
+			cutoff := time.Now().Add(-d)
+			table := table.New().Headers("User", "Fingerprint", "Type", "Comment", "Last Used")
+			for _, pk := range pks {
+				if pk.LastUsedAt.After(cutoff) {
+					continue
+				}
+
+				username, ok := usernames[pk.UserID]
+				if !ok {
+					user, err := be.UserByID(ctx, pk.UserID)
+					if err != nil {
+						return err
+					}
+					username = user.Username()
+					usernames[pk.UserID] = username
+				}
+
+				table = table.Row(
+					username,
+					pk.ShortFingerprint(),
+					pk.Key.Type(),
+					pk.Comment,
+					keyLastUsed(pk),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&unusedFor, "unused-for", "90d", "list keys unused for longer than this (e.g. 1y, 3mo, 2w, 5d4h)")
+
+	return cmd
+}
+
+// keyLastUsed returns when a public key was last used in a human readable
+// way.
+func keyLastUsed(pk proto.PublicKey) string {
+	if pk.LastUsedAt.IsZero() {
+		return "never"
+	}
+	return humanize.Time(pk.LastUsedAt)
+}
+
+// authorizedKeyWithComment returns the authorized key line of a public key
+// including its comment.
+func authorizedKeyWithComment(pk proto.PublicKey) string {
+	ak := sshutils.MarshalAuthorizedKey(pk.Key)
+	if pk.Comment != "" {
+		ak += " " + pk.Comment
+	}
+	return ak
+}
+
+// userRepo is the JSON output of the user repos command.
+type userRepo struct {
+	Repo         string              `json:"repo"`
+	AccessLevel  access.AccessLevel  `json:"access_level"`
+	Reason       access.Reason       `json:"reason"`
+	Owner        bool                `json:"owner"`
+	Collaborator *access.AccessLevel `json:"collaborator,omitempty"`
+}
+
+func userReposCommand() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "repos USERNAME",
+		Short: "List the repositories a user owns or collaborates on",
+		Long: "List the repositories a user owns or collaborates on, with the effective access level of the user and the rule that granted it. " +
+			"Users can list their own repositories, listing other users' requires admin access.",
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			// Users can audit their own access.
+			if u := proto.UserFromContext(ctx); u != nil && strings.EqualFold(u.Username(), args[0]) {
+				return nil
+			}
+			return checkIfServerAdmin(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			user, err := be.User(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			repos, err := be.UserRepos(ctx, user)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				res := make([]userRepo, 0, len(repos))
+				for _, r := range repos {
+					ur := userRepo{
+						Repo:        r.Repo,
+						AccessLevel: r.AccessLevel,
+						Reason:      r.Reason,
+						Owner:       r.Owner,
+					}
+					if r.Collaborator >= 0 {
+						ur.Collaborator = &r.Collaborator
+					}
+					res = append(res, ur)
+				}
+				bts, err := json.Marshal(res)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bts))
+				return nil
+			}
+
+			table := table.New().Headers("Repository", "Access", "Reason", "Owner", "Collaborator")
+			for _, r := range repos {
+				owner, collab := "no", "-"
+				if r.Owner {
+					owner = "yes"
+				}
+				if r.Collaborator >= 0 {
+					collab = r.Collaborator.String()
+				}
+				table = table.Row(r.Repo, r.AccessLevel.String(), r.Reason.String(), owner, collab)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&asJSON, "json", "j", false, "output as JSON")
+
+	return cmd
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss/table"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
@@ -25,7 +27,9 @@ func webhookCommand() *cobra.Command {
 		webhookCreateCommand(),
 		webhookDeleteCommand(),
 		webhookUpdateCommand(),
+		webhookTemplateCommand(),
 		webhookDeliveriesCommand(),
+		webhookRedeliverCommand(),
 	)
 
 	return cmd
@@ -251,13 +255,66 @@ func webhookUpdateCommand() *cobra.Command {
 	return cmd
 }
 
-func webhookDeliveriesCommand() *cobra.Command {
+func webhookTemplateCommand() *cobra.Command {
+	var reset bool
 	cmd := &cobra.Command{
-		Use:     "deliveries",
-		Short:   "Manage webhook deliveries",
-		Aliases: []string{"delivery", "deliver"},
+		Use:   "template REPOSITORY WEBHOOK_ID [TEMPLATE]",
+		Short: "Get or set the payload template of a repository webhook",
+		Long: "Get or set the payload template of a repository webhook.\n\n" +
+			"Templates use the Go text/template syntax and are executed with the event payload, " +
+			"the json function encodes a value as JSON. Use - to read the template from stdin.",
+		Args:              cobra.RangeArgs(2, 3),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid webhook ID: %w", err)
+			}
+
+			switch {
+			case reset:
+				return be.SetWebhookTemplate(ctx, repo, id, "")
+			case len(args) == 2:
+				wh, err := be.Webhook(ctx, repo, id)
+				if err != nil {
+					return err
+				}
+
+				cmd.Print(wh.Template)
+				return nil
+			}
+
+			tmpl := args[2]
+			if tmpl == "-" {
+				b, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				tmpl = string(b)
+			}
+
+			return be.SetWebhookTemplate(ctx, repo, id, tmpl)
+		},
 	}
 
+	cmd.Flags().BoolVarP(&reset, "reset", "r", false, "restore the default payload")
+
+	return cmd
+}
+
+func webhookDeliveriesCommand() *cobra.Command {
+	cmd := webhookDeliveriesListCommand()
+	cmd.Use = "deliveries REPOSITORY WEBHOOK_ID"
+	cmd.Short = "List and manage webhook deliveries"
+	cmd.Aliases = []string{"delivery", "deliver"}
+
 	cmd.AddCommand(
 		webhookDeliveriesListCommand(),
 		webhookDeliveriesRedeliverCommand(),
@@ -271,31 +328,44 @@ func webhookDeliveriesListCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "list REPOSITORY WEBHOOK_ID",
 		Short:             "List webhook deliveries",
+		Long:              "List the recent deliveries of a webhook, latest first, with their response status, duration, and the start of the response body or the request error.",
 		Args:              cobra.ExactArgs(2),
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
 			id, err := strconv.ParseInt(args[1], 10, 64)
 			if err != nil {
 				return fmt.Errorf("invalid webhook ID: %w", err)
 			}
 
-			dels, err := be.ListWebhookDeliveries(ctx, id)
+			dels, err := be.ListWebhookDeliveries(ctx, repo, id)
 			if err != nil {
 				return err
 			}
 
-			table := table.New().Headers("Status", "ID", "Event", "Created At")
+			table := table.New().Headers("Status", "ID", "Event", "Response", "Duration", "Body", "Created At")
 			for _, d := range dels {
 				status := "❌"
 				if d.ResponseStatus >= 200 && d.ResponseStatus < 300 {
 					status = "✅"
 				}
+				body := d.ResponseBody
+				if d.RequestError.Valid && d.RequestError.String != "" {
+					body = d.RequestError.String
+				}
 				table = table.Row(
 					status,
 					d.ID.String(),
 					d.Event.String(),
+					strconv.Itoa(d.ResponseStatus),
+					(time.Duration(d.DurationMS) * time.Millisecond).String(),
+					deliverySnippet(body),
 					humanize.Time(d.CreatedAt),
 				)
 			}
@@ -307,10 +377,22 @@ func webhookDeliveriesListCommand() *cobra.Command {
 	return cmd
 }
 
+// deliverySnippet returns the start of the first line of a delivery
+// response body.
+func deliverySnippet(body string) string {
+	const maxLen = 40
+	body, _, _ = strings.Cut(strings.TrimSpace(body), "\n")
+	if r := []rune(body); len(r) > maxLen {
+		body = string(r[:maxLen-1]) + "…"
+	}
+	return body
+}
+
 func webhookDeliveriesRedeliverCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "redeliver REPOSITORY WEBHOOK_ID DELIVERY_ID",
 		Short:             "Redeliver a webhook delivery",
+		Args:              cobra.ExactArgs(3),
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
@@ -337,14 +419,47 @@ func webhookDeliveriesRedeliverCommand() *cobra.Command {
 	return cmd
 }
 
+func webhookRedeliverCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "redeliver REPOSITORY DELIVERY_ID",
+		Short:             "Redeliver a webhook delivery",
+		Long:              "Redeliver a webhook delivery, of any webhook of the repository, with the same payload. The new attempt is added to the webhook deliveries.",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			delID, err := uuid.Parse(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid delivery ID: %w", err)
+			}
+
+			return be.RedeliverRepositoryWebhookDelivery(ctx, repo, delID)
+		},
+	}
+
+	return cmd
+}
+
 func webhookDeliveriesGetCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "get REPOSITORY WEBHOOK_ID DELIVERY_ID",
 		Short:             "Get a webhook delivery",
+		Args:              cobra.ExactArgs(3),
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
 			id, err := strconv.ParseInt(args[1], 10, 64)
 			if err != nil {
 				return fmt.Errorf("invalid webhook ID: %w", err)
@@ -355,7 +470,7 @@ func webhookDeliveriesGetCommand() *cobra.Command {
 				return fmt.Errorf("invalid delivery ID: %w", err)
 			}
 
-			del, err := be.WebhookDelivery(ctx, id, delID)
+			del, err := be.WebhookDelivery(ctx, repo, id, delID)
 			if err != nil {
 				return err
 			}
@@ -378,8 +493,10 @@ func webhookDeliveriesGetCommand() *cobra.Command {
 				fmt.Fprintf(out, "  %s\n", b) //nolint:errcheck
 			}
 
-			fmt.Fprintf(out, "Response Status: %d\n", del.ResponseStatus) //nolint:errcheck
-			fmt.Fprintf(out, "Response Headers:\n")                       //nolint:errcheck
+			fmt.Fprintf(out, "Response Status: %d\n", del.ResponseStatus)                      //nolint:errcheck
+			fmt.Fprintf(out, "Duration: %s\n", time.Duration(del.DurationMS)*time.Millisecond) //nolint:errcheck
+			fmt.Fprintf(out, "Created At: %s\n", del.CreatedAt.UTC().Format(time.RFC3339))     //nolint:errcheck
+			fmt.Fprintf(out, "Response Headers:\n")                                            //nolint:errcheck
 			resHeaders := strings.Split(del.ResponseHeaders, "\n")
 			for _, h := range resHeaders {
 				fmt.Fprintf(out, "  %s\n", h) //nolint:errcheck
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/spf13/cobra"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// WhoamiCommand returns a command that shows the identity of the session.
+func WhoamiCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show who you are authenticated as",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			pk := sshutils.PublicKeyFromContext(ctx)
+			user := proto.UserFromContext(ctx)
+
+			username := "anonymous"
+			if user != nil {
+				username = user.Username()
+			}
+
+			cmd.Printf("Username: %s\n", username)
+			if pk != nil {
+				cmd.Printf("Fingerprint: %s\n", gossh.FingerprintSHA256(pk))
+			}
+			// The access level of a repository that doesn't exist is the
+			// server-wide one, i.e. whether the user can create repositories.
+			cmd.Printf("Access: %s\n", be.AccessLevelForUser(ctx, "", user))
+			cmd.Printf("Admin: %t\n", user != nil && user.IsAdmin())
+			if user != nil && user.IsPending() {
+				cmd.Printf("Pending: true\n")
+			}
+			if user != nil {
+				used, limit, err := be.RepoQuota(ctx, user)
+				if err != nil {
+					return err
+				}
+				cmd.Printf("Repositories: %s\n", formatRepoQuota(used, limit))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// formatRepoQuota formats the number of repositories a user owns and the
+// limit, if any.
+func formatRepoQuota(used, limit int) string {
+	if limit == 0 {
+		return strconv.Itoa(used)
+	}
+	return fmt.Sprintf("%d of %d", used, limit)
+}
@@ -0,0 +1,56 @@
+package ssh
+
+import (
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// trueColorTerms are the TERM values of terminals supporting 24-bit colors
+// that don't say so in their name.
+var trueColorTerms = []string{
+	"alacritty",
+	"contour",
+	"foot",
+	"wezterm",
+	"xterm-ghostty",
+	"xterm-kitty",
+}
+
+// colorProfile returns the color profile of a client terminal, detected from
+// the TERM of its PTY and the COLORTERM and NO_COLOR variables it sent.
+func colorProfile(term string, environ []string) termenv.Profile {
+	getenv := func(key string) string {
+		for _, kv := range environ {
+			if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+				return v
+			}
+		}
+		return ""
+	}
+
+	term = strings.ToLower(term)
+	if getenv("NO_COLOR") != "" || term == "" || term == "dumb" {
+		return termenv.Ascii
+	}
+
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return termenv.TrueColor
+	}
+
+	for _, t := range trueColorTerms {
+		if term == t || strings.HasPrefix(term, t+"-") {
+			return termenv.TrueColor
+		}
+	}
+
+	switch {
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.HasSuffix(term, "-direct"):
+		return termenv.TrueColor
+	case strings.Contains(term, "256color"):
+		return termenv.ANSI256
+	default:
+		return termenv.ANSI
+	}
+}
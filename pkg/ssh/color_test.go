@@ -0,0 +1,35 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestColorProfile(t *testing.T) {
+	cases := []struct {
+		term    string
+		environ []string
+		want    termenv.Profile
+	}{
+		{"", nil, termenv.Ascii},
+		{"dumb", nil, termenv.Ascii},
+		{"xterm-256color", []string{"NO_COLOR=1"}, termenv.Ascii},
+		{"xterm-256color", []string{"COLORTERM=truecolor"}, termenv.TrueColor},
+		{"screen", []string{"COLORTERM=24bit"}, termenv.TrueColor},
+		{"xterm-kitty", nil, termenv.TrueColor},
+		{"wezterm", nil, termenv.TrueColor},
+		{"xterm-direct", nil, termenv.TrueColor},
+		{"xterm-256color", []string{"COLORTERM=yes"}, termenv.ANSI256},
+		{"tmux-256color", nil, termenv.ANSI256},
+		{"xterm", nil, termenv.ANSI},
+		{"linux", nil, termenv.ANSI},
+		{"vt100", []string{"NO_COLOR="}, termenv.ANSI},
+	}
+
+	for _, c := range cases {
+		if got := colorProfile(c.term, c.environ); got != c.want {
+			t.Errorf("colorProfile(%q, %q) = %v, want %v", c.term, c.environ, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package ssh
+
+import (
+	"strings"
+
+	"github.com/anmitsu/go-shlex"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/git"
+)
+
+// gitServices are the git commands clients run over SSH.
+var gitServices = []string{
+	git.UploadPackService.String(),
+	git.UploadArchiveService.String(),
+	git.ReceivePackService.String(),
+	git.LFSTransferService.String(),
+	git.LFSAuthenticateService,
+}
+
+// gitCommand returns the normalized args of a git command sent by a client
+// and whether the command is one. Clients send git commands in many forms,
+// all these are "git-upload-pack repo":
+//
+//	git-upload-pack repo
+//	git upload-pack repo
+//	/usr/bin/git-upload-pack repo
+//	"git-upload-pack 'repo'"
+//
+// Args of other commands are returned as is.
+func gitCommand(args []string) ([]string, bool) {
+	if len(args) == 0 {
+		return args, false
+	}
+
+	// The whole command was quoted, split it again.
+	if len(args) == 1 && strings.ContainsAny(args[0], " \t") {
+		split, err := shlex.Split(args[0], true)
+		if err != nil || len(split) == 0 {
+			return args, false
+		}
+		if norm, ok := gitCommand(split); ok {
+			return norm, true
+		}
+		return args, false
+	}
+
+	name, rest := gitCommandName(args)
+	if isGitService(name) {
+		return append([]string{name}, rest...), true
+	}
+
+	return args, false
+}
+
+// unsupportedGitCommand returns the name of the git command sent by a client
+// if it's one that isn't served, e.g. "git-foo" or "git status". It returns
+// false for other commands.
+func unsupportedGitCommand(args []string) (string, bool) {
+	if len(args) == 1 && strings.ContainsAny(args[0], " \t") {
+		split, err := shlex.Split(args[0], true)
+		if err != nil || len(split) == 0 {
+			return "", false
+		}
+		args = split
+	}
+	if len(args) == 0 {
+		return "", false
+	}
+
+	name, _ := gitCommandName(args)
+	if name != "git" && !strings.HasPrefix(name, "git-") || isGitService(name) {
+		return "", false
+	}
+
+	return name, true
+}
+
+// gitCommandName returns the normalized name of a git command and its
+// remaining args, "git-upload-pack" for "/usr/bin/git upload-pack".
+func gitCommandName(args []string) (string, []string) {
+	name := args[0]
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	rest := args[1:]
+	if name == "git" && len(rest) > 0 {
+		name = "git-" + rest[0]
+		rest = rest[1:]
+	}
+
+	return name, rest
+}
+
+// isAlias returns whether a command is one of the configured command
+// aliases, aliases can be named like git commands.
+func isAlias(cfg *config.Config, args []string) bool {
+	if cfg == nil || len(args) == 0 {
+		return false
+	}
+	_, ok := cfg.SSH.CommandAliases[args[0]]
+	return ok
+}
+
+func isGitService(name string) bool {
+	for _, s := range gitServices {
+		if name == s {
+			return true
+		}
+	}
+
+	return false
+}
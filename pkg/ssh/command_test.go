@@ -0,0 +1,68 @@
+package ssh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitCommand(t *testing.T) {
+	cases := []struct {
+		args  []string
+		want  []string
+		isGit bool
+	}{
+		{nil, nil, false},
+		{[]string{"git-upload-pack", "repo"}, []string{"git-upload-pack", "repo"}, true},
+		{[]string{"git-receive-pack", "/repo.git"}, []string{"git-receive-pack", "/repo.git"}, true},
+		{[]string{"git", "upload-pack", "repo"}, []string{"git-upload-pack", "repo"}, true},
+		{[]string{"git", "receive-pack", "repo"}, []string{"git-receive-pack", "repo"}, true},
+		{[]string{"git", "upload-archive", "repo"}, []string{"git-upload-archive", "repo"}, true},
+		{[]string{"/usr/bin/git-upload-pack", "repo"}, []string{"git-upload-pack", "repo"}, true},
+		{[]string{"/usr/local/bin/git", "receive-pack", "repo"}, []string{"git-receive-pack", "repo"}, true},
+		{[]string{`C:\Program Files\Git\mingw64\bin\git-upload-pack.exe`, "repo"}, []string{"git-upload-pack", "repo"}, true},
+		{[]string{"git.exe", "upload-pack", "repo"}, []string{"git-upload-pack", "repo"}, true},
+		{[]string{"git-upload-pack 'repo'"}, []string{"git-upload-pack", "repo"}, true},
+		{[]string{`git receive-pack "my repo"`}, []string{"git-receive-pack", "my repo"}, true},
+		{[]string{"git-lfs-authenticate", "repo", "download"}, []string{"git-lfs-authenticate", "repo", "download"}, true},
+		{[]string{"git", "lfs-transfer", "repo", "upload"}, []string{"git-lfs-transfer", "repo", "upload"}, true},
+		{[]string{"git"}, []string{"git"}, false},
+		{[]string{"git", "status"}, []string{"git", "status"}, false},
+		{[]string{"repo", "info", "/usr/bin/git-upload-pack"}, []string{"repo", "info", "/usr/bin/git-upload-pack"}, false},
+		{[]string{"repo info 'git-upload-pack'"}, []string{"repo info 'git-upload-pack'"}, false},
+		{[]string{"repo"}, []string{"repo"}, false},
+	}
+
+	for _, c := range cases {
+		got, isGit := gitCommand(c.args)
+		if !reflect.DeepEqual(got, c.want) || isGit != c.isGit {
+			t.Errorf("gitCommand(%q) = %q, %v, want %q, %v", c.args, got, isGit, c.want, c.isGit)
+		}
+	}
+}
+
+func TestUnsupportedGitCommand(t *testing.T) {
+	cases := []struct {
+		args        []string
+		want        string
+		unsupported bool
+	}{
+		{nil, "", false},
+		{[]string{"git-upload-pack", "repo"}, "", false},
+		{[]string{"git", "receive-pack", "repo"}, "", false},
+		{[]string{"git-something-weird", "repo"}, "git-something-weird", true},
+		{[]string{"/usr/bin/git-something-weird"}, "git-something-weird", true},
+		{[]string{"git", "status"}, "git-status", true},
+		{[]string{"git"}, "git", true},
+		{[]string{"git-foo 'repo'"}, "git-foo", true},
+		{[]string{"github"}, "", false},
+		{[]string{"repo", "info", "git-foo"}, "", false},
+		{[]string{"repo info 'git-foo'"}, "", false},
+	}
+
+	for _, c := range cases {
+		got, unsupported := unsupportedGitCommand(c.args)
+		if got != c.want || unsupported != c.unsupported {
+			t.Errorf("unsupportedGitCommand(%q) = %q, %v, want %q, %v", c.args, got, unsupported, c.want, c.unsupported)
+		}
+	}
+}
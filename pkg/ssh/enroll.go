@@ -0,0 +1,98 @@
+package ssh
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// maxEnrollAttempts is the number of usernames an unknown public key can try
+// before the connection is refused.
+const maxEnrollAttempts = 3
+
+// enrollInstruction is shown with the keyboard-interactive username prompt.
+const enrollInstruction = "This public key isn't registered. Choose a username to enroll, or leave it empty to continue anonymously."
+
+// publicKeyExtension is the permissions extension charmbracelet/ssh reads the
+// public key of a session from, once the connection is authenticated.
+const publicKeyExtension = "gliderlabs/ssh.PublicKey"
+
+// enrollPublicKeyCallback returns the public key callback of connections when
+// self enrollment is allowed. It replaces the one of charmbracelet/ssh, which
+// can't partially succeed. Known keys authenticate as usual, unknown ones
+// only partially succeed and are then prompted for a username with
+// keyboard-interactive authentication. Since the partial success is only
+// returned once the client proved it owns the key, it can't enroll someone
+// else's key.
+func (s *SSHServer) enrollPublicKeyCallback(ctx ssh.Context) func(gossh.ConnMetadata, gossh.PublicKey) (*gossh.Permissions, error) {
+	return func(_ gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+		ctx.Permissions().Permissions = &gossh.Permissions{}
+		if !s.PublicKeyHandler(ctx, key) {
+			return nil, ErrPermissionDenied
+		}
+
+		perms := ctx.Permissions().Permissions
+		if perms.Extensions == nil {
+			perms.Extensions = make(map[string]string)
+		}
+		perms.Extensions[publicKeyExtension] = base64.StdEncoding.EncodeToString(key.Marshal())
+		if s.be.IsKnownPublicKey(ctx, key) {
+			return perms, nil
+		}
+
+		return nil, &gossh.PartialSuccessError{
+			Next: gossh.ServerAuthCallbacks{
+				KeyboardInteractiveCallback: func(_ gossh.ConnMetadata, challenge gossh.KeyboardInteractiveChallenge) (*gossh.Permissions, error) {
+					if err := s.enroll(ctx, key, challenge); err != nil {
+						s.logger.Info("enrollment failed", "fingerprint", gossh.FingerprintSHA256(key), "err", err)
+						return nil, err
+					}
+					return perms, nil
+				},
+			},
+		}
+	}
+}
+
+// enroll prompts for a username and enrolls key as a new user. An empty
+// username skips the enrollment, the key then connects anonymously.
+func (s *SSHServer) enroll(ctx ssh.Context, key gossh.PublicKey, challenge gossh.KeyboardInteractiveChallenge) error {
+	instruction := enrollInstruction
+	for i := 0; i < maxEnrollAttempts; i++ {
+		answers, err := challenge("", instruction, []string{"Username: "}, []bool{true})
+		if err != nil {
+			return err
+		}
+		if len(answers) != 1 {
+			return errors.New("expected a username")
+		}
+
+		username := strings.TrimSpace(answers[0])
+		if username == "" {
+			return nil
+		}
+
+		user, err := s.be.EnrollUser(ctx, username, key)
+		if err != nil {
+			instruction = fmt.Sprintf("Error: %s. %s", err, enrollInstruction)
+			continue
+		}
+
+		ctx.SetValue(proto.ContextKeyUser, user)
+		msg := fmt.Sprintf("Enrolled as %s.", user.Username())
+		if user.IsPending() {
+			msg += " An admin needs to approve your account before you get access."
+		}
+
+		// Show the result without asking anything.
+		_, err = challenge("", msg, nil, nil)
+		return err
+	}
+
+	return errors.New("too many enrollment attempts")
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/git"
+	logr "github.com/charmbracelet/soft-serve/pkg/log"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ssh/cmd"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
@@ -26,6 +28,18 @@ import (
 // ErrPermissionDenied is returned when a user is not allowed connect.
 var ErrPermissionDenied = fmt.Errorf("permission denied")
 
+// ErrGitOnlyKey is returned when a git-only key requests a terminal or a
+// command other than git.
+var ErrGitOnlyKey = fmt.Errorf("this key can only be used for git commands")
+
+// ErrPendingUser is returned when a self-enrolled user who hasn't been
+// approved yet requests a terminal.
+var ErrPendingUser = fmt.Errorf("your account is waiting for an admin to approve it")
+
+// ErrStorageUnavailable is returned when the encrypted storage can't be
+// mounted.
+var ErrStorageUnavailable = fmt.Errorf("repository storage is unavailable")
+
 // AuthenticationMiddleware handles authentication.
 func AuthenticationMiddleware(sh ssh.Handler) ssh.Handler {
 	return func(s ssh.Session) {
@@ -56,7 +70,7 @@ func AuthenticationMiddleware(sh ssh.Handler) ssh.Handler {
 }
 
 // ContextMiddleware adds the config, backend, and logger to the session context.
-func ContextMiddleware(cfg *config.Config, dbx *db.DB, datastore store.Store, be *backend.Backend, logger *log.Logger) func(ssh.Handler) ssh.Handler {
+func ContextMiddleware(cfg *config.Config, dbx *db.DB, datastore store.Store, be backend.Service, logger *log.Logger) func(ssh.Handler) ssh.Handler {
 	return func(sh ssh.Handler) ssh.Handler {
 		return func(s ssh.Session) {
 			ctx := s.Context()
@@ -66,6 +80,55 @@ func ContextMiddleware(cfg *config.Config, dbx *db.DB, datastore store.Store, be
 			ctx.SetValue(store.ContextKey, datastore)
 			ctx.SetValue(backend.ContextKey, be)
 			ctx.SetValue(log.ContextKey, logger.WithPrefix("ssh"))
+
+			// Record key usage here rather than in the public key handler,
+			// clients can offer keys there without proving they own them.
+			if pk := s.PublicKey(); pk != nil && proto.UserFromContext(ctx) != nil {
+				be.TouchPublicKey(pk)
+			}
+
+			sh(s)
+		}
+	}
+}
+
+// StorageMiddleware keeps the encrypted storage open for the duration of the
+// session, see backend.OpenStorage.
+func StorageMiddleware(be backend.Service) func(ssh.Handler) ssh.Handler {
+	return func(sh ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			release, err := be.OpenStorage(s.Context())
+			if err != nil {
+				log.FromContext(s.Context()).Error("error mounting encrypted storage", "err", err)
+				wish.Fatalln(s, ErrStorageUnavailable)
+				return
+			}
+
+			defer release()
+			sh(s)
+		}
+	}
+}
+
+// SessionsMiddleware registers the session in the session registry for the
+// duration of the session and adds the registry to the session context.
+//
+// The session is unregistered using a deferred call, so it's removed even if
+// a later handler panics and the panic is caught by the recover middleware.
+func SessionsMiddleware(sessions *sshutils.Sessions) func(ssh.Handler) ssh.Handler {
+	return func(sh ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			ctx := s.Context()
+			ctx.SetValue(sshutils.ContextKeySessions, sessions)
+
+			username := s.User()
+			if user := proto.UserFromContext(ctx); user != nil {
+				username = user.Username()
+			}
+
+			id := sessions.Add(s, username)
+			defer sessions.Remove(id)
+
 			sh(s)
 		}
 	}
@@ -82,23 +145,60 @@ var cliCommandCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 // This middleware must be run after the ContextMiddleware.
 func CommandMiddleware(sh ssh.Handler) ssh.Handler {
 	return func(s ssh.Session) {
+		ctx := s.Context()
+		cfg := config.FromContext(ctx)
+
+		// Deploy keys can only run git commands, they don't have access
+		// to the TUI or the CLI.
+		// User keys can be flagged git-only too, e.g. for service accounts.
+		// Self-enrolled users only see who they are until they're approved.
+		var deployKey, gitOnly bool
+		user := proto.UserFromContext(ctx)
+		pending := user != nil && user.IsPending()
+		if pk := s.PublicKey(); pk != nil {
+			be := backend.FromContext(ctx)
+			if user == nil {
+				_, err := be.DeployKeyByPublicKey(ctx, pk)
+				deployKey = err == nil
+				gitOnly = deployKey
+			} else {
+				gitOnly = be.IsGitOnlyPublicKey(ctx, pk)
+			}
+		}
+
+		args, isGit := gitCommand(s.Command())
+		if name, ok := unsupportedGitCommand(args); ok && !isAlias(cfg, args) {
+			// Git clients expect a pktline, not the TUI or the CLI
+			// usage.
+			err := fmt.Errorf("%w: %s", git.ErrUnsupportedGitCommand, name)
+			git.WritePktlineErr(s, err) // nolint: errcheck
+			s.Exit(1)                   // nolint: errcheck
+			return
+		}
+
+		// Git commands run even if the client requested a PTY, some wrap
+		// them in ssh -t.
 		_, _, ptyReq := s.Pty()
-		if ptyReq {
+		if ptyReq && !isGit {
+			// Don't launch the TUI for keys that can't use it.
+			if gitOnly {
+				wish.Fatalln(s, ErrGitOnlyKey)
+				return
+			}
+			if pending {
+				wish.Fatalln(s, ErrPendingUser)
+				return
+			}
 			sh(s)
 			return
 		}
 
-		ctx := s.Context()
-		cfg := config.FromContext(ctx)
-
 		renderer := bm.MakeRenderer(s)
 		if testrun, ok := os.LookupEnv("SOFT_SERVE_NO_COLOR"); ok && testrun == "1" {
 			// Disable colors when running tests.
 			renderer.SetColorProfile(termenv.Ascii)
 		}
 
-		args := s.Command()
-		cliCommandCounter.WithLabelValues(cmd.CommandName(args)).Inc()
 		rootCmd := &cobra.Command{
 			Short:        "Soft Serve is a self-hostable Git server for the command line.",
 			SilenceUsage: true,
@@ -111,27 +211,67 @@ func CommandMiddleware(sh ssh.Handler) ssh.Handler {
 			cmd.GitUploadPackCommand(),
 			cmd.GitUploadArchiveCommand(),
 			cmd.GitReceivePackCommand(),
-			cmd.RepoCommand(renderer),
-			cmd.SettingsCommand(),
-			cmd.UserCommand(),
-			cmd.InfoCommand(),
-			cmd.PubkeyCommand(),
-			cmd.SetUsernameCommand(),
-			cmd.JWTCommand(),
-			cmd.TokenCommand(),
 		)
 
-		if cfg.LFS.Enabled {
+		if gitOnly {
+			// Deploy keys authenticate LFS over SSH only, users can get
+			// HTTP credentials.
+			if cfg.LFS.Enabled && !deployKey {
+				rootCmd.AddCommand(
+					cmd.GitLFSAuthenticateCommand(),
+				)
+			}
+			if cfg.LFS.Enabled && cfg.LFS.SSHEnabled {
+				rootCmd.AddCommand(
+					cmd.GitLFSTransfer(),
+				)
+			}
+		} else if pending {
+			rootCmd.AddCommand(
+				cmd.WhoamiCommand(),
+			)
+		} else {
 			rootCmd.AddCommand(
-				cmd.GitLFSAuthenticateCommand(),
+				cmd.RepoCommand(renderer),
+				cmd.CatCommand(),
+				cmd.SettingsCommand(),
+				cmd.UserCommand(),
+				cmd.InfoCommand(),
+				cmd.ServerInfoCommand(),
+				cmd.ServerCommand(),
+				cmd.WhoamiCommand(),
+				cmd.PubkeyCommand(),
+				cmd.SetUsernameCommand(),
+				cmd.ThemeCommand(),
+				cmd.JWTCommand(),
+				cmd.TokenCommand(),
+				cmd.SessionCommand(),
+				cmd.AccessCommand(),
 			)
 
-			if cfg.LFS.SSHEnabled {
+			if cfg.LFS.Enabled {
 				rootCmd.AddCommand(
-					cmd.GitLFSTransfer(),
+					cmd.GitLFSAuthenticateCommand(),
 				)
+
+				if cfg.LFS.SSHEnabled {
+					rootCmd.AddCommand(
+						cmd.GitLFSTransfer(),
+					)
+				}
 			}
+
+			// Expand aliases once all the built-in commands are known,
+			// aliases can't shadow them.
+			var err error
+			args, err = cmd.ExpandAlias(rootCmd, cfg.SSH.CommandAliases, args)
+			if err != nil {
+				wish.Fatalln(s, err)
+				return
+			}
+			cmd.AddAliasCommands(rootCmd, cfg.SSH.CommandAliases)
 		}
+		cliCommandCounter.WithLabelValues(cmd.CommandName(args)).Inc()
 
 		rootCmd.SetArgs(args)
 		if len(args) == 0 {
@@ -150,10 +290,13 @@ func CommandMiddleware(sh ssh.Handler) ssh.Handler {
 	}
 }
 
-// LoggingMiddleware logs the ssh connection and command.
+// LoggingMiddleware logs the ssh connection and command. Only a sample of the
+// successful sessions is logged, see Log.SampleRate, sessions exiting with an
+// error are always logged.
 func LoggingMiddleware(sh ssh.Handler) ssh.Handler {
 	return func(s ssh.Session) {
 		ctx := s.Context()
+		cfg := config.FromContext(ctx)
 		logger := log.FromContext(ctx).WithPrefix("ssh")
 		ct := time.Now()
 		hpk := sshutils.MarshalAuthorizedKey(s.PublicKey())
@@ -189,9 +332,27 @@ func LoggingMiddleware(sh ssh.Handler) ssh.Handler {
 			)
 		}
 
+		sampled := cfg == nil || logr.Sample(cfg.Log.SampleRate)
 		msg := fmt.Sprintf("user %q", s.User())
-		logger.Debug(msg+" connected", logArgs...)
-		sh(s)
-		logger.Debug(msg+" disconnected", append(logArgs, "duration", time.Since(ct))...)
+		if sampled {
+			logger.Debug(msg+" connected", logArgs...)
+		}
+		es := &exitSession{Session: s}
+		sh(es)
+		if sampled || es.code != 0 {
+			logger.Debug(msg+" disconnected", append(logArgs, "exit", es.code, "duration", time.Since(ct))...)
+		}
 	}
 }
+
+// exitSession is a session recording its exit status.
+type exitSession struct {
+	ssh.Session
+	code int
+}
+
+// Exit implements ssh.Session.
+func (s *exitSession) Exit(code int) error {
+	s.code = code
+	return s.Session.Exit(code)
+}
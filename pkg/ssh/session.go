@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	bm "github.com/charmbracelet/wish/bubbletea"
@@ -55,12 +56,25 @@ func SessionHandler(s ssh.Session) *tea.Program {
 	}
 
 	renderer := bm.MakeRenderer(s)
+	renderer.SetColorProfile(colorProfile(pty.Term, s.Environ()))
 	if testrun, ok := os.LookupEnv("SOFT_SERVE_NO_COLOR"); ok && testrun == "1" {
 		// Disable colors when running tests.
 		renderer.SetColorProfile(termenv.Ascii)
 	}
 
 	c := common.NewCommon(ctx, renderer, pty.Window.Width, pty.Window.Height)
+	theme := cfg.UI.Theme
+	if user := proto.UserFromContext(ctx); user != nil && user.Theme() != "" {
+		theme = user.Theme()
+	}
+	if t, ok := styles.LookupTheme(theme); ok {
+		c.Styles = styles.NewStyles(renderer, t)
+	}
+	timeFormat := cfg.UI.TimeFormat
+	if user := proto.UserFromContext(ctx); user != nil && user.TimeFormat() != "" {
+		timeFormat = user.TimeFormat()
+	}
+	c.SetTimeFormat(timeFormat)
 	c.SetValue(common.ConfigKey, cfg)
 	m := NewUI(c, initialRepo)
 	opts := bm.MakeOptions(s)
@@ -77,6 +91,7 @@ func SessionHandler(s ssh.Session) *tea.Program {
 	start := time.Now()
 	go func() {
 		<-ctx.Done()
+		m.Close() // nolint: errcheck
 		tuiSessionDuration.WithLabelValues(initialRepo, pty.Term).Add(time.Since(start).Seconds())
 	}()
 
@@ -0,0 +1,88 @@
+package ssh
+
+import (
+	"sync"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// sessionObjectReaders is the number of git cat-file processes a TUI session
+// runs for the selected repository. The repo panes load concurrently.
+const sessionObjectReaders = 4
+
+// sessionRepo is the repository selected in a TUI session. It opens the git
+// repository once and reads the files of its trees through long-lived git
+// cat-file processes instead of a git process per file. The processes run
+// until the repository is closed.
+type sessionRepo struct {
+	proto.Repository
+
+	mu      sync.Mutex
+	repo    *git.Repository
+	objects *git.ObjectReader
+	closed  bool
+}
+
+var _ proto.Repository = (*sessionRepo)(nil)
+
+// Open implements proto.Repository.
+func (r *sessionRepo) Open() (*git.Repository, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return r.Repository.Open()
+	}
+	if r.repo != nil {
+		return r.repo, nil
+	}
+	repo, err := r.Repository.Open()
+	if err != nil {
+		return nil, err
+	}
+	r.objects = git.NewObjectReader(repo.Path, sessionObjectReaders)
+	r.repo = repo.WithObjectReader(r.objects)
+	return r.repo, nil
+}
+
+// Close stops the git cat-file processes of the repository.
+func (r *sessionRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	r.repo = nil
+	if r.objects == nil {
+		return nil
+	}
+	return r.objects.Close()
+}
+
+// setSessionRepo makes r the selected repository of the session, closing the
+// previously selected one.
+func (ui *UI) setSessionRepo(r proto.Repository) proto.Repository {
+	ui.repoMu.Lock()
+	defer ui.repoMu.Unlock()
+	if ui.repo != nil {
+		ui.repo.Close() // nolint: errcheck
+		ui.repo = nil
+	}
+	if ui.closed {
+		return r
+	}
+	ui.repo = &sessionRepo{Repository: r}
+	return ui.repo
+}
+
+// Close closes the selected repository of the session. It's called when the
+// session ends.
+func (ui *UI) Close() error {
+	ui.repoMu.Lock()
+	defer ui.repoMu.Unlock()
+	ui.closed = true
+	if ui.repo == nil {
+		return nil
+	}
+	err := ui.repo.Close()
+	ui.repo = nil
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,9 +13,8 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
-	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/store"
-	"github.com/charmbracelet/soft-serve/pkg/store/database"
 	"github.com/charmbracelet/soft-serve/pkg/test"
 	"github.com/charmbracelet/ssh"
 	bm "github.com/charmbracelet/wish/bubbletea"
@@ -22,7 +22,6 @@ import (
 	"github.com/matryer/is"
 	"github.com/muesli/termenv"
 	gossh "golang.org/x/crypto/ssh"
-	_ "modernc.org/sqlite" // sqlite driver
 )
 
 func TestSession(t *testing.T) {
@@ -50,34 +49,12 @@ func TestSession(t *testing.T) {
 
 func setup(tb testing.TB) (*gossh.Session, func() error) {
 	tb.Helper()
-	is := is.New(tb)
-	dp := tb.TempDir()
-	is.NoErr(os.Setenv("SOFT_SERVE_DATA_PATH", dp))
-	is.NoErr(os.Setenv("SOFT_SERVE_GIT_LISTEN_ADDR", ":9418"))
-	is.NoErr(os.Setenv("SOFT_SERVE_SSH_LISTEN_ADDR", fmt.Sprintf(":%d", test.RandomPort())))
-	tb.Cleanup(func() {
-		is.NoErr(os.Unsetenv("SOFT_SERVE_DATA_PATH"))
-		is.NoErr(os.Unsetenv("SOFT_SERVE_GIT_LISTEN_ADDR"))
-		is.NoErr(os.Unsetenv("SOFT_SERVE_SSH_LISTEN_ADDR"))
-		is.NoErr(os.RemoveAll(dp))
+	ctx, be := test.NewBackend(tb, func(cfg *config.Config) {
+		cfg.SSH.ListenAddr = config.ListenAddrs{fmt.Sprintf(":%d", test.RandomPort())}
 	})
-	ctx := context.TODO()
-	cfg := config.DefaultConfig()
-	if err := cfg.Validate(); err != nil {
-		log.Fatal(err)
-	}
-	ctx = config.WithContext(ctx, cfg)
-	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
-	if err != nil {
-		tb.Fatal(err)
-	}
-	if err := migrate.Migrate(ctx, dbx); err != nil {
-		tb.Fatal(err)
-	}
-	dbstore := database.New(ctx, dbx)
-	ctx = store.WithContext(ctx, dbstore)
-	be := backend.New(ctx, cfg, dbx, dbstore)
-	ctx = backend.WithContext(ctx, be)
+	cfg := config.FromContext(ctx)
+	dbx := db.FromContext(ctx)
+	dbstore := store.FromContext(ctx)
 	return testsession.New(tb, &ssh.Server{
 		Handler: ContextMiddleware(cfg, dbx, dbstore, be, log.Default())(bm.MiddlewareWithProgramHandler(SessionHandler, termenv.ANSI256)(func(s ssh.Session) {
 			_, _, active := s.Pty()
@@ -88,3 +65,34 @@ func setup(tb testing.TB) (*gossh.Session, func() error) {
 		})),
 	}, nil), dbx.Close
 }
+
+// listingBackend is a backend.Service other than *backend.Backend that
+// records the repository listings.
+type listingBackend struct {
+	backend.Service
+	listed bool
+}
+
+func (b *listingBackend) VisibleRepositories(ctx context.Context, pk gossh.PublicKey) ([]proto.Repository, error) {
+	b.listed = true
+	return b.Service.VisibleRepositories(ctx, pk)
+}
+
+func TestSessionService(t *testing.T) {
+	is := is.New(t)
+	ctx, be := test.NewBackend(t)
+	admin, err := be.User(ctx, "admin")
+	is.NoErr(err)
+	_, err = be.CreateRepository(ctx, "repo1", admin, proto.RepositoryOptions{})
+	is.NoErr(err)
+
+	// The CLI only uses the backend through backend.Service.
+	svc := &listingBackend{Service: be}
+	s := testsession.New(t, &ssh.Server{
+		Handler: ContextMiddleware(config.FromContext(ctx), db.FromContext(ctx), store.FromContext(ctx), svc, log.Default())(CommandMiddleware(nil)),
+	}, nil)
+	out, err := s.Output("repo list")
+	is.NoErr(err)
+	is.True(strings.Contains(string(out), "repo1"))
+	is.True(svc.listed)
+}
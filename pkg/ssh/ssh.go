@@ -2,11 +2,13 @@ package ssh
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"runtime"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/keygen"
@@ -15,12 +17,13 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"github.com/charmbracelet/soft-serve/pkg/store"
-	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	bm "github.com/charmbracelet/wish/bubbletea"
 	rm "github.com/charmbracelet/wish/recover"
+	"github.com/muesli/termenv"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	gossh "golang.org/x/crypto/ssh"
@@ -44,11 +47,12 @@ var (
 
 // SSHServer is a SSH server that implements the git protocol.
 type SSHServer struct { // nolint: revive
-	srv    *ssh.Server
-	cfg    *config.Config
-	be     *backend.Backend
-	ctx    context.Context
-	logger *log.Logger
+	srvs     []*ssh.Server
+	cfg      *config.Config
+	be       backend.Service
+	ctx      context.Context
+	logger   *log.Logger
+	sessions *sshutils.Sessions
 }
 
 // NewSSHServer returns a new SSHServer.
@@ -59,23 +63,28 @@ func NewSSHServer(ctx context.Context) (*SSHServer, error) {
 	datastore := store.FromContext(ctx)
 	be := backend.FromContext(ctx)
 
-	var err error
 	s := &SSHServer{
-		cfg:    cfg,
-		ctx:    ctx,
-		be:     be,
-		logger: logger,
+		cfg:      cfg,
+		ctx:      ctx,
+		be:       be,
+		logger:   logger,
+		sessions: sshutils.NewSessions(),
 	}
 
 	mw := []wish.Middleware{
 		rm.MiddlewareWithLogger(
 			logger,
-			// BubbleTea middleware.
-			bm.MiddlewareWithProgramHandler(SessionHandler, common.DefaultColorProfile),
+			// BubbleTea middleware. The color profile isn't forced, SessionHandler
+			// renders with the one of the client terminal.
+			bm.MiddlewareWithProgramHandler(SessionHandler, termenv.Ascii),
 			// CLI middleware.
 			CommandMiddleware,
 			// Logging middleware.
 			LoggingMiddleware,
+			// Sessions middleware.
+			SessionsMiddleware(s.sessions),
+			// Encrypted storage middleware.
+			StorageMiddleware(be),
 			// Context middleware.
 			ContextMiddleware(cfg, dbx, datastore, be, logger),
 			// Authentication middleware.
@@ -86,70 +95,167 @@ func NewSSHServer(ctx context.Context) (*SSHServer, error) {
 		),
 	}
 
+	for _, addr := range cfg.SSH.ListenAddr {
+		srv, err := s.newServer(addr, mw)
+		if err != nil {
+			return nil, err
+		}
+		s.srvs = append(s.srvs, srv)
+	}
+
+	// Create client ssh key
+	if _, err := os.Stat(cfg.SSH.ClientKeyPath); err != nil && os.IsNotExist(err) {
+		_, err := keygen.New(cfg.SSH.ClientKeyPath, keygen.WithKeyType(keygen.Ed25519), keygen.WithWrite())
+		if err != nil {
+			return nil, fmt.Errorf("client ssh key: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// newServer returns a server listening on addr. Every server shares the host
+// keys and middlewares, the allowed networks can be set per address.
+func (s *SSHServer) newServer(addr string, mw []wish.Middleware) (*ssh.Server, error) {
+	cfg := s.cfg
+	logger := s.logger
 	opts := []ssh.Option{
-		ssh.PublicKeyAuth(s.PublicKeyHandler),
 		ssh.KeyboardInteractiveAuth(s.KeyboardInteractiveHandler),
-		wish.WithAddress(cfg.SSH.ListenAddr),
+		wish.WithAddress(addr),
 		wish.WithHostKeyPath(cfg.SSH.KeyPath),
 		wish.WithMiddleware(mw...),
 	}
+	if !cfg.Auth.AllowSelfEnroll {
+		// With self enrollment, the public key callback is set per
+		// connection, see enrollPublicKeyCallback.
+		opts = append(opts, ssh.PublicKeyAuth(s.PublicKeyHandler))
+	}
 	if runtime.GOOS == "windows" {
 		opts = append(opts, ssh.EmulatePty())
 	} else {
 		opts = append(opts, ssh.AllocatePty())
 	}
-	s.srv, err = wish.NewServer(opts...)
+	srv, err := wish.NewServer(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	if config.IsDebug() {
-		s.srv.ServerConfigCallback = func(_ ssh.Context) *gossh.ServerConfig {
-			return &gossh.ServerConfig{
-				AuthLogCallback: func(conn gossh.ConnMetadata, method string, err error) {
-					logger.Debug("authentication", "user", conn.User(), "method", method, "err", err)
-				},
+	srv.ServerConfigCallback = func(ctx ssh.Context) *gossh.ServerConfig {
+		// The callback runs with the server locked, right before the host
+		// keys are added to the connection config, so rotated keys are
+		// offered without a restart. Later keys replace the earlier ones of
+		// the same type, retired keys are preferred until they expire.
+		if signers, err := s.be.HostSigners(); err != nil {
+			logger.Error("error reading host keys", "err", err)
+		} else {
+			srv.HostSigners = srv.HostSigners[:0]
+			for _, signer := range signers {
+				srv.HostSigners = append(srv.HostSigners, signer)
 			}
 		}
+
+		var scfg gossh.ServerConfig
+		if config.IsDebug() {
+			scfg.AuthLogCallback = func(conn gossh.ConnMetadata, method string, err error) {
+				logger.Debug("authentication", "user", conn.User(), "method", method, "err", err)
+			}
+		}
+		if cfg.Auth.AllowSelfEnroll {
+			scfg.PublicKeyCallback = s.enrollPublicKeyCallback(ctx)
+		}
+		return &scfg
 	}
 
-	if cfg.SSH.MaxTimeout > 0 {
-		s.srv.MaxTimeout = time.Duration(cfg.SSH.MaxTimeout) * time.Second
+	allowed, denied := cfg.SSH.AllowedCIDRs, cfg.SSH.DeniedCIDRs
+	if l, ok := cfg.SSH.Listeners[addr]; ok {
+		if l.AllowedCIDRs != nil {
+			allowed = l.AllowedCIDRs
+		}
+		if l.DeniedCIDRs != nil {
+			denied = l.DeniedCIDRs
+		}
+	}
+	filter, err := newSourceFilter(allowed, denied)
+	if err != nil {
+		return nil, err
+	}
+	if !filter.Empty() {
+		// Drop connections from disallowed networks before the handshake.
+		srv.ConnCallback = func(_ ssh.Context, conn net.Conn) net.Conn {
+			if ip := addrIP(conn.RemoteAddr()); !filter.Allowed(ip) {
+				logger.Info("dropped connection", "addr", conn.RemoteAddr().String(), "listener", addr)
+				return nil
+			}
+			return conn
+		}
 	}
 
-	if cfg.SSH.IdleTimeout > 0 {
-		s.srv.IdleTimeout = time.Duration(cfg.SSH.IdleTimeout) * time.Second
+	if cfg.SSH.MaxTimeout > 0 {
+		srv.MaxTimeout = time.Duration(cfg.SSH.MaxTimeout) * time.Second
 	}
 
-	// Create client ssh key
-	if _, err := os.Stat(cfg.SSH.ClientKeyPath); err != nil && os.IsNotExist(err) {
-		_, err := keygen.New(cfg.SSH.ClientKeyPath, keygen.WithKeyType(keygen.Ed25519), keygen.WithWrite())
-		if err != nil {
-			return nil, fmt.Errorf("client ssh key: %w", err)
-		}
+	if cfg.SSH.IdleTimeout > 0 {
+		srv.IdleTimeout = time.Duration(cfg.SSH.IdleTimeout) * time.Second
 	}
 
-	return s, nil
+	return srv, nil
 }
 
-// ListenAndServe starts the SSH server.
+// ListenAndServe starts the SSH server on every listen address. It returns
+// once all of them are closed, or closes them all if one fails.
 func (s *SSHServer) ListenAndServe() error {
-	return s.srv.ListenAndServe()
+	errc := make(chan error, len(s.srvs))
+	for _, srv := range s.srvs {
+		go func(srv *ssh.Server) {
+			errc <- srv.ListenAndServe()
+		}(srv)
+	}
+
+	var err error
+	for range s.srvs {
+		e := <-errc
+		if err == nil {
+			err = e
+			if !errors.Is(e, ssh.ErrServerClosed) {
+				s.Close() // nolint: errcheck
+			}
+		}
+	}
+
+	return err
 }
 
-// Serve starts the SSH server on the given net.Listener.
+// Serve starts the SSH server on the given net.Listener, using the settings
+// of the first listen address.
 func (s *SSHServer) Serve(l net.Listener) error {
-	return s.srv.Serve(l)
+	if len(s.srvs) == 0 {
+		return errors.New("no ssh listen address")
+	}
+	return s.srvs[0].Serve(l)
 }
 
 // Close closes the SSH server.
 func (s *SSHServer) Close() error {
-	return s.srv.Close()
+	var errs []error
+	for _, srv := range s.srvs {
+		errs = append(errs, srv.Close())
+	}
+	return errors.Join(errs...)
 }
 
 // Shutdown gracefully shuts down the SSH server.
 func (s *SSHServer) Shutdown(ctx context.Context) error {
-	return s.srv.Shutdown(ctx)
+	var wg sync.WaitGroup
+	errs := make([]error, len(s.srvs))
+	for i, srv := range s.srvs {
+		wg.Add(1)
+		go func(i int, srv *ssh.Server) {
+			defer wg.Done()
+			errs[i] = srv.Shutdown(ctx)
+		}(i, srv)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
 }
 
 func initializePermissions(ctx ssh.Context) {
@@ -176,6 +282,14 @@ func (s *SSHServer) PublicKeyHandler(ctx ssh.Context, pk ssh.PublicKey) (allowed
 		publicKeyCounter.WithLabelValues(strconv.FormatBool(*allowed)).Inc()
 	}(&allowed)
 
+	if s.cfg.SSH.EnforceKeyPolicy {
+		if err := s.be.CheckKeyPolicy(pk); err != nil {
+			s.logger.Info("rejected public key", "fingerprint", gossh.FingerprintSHA256(pk), "err", err)
+			allowed = false
+			return
+		}
+	}
+
 	user, _ := s.be.UserByPublicKey(ctx, pk)
 	if user != nil {
 		ctx.SetValue(proto.ContextKeyUser, user)
@@ -196,7 +310,8 @@ func (s *SSHServer) PublicKeyHandler(ctx ssh.Context, pk ssh.PublicKey) (allowed
 // KeyboardInteractiveHandler handles keyboard interactive authentication.
 // This is used after all public key authentication has failed.
 func (s *SSHServer) KeyboardInteractiveHandler(ctx ssh.Context, _ gossh.KeyboardInteractiveChallenge) bool {
-	ac := s.be.AllowKeyless(ctx)
+	// Keyless connections can always read the anonymous repositories.
+	ac := s.be.AllowKeyless(ctx) || s.be.AnonymousRepos()
 	keyboardInteractiveCounter.WithLabelValues(strconv.FormatBool(ac)).Inc()
 
 	// If we're allowing keyless access, reset the public key fingerprint
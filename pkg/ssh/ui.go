@@ -2,12 +2,14 @@ package ssh
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/footer"
@@ -44,6 +46,12 @@ type UI struct {
 	footer      *footer.Footer
 	showFooter  bool
 	error       error
+
+	// repoMu guards the session repository, set and closed outside of the
+	// update loop.
+	repoMu sync.Mutex
+	repo   *sessionRepo
+	closed bool
 }
 
 // NewUI returns a new UI model.
@@ -110,6 +118,7 @@ func (ui *UI) FullHelp() [][]key.Binding {
 	}
 	h := []key.Binding{
 		ui.common.KeyMap.Help,
+		ui.common.KeyMap.TimeFormat,
 	}
 	if !ui.IsFiltering() {
 		h = append(h, ui.common.KeyMap.Quit)
@@ -140,6 +149,7 @@ func (ui *UI) Init() tea.Cmd {
 		repo.NewLog(ui.common),
 		repo.NewRefs(ui.common, git.RefsHeads),
 		repo.NewRefs(ui.common, git.RefsTags),
+		repo.NewIssues(ui.common),
 	)
 	ui.SetSize(ui.common.Width, ui.common.Height)
 	cmds := make([]tea.Cmd, 0)
@@ -155,12 +165,18 @@ func (ui *UI) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-// IsFiltering returns true if the selection page is filtering.
+// IsFiltering returns true if the selection page is filtering or the repo
+// page is capturing input.
 func (ui *UI) IsFiltering() bool {
-	if ui.activePage == selectionPage {
+	switch ui.activePage {
+	case selectionPage:
 		if s, ok := ui.pages[selectionPage].(*selection.Selection); ok && s.FilterState() == list.Filtering {
 			return true
 		}
+	case repoPage:
+		if r, ok := ui.pages[repoPage].(*repo.Repo); ok && r.CapturesInput() {
+			return true
+		}
 	}
 	return false
 }
@@ -188,8 +204,10 @@ func (ui *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				ui.state = readyState
 				// Always show the footer on error.
 				ui.showFooter = ui.footer.ShowAll()
-			case key.Matches(msg, ui.common.KeyMap.Help):
+			case key.Matches(msg, ui.common.KeyMap.Help) && !ui.IsFiltering():
 				cmds = append(cmds, footer.ToggleFooterCmd)
+			case key.Matches(msg, ui.common.KeyMap.TimeFormat) && !ui.IsFiltering():
+				cmds = append(cmds, ui.toggleTimeFormat())
 			case key.Matches(msg, ui.common.KeyMap.Quit):
 				if !ui.IsFiltering() {
 					// Stop bubblezone background workers.
@@ -295,6 +313,35 @@ func (ui *UI) View() string {
 	)
 }
 
+// toggleTimeFormat switches the timestamps between relative and absolute, and
+// returns the command saving the choice to the user's profile. Anonymous
+// users keep the choice for the session.
+func (ui *UI) toggleTimeFormat() tea.Cmd {
+	format := config.TimeFormatAbsolute
+	if ui.common.TimeFormat() == config.TimeFormatAbsolute {
+		format = config.TimeFormatRelative
+	}
+	ui.common.SetTimeFormat(format)
+
+	ctx := ui.common.Context()
+	be := ui.common.Backend()
+	pk := ui.common.PublicKey()
+	logger := ui.common.Logger
+	return func() tea.Msg {
+		if pk == nil {
+			return nil
+		}
+		user, err := be.UserByPublicKey(ctx, pk)
+		if err != nil {
+			return nil
+		}
+		if err := be.SetTimeFormat(ctx, user.Username(), format); err != nil {
+			logger.Error("failed to save time format", "user", user.Username(), "err", err)
+		}
+		return nil
+	}
+}
+
 func (ui *UI) openRepo(rn string) (proto.Repository, error) {
 	cfg := ui.common.Config()
 	if cfg == nil {
@@ -303,14 +350,14 @@ func (ui *UI) openRepo(rn string) (proto.Repository, error) {
 
 	ctx := ui.common.Context()
 	be := ui.common.Backend()
-	repos, err := be.Repositories(ctx)
+	repos, err := be.VisibleRepositories(ctx, ui.common.PublicKey())
 	if err != nil {
 		ui.common.Logger.Debugf("ui: failed to list repos: %v", err)
 		return nil, err
 	}
 	for _, r := range repos {
 		if r.Name() == rn {
-			return r, nil
+			return ui.setSessionRepo(r), nil
 		}
 	}
 	return nil, common.ErrMissingRepo
@@ -0,0 +1,125 @@
+package sshutils
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// SessionInfo describes an active SSH session.
+type SessionInfo struct {
+	// ID is the unique identifier of the session.
+	ID int64
+	// User is the username or the SSH user of the session.
+	User string
+	// RemoteAddr is the remote address of the session.
+	RemoteAddr string
+	// Operation is the command the session is running. It's "interactive" for
+	// PTY sessions without a command.
+	Operation string
+	// StartedAt is the time the session started.
+	StartedAt time.Time
+}
+
+// Duration returns how long the session has been running.
+func (i SessionInfo) Duration() time.Duration {
+	return time.Since(i.StartedAt)
+}
+
+type sessionEntry struct {
+	info SessionInfo
+	s    ssh.Session
+}
+
+// Sessions is an in-memory registry of active SSH sessions.
+type Sessions struct {
+	mu      sync.RWMutex
+	nextID  int64
+	entries map[int64]*sessionEntry
+}
+
+// NewSessions returns a new session registry.
+func NewSessions() *Sessions {
+	return &Sessions{
+		entries: make(map[int64]*sessionEntry),
+	}
+}
+
+// Add registers a session with the given user and returns its id.
+func (r *Sessions) Add(s ssh.Session, user string) int64 {
+	op := strings.Join(s.Command(), " ")
+	if _, _, isPty := s.Pty(); isPty && op == "" {
+		op = "interactive"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	id := r.nextID
+	r.entries[id] = &sessionEntry{
+		info: SessionInfo{
+			ID:         id,
+			User:       user,
+			RemoteAddr: s.RemoteAddr().String(),
+			Operation:  op,
+			StartedAt:  time.Now(),
+		},
+		s: s,
+	}
+
+	return id
+}
+
+// Remove unregisters the session with the given id.
+func (r *Sessions) Remove(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, id)
+}
+
+// List returns the active sessions sorted by id.
+func (r *Sessions) List() []SessionInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := make([]SessionInfo, 0, len(r.entries))
+	for _, e := range r.entries {
+		infos = append(infos, e.info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
+// Kill forcibly terminates the session with the given id. It returns false if
+// the session doesn't exist.
+func (r *Sessions) Kill(id int64) bool {
+	r.mu.RLock()
+	e, ok := r.entries[id]
+	r.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	e.s.Exit(1) // nolint: errcheck
+	e.s.Close() // nolint: errcheck
+	r.Remove(id)
+	return true
+}
+
+// ContextKeySessions is the context key for the session registry.
+var ContextKeySessions = &struct{ string }{"sessions"}
+
+// SessionsFromContext returns the session registry from the context.
+func SessionsFromContext(ctx context.Context) *Sessions {
+	if r, ok := ctx.Value(ContextKeySessions).(*Sessions); ok {
+		return r
+	}
+	return nil
+}
@@ -3,6 +3,10 @@ package sshutils
 import (
 	"bytes"
 	"context"
+	"crypto/dsa" // nolint: staticcheck
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 
 	"github.com/charmbracelet/ssh"
 	gossh "golang.org/x/crypto/ssh"
@@ -31,6 +35,28 @@ func KeysEqual(a, b gossh.PublicKey) bool {
 	return ssh.KeysEqual(a, b)
 }
 
+// KeySize returns the size, in bits, of a public key. It returns 0 for
+// unknown key types.
+func KeySize(pk gossh.PublicKey) int {
+	cpk, ok := pk.(gossh.CryptoPublicKey)
+	if !ok {
+		return 0
+	}
+
+	switch k := cpk.CryptoPublicKey().(type) {
+	case *rsa.PublicKey:
+		return k.N.BitLen()
+	case *dsa.PublicKey:
+		return k.P.BitLen()
+	case *ecdsa.PublicKey:
+		return k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return 256
+	}
+
+	return 0
+}
+
 // PublicKeyFromContext returns the public key from the context.
 func PublicKeyFromContext(ctx context.Context) gossh.PublicKey {
 	if pk, ok := ctx.Value(ssh.ContextKeyPublicKey).(gossh.PublicKey); ok {
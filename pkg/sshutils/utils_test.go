@@ -122,3 +122,26 @@ func TestKeysEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestKeySize(t *testing.T) {
+	goodKey1, goodKey2 := generateKeys(t)
+	weakKey, err := keygen.New("", keygen.WithKeyType(keygen.RSA), keygen.WithBitSize(1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		in       ssh.PublicKey
+		expected int
+	}{
+		{goodKey1.PublicKey(), 256},
+		{goodKey2.PublicKey(), 4096},
+		{weakKey.PublicKey(), 1024},
+	}
+
+	for _, c := range cases {
+		if out := KeySize(c.in); out != c.expected {
+			t.Errorf("KeySize(%s) = %d, expected %d", c.in.Type(), out, c.expected)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
@@ -16,7 +17,7 @@ type collabStore struct{}
 var _ store.CollaboratorStore = (*collabStore)(nil)
 
 // AddCollabByUsernameAndRepo implements store.CollaboratorStore.
-func (*collabStore) AddCollabByUsernameAndRepo(ctx context.Context, tx db.Handler, username string, repo string, level access.AccessLevel) error {
+func (*collabStore) AddCollabByUsernameAndRepo(ctx context.Context, tx db.Handler, username string, repo string, level access.AccessLevel, expiresAt time.Time) error {
 	username = strings.ToLower(username)
 	if err := utils.ValidateUsername(username); err != nil {
 		return err
@@ -24,7 +25,12 @@ func (*collabStore) AddCollabByUsernameAndRepo(ctx context.Context, tx db.Handle
 
 	repo = utils.SanitizeRepo(repo)
 
-	query := tx.Rebind(`INSERT INTO collabs (access_level, user_id, repo_id, updated_at)
+	var expires interface{}
+	if !expiresAt.IsZero() {
+		expires = expiresAt.UTC()
+	}
+
+	query := tx.Rebind(`INSERT INTO collabs (access_level, user_id, repo_id, expires_at, updated_at)
 			VALUES (
 				?,
 				(
@@ -33,9 +39,10 @@ func (*collabStore) AddCollabByUsernameAndRepo(ctx context.Context, tx db.Handle
 				(
 					SELECT id FROM repos WHERE name = ?
 				),
+				?,
 				CURRENT_TIMESTAMP
 			);`)
-	_, err := tx.ExecContext(ctx, query, level, username, repo)
+	_, err := tx.ExecContext(ctx, query, level, username, repo, expires)
 	return err
 }
 
@@ -124,3 +131,40 @@ func (*collabStore) RemoveCollabByUsernameAndRepo(ctx context.Context, tx db.Han
 	_, err := tx.ExecContext(ctx, query, username, repo)
 	return err
 }
+
+// SetCollabPathsByUsernameAndRepo implements store.CollaboratorStore.
+func (*collabStore) SetCollabPathsByUsernameAndRepo(ctx context.Context, tx db.Handler, username string, repo string, paths []string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`
+		UPDATE
+			collabs
+		SET
+			paths = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id = (
+				SELECT id FROM users WHERE username = ?
+			) AND repo_id = (
+				SELECT id FROM repos WHERE name = ?
+			)
+	`)
+	res, err := tx.ExecContext(ctx, query, strings.Join(paths, "\n"), username, repo)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return db.ErrRecordNotFound
+	}
+
+	return nil
+}
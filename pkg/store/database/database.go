@@ -19,9 +19,13 @@ type datastore struct {
 	*repoStore
 	*userStore
 	*collabStore
+	*deployKeyStore
 	*lfsStore
 	*accessTokenStore
 	*webhookStore
+	*redirectStore
+	*pushEmailStore
+	*readStore
 }
 
 // New returns a new store.Store database.
@@ -39,8 +43,12 @@ func New(ctx context.Context, db *db.DB) store.Store {
 		repoStore:        &repoStore{},
 		userStore:        &userStore{},
 		collabStore:      &collabStore{},
+		deployKeyStore:   &deployKeyStore{},
 		lfsStore:         &lfsStore{},
 		accessTokenStore: &accessTokenStore{},
+		redirectStore:    &redirectStore{},
+		pushEmailStore:   &pushEmailStore{},
+		readStore:        &readStore{},
 	}
 
 	return s
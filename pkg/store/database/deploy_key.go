@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"golang.org/x/crypto/ssh"
+)
+
+type deployKeyStore struct{}
+
+var _ store.DeployKeyStore = (*deployKeyStore)(nil)
+
+// AddDeployKeyByRepo implements store.DeployKeyStore.
+func (*deployKeyStore) AddDeployKeyByRepo(ctx context.Context, tx db.Handler, repo string, pk ssh.PublicKey, comment string, level access.AccessLevel) error {
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`INSERT INTO deploy_keys (repo_id, public_key, comment, access_level, updated_at)
+			VALUES (
+				(
+					SELECT id FROM repos WHERE name = ?
+				),
+				?,
+				?,
+				?,
+				CURRENT_TIMESTAMP
+			);`)
+	_, err := tx.ExecContext(ctx, query, repo, sshutils.MarshalAuthorizedKey(pk), comment, level)
+	return err
+}
+
+// RemoveDeployKeyByRepo implements store.DeployKeyStore.
+func (*deployKeyStore) RemoveDeployKeyByRepo(ctx context.Context, tx db.Handler, repo string, pk ssh.PublicKey) error {
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`DELETE FROM deploy_keys
+			WHERE public_key = ? AND repo_id = (
+				SELECT id FROM repos WHERE name = ?
+			);`)
+	res, err := tx.ExecContext(ctx, query, sshutils.MarshalAuthorizedKey(pk), repo)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return db.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ListDeployKeysByRepo implements store.DeployKeyStore.
+func (*deployKeyStore) ListDeployKeysByRepo(ctx context.Context, tx db.Handler, repo string) ([]models.DeployKey, error) {
+	var ms []models.DeployKey
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`SELECT deploy_keys.*, repos.name AS repo_name
+			FROM deploy_keys
+			INNER JOIN repos ON repos.id = deploy_keys.repo_id
+			WHERE repos.name = ?
+			ORDER BY deploy_keys.id ASC;`)
+	err := tx.SelectContext(ctx, &ms, query, repo)
+	return ms, err
+}
+
+// FindDeployKeyByPublicKey implements store.DeployKeyStore.
+func (*deployKeyStore) FindDeployKeyByPublicKey(ctx context.Context, tx db.Handler, pk ssh.PublicKey) (models.DeployKey, error) {
+	var m models.DeployKey
+	query := tx.Rebind(`SELECT deploy_keys.*, repos.name AS repo_name
+			FROM deploy_keys
+			INNER JOIN repos ON repos.id = deploy_keys.repo_id
+			WHERE deploy_keys.public_key = ?;`)
+	err := tx.GetContext(ctx, &m, query, sshutils.MarshalAuthorizedKey(pk))
+	return m, err
+}
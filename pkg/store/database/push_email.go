@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+type pushEmailStore struct{}
+
+var _ store.PushEmailStore = (*pushEmailStore)(nil)
+
+// GetRepoPushEmailByName implements store.PushEmailStore.
+func (*pushEmailStore) GetRepoPushEmailByName(ctx context.Context, tx db.Handler, repo string) (models.RepoPushEmail, error) {
+	var m models.RepoPushEmail
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`SELECT repo_push_emails.*
+			FROM repo_push_emails
+			INNER JOIN repos ON repos.id = repo_push_emails.repo_id
+			WHERE repos.name = ?;`)
+	err := tx.GetContext(ctx, &m, query, repo)
+	return m, err
+}
+
+// SetRepoPushEmailByName implements store.PushEmailStore.
+func (s *pushEmailStore) SetRepoPushEmailByName(ctx context.Context, tx db.Handler, repo string, recipients string, from string, smtpURL string) error {
+	repo = utils.SanitizeRepo(repo)
+
+	// Replace the existing settings of the repository.
+	if err := s.RemoveRepoPushEmailByName(ctx, tx, repo); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`INSERT INTO repo_push_emails (repo_id, recipients, from_address, smtp_url, updated_at)
+			VALUES (
+				(
+					SELECT id FROM repos WHERE name = ?
+				),
+				?,
+				?,
+				?,
+				CURRENT_TIMESTAMP
+			);`)
+	_, err := tx.ExecContext(ctx, query, repo, recipients, from, smtpURL)
+	return err
+}
+
+// RemoveRepoPushEmailByName implements store.PushEmailStore.
+func (*pushEmailStore) RemoveRepoPushEmailByName(ctx context.Context, tx db.Handler, repo string) error {
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`DELETE FROM repo_push_emails
+			WHERE repo_id = (
+				SELECT id FROM repos WHERE name = ?
+			);`)
+	_, err := tx.ExecContext(ctx, query, repo)
+	return err
+}
+
+// EnqueuePushEmail implements store.PushEmailStore.
+func (*pushEmailStore) EnqueuePushEmail(ctx context.Context, tx db.Handler, repo string, subject string, body string) error {
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`INSERT INTO push_email_queue (repo_id, subject, body)
+			VALUES (
+				(
+					SELECT id FROM repos WHERE name = ?
+				),
+				?,
+				?
+			);`)
+	_, err := tx.ExecContext(ctx, query, repo, subject, body)
+	return err
+}
+
+// ListPushEmails implements store.PushEmailStore.
+func (*pushEmailStore) ListPushEmails(ctx context.Context, tx db.Handler, limit int) ([]models.PushEmail, error) {
+	var ms []models.PushEmail
+	query := tx.Rebind(`SELECT push_email_queue.*, repos.name AS repo_name
+			FROM push_email_queue
+			INNER JOIN repos ON repos.id = push_email_queue.repo_id
+			ORDER BY push_email_queue.id ASC
+			LIMIT ?;`)
+	err := tx.SelectContext(ctx, &ms, query, limit)
+	return ms, err
+}
+
+// RemovePushEmail implements store.PushEmailStore.
+func (*pushEmailStore) RemovePushEmail(ctx context.Context, tx db.Handler, id int64) error {
+	query := tx.Rebind(`DELETE FROM push_email_queue WHERE id = ?;`)
+	_, err := tx.ExecContext(ctx, query, id)
+	return err
+}
+
+// IncrementPushEmailAttempts implements store.PushEmailStore.
+func (*pushEmailStore) IncrementPushEmailAttempts(ctx context.Context, tx db.Handler, id int64) error {
+	query := tx.Rebind(`UPDATE push_email_queue SET attempts = attempts + 1 WHERE id = ?;`)
+	_, err := tx.ExecContext(ctx, query, id)
+	return err
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+type readStore struct{}
+
+var _ store.ReadStore = (*readStore)(nil)
+
+// AddRepoRead implements store.ReadStore.
+func (*readStore) AddRepoRead(ctx context.Context, tx db.Handler, repo string, userID int64, transport string, clone bool) error {
+	repo = utils.SanitizeRepo(repo)
+	var uid *int64
+	if userID > 0 {
+		uid = &userID
+	}
+
+	query := tx.Rebind(`INSERT INTO repo_reads (repo_id, user_id, transport, clone)
+			VALUES (
+				(
+					SELECT id FROM repos WHERE name = ?
+				),
+				?,
+				?,
+				?
+			);`)
+	_, err := tx.ExecContext(ctx, query, repo, uid, transport, clone)
+	return err
+}
+
+// ListRepoReadsByName implements store.ReadStore.
+func (*readStore) ListRepoReadsByName(ctx context.Context, tx db.Handler, repo string, limit int) ([]models.RepoRead, error) {
+	var ms []models.RepoRead
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`SELECT repo_reads.*, users.username AS username
+			FROM repo_reads
+			INNER JOIN repos ON repos.id = repo_reads.repo_id
+			LEFT JOIN users ON users.id = repo_reads.user_id
+			WHERE repos.name = ?
+			ORDER BY repo_reads.created_at DESC, repo_reads.id DESC
+			LIMIT ?;`)
+	err := tx.SelectContext(ctx, &ms, query, repo, limit)
+	return ms, err
+}
+
+// DeleteRepoReadsBefore implements store.ReadStore.
+func (*readStore) DeleteRepoReadsBefore(ctx context.Context, tx db.Handler, before time.Time) (int64, error) {
+	query := tx.Rebind(`DELETE FROM repo_reads WHERE created_at < ?;`)
+	res, err := tx.ExecContext(ctx, query, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+type redirectStore struct{}
+
+var _ store.RedirectStore = (*redirectStore)(nil)
+
+// AddRepoRedirect implements store.RedirectStore.
+func (*redirectStore) AddRepoRedirect(ctx context.Context, tx db.Handler, name string, repo string) error {
+	name = utils.SanitizeRepo(name)
+	repo = utils.SanitizeRepo(repo)
+
+	// Replace any existing redirect using the same name.
+	if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM repo_redirects WHERE name = ?;`), name); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`INSERT INTO repo_redirects (name, repo_id, updated_at)
+			VALUES (
+				?,
+				(
+					SELECT id FROM repos WHERE name = ?
+				),
+				CURRENT_TIMESTAMP
+			);`)
+	_, err := tx.ExecContext(ctx, query, name, repo)
+	return err
+}
+
+// GetRepoRedirectByName implements store.RedirectStore.
+func (*redirectStore) GetRepoRedirectByName(ctx context.Context, tx db.Handler, name string) (models.RepoRedirect, error) {
+	var m models.RepoRedirect
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind(`SELECT repo_redirects.*, repos.name AS repo_name
+			FROM repo_redirects
+			INNER JOIN repos ON repos.id = repo_redirects.repo_id
+			WHERE repo_redirects.name = ?;`)
+	err := tx.GetContext(ctx, &m, query, name)
+	return m, err
+}
+
+// ListRepoRedirects implements store.RedirectStore.
+func (*redirectStore) ListRepoRedirects(ctx context.Context, tx db.Handler) ([]models.RepoRedirect, error) {
+	var ms []models.RepoRedirect
+	query := tx.Rebind(`SELECT repo_redirects.*, repos.name AS repo_name
+			FROM repo_redirects
+			INNER JOIN repos ON repos.id = repo_redirects.repo_id
+			ORDER BY repo_redirects.name ASC;`)
+	err := tx.SelectContext(ctx, &ms, query)
+	return ms, err
+}
+
+// RemoveRepoRedirectByName implements store.RedirectStore.
+func (*redirectStore) RemoveRepoRedirectByName(ctx context.Context, tx db.Handler, name string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind(`DELETE FROM repo_redirects WHERE name = ?;`)
+	_, err := tx.ExecContext(ctx, query, name)
+	return err
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -13,18 +15,25 @@ type repoStore struct{}
 
 var _ store.RepositoryStore = (*repoStore)(nil)
 
+// normalizedNameValue is the normalized name of a new repository, given the
+// lowercased name twice. It's empty when another repository has the same
+// normalized name, which only happens for repositories created before
+// case-only collisions were rejected.
+const normalizedNameValue = `CASE WHEN EXISTS (SELECT 1 FROM repos WHERE normalized_name = ?) THEN NULL ELSE ? END`
+
 // CreateRepo implements store.RepositoryStore.
 func (*repoStore) CreateRepo(ctx context.Context, tx db.Handler, name string, userID int64, projectName string, description string, isPrivate bool, isHidden bool, isMirror bool) error {
 	name = utils.SanitizeRepo(name)
+	normalized := strings.ToLower(name)
 	values := []interface{}{
-		name, projectName, description, isPrivate, isMirror, isHidden,
+		name, normalized, normalized, projectName, description, isPrivate, isMirror, isHidden,
 	}
-	query := `INSERT INTO repos (name, project_name, description, private, mirror, hidden, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP);`
+	query := `INSERT INTO repos (name, normalized_name, project_name, description, private, mirror, hidden, updated_at)
+			VALUES (?, ` + normalizedNameValue + `, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP);`
 	if userID > 0 {
-		query = `INSERT INTO repos (name, project_name, description, private, mirror, hidden, updated_at, user_id)
-			VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?);`
-		values = append(values, userID)
+		query = `INSERT INTO repos (name, normalized_name, project_name, description, private, mirror, hidden, updated_at, user_id, created_by)
+			VALUES (?, ` + normalizedNameValue + `, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?);`
+		values = append(values, userID, userID)
 	}
 
 	query = tx.Rebind(query)
@@ -56,6 +65,22 @@ func (*repoStore) GetUserRepos(ctx context.Context, tx db.Handler, userID int64)
 	return repos, db.WrapError(err)
 }
 
+// GetCollabRepos implements store.RepositoryStore.
+func (*repoStore) GetCollabRepos(ctx context.Context, tx db.Handler, userID int64) ([]models.Repo, error) {
+	var repos []models.Repo
+	query := tx.Rebind(`
+		SELECT
+			repos.*
+		FROM
+			repos
+		INNER JOIN collabs ON collabs.repo_id = repos.id
+		WHERE
+			collabs.user_id = ?;
+	`)
+	err := tx.SelectContext(ctx, &repos, query, userID)
+	return repos, db.WrapError(err)
+}
+
 // GetRepoByName implements store.RepositoryStore.
 func (*repoStore) GetRepoByName(ctx context.Context, tx db.Handler, name string) (models.Repo, error) {
 	var repo models.Repo
@@ -65,6 +90,15 @@ func (*repoStore) GetRepoByName(ctx context.Context, tx db.Handler, name string)
 	return repo, db.WrapError(err)
 }
 
+// GetRepoNameByNormalizedName implements store.RepositoryStore.
+func (*repoStore) GetRepoNameByNormalizedName(ctx context.Context, tx db.Handler, name string) (string, error) {
+	var repo string
+	name = strings.ToLower(utils.SanitizeRepo(name))
+	query := tx.Rebind("SELECT name FROM repos WHERE normalized_name = ?;")
+	err := tx.GetContext(ctx, &repo, query, name)
+	return repo, db.WrapError(err)
+}
+
 // GetRepoDescriptionByName implements store.RepositoryStore.
 func (*repoStore) GetRepoDescriptionByName(ctx context.Context, tx db.Handler, name string) (string, error) {
 	var description string
@@ -83,6 +117,15 @@ func (*repoStore) GetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name
 	return isHidden, db.WrapError(err)
 }
 
+// GetRepoIsArchivedByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsArchivedByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isArchived bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT archived FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isArchived, query, name)
+	return isArchived, db.WrapError(err)
+}
+
 // GetRepoIsMirrorByName implements store.RepositoryStore.
 func (*repoStore) GetRepoIsMirrorByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
 	var isMirror bool
@@ -92,6 +135,78 @@ func (*repoStore) GetRepoIsMirrorByName(ctx context.Context, tx db.Handler, name
 	return isMirror, db.WrapError(err)
 }
 
+// GetRepoRequireSignedCommitsByName implements store.RepositoryStore.
+func (*repoStore) GetRepoRequireSignedCommitsByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var require bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT require_signed_commits FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &require, query, name)
+	return require, db.WrapError(err)
+}
+
+// GetRepoReadAuditByName implements store.RepositoryStore.
+func (*repoStore) GetRepoReadAuditByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var audit bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT read_audit FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &audit, query, name)
+	return audit, db.WrapError(err)
+}
+
+// GetRepoSmudgeLFSArchivesByName implements store.RepositoryStore.
+func (*repoStore) GetRepoSmudgeLFSArchivesByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var smudge bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT smudge_lfs_archives FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &smudge, query, name)
+	return smudge, db.WrapError(err)
+}
+
+// GetRepoAllowBranchDeletionByName implements store.RepositoryStore.
+func (*repoStore) GetRepoAllowBranchDeletionByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var allow bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT allow_branch_deletion FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &allow, query, name)
+	return allow, db.WrapError(err)
+}
+
+// GetRepoAllowArchivesByName implements store.RepositoryStore.
+func (*repoStore) GetRepoAllowArchivesByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var allow bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT allow_archives FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &allow, query, name)
+	return allow, db.WrapError(err)
+}
+
+// GetRepoImmutableTagsByName implements store.RepositoryStore.
+func (*repoStore) GetRepoImmutableTagsByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var immutable bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT immutable_tags FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &immutable, query, name)
+	return immutable, db.WrapError(err)
+}
+
+// GetRepoPruneMergedBranchesByName implements store.RepositoryStore.
+func (*repoStore) GetRepoPruneMergedBranchesByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var prune bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT prune_merged_branches FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &prune, query, name)
+	return prune, db.WrapError(err)
+}
+
+// GetRepoRequireLinearHistoryByName implements store.RepositoryStore.
+func (*repoStore) GetRepoRequireLinearHistoryByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var require bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT require_linear_history FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &require, query, name)
+	return require, db.WrapError(err)
+}
+
 // GetRepoIsPrivateByName implements store.RepositoryStore.
 func (*repoStore) GetRepoIsPrivateByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
 	var isPrivate bool
@@ -113,11 +228,27 @@ func (*repoStore) GetRepoProjectNameByName(ctx context.Context, tx db.Handler, n
 // SetRepoDescriptionByName implements store.RepositoryStore.
 func (*repoStore) SetRepoDescriptionByName(ctx context.Context, tx db.Handler, name string, description string) error {
 	name = utils.SanitizeRepo(name)
-	query := tx.Rebind("UPDATE repos SET description = ? WHERE name = ?;")
+	query := tx.Rebind("UPDATE repos SET description = ?, description_generated = false WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, description, name)
+	return db.WrapError(err)
+}
+
+// SetRepoGeneratedDescriptionByName implements store.RepositoryStore.
+func (*repoStore) SetRepoGeneratedDescriptionByName(ctx context.Context, tx db.Handler, name string, description string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET description = ?, description_generated = true WHERE name = ?;")
 	_, err := tx.ExecContext(ctx, query, description, name)
 	return db.WrapError(err)
 }
 
+// SetRepoReadmeDescriptionByName implements store.RepositoryStore.
+func (*repoStore) SetRepoReadmeDescriptionByName(ctx context.Context, tx db.Handler, name string, enabled sql.NullBool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET readme_description = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, enabled, name)
+	return db.WrapError(err)
+}
+
 // SetRepoIsHiddenByName implements store.RepositoryStore.
 func (*repoStore) SetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name string, isHidden bool) error {
 	name = utils.SanitizeRepo(name)
@@ -126,6 +257,100 @@ func (*repoStore) SetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name
 	return db.WrapError(err)
 }
 
+// SetRepoIsArchivedByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsArchivedByName(ctx context.Context, tx db.Handler, name string, isArchived bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET archived = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isArchived, name)
+	return db.WrapError(err)
+}
+
+// SetRepoRequireSignedCommitsByName implements store.RepositoryStore.
+func (*repoStore) SetRepoRequireSignedCommitsByName(ctx context.Context, tx db.Handler, name string, require bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET require_signed_commits = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, require, name)
+	return db.WrapError(err)
+}
+
+// SetRepoReadAuditByName implements store.RepositoryStore.
+func (*repoStore) SetRepoReadAuditByName(ctx context.Context, tx db.Handler, name string, audit bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET read_audit = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, audit, name)
+	return db.WrapError(err)
+}
+
+// SetRepoSmudgeLFSArchivesByName implements store.RepositoryStore.
+func (*repoStore) SetRepoSmudgeLFSArchivesByName(ctx context.Context, tx db.Handler, name string, smudge bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET smudge_lfs_archives = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, smudge, name)
+	return db.WrapError(err)
+}
+
+// SetRepoAllowBranchDeletionByName implements store.RepositoryStore.
+func (*repoStore) SetRepoAllowBranchDeletionByName(ctx context.Context, tx db.Handler, name string, allow bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET allow_branch_deletion = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, allow, name)
+	return db.WrapError(err)
+}
+
+// SetRepoAllowArchivesByName implements store.RepositoryStore.
+func (*repoStore) SetRepoAllowArchivesByName(ctx context.Context, tx db.Handler, name string, allow bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET allow_archives = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, allow, name)
+	return db.WrapError(err)
+}
+
+// SetRepoImmutableTagsByName implements store.RepositoryStore.
+func (*repoStore) SetRepoImmutableTagsByName(ctx context.Context, tx db.Handler, name string, immutable bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET immutable_tags = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, immutable, name)
+	return db.WrapError(err)
+}
+
+// SetRepoPruneMergedBranchesByName implements store.RepositoryStore.
+func (*repoStore) SetRepoPruneMergedBranchesByName(ctx context.Context, tx db.Handler, name string, prune bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET prune_merged_branches = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, prune, name)
+	return db.WrapError(err)
+}
+
+// SetRepoRequireLinearHistoryByName implements store.RepositoryStore.
+func (*repoStore) SetRepoRequireLinearHistoryByName(ctx context.Context, tx db.Handler, name string, require bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET require_linear_history = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, require, name)
+	return db.WrapError(err)
+}
+
+// IncrRepoPushesSinceGCByName implements store.RepositoryStore.
+func (*repoStore) IncrRepoPushesSinceGCByName(ctx context.Context, tx db.Handler, name string) (int64, error) {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET pushes_since_gc = pushes_since_gc + 1 WHERE name = ?;")
+	if _, err := tx.ExecContext(ctx, query, name); err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	var pushes int64
+	query = tx.Rebind("SELECT pushes_since_gc FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &pushes, query, name)
+	return pushes, db.WrapError(err)
+}
+
+// ResetRepoPushesSinceGCByName implements store.RepositoryStore.
+func (*repoStore) ResetRepoPushesSinceGCByName(ctx context.Context, tx db.Handler, name string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET pushes_since_gc = 0 WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, name)
+	return db.WrapError(err)
+}
+
 // SetRepoIsPrivateByName implements store.RepositoryStore.
 func (*repoStore) SetRepoIsPrivateByName(ctx context.Context, tx db.Handler, name string, isPrivate bool) error {
 	name = utils.SanitizeRepo(name)
@@ -138,8 +363,19 @@ func (*repoStore) SetRepoIsPrivateByName(ctx context.Context, tx db.Handler, nam
 func (*repoStore) SetRepoNameByName(ctx context.Context, tx db.Handler, name string, newName string) error {
 	name = utils.SanitizeRepo(name)
 	newName = utils.SanitizeRepo(newName)
-	query := tx.Rebind("UPDATE repos SET name = ? WHERE name = ?;")
-	_, err := tx.ExecContext(ctx, query, newName, name)
+	normalized := strings.ToLower(newName)
+	query := tx.Rebind(`UPDATE repos SET name = ?,
+		normalized_name = CASE WHEN EXISTS (SELECT 1 FROM repos WHERE normalized_name = ? AND name <> ?) THEN NULL ELSE ? END
+		WHERE name = ?;`)
+	_, err := tx.ExecContext(ctx, query, newName, normalized, name, normalized, name)
+	return db.WrapError(err)
+}
+
+// SetRepoUserIDByName implements store.RepositoryStore.
+func (*repoStore) SetRepoUserIDByName(ctx context.Context, tx db.Handler, name string, userID int64) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET user_id = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, userID, name)
 	return db.WrapError(err)
 }
 
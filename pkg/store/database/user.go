@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
@@ -179,6 +181,72 @@ func (*userStore) ListPublicKeysByUsername(ctx context.Context, tx db.Handler, u
 	return pks, nil
 }
 
+// ListPublicKeyModelsByUsername implements store.UserStore.
+func (*userStore) ListPublicKeyModelsByUsername(ctx context.Context, tx db.Handler, username string) ([]models.PublicKey, error) {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	var ms []models.PublicKey
+	query := tx.Rebind(`SELECT public_keys.id, public_keys.user_id, public_keys.public_key, public_keys.comment, public_keys.last_used_at, public_keys.git_only
+			FROM public_keys
+			INNER JOIN users ON users.id = public_keys.user_id
+			WHERE users.username = ?
+			ORDER BY public_keys.id ASC;`)
+	err := tx.SelectContext(ctx, &ms, query, username)
+	return ms, err
+}
+
+// ListAllPublicKeyModels implements store.UserStore.
+func (*userStore) ListAllPublicKeyModels(ctx context.Context, tx db.Handler) ([]models.PublicKey, error) {
+	var ms []models.PublicKey
+	query := tx.Rebind(`SELECT id, user_id, public_key, comment, last_used_at, git_only
+			FROM public_keys
+			ORDER BY id ASC;`)
+	err := tx.SelectContext(ctx, &ms, query)
+	return ms, err
+}
+
+// SetPublicKeyComment implements store.UserStore.
+func (*userStore) SetPublicKeyComment(ctx context.Context, tx db.Handler, pk ssh.PublicKey, comment string) error {
+	query := tx.Rebind(`UPDATE public_keys SET comment = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE public_key = ?;`)
+	_, err := tx.ExecContext(ctx, query, comment, sshutils.MarshalAuthorizedKey(pk))
+	return err
+}
+
+// SetPublicKeyGitOnlyByID implements store.UserStore.
+func (*userStore) SetPublicKeyGitOnlyByID(ctx context.Context, tx db.Handler, id int64, gitOnly bool) error {
+	query := tx.Rebind(`UPDATE public_keys SET git_only = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?;`)
+	_, err := tx.ExecContext(ctx, query, gitOnly, id)
+	return err
+}
+
+// IsPublicKeyGitOnly implements store.UserStore.
+func (*userStore) IsPublicKeyGitOnly(ctx context.Context, tx db.Handler, pk ssh.PublicKey) (bool, error) {
+	var gitOnly bool
+	query := tx.Rebind(`SELECT git_only FROM public_keys WHERE public_key = ?;`)
+	err := tx.GetContext(ctx, &gitOnly, query, sshutils.MarshalAuthorizedKey(pk))
+	return gitOnly, err
+}
+
+// SetPublicKeyLastUsed implements store.UserStore.
+func (*userStore) SetPublicKeyLastUsed(ctx context.Context, tx db.Handler, pk ssh.PublicKey, t time.Time) error {
+	query := tx.Rebind(`UPDATE public_keys SET last_used_at = ?
+			WHERE public_key = ? AND (last_used_at IS NULL OR last_used_at < ?);`)
+	_, err := tx.ExecContext(ctx, query, t, sshutils.MarshalAuthorizedKey(pk), t)
+	return err
+}
+
+// RemovePublicKeyByID implements store.UserStore.
+func (*userStore) RemovePublicKeyByID(ctx context.Context, tx db.Handler, id int64) error {
+	query := tx.Rebind(`DELETE FROM public_keys WHERE id = ?;`)
+	_, err := tx.ExecContext(ctx, query, id)
+	return err
+}
+
 // RemovePublicKeyByUsername implements store.UserStore.
 func (*userStore) RemovePublicKeyByUsername(ctx context.Context, tx db.Handler, username string, pk ssh.PublicKey) error {
 	username = strings.ToLower(username)
@@ -240,3 +308,89 @@ func (*userStore) SetUserPasswordByUsername(ctx context.Context, tx db.Handler,
 	_, err := tx.ExecContext(ctx, query, password, username)
 	return err
 }
+
+// SetUserThemeByUsername implements store.UserStore.
+func (*userStore) SetUserThemeByUsername(ctx context.Context, tx db.Handler, username string, theme string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`UPDATE users SET theme = ? WHERE username = ?;`)
+	_, err := tx.ExecContext(ctx, query, theme, username)
+	return err
+}
+
+// SetUserAccessLevelByUsername implements store.UserStore.
+func (*userStore) SetUserAccessLevelByUsername(ctx context.Context, tx db.Handler, username string, level access.AccessLevel) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`UPDATE users SET access_level = ? WHERE username = ?;`)
+	_, err := tx.ExecContext(ctx, query, level.String(), username)
+	return err
+}
+
+// SetUserPendingByUsername implements store.UserStore.
+func (*userStore) SetUserPendingByUsername(ctx context.Context, tx db.Handler, username string, pending bool) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`UPDATE users SET pending = ? WHERE username = ?;`)
+	_, err := tx.ExecContext(ctx, query, pending, username)
+	return err
+}
+
+// SetUserTimeFormatByUsername implements store.UserStore.
+func (*userStore) SetUserTimeFormatByUsername(ctx context.Context, tx db.Handler, username string, format string) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`UPDATE users SET time_format = ? WHERE username = ?;`)
+	_, err := tx.ExecContext(ctx, query, format, username)
+	return err
+}
+
+// AddUserPinnedRepo implements store.UserStore.
+func (*userStore) AddUserPinnedRepo(ctx context.Context, tx db.Handler, userID int64, repo string) error {
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`INSERT INTO user_pinned_repos (user_id, repo_id)
+			VALUES (
+				?,
+				(
+					SELECT id FROM repos WHERE name = ?
+				)
+			)
+			ON CONFLICT (user_id, repo_id) DO NOTHING;`)
+	_, err := tx.ExecContext(ctx, query, userID, repo)
+	return err
+}
+
+// RemoveUserPinnedRepo implements store.UserStore.
+func (*userStore) RemoveUserPinnedRepo(ctx context.Context, tx db.Handler, userID int64, repo string) error {
+	repo = utils.SanitizeRepo(repo)
+	query := tx.Rebind(`DELETE FROM user_pinned_repos
+			WHERE user_id = ? AND repo_id = (
+				SELECT id FROM repos WHERE name = ?
+			);`)
+	_, err := tx.ExecContext(ctx, query, userID, repo)
+	return err
+}
+
+// ListUserPinnedRepos implements store.UserStore.
+func (*userStore) ListUserPinnedRepos(ctx context.Context, tx db.Handler, userID int64) ([]string, error) {
+	var names []string
+	query := tx.Rebind(`SELECT repos.name
+			FROM user_pinned_repos
+			INNER JOIN repos ON repos.id = user_pinned_repos.repo_id
+			WHERE user_pinned_repos.user_id = ?
+			ORDER BY user_pinned_repos.created_at ASC, user_pinned_repos.id ASC;`)
+	err := tx.SelectContext(ctx, &names, query, userID)
+	return names, err
+}
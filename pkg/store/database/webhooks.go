@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -28,14 +29,14 @@ func (*webhookStore) CreateWebhook(ctx context.Context, h db.Handler, repoID int
 }
 
 // CreateWebhookDelivery implements store.WebhookStore.
-func (*webhookStore) CreateWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestError error, requestHeaders string, requestBody string, responseStatus int, responseHeaders string, responseBody string) error {
-	query := h.Rebind(`INSERT INTO webhook_deliveries (id, webhook_id, event, request_url, request_method, request_error, request_headers, request_body, response_status, response_headers, response_body)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`)
+func (*webhookStore) CreateWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestError error, requestHeaders string, requestBody string, responseStatus int, responseHeaders string, responseBody string, duration time.Duration) error {
+	query := h.Rebind(`INSERT INTO webhook_deliveries (id, webhook_id, event, request_url, request_method, request_error, request_headers, request_body, response_status, response_headers, response_body, duration_ms)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`)
 	var reqErr string
 	if requestError != nil {
 		reqErr = requestError.Error()
 	}
-	_, err := h.ExecContext(ctx, query, id, webhookID, event, url, method, reqErr, requestHeaders, requestBody, responseStatus, responseHeaders, responseBody)
+	_, err := h.ExecContext(ctx, query, id, webhookID, event, url, method, reqErr, requestHeaders, requestBody, responseStatus, responseHeaders, responseBody, duration.Milliseconds())
 	return err
 }
 
@@ -73,6 +74,19 @@ func (*webhookStore) DeleteWebhookDeliveryByID(ctx context.Context, h db.Handler
 	return err
 }
 
+// PruneWebhookDeliveries implements store.WebhookStore.
+func (*webhookStore) PruneWebhookDeliveries(ctx context.Context, h db.Handler, webhookID int64, keep int) error {
+	query := h.Rebind(`DELETE FROM webhook_deliveries
+			WHERE webhook_id = ? AND id NOT IN (
+				SELECT id FROM webhook_deliveries
+				WHERE webhook_id = ?
+				ORDER BY created_at DESC, id DESC
+				LIMIT ?
+			);`)
+	_, err := h.ExecContext(ctx, query, webhookID, webhookID, keep)
+	return err
+}
+
 // DeleteWebhookEventsByWebhookID implements store.WebhookStore.
 func (*webhookStore) DeleteWebhookEventsByID(ctx context.Context, h db.Handler, ids []int64) error {
 	query, args, err := sqlx.In(`DELETE FROM webhook_events WHERE id IN (?);`, ids)
@@ -109,6 +123,17 @@ func (*webhookStore) GetWebhookDeliveryByID(ctx context.Context, h db.Handler, w
 	return whd, err
 }
 
+// GetWebhookDeliveryByRepoID implements store.WebhookStore.
+func (*webhookStore) GetWebhookDeliveryByRepoID(ctx context.Context, h db.Handler, repoID int64, id uuid.UUID) (models.WebhookDelivery, error) {
+	query := h.Rebind(`SELECT webhook_deliveries.*
+			FROM webhook_deliveries
+			INNER JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+			WHERE webhooks.repo_id = ? AND webhook_deliveries.id = ?;`)
+	var whd models.WebhookDelivery
+	err := h.GetContext(ctx, &whd, query, repoID, id)
+	return whd, err
+}
+
 // GetWebhookEventByID implements store.WebhookStore.
 func (*webhookStore) GetWebhookEventByID(ctx context.Context, h db.Handler, id int64) (models.WebhookEvent, error) {
 	query := h.Rebind(`SELECT * FROM webhook_events WHERE id = ?;`)
@@ -151,7 +176,10 @@ func (*webhookStore) GetWebhooksByRepoIDWhereEvent(ctx context.Context, h db.Han
 
 // ListWebhookDeliveriesByWebhookID implements store.WebhookStore.
 func (*webhookStore) ListWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error) {
-	query := h.Rebind(`SELECT id, response_status, event FROM webhook_deliveries WHERE webhook_id = ?;`)
+	query := h.Rebind(`SELECT id, webhook_id, event, request_error, response_status, SUBSTR(response_body, 1, 200) AS response_body, duration_ms, created_at
+			FROM webhook_deliveries
+			WHERE webhook_id = ?
+			ORDER BY created_at DESC, id DESC;`)
 	var whds []models.WebhookDelivery
 	err := h.SelectContext(ctx, &whds, query, webhookID)
 	return whds, err
@@ -163,3 +191,10 @@ func (*webhookStore) UpdateWebhookByID(ctx context.Context, h db.Handler, repoID
 	_, err := h.ExecContext(ctx, query, url, secret, contentType, active, repoID, id)
 	return err
 }
+
+// SetWebhookTemplateByID implements store.WebhookStore.
+func (*webhookStore) SetWebhookTemplateByID(ctx context.Context, h db.Handler, repoID int64, id int64, template string) error {
+	query := h.Rebind(`UPDATE webhooks SET template = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, template, repoID, id)
+	return err
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// PushEmailStore is an interface for managing push emails.
+type PushEmailStore interface {
+	GetRepoPushEmailByName(ctx context.Context, h db.Handler, repo string) (models.RepoPushEmail, error)
+	SetRepoPushEmailByName(ctx context.Context, h db.Handler, repo string, recipients string, from string, smtpURL string) error
+	RemoveRepoPushEmailByName(ctx context.Context, h db.Handler, repo string) error
+
+	EnqueuePushEmail(ctx context.Context, h db.Handler, repo string, subject string, body string) error
+	ListPushEmails(ctx context.Context, h db.Handler, limit int) ([]models.PushEmail, error)
+	RemovePushEmail(ctx context.Context, h db.Handler, id int64) error
+	IncrementPushEmailAttempts(ctx context.Context, h db.Handler, id int64) error
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ReadStore is an interface for managing audited repository reads.
+type ReadStore interface {
+	AddRepoRead(ctx context.Context, h db.Handler, repo string, userID int64, transport string, clone bool) error
+	ListRepoReadsByName(ctx context.Context, h db.Handler, repo string, limit int) ([]models.RepoRead, error)
+	DeleteRepoReadsBefore(ctx context.Context, h db.Handler, before time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// RedirectStore is an interface for managing repository redirects.
+type RedirectStore interface {
+	AddRepoRedirect(ctx context.Context, h db.Handler, name string, repo string) error
+	GetRepoRedirectByName(ctx context.Context, h db.Handler, name string) (models.RepoRedirect, error)
+	ListRepoRedirects(ctx context.Context, h db.Handler) ([]models.RepoRedirect, error)
+	RemoveRepoRedirectByName(ctx context.Context, h db.Handler, name string) error
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -10,19 +11,44 @@ import (
 // RepositoryStore is an interface for managing repositories.
 type RepositoryStore interface {
 	GetRepoByName(ctx context.Context, h db.Handler, name string) (models.Repo, error)
+	GetRepoNameByNormalizedName(ctx context.Context, h db.Handler, name string) (string, error)
 	GetAllRepos(ctx context.Context, h db.Handler) ([]models.Repo, error)
 	GetUserRepos(ctx context.Context, h db.Handler, userID int64) ([]models.Repo, error)
+	GetCollabRepos(ctx context.Context, h db.Handler, userID int64) ([]models.Repo, error)
 	CreateRepo(ctx context.Context, h db.Handler, name string, userID int64, projectName string, description string, isPrivate bool, isHidden bool, isMirror bool) error
 	DeleteRepoByName(ctx context.Context, h db.Handler, name string) error
 	SetRepoNameByName(ctx context.Context, h db.Handler, name string, newName string) error
+	SetRepoUserIDByName(ctx context.Context, h db.Handler, name string, userID int64) error
 
 	GetRepoProjectNameByName(ctx context.Context, h db.Handler, name string) (string, error)
 	SetRepoProjectNameByName(ctx context.Context, h db.Handler, name string, projectName string) error
 	GetRepoDescriptionByName(ctx context.Context, h db.Handler, name string) (string, error)
 	SetRepoDescriptionByName(ctx context.Context, h db.Handler, name string, description string) error
+	SetRepoGeneratedDescriptionByName(ctx context.Context, h db.Handler, name string, description string) error
+	SetRepoReadmeDescriptionByName(ctx context.Context, h db.Handler, name string, enabled sql.NullBool) error
 	GetRepoIsPrivateByName(ctx context.Context, h db.Handler, name string) (bool, error)
 	SetRepoIsPrivateByName(ctx context.Context, h db.Handler, name string, isPrivate bool) error
 	GetRepoIsHiddenByName(ctx context.Context, h db.Handler, name string) (bool, error)
 	SetRepoIsHiddenByName(ctx context.Context, h db.Handler, name string, isHidden bool) error
+	GetRepoIsArchivedByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsArchivedByName(ctx context.Context, h db.Handler, name string, isArchived bool) error
 	GetRepoIsMirrorByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	GetRepoRequireSignedCommitsByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoRequireSignedCommitsByName(ctx context.Context, h db.Handler, name string, require bool) error
+	GetRepoReadAuditByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoReadAuditByName(ctx context.Context, h db.Handler, name string, audit bool) error
+	GetRepoSmudgeLFSArchivesByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoSmudgeLFSArchivesByName(ctx context.Context, h db.Handler, name string, smudge bool) error
+	GetRepoAllowBranchDeletionByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoAllowBranchDeletionByName(ctx context.Context, h db.Handler, name string, allow bool) error
+	GetRepoPruneMergedBranchesByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoPruneMergedBranchesByName(ctx context.Context, h db.Handler, name string, prune bool) error
+	GetRepoRequireLinearHistoryByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoRequireLinearHistoryByName(ctx context.Context, h db.Handler, name string, require bool) error
+	GetRepoAllowArchivesByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoAllowArchivesByName(ctx context.Context, h db.Handler, name string, allow bool) error
+	GetRepoImmutableTagsByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoImmutableTagsByName(ctx context.Context, h db.Handler, name string, immutable bool) error
+	IncrRepoPushesSinceGCByName(ctx context.Context, h db.Handler, name string) (int64, error)
+	ResetRepoPushesSinceGCByName(ctx context.Context, h db.Handler, name string) error
 }
@@ -5,8 +5,12 @@ type Store interface {
 	RepositoryStore
 	UserStore
 	CollaboratorStore
+	DeployKeyStore
 	SettingStore
 	LFSStore
 	AccessTokenStore
 	WebhookStore
+	RedirectStore
+	PushEmailStore
+	ReadStore
 }
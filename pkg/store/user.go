@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
+	"time"
 
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"golang.org/x/crypto/ssh"
@@ -23,6 +25,20 @@ type UserStore interface {
 	RemovePublicKeyByUsername(ctx context.Context, h db.Handler, username string, pk ssh.PublicKey) error
 	ListPublicKeysByUserID(ctx context.Context, h db.Handler, id int64) ([]ssh.PublicKey, error)
 	ListPublicKeysByUsername(ctx context.Context, h db.Handler, username string) ([]ssh.PublicKey, error)
+	ListPublicKeyModelsByUsername(ctx context.Context, h db.Handler, username string) ([]models.PublicKey, error)
+	ListAllPublicKeyModels(ctx context.Context, h db.Handler) ([]models.PublicKey, error)
+	SetPublicKeyComment(ctx context.Context, h db.Handler, pk ssh.PublicKey, comment string) error
+	SetPublicKeyGitOnlyByID(ctx context.Context, h db.Handler, id int64, gitOnly bool) error
+	IsPublicKeyGitOnly(ctx context.Context, h db.Handler, pk ssh.PublicKey) (bool, error)
+	SetPublicKeyLastUsed(ctx context.Context, h db.Handler, pk ssh.PublicKey, t time.Time) error
+	RemovePublicKeyByID(ctx context.Context, h db.Handler, id int64) error
 	SetUserPassword(ctx context.Context, h db.Handler, userID int64, password string) error
 	SetUserPasswordByUsername(ctx context.Context, h db.Handler, username string, password string) error
+	SetUserThemeByUsername(ctx context.Context, h db.Handler, username string, theme string) error
+	SetUserAccessLevelByUsername(ctx context.Context, h db.Handler, username string, level access.AccessLevel) error
+	SetUserPendingByUsername(ctx context.Context, h db.Handler, username string, pending bool) error
+	SetUserTimeFormatByUsername(ctx context.Context, h db.Handler, username string, format string) error
+	AddUserPinnedRepo(ctx context.Context, h db.Handler, userID int64, repo string) error
+	RemoveUserPinnedRepo(ctx context.Context, h db.Handler, userID int64, repo string) error
+	ListUserPinnedRepos(ctx context.Context, h db.Handler, userID int64) ([]string, error)
 }
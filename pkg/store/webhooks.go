@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -20,6 +21,8 @@ type WebhookStore interface {
 	CreateWebhook(ctx context.Context, h db.Handler, repoID int64, url string, secret string, contentType int, active bool) (int64, error)
 	// UpdateWebhookByID updates a webhook by its ID.
 	UpdateWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, contentType int, active bool) error
+	// SetWebhookTemplateByID sets the payload template of a webhook by its ID.
+	SetWebhookTemplateByID(ctx context.Context, h db.Handler, repoID int64, id int64, template string) error
 	// DeleteWebhookByID deletes a webhook by its ID.
 	DeleteWebhookByID(ctx context.Context, h db.Handler, id int64) error
 	// DeleteWebhookForRepoByID deletes a webhook for a repository by its ID.
@@ -36,13 +39,21 @@ type WebhookStore interface {
 
 	// GetWebhookDeliveryByID returns a webhook delivery by its ID.
 	GetWebhookDeliveryByID(ctx context.Context, h db.Handler, webhookID int64, id uuid.UUID) (models.WebhookDelivery, error)
+	// GetWebhookDeliveryByRepoID returns a webhook delivery of any webhook of
+	// a repository by its ID.
+	GetWebhookDeliveryByRepoID(ctx context.Context, h db.Handler, repoID int64, id uuid.UUID) (models.WebhookDelivery, error)
 	// GetWebhookDeliveriesByWebhookID returns all webhook deliveries for a webhook.
 	GetWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error)
-	// ListWebhookDeliveriesByWebhookID returns all webhook deliveries for a webhook.
-	// This only returns the delivery ID, response status, and event.
+	// ListWebhookDeliveriesByWebhookID returns all webhook deliveries for a webhook,
+	// latest first. This only returns the delivery ID, event, request error,
+	// response status, duration, creation time, and the first 200 characters
+	// of the response body.
 	ListWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error)
 	// CreateWebhookDelivery creates a webhook delivery.
-	CreateWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestError error, requestHeaders string, requestBody string, responseStatus int, responseHeaders string, responseBody string) error
+	CreateWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestError error, requestHeaders string, requestBody string, responseStatus int, responseHeaders string, responseBody string, duration time.Duration) error
 	// DeleteWebhookDeliveryByID deletes a webhook delivery by its ID.
 	DeleteWebhookDeliveryByID(ctx context.Context, h db.Handler, webhookID int64, id uuid.UUID) error
+	// PruneWebhookDeliveries deletes the deliveries of a webhook except the
+	// latest keep ones.
+	PruneWebhookDeliveries(ctx context.Context, h db.Handler, webhookID int64, keep int) error
 }
@@ -0,0 +1,142 @@
+package sync
+
+import (
+	"context"
+	"sync"
+)
+
+// SchedulerPolicy is the policy used to admit waiting operations.
+type SchedulerPolicy string
+
+const (
+	// SchedulerFIFO admits waiting operations in arrival order.
+	SchedulerFIFO SchedulerPolicy = "fifo"
+
+	// SchedulerFair admits the waiting operation of the key with the fewest
+	// running operations first, so a single key can't hold all the slots
+	// while others are waiting. Ties are admitted in arrival order.
+	SchedulerFair SchedulerPolicy = "fair"
+)
+
+// Scheduler limits the number of operations running at the same time and
+// decides which waiting operation runs next when a slot frees up.
+type Scheduler struct {
+	policy SchedulerPolicy
+	limit  int
+
+	mu      sync.Mutex
+	running int
+	active  map[string]int
+	waiters []*waiter
+}
+
+type waiter struct {
+	key   string
+	ready chan struct{}
+}
+
+// NewScheduler returns a new scheduler that runs at most limit operations at
+// the same time. A limit of 0 or less admits every operation immediately.
+func NewScheduler(policy SchedulerPolicy, limit int) *Scheduler {
+	return &Scheduler{
+		policy: policy,
+		limit:  limit,
+		active: make(map[string]int),
+	}
+}
+
+// Acquire waits for a slot to run an operation for key. The returned function
+// must be called once the operation is done to free the slot. It returns the
+// context error if the context is done before a slot is available.
+func (s *Scheduler) Acquire(ctx context.Context, key string) (func(), error) {
+	s.mu.Lock()
+	if s.limit <= 0 || (s.running < s.limit && len(s.waiters) == 0) {
+		s.admit(key)
+		s.mu.Unlock()
+		return s.releaseFunc(key), nil
+	}
+
+	w := &waiter{key: key, ready: make(chan struct{})}
+	s.waiters = append(s.waiters, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return s.releaseFunc(key), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		for i, ww := range s.waiters {
+			if ww == w {
+				s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+				s.mu.Unlock()
+				return nil, ctx.Err()
+			}
+		}
+		s.mu.Unlock()
+
+		// The slot was granted while the context was done.
+		s.release(key)
+		return nil, ctx.Err()
+	}
+}
+
+// Running returns the number of running operations.
+func (s *Scheduler) Running() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Waiting returns the number of operations waiting for a slot.
+func (s *Scheduler) Waiting() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiters)
+}
+
+// admit must be called with mu held.
+func (s *Scheduler) admit(key string) {
+	s.running++
+	s.active[key]++
+}
+
+func (s *Scheduler) releaseFunc(key string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { s.release(key) })
+	}
+}
+
+func (s *Scheduler) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running--
+	if s.active[key]--; s.active[key] <= 0 {
+		delete(s.active, key)
+	}
+
+	for len(s.waiters) > 0 && (s.limit <= 0 || s.running < s.limit) {
+		i := s.next()
+		w := s.waiters[i]
+		s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+		s.admit(w.key)
+		close(w.ready)
+	}
+}
+
+// next returns the index of the next waiter to admit. It must be called with
+// mu held and at least one waiter.
+func (s *Scheduler) next() int {
+	if s.policy != SchedulerFair {
+		return 0
+	}
+
+	next := 0
+	for i, w := range s.waiters {
+		if s.active[w.key] < s.active[s.waiters[next].key] {
+			next = i
+		}
+	}
+	return next
+}
@@ -0,0 +1,100 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// acquireAsync acquires a slot for key in the background and sends the key on
+// admitted once it's admitted.
+func acquireAsync(t *testing.T, s *Scheduler, key string, admitted chan<- string) {
+	t.Helper()
+	waiting := s.Waiting()
+	go func() {
+		if _, err := s.Acquire(context.Background(), key); err != nil {
+			t.Errorf("acquire %s: %v", key, err)
+			return
+		}
+		admitted <- key
+	}()
+
+	// Wait for the operation to be queued so arrival order is deterministic.
+	for s.Waiting() == waiting {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerFIFO(t *testing.T) {
+	s := NewScheduler(SchedulerFIFO, 2)
+	r1, _ := s.Acquire(context.Background(), "alice")
+	r2, _ := s.Acquire(context.Background(), "alice")
+
+	admitted := make(chan string, 2)
+	acquireAsync(t, s, "alice", admitted)
+	acquireAsync(t, s, "bob", admitted)
+
+	r1()
+	if key := <-admitted; key != "alice" {
+		t.Fatalf("expected alice to be admitted first, got %s", key)
+	}
+
+	r2()
+	if key := <-admitted; key != "bob" {
+		t.Fatalf("expected bob to be admitted, got %s", key)
+	}
+}
+
+func TestSchedulerFair(t *testing.T) {
+	s := NewScheduler(SchedulerFair, 2)
+	r1, _ := s.Acquire(context.Background(), "alice")
+	r2, _ := s.Acquire(context.Background(), "alice")
+
+	admitted := make(chan string, 2)
+	acquireAsync(t, s, "alice", admitted)
+	acquireAsync(t, s, "bob", admitted)
+
+	// alice still runs an operation, bob runs none, so bob goes first.
+	r1()
+	if key := <-admitted; key != "bob" {
+		t.Fatalf("expected bob to be admitted first, got %s", key)
+	}
+
+	r2()
+	if key := <-admitted; key != "alice" {
+		t.Fatalf("expected alice to be admitted, got %s", key)
+	}
+}
+
+func TestSchedulerUnlimited(t *testing.T) {
+	s := NewScheduler(SchedulerFIFO, 0)
+	for i := 0; i < 10; i++ {
+		if _, err := s.Acquire(context.Background(), "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Running() != 10 {
+		t.Errorf("expected 10 running operations, got %d", s.Running())
+	}
+}
+
+func TestSchedulerContextDone(t *testing.T) {
+	s := NewScheduler(SchedulerFair, 1)
+	release, _ := s.Acquire(context.Background(), "alice")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx, "bob"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if s.Waiting() != 0 {
+		t.Errorf("expected no waiting operations, got %d", s.Waiting())
+	}
+
+	release()
+	release() // releasing twice is a no-op
+	if s.Running() != 0 {
+		t.Errorf("expected no running operations, got %d", s.Running())
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+)
+
+// NewBackend returns a new backend for testing, and a context holding the
+// backend, its config, database, and store.
+//
+// The backend keeps its metadata in an in-memory database and its
+// repositories in a temporary directory, both are removed when the test is
+// done. fns can modify the config before it's validated.
+func NewBackend(tb testing.TB, fns ...func(cfg *config.Config)) (context.Context, *backend.Backend) {
+	tb.Helper()
+	ctx := context.TODO()
+	cfg := config.DefaultConfig()
+	cfg.DataPath = tb.TempDir()
+	cfg.DB.Driver = "sqlite"
+	cfg.DB.DataSource = db.MemoryDataSource
+	cfg.DB.AllowInMemory = true
+	for _, fn := range fns {
+		fn(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		tb.Fatal(err)
+	}
+	ctx = config.WithContext(ctx, cfg)
+
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		if err := dbx.Close(); err != nil {
+			tb.Error(err)
+		}
+	})
+	if err := migrate.Migrate(ctx, dbx); err != nil {
+		tb.Fatal(err)
+	}
+	ctx = db.WithContext(ctx, dbx)
+
+	datastore := database.New(ctx, dbx)
+	ctx = store.WithContext(ctx, datastore)
+	be := backend.New(ctx, cfg, dbx, datastore)
+	ctx = backend.WithContext(ctx, be)
+
+	return ctx, be
+}
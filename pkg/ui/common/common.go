@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +14,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/ui/keymap"
 	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
 	"github.com/charmbracelet/ssh"
+	"github.com/dustin/go-humanize"
 	zone "github.com/lrstanley/bubblezone"
 	"github.com/muesli/termenv"
 )
@@ -38,6 +40,10 @@ type Common struct {
 	Output        *termenv.Output
 	Logger        *log.Logger
 	HideCloneCmd  bool
+
+	// timeFormat is shared by the copies of Common so toggling it applies to
+	// all components.
+	timeFormat *string
 }
 
 // NewCommon returns a new Common struct.
@@ -55,6 +61,8 @@ func NewCommon(ctx context.Context, out *lipgloss.Renderer, width, height int) C
 		KeyMap:   keymap.DefaultKeyMap(),
 		Zone:     zone.New(),
 		Logger:   log.FromContext(ctx).WithPrefix("ui"),
+
+		timeFormat: new(string),
 	}
 }
 
@@ -69,6 +77,55 @@ func (c *Common) SetSize(width, height int) {
 	c.Height = height
 }
 
+// TimeFormat returns the format of the timestamps, either
+// config.TimeFormatRelative or config.TimeFormatAbsolute.
+func (c *Common) TimeFormat() string {
+	if c.timeFormat != nil && *c.timeFormat == config.TimeFormatAbsolute {
+		return config.TimeFormatAbsolute
+	}
+	return config.TimeFormatRelative
+}
+
+// SetTimeFormat sets the format of the timestamps of all components.
+func (c *Common) SetTimeFormat(format string) {
+	if c.timeFormat == nil {
+		c.timeFormat = new(string)
+	}
+	*c.timeFormat = format
+}
+
+// FormatTime formats a timestamp relative to now, e.g. "3 days ago", or as
+// an absolute timestamp, e.g. "2024-03-01 14:05 UTC", depending on the time
+// format.
+func (c *Common) FormatTime(t time.Time) string {
+	if c.TimeFormat() == config.TimeFormatAbsolute {
+		return t.Format("2006-01-02 15:04 MST")
+	}
+	return humanize.Time(t)
+}
+
+// FormatDate formats the date of a timestamp for narrow columns, e.g. "Mar
+// 01", or "2024-03-01" with the absolute time format.
+func (c *Common) FormatDate(t time.Time) string {
+	if c.TimeFormat() == config.TimeFormatAbsolute {
+		return t.Format("2006-01-02")
+	}
+	date := t.Format("Jan 02")
+	if t.Year() != time.Now().Year() {
+		date += fmt.Sprintf(" %d", t.Year())
+	}
+	return date
+}
+
+// ColorProfile returns the color profile of the terminal the components are
+// rendered to.
+func (c *Common) ColorProfile() termenv.Profile {
+	if c.Renderer == nil {
+		return DefaultColorProfile
+	}
+	return c.Renderer.ColorProfile()
+}
+
 // Context returns the context.
 func (c *Common) Context() context.Context {
 	return c.ctx
@@ -80,7 +137,7 @@ func (c *Common) Config() *config.Config {
 }
 
 // Backend returns the Soft Serve backend.
-func (c *Common) Backend() *backend.Backend {
+func (c *Common) Backend() backend.Service {
 	return backend.FromContext(c.ctx)
 }
 
@@ -102,12 +159,16 @@ func (c *Common) PublicKey() ssh.PublicKey {
 	return nil
 }
 
-// CloneCmd returns the clone command string.
-func (c *Common) CloneCmd(publicURL, name string) string {
+// CloneCmd returns the clone command string of the preferred protocol.
+func (c *Common) CloneCmd(name string) string {
 	if c.HideCloneCmd {
 		return ""
 	}
-	return fmt.Sprintf("git clone %s", RepoURL(publicURL, name))
+	urls := CloneURLs(c.Config(), name)
+	if len(urls) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("git clone %s", urls[0])
 }
 
 // IsFileMarkdown returns true if the file is markdown.
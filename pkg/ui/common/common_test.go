@@ -1,9 +1,16 @@
 package common_test
 
 import (
+	"context"
+	"io"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/dustin/go-humanize"
 )
 
 func TestIsFileMarkdown(t *testing.T) {
@@ -28,3 +35,80 @@ func TestIsFileMarkdown(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneURLs(t *testing.T) {
+	cases := []struct {
+		name      string
+		preferred string
+		disabled  []string
+		want      []string
+	}{
+		{"default", "", nil, []string{"git@example.com:repo.git", "https://example.com/repo.git", "git://example.com/repo.git"}},
+		{"https first", "https", nil, []string{"https://example.com/repo.git", "git@example.com:repo.git", "git://example.com/repo.git"}},
+		{"git first", "git", nil, []string{"git://example.com/repo.git", "git@example.com:repo.git", "https://example.com/repo.git"}},
+		{"disabled preferred", "https", []string{"https"}, []string{"git@example.com:repo.git", "git://example.com/repo.git"}},
+		{"all disabled", "ssh", []string{"ssh", "https", "git"}, []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.SSH.PublicURL = "ssh://example.com"
+			cfg.HTTP.PublicURL = "https://example.com"
+			cfg.Git.PublicURL = "git://example.com"
+			cfg.UI.PreferredProtocol = c.preferred
+			for _, d := range c.disabled {
+				switch d {
+				case "ssh":
+					cfg.SSH.Enabled = false
+				case "https":
+					cfg.HTTP.Enabled = false
+				case "git":
+					cfg.Git.Enabled = false
+				}
+			}
+			if got := common.CloneURLs(cfg, "repo"); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("CloneURLs() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	c := common.NewCommon(context.TODO(), lipgloss.NewRenderer(io.Discard), 80, 24)
+	copied := c
+	ts := time.Date(2020, 3, 1, 14, 5, 0, 0, time.UTC)
+
+	if got, want := c.FormatTime(ts), humanize.Time(ts); got != want {
+		t.Errorf("FormatTime() = %q, want %q", got, want)
+	}
+	if got, want := c.FormatDate(ts), "Mar 01 2020"; got != want {
+		t.Errorf("FormatDate() = %q, want %q", got, want)
+	}
+
+	// Copies of Common share the time format.
+	c.SetTimeFormat(config.TimeFormatAbsolute)
+	if got, want := copied.FormatTime(ts), "2020-03-01 14:05 UTC"; got != want {
+		t.Errorf("FormatTime() = %q, want %q", got, want)
+	}
+	if got, want := copied.FormatDate(ts), "2020-03-01"; got != want {
+		t.Errorf("FormatDate() = %q, want %q", got, want)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	cases := []struct {
+		values []int64
+		want   string
+	}{
+		{nil, ""},
+		{[]int64{0, 0}, "▁▁"},
+		{[]int64{0, 1, 7, 14}, "▁▂▅█"},
+		{[]int64{1, 1000}, "▂█"},
+	}
+	for _, c := range cases {
+		if got := common.Sparkline(c.values); got != c.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", c.values, got, c.want)
+		}
+	}
+}
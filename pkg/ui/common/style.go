@@ -6,7 +6,9 @@ import (
 	"github.com/muesli/termenv"
 )
 
-// DefaultColorProfile is the default color profile used by the SSH server.
+// DefaultColorProfile is the color profile of the output rendered outside of
+// TUI sessions, e.g. by commands. TUI sessions use the profile of the client
+// terminal.
 var DefaultColorProfile = termenv.ANSI256
 
 func strptr(s string) *string {
@@ -38,8 +40,14 @@ func StyleRenderer() gansi.RenderContext {
 // StyleRendererWithStyles returns a new Glamour renderer with the
 // DefaultColorProfile and styles.
 func StyleRendererWithStyles(styles gansi.StyleConfig) gansi.RenderContext {
+	return StyleRendererWithProfile(styles, DefaultColorProfile)
+}
+
+// StyleRendererWithProfile returns a new Glamour renderer with the color
+// profile and styles.
+func StyleRendererWithProfile(styles gansi.StyleConfig, profile termenv.Profile) gansi.RenderContext {
 	return gansi.NewRenderContext(gansi.Options{
-		ColorProfile: DefaultColorProfile,
+		ColorProfile: profile,
 		Styles:       styles,
 	})
 }
@@ -3,7 +3,10 @@ package common
 import (
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
 
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/muesli/reflow/truncate"
 )
@@ -33,3 +36,62 @@ func RepoURL(publicURL, name string) string {
 
 	return fmt.Sprintf("%s/%s", publicURL, name)
 }
+
+// CloneURLs returns the URLs of the repository for the enabled servers,
+// starting with the preferred protocol of the UI.
+func CloneURLs(cfg *config.Config, name string) []string {
+	if cfg == nil {
+		return nil
+	}
+
+	servers := []struct {
+		protocol string
+		url      string
+		enabled  bool
+	}{
+		{"ssh", cfg.SSH.PublicURL, cfg.SSH.Enabled},
+		{"https", cfg.HTTP.PublicURL, cfg.HTTP.Enabled},
+		{"git", cfg.Git.PublicURL, cfg.Git.Enabled},
+	}
+
+	preferred := cfg.UI.PreferredProtocol
+	if preferred == "" {
+		preferred = "ssh"
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		return servers[i].protocol == preferred && servers[j].protocol != preferred
+	})
+
+	urls := make([]string, 0, len(servers))
+	for _, s := range servers {
+		if s.enabled && s.url != "" {
+			urls = append(urls, RepoURL(s.url, name))
+		}
+	}
+
+	return urls
+}
+
+// sparkLevels are the bars of sparklines, from lowest to highest.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns a bar per value, scaled to the highest value. Zero values
+// get the lowest bar, other values at least the second lowest.
+func Sparkline(values []int64) string {
+	var highest int64
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if v > 0 {
+			// Round up so that small values still show.
+			top := int64(len(sparkLevels) - 1)
+			level = int((v*top + highest - 1) / highest)
+		}
+		sb.WriteRune(sparkLevels[level])
+	}
+	return sb.String()
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	vp "github.com/charmbracelet/soft-serve/pkg/ui/components/viewport"
-	"github.com/muesli/termenv"
 )
 
 const (
@@ -51,7 +50,7 @@ func New(c common.Common, content, extension string) *Code {
 	}
 	st := common.StyleConfig()
 	r.styleConfig = st
-	r.renderContext = common.StyleRendererWithStyles(st)
+	r.renderContext = common.StyleRendererWithProfile(st, c.ColorProfile())
 	r.SetSize(c.Width, c.Height)
 	return r
 }
@@ -211,6 +210,7 @@ func (r *Code) glamourize(w int, md string) (string, error) {
 	}
 	tr, err := glamour.NewTermRenderer(
 		glamour.WithStyles(r.styleConfig),
+		glamour.WithColorProfile(r.common.ColorProfile()),
 		glamour.WithWordWrap(w),
 	)
 	if err != nil {
@@ -243,10 +243,7 @@ func (r *Code) renderFile(path, content string) (string, error) {
 		st := common.StyleConfig()
 		var m uint
 		st.CodeBlock.Margin = &m
-		rc = gansi.NewRenderContext(gansi.Options{
-			ColorProfile: termenv.TrueColor,
-			Styles:       st,
-		})
+		rc = common.StyleRendererWithProfile(st, r.common.ColorProfile())
 	}
 	err := formatter.Render(&s, rc)
 	if err != nil {
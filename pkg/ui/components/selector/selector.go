@@ -179,6 +179,13 @@ func (s *Selector) SetItems(items []IdentifiableItem) tea.Cmd {
 	return s.Model.SetItems(its)
 }
 
+// SetItem replaces the item at the given index.
+func (s *Selector) SetItem(index int, item IdentifiableItem) tea.Cmd {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.Model.SetItem(index, item)
+}
+
 // Index returns the index of the selected item.
 func (s *Selector) Index() int {
 	s.mtx.RLock()
@@ -200,6 +207,15 @@ func (s *Selector) VisibleItems() []list.Item {
 	return s.Model.VisibleItems()
 }
 
+// PageItems returns the visible items on the current page.
+func (s *Selector) PageItems() []list.Item {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	items := s.Model.VisibleItems()
+	start, end := s.Model.Paginator.GetSliceBounds(len(items))
+	return items[start:end]
+}
+
 // FilterState returns the filter state.
 func (s *Selector) FilterState() list.FilterState {
 	s.mtx.RLock()
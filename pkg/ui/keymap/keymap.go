@@ -23,6 +23,10 @@ type KeyMap struct {
 	BackItem   key.Binding
 
 	Copy key.Binding
+
+	TimeFormat key.Binding
+
+	Pin key.Binding
 }
 
 // DefaultKeyMap returns the default key map.
@@ -226,5 +230,25 @@ func DefaultKeyMap() *KeyMap {
 		),
 	)
 
+	km.TimeFormat = key.NewBinding(
+		key.WithKeys(
+			"T",
+		),
+		key.WithHelp(
+			"T",
+			"toggle relative dates",
+		),
+	)
+
+	km.Pin = key.NewBinding(
+		key.WithKeys(
+			"p",
+		),
+		key.WithHelp(
+			"p",
+			"pin",
+		),
+	)
+
 	return km
 }
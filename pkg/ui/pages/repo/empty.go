@@ -2,12 +2,23 @@ package repo
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 )
 
 func defaultEmptyRepoMsg(cfg *config.Config, repo string) string {
+	urls := common.CloneURLs(cfg, repo)
+	if len(urls) == 0 {
+		urls = []string{common.RepoURL(cfg.SSH.PublicURL, repo)}
+	}
+	// The other protocols are listed as alternatives.
+	clone := []string{"git clone " + urls[0]}
+	for _, u := range urls[1:] {
+		clone = append(clone, "# or: git clone "+u)
+	}
+
 	return fmt.Sprintf(`# Quick Start
 
 Get started by cloning this repository, add your files, commit, and push.
@@ -15,7 +26,7 @@ Get started by cloning this repository, add your files, commit, and push.
 ## Clone this repository.
 
 `+"```"+`sh
-git clone %[1]s
+%[3]s
 `+"```"+`
 
 ## Creating a new repository on the command line
@@ -24,17 +35,17 @@ git clone %[1]s
 touch README.md
 git init
 git add README.md
-git branch -M main
+git branch -M %[2]s
 git commit -m "first commit"
 git remote add origin %[1]s
-git push -u origin main
+git push -u origin %[2]s
 `+"```"+`
 
 ## Pushing an existing repository from the command line
 
 `+"```"+`sh
 git remote add origin %[1]s
-git push -u origin main
+git push -u origin %[2]s
 `+"```"+`
-`, common.RepoURL(cfg.SSH.PublicURL, repo))
+`, urls[0], cfg.Git.DefaultBranch, strings.Join(clone, "\n"))
 }
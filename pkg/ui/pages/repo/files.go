@@ -29,6 +29,7 @@ var (
 	errNoFileSelected = errors.New("no file selected")
 	errBinaryFile     = errors.New("binary file")
 	errInvalidFile    = errors.New("invalid file")
+	errMaxTreeDepth   = errors.New("maximum directory depth reached")
 )
 
 var (
@@ -251,6 +252,10 @@ func (f *Files) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case selector.SelectMsg:
 		switch sel := msg.IdentifiableItem.(type) {
 		case FileItem:
+			if sel.entry.IsTree() && f.maxDepthReached() {
+				cmds = append(cmds, common.ErrorCmd(errMaxTreeDepth))
+				break
+			}
 			f.currentItem = &sel
 			f.path = filepath.Join(f.path, sel.entry.Name())
 			if sel.entry.IsTree() {
@@ -258,6 +263,8 @@ func (f *Files) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				cmds = append(cmds, f.selectFileCmd)
 			}
+		case FileMoreItem:
+			cmds = append(cmds, f.loadMoreCmd(sel))
 		}
 	case GoBackMsg:
 		switch f.activeView {
@@ -304,6 +311,8 @@ func (f *Files) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch f.activeView {
 		case filesViewFiles:
 			if f.repo != nil {
+				// Reload as many entries as needed to keep the selection.
+				f.cursor = f.selector.Index()
 				cmds = append(cmds, f.updateFilesCmd)
 			}
 		case filesViewContent:
@@ -410,6 +419,13 @@ func (f *Files) updateFilesCmd() tea.Msg {
 		return common.ErrorCmd(err)
 	}
 	ents.Sort()
+
+	// Load the entries up to the cursor when going back to a directory.
+	limit := f.maxTreeEntries()
+	if limit > 0 && f.cursor >= limit {
+		limit = (f.cursor/limit + 1) * limit
+	}
+	ents, more := pageEntries(ents, limit)
 	for _, e := range ents {
 		if e.IsTree() {
 			dirs = append(dirs, FileItem{entry: e})
@@ -417,7 +433,63 @@ func (f *Files) updateFilesCmd() tea.Msg {
 			files = append(files, FileItem{entry: e})
 		}
 	}
-	return FileItemsMsg(append(dirs, files...))
+	items := append(dirs, files...)
+	if more != nil {
+		items = append(items, *more)
+	}
+	return FileItemsMsg(items)
+}
+
+// loadMoreCmd replaces the "load more" item with the next entries of the
+// directory.
+func (f *Files) loadMoreCmd(sel FileMoreItem) tea.Cmd {
+	loaded := f.selector.Items()
+	items := make([]selector.IdentifiableItem, 0, len(loaded))
+	for _, i := range loaded {
+		if i, ok := i.(FileItem); ok {
+			items = append(items, i)
+		}
+	}
+	f.cursor = len(items)
+	ents, more := pageEntries(sel.rest, f.maxTreeEntries())
+	for _, e := range ents {
+		items = append(items, FileItem{entry: e})
+	}
+	if more != nil {
+		items = append(items, *more)
+	}
+	return f.setItems(items)
+}
+
+// pageEntries returns the first limit entries, and an item to load the rest
+// of them if there are more. A limit of 0 returns all the entries.
+func pageEntries(ents git.Entries, limit int) (git.Entries, *FileMoreItem) {
+	if limit <= 0 || len(ents) <= limit {
+		return ents, nil
+	}
+	return ents[:limit], &FileMoreItem{rest: ents[limit:]}
+}
+
+func (f *Files) maxTreeEntries() int {
+	if cfg := f.common.Config(); cfg != nil {
+		return cfg.UI.MaxTreeEntries
+	}
+	return 0
+}
+
+// maxDepthReached returns true if the current directory is nested as deep as
+// the browser allows.
+func (f *Files) maxDepthReached() bool {
+	cfg := f.common.Config()
+	if cfg == nil || cfg.UI.MaxTreeDepth <= 0 {
+		return false
+	}
+	path := filepath.ToSlash(filepath.Clean(f.path))
+	depth := 0
+	if path != "." && path != "" {
+		depth = strings.Count(path, "/") + 1
+	}
+	return depth >= cfg.UI.MaxTreeDepth
 }
 
 func (f *Files) selectTreeCmd() tea.Msg {
@@ -467,7 +539,7 @@ func (f *Files) selectFileCmd() tea.Msg {
 			return common.ErrorMsg(errBinaryFile)
 		}
 
-		c, err := fi.Bytes()
+		c, err := fi.Contents()
 		if err != nil {
 			f.path = filepath.Dir(f.path)
 			return common.ErrorMsg(err)
@@ -43,6 +43,32 @@ func (i FileItem) Mode() fs.FileMode {
 // FilterValue implements list.Item.
 func (i FileItem) FilterValue() string { return i.Title() }
 
+// FileMoreItem is a list item that loads the remaining entries of a
+// directory that has more entries than the browser shows at once.
+type FileMoreItem struct {
+	// rest is the entries that aren't loaded yet.
+	rest git.Entries
+}
+
+// ID returns the ID of the item. Tree entries can't contain slashes so it
+// never clashes with a file name.
+func (i FileMoreItem) ID() string {
+	return "/more"
+}
+
+// Title returns the title of the item.
+func (i FileMoreItem) Title() string {
+	return fmt.Sprintf("… %d more entries", len(i.rest))
+}
+
+// Description returns the description of the item.
+func (i FileMoreItem) Description() string {
+	return ""
+}
+
+// FilterValue implements list.Item.
+func (i FileMoreItem) FilterValue() string { return "" }
+
 // FileItems is a list of file items.
 type FileItems []FileItem
 
@@ -93,6 +119,11 @@ func (d FileItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 
 // Render implements list.ItemDelegate.
 func (d FileItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	if more, ok := listItem.(FileMoreItem); ok {
+		d.renderMore(w, m, index, more)
+		return
+	}
+
 	i, ok := listItem.(FileItem)
 	if !ok {
 		return
@@ -154,3 +185,24 @@ func (d FileItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		),
 	)
 }
+
+func (d FileItemDelegate) renderMore(w io.Writer, m list.Model, index int, i FileMoreItem) {
+	s := d.common.Styles.Tree
+	nameStyle := s.Normal.FileDir
+	selector := " "
+	if index == m.Index() {
+		nameStyle = s.Active.FileDir
+		selector = ">"
+	}
+	fmt.Fprint(w, s.Selector.Render(selector)) //nolint:errcheck
+	truncate := d.common.Renderer.NewStyle().MaxWidth(m.Width() -
+		s.Selector.GetHorizontalFrameSize() -
+		s.Selector.GetWidth())
+	//nolint:errcheck
+	fmt.Fprint(w,
+		d.common.Zone.Mark(
+			i.ID(),
+			truncate.Render(nameStyle.Render(i.Title())),
+		),
+	)
+}
@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+)
+
+// IssueItem is an issue item.
+type IssueItem struct {
+	proto.Issue
+}
+
+// ID implements selector.IdentifiableItem.
+func (i IssueItem) ID() string {
+	return "issue-" + strconv.FormatInt(i.Issue.ID, 10)
+}
+
+// Title returns the title of the issue.
+func (i IssueItem) Title() string {
+	return i.Issue.Title
+}
+
+// Description returns the description of the issue.
+func (i IssueItem) Description() string {
+	return ""
+}
+
+// FilterValue implements list.Item.
+func (i IssueItem) FilterValue() string { return i.Title() }
+
+// IssueItemDelegate is the delegate for issue items.
+type IssueItemDelegate struct {
+	common *common.Common
+}
+
+// Height implements list.ItemDelegate.
+func (d IssueItemDelegate) Height() int { return 1 }
+
+// Spacing implements list.ItemDelegate.
+func (d IssueItemDelegate) Spacing() int { return 0 }
+
+// Update implements list.ItemDelegate.
+func (d IssueItemDelegate) Update(tea.Msg, *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render implements list.ItemDelegate.
+func (d IssueItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(IssueItem)
+	if !ok {
+		return
+	}
+
+	s := d.common.Styles.Issue
+	st := s.Normal.Title
+	selector := " "
+	if index == m.Index() {
+		selector = ">"
+		st = s.Active.Title
+	}
+
+	state := s.Open.Render(item.State.String())
+	if !item.IsOpen() {
+		state = s.Closed.Render(item.State.String())
+	}
+
+	meta := fmt.Sprintf("opened %s", d.common.FormatTime(item.CreatedAt))
+	if item.Author != "" {
+		meta += " by " + item.Author
+	}
+
+	line := lipgloss.JoinHorizontal(lipgloss.Top,
+		s.Selector.Render(selector),
+		st.Render(s.ID.Render(fmt.Sprintf("#%d", item.Issue.ID))+" "+item.Title()),
+		" ",
+		state,
+		" ",
+		s.Meta.Render(meta),
+	)
+	fmt.Fprint(w, d.common.Zone.Mark( //nolint:errcheck
+		item.ID(),
+		common.TruncateString(line, m.Width()),
+	))
+}
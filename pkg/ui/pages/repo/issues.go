@@ -0,0 +1,412 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/charmbracelet/soft-serve/pkg/ui/components/code"
+	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
+)
+
+type issuesState int
+
+const (
+	issuesStateLoading issuesState = iota
+	issuesStateList
+	issuesStateView
+	issuesStateForm
+)
+
+// IssueItemsMsg is a message sent when the issues are loaded.
+type IssueItemsMsg []proto.Issue
+
+// IssueCreatedMsg is a message sent when an issue is created.
+type IssueCreatedMsg proto.Issue
+
+// Issues is the issues component page.
+type Issues struct {
+	common    common.Common
+	repo      proto.Repository
+	list      *selector.Selector
+	code      *code.Code
+	spinner   spinner.Model
+	state     issuesState
+	current   *proto.Issue
+	title     textinput.Model
+	body      textarea.Model
+	newKey    key.Binding
+	submitKey key.Binding
+	nextKey   key.Binding
+}
+
+// NewIssues creates a new issues model.
+func NewIssues(common common.Common) *Issues {
+	s := spinner.New(spinner.WithSpinner(spinner.Dot),
+		spinner.WithStyle(common.Styles.Spinner))
+	sel := selector.New(common, []selector.IdentifiableItem{}, IssueItemDelegate{&common})
+	sel.SetShowFilter(false)
+	sel.SetShowHelp(false)
+	sel.SetShowPagination(false)
+	sel.SetShowStatusBar(false)
+	sel.SetShowTitle(false)
+	sel.SetFilteringEnabled(false)
+	sel.DisableQuitKeybindings()
+	sel.KeyMap.NextPage = common.KeyMap.NextPage
+	sel.KeyMap.PrevPage = common.KeyMap.PrevPage
+	c := code.New(common, "", "")
+	c.UseGlamour = true
+	c.NoContentStyle = c.NoContentStyle.SetString("No description provided.")
+	ti := textinput.New()
+	ti.Placeholder = "Title"
+	ti.CharLimit = 256
+	ta := textarea.New()
+	ta.Placeholder = "Leave a comment (markdown is supported)"
+	ta.ShowLineNumbers = false
+	return &Issues{
+		common:  common,
+		list:    sel,
+		code:    c,
+		spinner: s,
+		title:   ti,
+		body:    ta,
+		newKey: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "new issue"),
+		),
+		submitKey: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "submit"),
+		),
+		nextKey: key.NewBinding(
+			key.WithKeys("tab", "shift+tab"),
+			key.WithHelp("tab", "next field"),
+		),
+	}
+}
+
+// Path implements common.TabComponent.
+func (s *Issues) Path() string {
+	switch s.state {
+	case issuesStateView:
+		if s.current != nil {
+			return fmt.Sprintf("#%d", s.current.ID)
+		}
+	case issuesStateForm:
+		return "new"
+	}
+	return ""
+}
+
+// TabName returns the name of the tab.
+func (s *Issues) TabName() string {
+	return "Issues"
+}
+
+// CapturesInput returns whether the component is capturing keyboard input.
+func (s *Issues) CapturesInput() bool {
+	return s.state == issuesStateForm
+}
+
+// SetSize implements common.Component.
+func (s *Issues) SetSize(width, height int) {
+	s.common.SetSize(width, height)
+	s.list.SetSize(width, height)
+	s.code.SetSize(width, height)
+	margin := s.common.Styles.Issue.FormMargin.GetHorizontalFrameSize()
+	s.title.Width = width - margin - 2
+	s.body.SetWidth(width - margin)
+	// Leave room for the labels, the title input, and the help line.
+	s.body.SetHeight(max(height-8, 3))
+}
+
+// ShortHelp implements help.KeyMap.
+func (s *Issues) ShortHelp() []key.Binding {
+	switch s.state {
+	case issuesStateForm:
+		return []key.Binding{
+			s.nextKey,
+			s.submitKey,
+		}
+	case issuesStateView:
+		return []key.Binding{
+			s.common.KeyMap.UpDown,
+		}
+	default:
+		return []key.Binding{
+			s.common.KeyMap.SelectItem,
+			s.common.KeyMap.UpDown,
+			s.newKey,
+		}
+	}
+}
+
+// FullHelp implements help.KeyMap.
+func (s *Issues) FullHelp() [][]key.Binding {
+	switch s.state {
+	case issuesStateForm:
+		return [][]key.Binding{
+			{
+				s.nextKey,
+				s.submitKey,
+			},
+		}
+	case issuesStateView:
+		return [][]key.Binding{
+			{
+				s.code.KeyMap.PageDown,
+				s.code.KeyMap.PageUp,
+				s.code.KeyMap.HalfPageDown,
+				s.code.KeyMap.HalfPageUp,
+			},
+			{
+				s.code.KeyMap.Down,
+				s.code.KeyMap.Up,
+				s.common.KeyMap.GotoTop,
+				s.common.KeyMap.GotoBottom,
+			},
+		}
+	default:
+		k := s.list.KeyMap
+		return [][]key.Binding{
+			{
+				s.common.KeyMap.SelectItem,
+				s.newKey,
+			},
+			{
+				k.CursorUp,
+				k.CursorDown,
+				k.NextPage,
+				k.PrevPage,
+			},
+		}
+	}
+}
+
+// StatusBarValue implements statusbar.StatusBar.
+func (s *Issues) StatusBarValue() string {
+	switch s.state {
+	case issuesStateForm:
+		return "New issue"
+	case issuesStateView:
+		if s.current != nil {
+			return fmt.Sprintf("#%d %s", s.current.ID, s.current.Title)
+		}
+	default:
+		if item, ok := s.list.SelectedItem().(IssueItem); ok {
+			return fmt.Sprintf("#%d %s", item.Issue.ID, item.Title())
+		}
+	}
+	return " "
+}
+
+// StatusBarInfo implements statusbar.StatusBar.
+func (s *Issues) StatusBarInfo() string {
+	switch s.state {
+	case issuesStateList:
+		totalPages := s.list.TotalPages()
+		if totalPages <= 1 {
+			return "p. 1/1"
+		}
+		return fmt.Sprintf("p. %d/%d", s.list.Page()+1, totalPages)
+	case issuesStateView:
+		return fmt.Sprintf("☰ %d%%", s.code.ScrollPosition())
+	default:
+		return ""
+	}
+}
+
+// SpinnerID implements common.TabComponent.
+func (s *Issues) SpinnerID() int {
+	return s.spinner.ID()
+}
+
+// Init implements tea.Model.
+func (s *Issues) Init() tea.Cmd {
+	s.state = issuesStateLoading
+	s.current = nil
+	return tea.Batch(s.spinner.Tick, s.fetchIssues)
+}
+
+// Update implements tea.Model.
+func (s *Issues) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+	switch msg := msg.(type) {
+	case RepoMsg:
+		s.repo = msg
+		s.list.Select(0)
+		cmds = append(cmds, s.Init())
+	case tea.WindowSizeMsg:
+		s.SetSize(msg.Width, msg.Height)
+	case spinner.TickMsg:
+		if s.state == issuesStateLoading && s.spinner.ID() == msg.ID {
+			sp, cmd := s.spinner.Update(msg)
+			s.spinner = sp
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+	case IssueItemsMsg:
+		s.state = issuesStateList
+		items := make([]selector.IdentifiableItem, len(msg))
+		// Show the most recent issues first.
+		for i, issue := range msg {
+			items[len(msg)-1-i] = IssueItem{issue}
+		}
+		cmds = append(cmds, s.list.SetItems(items))
+	case IssueCreatedMsg:
+		cmds = append(cmds, s.Init())
+	case selector.SelectMsg:
+		switch i := msg.IdentifiableItem.(type) {
+		case IssueItem:
+			issue := i.Issue
+			s.current = &issue
+			s.state = issuesStateView
+			s.code.GotoTop()
+			cmds = append(cmds, s.code.SetContent(s.renderIssue(issue), ".md"))
+		}
+	case GoBackMsg:
+		s.state = issuesStateList
+		s.current = nil
+		s.title.Blur()
+		s.body.Blur()
+	case tea.KeyMsg:
+		switch s.state {
+		case issuesStateList:
+			switch {
+			case key.Matches(msg, s.common.KeyMap.SelectItem):
+				cmds = append(cmds, s.list.SelectItemCmd)
+			case key.Matches(msg, s.newKey):
+				if proto.UserFromContext(s.common.Context()) == nil {
+					return s, common.ErrorCmd(errors.New("you must be logged in to create issues"))
+				}
+				s.state = issuesStateForm
+				s.title.Reset()
+				s.body.Reset()
+				s.body.Blur()
+				return s, s.title.Focus()
+			}
+		case issuesStateForm:
+			switch {
+			case key.Matches(msg, s.submitKey):
+				return s, s.createIssue(s.title.Value(), s.body.Value())
+			case key.Matches(msg, s.nextKey):
+				if s.title.Focused() {
+					s.title.Blur()
+					return s, s.body.Focus()
+				}
+				s.body.Blur()
+				return s, s.title.Focus()
+			}
+		}
+	}
+	switch s.state {
+	case issuesStateList:
+		l, cmd := s.list.Update(msg)
+		s.list = l.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	case issuesStateView:
+		c, cmd := s.code.Update(msg)
+		s.code = c.(*code.Code)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	case issuesStateForm:
+		var cmd tea.Cmd
+		if s.title.Focused() {
+			s.title, cmd = s.title.Update(msg)
+		} else {
+			s.body, cmd = s.body.Update(msg)
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return s, tea.Batch(cmds...)
+}
+
+// View implements tea.Model.
+func (s *Issues) View() string {
+	switch s.state {
+	case issuesStateLoading:
+		return renderLoading(s.common, s.spinner)
+	case issuesStateList:
+		if len(s.list.Items()) == 0 {
+			return s.common.Styles.NoContent.Render("No issues found. Press n to create one.")
+		}
+		return s.list.View()
+	case issuesStateView:
+		return s.code.View()
+	case issuesStateForm:
+		st := s.common.Styles.Issue
+		return st.FormMargin.Render(lipgloss.JoinVertical(lipgloss.Left,
+			st.FormLabel.Render("Title"),
+			s.title.View(),
+			"",
+			st.FormLabel.Render("Description"),
+			s.body.View(),
+			st.FormHelp.Render("tab: next field • ctrl+s: submit • esc: cancel"),
+		))
+	}
+	return ""
+}
+
+func (s *Issues) renderIssue(issue proto.Issue) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s #%d\n\n", issue.Title, issue.ID)
+	meta := fmt.Sprintf("**%s** · opened %s", issue.State, s.common.FormatTime(issue.CreatedAt))
+	if issue.Author != "" {
+		meta += " by " + issue.Author
+	}
+	if !issue.IsOpen() {
+		meta += fmt.Sprintf(" · closed %s", s.common.FormatTime(issue.UpdatedAt))
+	}
+	sb.WriteString(meta + "\n\n---\n\n")
+	body := strings.TrimSpace(issue.Body)
+	if body == "" {
+		body = "_No description provided._"
+	}
+	sb.WriteString(body)
+	return sb.String()
+}
+
+func (s *Issues) fetchIssues() tea.Msg {
+	be := s.common.Backend()
+	if s.repo == nil || be == nil {
+		return IssueItemsMsg(nil)
+	}
+
+	issues, err := be.ListIssues(s.common.Context(), s.repo.Name())
+	if err != nil {
+		return common.ErrorMsg(err)
+	}
+
+	return IssueItemsMsg(issues)
+}
+
+func (s *Issues) createIssue(title, body string) tea.Cmd {
+	return func() tea.Msg {
+		be := s.common.Backend()
+		if s.repo == nil || be == nil {
+			return common.ErrorMsg(common.ErrMissingRepo)
+		}
+
+		ctx := s.common.Context()
+		issue, err := be.CreateIssue(ctx, s.repo.Name(), proto.UserFromContext(ctx), title, body)
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+
+		return IssueCreatedMsg(issue)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/viewport"
 	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
 	"github.com/muesli/reflow/wrap"
+	"github.com/muesli/termenv"
 )
 
 var waitBeforeLoading = time.Millisecond * 100
@@ -279,7 +280,7 @@ func (l *Log) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			lipgloss.JoinVertical(lipgloss.Left,
 				l.renderCommit(l.selectedCommit),
 				renderSummary(msg, l.common.Styles, l.common.Width),
-				renderDiff(msg, l.common.Width),
+				renderDiff(msg, l.common.Width, l.common.ColorProfile()),
 			),
 		)
 		l.vp.GotoTop()
@@ -293,7 +294,7 @@ func (l *Log) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				lipgloss.JoinVertical(lipgloss.Left,
 					l.renderCommit(l.selectedCommit),
 					renderSummary(l.currentDiff, l.common.Styles, l.common.Width),
-					renderDiff(l.currentDiff, l.common.Width),
+					renderDiff(l.currentDiff, l.common.Width, l.common.ColorProfile()),
 				),
 			)
 		}
@@ -500,7 +501,12 @@ func (l *Log) renderCommit(c *git.Commit) string {
 }
 
 func renderSummary(diff *git.Diff, styles *styles.Styles, width int) string {
-	stats := strings.Split(diff.Stats().String(), "\n")
+	return wrap.String(renderDiffStat(diff.Stats().String(), styles), width-2)
+}
+
+// renderDiffStat colors the additions and deletions of a diffstat.
+func renderDiffStat(stat string, styles *styles.Styles) string {
+	stats := strings.Split(stat, "\n")
 	for i, line := range stats {
 		ch := strings.Split(line, "|")
 		if len(ch) > 1 {
@@ -510,17 +516,17 @@ func renderSummary(diff *git.Diff, styles *styles.Styles, width int) string {
 			stats[i] = strings.Join(ch[:len(ch)-1], "|") + "|" + adddel
 		}
 	}
-	return wrap.String(strings.Join(stats, "\n"), width-2)
+	return strings.Join(stats, "\n")
 }
 
-func renderDiff(diff *git.Diff, width int) string {
+func renderDiff(diff *git.Diff, width int, profile termenv.Profile) string {
 	var s strings.Builder
 	var pr strings.Builder
 	diffChroma := &gansi.CodeBlockElement{
 		Code:     diff.Patch(),
 		Language: "diff",
 	}
-	err := diffChroma.Render(&pr, common.StyleRenderer())
+	err := diffChroma.Render(&pr, common.StyleRendererWithProfile(common.StyleConfig(), profile))
 	if err != nil {
 		s.WriteString(fmt.Sprintf("\n%s", err.Error()))
 	} else {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -124,10 +123,7 @@ func (d LogItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		}
 		who += " "
 	}
-	date := i.Committer.When.Format("Jan 02")
-	if i.Committer.When.Year() != time.Now().Year() {
-		date += fmt.Sprintf(" %d", i.Committer.When.Year())
-	}
+	date := d.common.FormatDate(i.Committer.When)
 	who += styles.Desc.Render("on ") + styles.Keyword.Render(date)
 	who = common.TruncateString(who, m.Width()-horizontalFrameSize)
 	fmt.Fprint(w, //nolint:errcheck
@@ -28,6 +28,9 @@ type Readme struct {
 	readmePath string
 	spinner    spinner.Model
 	isLoading  bool
+	// empty is true when the repository has no branches and the quick start
+	// is shown instead of the readme.
+	empty bool
 }
 
 // NewReadme creates a new readme model.
@@ -103,16 +106,23 @@ func (r *Readme) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		r.repo = msg
 	case RefMsg:
 		r.ref = msg
+		r.empty = false
 		cmds = append(cmds, r.Init())
 	case tea.WindowSizeMsg:
 		r.SetSize(msg.Width, msg.Height)
 	case EmptyRepoMsg:
+		r.isLoading = false
+		r.empty = true
 		cmds = append(cmds,
 			r.code.SetContent(defaultEmptyRepoMsg(r.common.Config(),
 				r.repo.Name()), ".md"),
 		)
 	case ReadmeMsg:
 		r.isLoading = false
+		// Don't replace the quick start with the missing readme.
+		if r.empty && msg.Content == "" {
+			break
+		}
 		r.readmePath = msg.Path
 		r.code.GotoTop()
 		cmds = append(cmds, r.code.SetContent(msg.Content, msg.Path))
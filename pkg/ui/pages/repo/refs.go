@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/soft-serve/git"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/charmbracelet/soft-serve/pkg/ui/components/code"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
 )
 
@@ -19,10 +20,57 @@ type RefMsg *git.Reference
 
 // RefItemsMsg is a message that contains a list of RefItem.
 type RefItemsMsg struct {
+	prefix        string
+	items         RefItems
+	defaultBranch string
+}
+
+// RefAheadBehindMsg is a message that contains the number of commits
+// branches are ahead and behind the default branch.
+type RefAheadBehindMsg struct {
 	prefix string
-	items  []selector.IdentifiableItem
+	counts map[string][2]int64
+}
+
+// RefCompareMsg is a message that contains the comparison of a reference
+// with the compare base.
+type RefCompareMsg struct {
+	prefix     string
+	base       string
+	head       string
+	comparison *git.Comparison
+	// mergeStrategy is the preferred merge strategy of the repository.
+	mergeStrategy proto.MergeStrategy
+	err           error
 }
 
+// maxCompareCommits is the maximum number of commits listed when comparing
+// references.
+const maxCompareCommits = 100
+
+type refsState int
+
+const (
+	refsStateList refsState = iota
+	refsStateComparing
+	refsStateCompare
+)
+
+var sortRefs = key.NewBinding(
+	key.WithKeys("s"),
+	key.WithHelp("s", "toggle sort"),
+)
+
+var compareRef = key.NewBinding(
+	key.WithKeys("v"),
+	key.WithHelp("v", "compare"),
+)
+
+var setCompareBase = key.NewBinding(
+	key.WithKeys("m"),
+	key.WithHelp("m", "set compare base"),
+)
+
 // Refs is a component that displays a list of references.
 type Refs struct {
 	common    common.Common
@@ -33,14 +81,35 @@ type Refs struct {
 	refPrefix string
 	spinner   spinner.Model
 	isLoading bool
+
+	// items are all the references in display order.
+	items         RefItems
+	defaultBranch string
+	sortByName    bool
+
+	// aheadBehind caches the ahead and behind counts of the branches
+	// relative to the default branch, they're loaded lazily for the
+	// visible page. pending are the branches being loaded.
+	aheadBehind map[string][2]int64
+	pending     map[string]bool
+
+	// state is whether the list or a comparison is shown. code displays the
+	// comparison of a reference with compareBase, the default branch when
+	// empty.
+	state       refsState
+	code        *code.Code
+	compareBase string
 }
 
 // NewRefs creates a new Refs component.
 func NewRefs(common common.Common, refPrefix string) *Refs {
 	r := &Refs{
-		common:    common,
-		refPrefix: refPrefix,
-		isLoading: true,
+		common:      common,
+		refPrefix:   refPrefix,
+		isLoading:   true,
+		aheadBehind: make(map[string][2]int64),
+		pending:     make(map[string]bool),
+		code:        code.New(common, "", ""),
 	}
 	s := selector.New(common, []selector.IdentifiableItem{}, RefItemDelegate{&common})
 	s.SetShowFilter(false)
@@ -76,10 +145,18 @@ func (r *Refs) TabName() string {
 func (r *Refs) SetSize(width, height int) {
 	r.common.SetSize(width, height)
 	r.selector.SetSize(width, height)
+	r.code.SetSize(width, height)
 }
 
 // ShortHelp implements help.KeyMap.
 func (r *Refs) ShortHelp() []key.Binding {
+	if r.state != refsStateList {
+		return []key.Binding{
+			r.common.KeyMap.BackItem,
+			r.common.KeyMap.UpDown,
+		}
+	}
+
 	copyKey := r.common.KeyMap.Copy
 	copyKey.SetHelp("c", "copy ref")
 	k := r.selector.KeyMap
@@ -88,11 +165,25 @@ func (r *Refs) ShortHelp() []key.Binding {
 		k.CursorUp,
 		k.CursorDown,
 		copyKey,
+		sortRefs,
+		compareRef,
 	}
 }
 
 // FullHelp implements help.KeyMap.
 func (r *Refs) FullHelp() [][]key.Binding {
+	if r.state != refsStateList {
+		return [][]key.Binding{
+			{r.common.KeyMap.BackItem},
+			{
+				r.code.KeyMap.Down,
+				r.code.KeyMap.Up,
+				r.common.KeyMap.GotoTop,
+				r.common.KeyMap.GotoBottom,
+			},
+		}
+	}
+
 	copyKey := r.common.KeyMap.Copy
 	copyKey.SetHelp("c", "copy ref")
 	k := r.selector.KeyMap
@@ -108,6 +199,11 @@ func (r *Refs) FullHelp() [][]key.Binding {
 			k.GoToStart,
 			k.GoToEnd,
 			copyKey,
+			sortRefs,
+		},
+		{
+			compareRef,
+			setCompareBase,
 		},
 	}
 }
@@ -115,6 +211,9 @@ func (r *Refs) FullHelp() [][]key.Binding {
 // Init implements tea.Model.
 func (r *Refs) Init() tea.Cmd {
 	r.isLoading = true
+	r.state = refsStateList
+	r.aheadBehind = make(map[string][2]int64)
+	r.pending = make(map[string]bool)
 	return tea.Batch(r.spinner.Tick, r.updateItemsCmd)
 }
 
@@ -132,13 +231,34 @@ func (r *Refs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		r.SetSize(msg.Width, msg.Height)
 	case RefItemsMsg:
 		if r.refPrefix == msg.prefix {
-			cmds = append(cmds, r.selector.SetItems(msg.items))
+			r.items = msg.items
+			r.defaultBranch = msg.defaultBranch
+			r.sortItems()
+			cmds = append(cmds, r.selector.SetItems(r.selectorItems()))
 			i := r.selector.SelectedItem()
 			if i != nil {
 				r.activeRef = i.(RefItem).Reference
 			}
 			r.isLoading = false
+			cmds = append(cmds, r.aheadBehindCmd())
+		}
+	case RefAheadBehindMsg:
+		if r.refPrefix == msg.prefix {
+			cmds = append(cmds, r.setAheadBehind(msg.counts))
+		}
+	case RefCompareMsg:
+		if r.refPrefix == msg.prefix && r.state == refsStateComparing {
+			if msg.err != nil {
+				r.state = refsStateList
+				cmds = append(cmds, common.ErrorCmd(msg.err))
+				break
+			}
+			r.state = refsStateCompare
+			cmds = append(cmds, r.code.SetContent(r.renderComparison(msg), ".txt"))
+			r.code.GotoTop()
 		}
+	case GoBackMsg:
+		r.state = refsStateList
 	case selector.ActiveMsg:
 		switch sel := msg.IdentifiableItem.(type) {
 		case RefItem:
@@ -153,15 +273,35 @@ func (r *Refs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			)
 		}
 	case tea.KeyMsg:
+		if r.state != refsStateList {
+			if key.Matches(msg, r.common.KeyMap.BackItem) {
+				cmds = append(cmds, goBackCmd)
+			}
+			break
+		}
 		switch {
 		case key.Matches(msg, r.common.KeyMap.SelectItem):
 			cmds = append(cmds, r.selector.SelectItemCmd)
+		case key.Matches(msg, sortRefs) && !r.isLoading:
+			r.sortByName = !r.sortByName
+			r.sortItems()
+			cmds = append(cmds, r.selector.SetItems(r.selectorItems()))
+			r.selectActiveRef()
+		case key.Matches(msg, compareRef) && !r.isLoading:
+			if i, ok := r.selector.SelectedItem().(RefItem); ok {
+				r.state = refsStateComparing
+				cmds = append(cmds, r.spinner.Tick, r.compareCmd(r.base(), i.ID()))
+			}
+		case key.Matches(msg, setCompareBase) && !r.isLoading:
+			if i, ok := r.selector.SelectedItem().(RefItem); ok {
+				r.compareBase = i.ID()
+			}
 		}
 	case EmptyRepoMsg:
 		r.ref = nil
-		cmds = append(cmds, r.setItems([]selector.IdentifiableItem{}))
+		cmds = append(cmds, r.setItems(RefItems{}))
 	case spinner.TickMsg:
-		if r.isLoading && r.spinner.ID() == msg.ID {
+		if (r.isLoading || r.state == refsStateComparing) && r.spinner.ID() == msg.ID {
 			s, cmd := r.spinner.Update(msg)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
@@ -169,18 +309,34 @@ func (r *Refs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			r.spinner = s
 		}
 	}
-	m, cmd := r.selector.Update(msg)
-	r.selector = m.(*selector.Selector)
-	if cmd != nil {
-		cmds = append(cmds, cmd)
+	if r.state == refsStateList {
+		m, cmd := r.selector.Update(msg)
+		r.selector = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			// The page might have changed.
+			cmds = append(cmds, r.aheadBehindCmd())
+		}
+	} else {
+		c, cmd := r.code.Update(msg)
+		r.code = c.(*code.Code)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 	return r, tea.Batch(cmds...)
 }
 
 // View implements tea.Model.
 func (r *Refs) View() string {
-	if r.isLoading {
+	switch {
+	case r.isLoading, r.state == refsStateComparing:
 		return renderLoading(r.common, r.spinner)
+	case r.state == refsStateCompare:
+		return r.code.View()
 	}
 	return r.selector.View()
 }
@@ -195,24 +351,69 @@ func (r *Refs) StatusBarValue() string {
 	if r.activeRef == nil {
 		return ""
 	}
+	if r.state != refsStateList {
+		return fmt.Sprintf("%s...%s", shortRef(r.base()), r.activeRef.Name().Short())
+	}
 	return r.activeRef.Name().String()
 }
 
 // StatusBarInfo implements statusbar.StatusBar.
 func (r *Refs) StatusBarInfo() string {
+	if r.state == refsStateCompare {
+		return fmt.Sprintf("☰ %d%%", r.code.ScrollPosition())
+	}
+	sortBy := "recent"
+	if r.sortByName {
+		sortBy = "name"
+	}
+	if r.compareBase != "" {
+		sortBy = fmt.Sprintf("base %s · %s", shortRef(r.compareBase), sortBy)
+	}
 	totalPages := r.selector.TotalPages()
 	if totalPages <= 1 {
-		return "p. 1/1"
+		return fmt.Sprintf("%s · p. 1/1", sortBy)
 	}
-	return fmt.Sprintf("p. %d/%d", r.selector.Page()+1, totalPages)
+	return fmt.Sprintf("%s · p. %d/%d", sortBy, r.selector.Page()+1, totalPages)
 }
 
 func (r *Refs) updateItemsCmd() tea.Msg {
-	its := make(RefItems, 0)
 	rr, err := r.repo.Open()
 	if err != nil {
 		return common.ErrorMsg(err)
 	}
+
+	// Branches are listed with a single git command to stay responsive on
+	// repositories with many branches.
+	if r.refPrefix == git.RefsHeads {
+		tips, err := rr.BranchTips()
+		if err != nil {
+			r.common.Logger.Debugf("ui: error getting branches: %v", err)
+			return common.ErrorMsg(err)
+		}
+
+		var defaultBranch string
+		if head, err := rr.HEAD(); err == nil {
+			defaultBranch = head.Name().String()
+		}
+
+		its := make(RefItems, len(tips))
+		for i := range tips {
+			tip := tips[i]
+			its[i] = RefItem{
+				Reference: tip.Reference,
+				Tip:       &tip,
+				IsDefault: tip.Name().String() == defaultBranch,
+			}
+		}
+
+		return RefItemsMsg{
+			items:         its,
+			prefix:        r.refPrefix,
+			defaultBranch: defaultBranch,
+		}
+	}
+
+	its := make(RefItems, 0)
 	refs, err := rr.References()
 	if err != nil {
 		r.common.Logger.Debugf("ui: error getting references: %v", err)
@@ -235,18 +436,13 @@ func (r *Refs) updateItemsCmd() tea.Msg {
 			its = append(its, refItem)
 		}
 	}
-	sort.Sort(its)
-	items := make([]selector.IdentifiableItem, len(its))
-	for i, it := range its {
-		items[i] = it
-	}
 	return RefItemsMsg{
-		items:  items,
+		items:  its,
 		prefix: r.refPrefix,
 	}
 }
 
-func (r *Refs) setItems(items []selector.IdentifiableItem) tea.Cmd {
+func (r *Refs) setItems(items RefItems) tea.Cmd {
 	return func() tea.Msg {
 		return RefItemsMsg{
 			items:  items,
@@ -255,6 +451,190 @@ func (r *Refs) setItems(items []selector.IdentifiableItem) tea.Cmd {
 	}
 }
 
+// sortItems sorts the items by name or by most recent commit.
+func (r *Refs) sortItems() {
+	if r.sortByName {
+		sort.SliceStable(r.items, func(i, j int) bool {
+			return r.items[i].Short() < r.items[j].Short()
+		})
+	} else {
+		sort.Stable(r.items)
+	}
+}
+
+// selectorItems returns the items with their cached ahead and behind counts.
+func (r *Refs) selectorItems() []selector.IdentifiableItem {
+	items := make([]selector.IdentifiableItem, len(r.items))
+	for i, it := range r.items {
+		if c, ok := r.aheadBehind[it.ID()]; ok {
+			it.Ahead, it.Behind, it.AheadBehindLoaded = c[0], c[1], true
+		}
+		items[i] = it
+	}
+	return items
+}
+
+// selectActiveRef selects the active reference after the items changed.
+func (r *Refs) selectActiveRef() {
+	if r.activeRef == nil {
+		return
+	}
+	for i, it := range r.items {
+		if it.ID() == r.activeRef.Name().String() {
+			r.selector.Select(i)
+			return
+		}
+	}
+}
+
+// aheadBehindCmd loads the ahead and behind counts of the branches on the
+// current page that aren't loaded yet.
+func (r *Refs) aheadBehindCmd() tea.Cmd {
+	if r.refPrefix != git.RefsHeads || r.repo == nil || r.defaultBranch == "" || r.isLoading {
+		return nil
+	}
+
+	var ids []string
+	for _, it := range r.selector.PageItems() {
+		i, ok := it.(RefItem)
+		if !ok || i.IsDefault || i.AheadBehindLoaded || r.pending[i.ID()] {
+			continue
+		}
+		r.pending[i.ID()] = true
+		ids = append(ids, i.ID())
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	repo, base, prefix, logger := r.repo, r.defaultBranch, r.refPrefix, r.common.Logger
+	return func() tea.Msg {
+		counts := make(map[string][2]int64, len(ids))
+		rr, err := repo.Open()
+		if err != nil {
+			logger.Debugf("ui: error opening repository: %v", err)
+			return RefAheadBehindMsg{prefix: prefix, counts: counts}
+		}
+
+		for _, id := range ids {
+			ahead, behind, err := rr.AheadBehind(base, id)
+			if err != nil {
+				logger.Debugf("ui: error counting commits ahead and behind %s: %v", id, err)
+				continue
+			}
+			counts[id] = [2]int64{ahead, behind}
+		}
+
+		return RefAheadBehindMsg{prefix: prefix, counts: counts}
+	}
+}
+
+// setAheadBehind updates the items with the loaded ahead and behind counts.
+func (r *Refs) setAheadBehind(counts map[string][2]int64) tea.Cmd {
+	for id, c := range counts {
+		r.aheadBehind[id] = c
+		delete(r.pending, id)
+	}
+
+	cmds := make([]tea.Cmd, 0)
+	for i, it := range r.selector.Items() {
+		item, ok := it.(RefItem)
+		if !ok {
+			continue
+		}
+		if c, ok := counts[item.ID()]; ok {
+			item.Ahead, item.Behind, item.AheadBehindLoaded = c[0], c[1], true
+			cmds = append(cmds, r.selector.SetItem(i, item))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// base returns the reference compared against.
+func (r *Refs) base() string {
+	switch {
+	case r.compareBase != "":
+		return r.compareBase
+	case r.defaultBranch != "":
+		return r.defaultBranch
+	}
+	return "HEAD"
+}
+
+// compareCmd compares head with base, like git log base..head and git diff
+// base...head.
+func (r *Refs) compareCmd(base, head string) tea.Cmd {
+	repo, prefix := r.repo, r.refPrefix
+	be, ctx, logger := r.common.Backend(), r.common.Context(), r.common.Logger
+	return func() tea.Msg {
+		msg := RefCompareMsg{prefix: prefix, base: base, head: head}
+		rr, err := repo.Open()
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+
+		msg.comparison, msg.err = rr.Compare(base, head, maxCompareCommits, 0)
+		if be != nil && msg.err == nil {
+			// The strategy is only a hint, compare without it.
+			msg.mergeStrategy, err = be.MergeStrategy(ctx, repo.Name())
+			if err != nil {
+				logger.Debugf("ui: error getting merge strategy: %v", err)
+			}
+		}
+		return msg
+	}
+}
+
+// renderComparison renders the commits and the diffstat of a comparison.
+func (r *Refs) renderComparison(msg RefCompareMsg) string {
+	st := r.common.Styles
+	c := msg.comparison
+	base, head := shortRef(msg.base), shortRef(msg.head)
+
+	var s strings.Builder
+	s.WriteString(st.Stash.Title.Render(fmt.Sprintf("Comparing %s...%s", base, head)))
+	s.WriteString("\n\n")
+	s.WriteString(c.Summary(base, head))
+	s.WriteString("\n")
+	if msg.mergeStrategy != "" {
+		s.WriteString(st.Ref.Normal.ItemDesc.Render("Preferred merge strategy: " + msg.mergeStrategy.String()))
+		s.WriteString("\n")
+	}
+
+	if len(c.Commits) > 0 {
+		s.WriteString("\n")
+		for _, commit := range c.Commits {
+			fmt.Fprintf(&s, "%s %s %s\n",
+				st.Log.CommitHash.Render(commit.ID.String()[:7]),
+				commit.Summary(),
+				st.Ref.Normal.ItemDesc.Render(commit.Author.Name),
+			)
+		}
+		if more := c.Ahead - int64(len(c.Commits)); more > 0 {
+			fmt.Fprintf(&s, "… %d more commits\n", more)
+		}
+	}
+
+	if c.DiffStat != "" {
+		s.WriteString("\n")
+		s.WriteString(renderDiffStat(c.DiffStat, st))
+	}
+
+	return s.String()
+}
+
+// shortRef returns the branch or tag name of a reference name.
+func shortRef(name string) string {
+	for _, prefix := range []string{git.RefsHeads, git.RefsTags} {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix)
+		}
+	}
+	return name
+}
+
 func switchRefCmd(ref *git.Reference) tea.Cmd {
 	return func() tea.Msg {
 		return RefMsg(ref)
@@ -12,7 +12,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/git"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
-	"github.com/dustin/go-humanize"
 	"github.com/muesli/reflow/truncate"
 )
 
@@ -21,6 +20,16 @@ type RefItem struct {
 	*git.Reference
 	*git.Tag
 	*git.Commit
+
+	// Tip is the tip commit information of a branch.
+	Tip *git.BranchTip
+	// IsDefault is true if the reference is the default branch.
+	IsDefault bool
+	// Ahead and Behind are the number of commits the branch is ahead and
+	// behind the default branch, they're set once AheadBehindLoaded is true.
+	Ahead             int64
+	Behind            int64
+	AheadBehindLoaded bool
 }
 
 // ID implements selector.IdentifiableItem.
@@ -46,6 +55,17 @@ func (i RefItem) Short() string {
 // FilterValue implements list.Item.
 func (i RefItem) FilterValue() string { return i.Short() }
 
+// when returns the date of the reference's commit, if known.
+func (i RefItem) when() (time.Time, bool) {
+	if i.Tip != nil {
+		return i.Tip.When, true
+	}
+	if i.Commit != nil {
+		return i.Commit.Author.When, true
+	}
+	return time.Time{}, false
+}
+
 // RefItems is a list of git references.
 type RefItems []RefItem
 
@@ -57,12 +77,12 @@ func (cl RefItems) Swap(i, j int) { cl[i], cl[j] = cl[j], cl[i] }
 
 // Less implements sort.Interface.
 func (cl RefItems) Less(i, j int) bool {
-	if cl[i].Commit != nil && cl[j].Commit != nil {
-		return cl[i].Commit.Author.When.After(cl[j].Commit.Author.When)
-	} else if cl[i].Commit != nil && cl[j].Commit == nil {
-		return true
+	wi, oki := cl[i].when()
+	wj, okj := cl[j].when()
+	if oki && okj {
+		return wi.After(wj)
 	}
-	return false
+	return oki && !okj
 }
 
 // RefItemDelegate is the delegate for the ref item.
@@ -125,6 +145,8 @@ func (d RefItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 	c := i.Commit
 	if c != nil {
 		sha = c.ID.String()[:7]
+	} else if i.Tip != nil && len(i.Tip.ID) >= 7 {
+		sha = i.Tip.ID[:7]
 	}
 
 	ref := i.Short()
@@ -132,10 +154,7 @@ func (d RefItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 	var desc string
 	if isTag {
 		if c != nil {
-			date := c.Committer.When.Format("Jan 02")
-			if c.Committer.When.Year() != time.Now().Year() {
-				date += fmt.Sprintf(" %d", c.Committer.When.Year())
-			}
+			date := d.common.FormatDate(c.Committer.When)
 			desc += " " + st.ItemDesc.Render(date)
 		}
 
@@ -162,6 +181,42 @@ func (d RefItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 				}
 			}
 		}
+	} else if t := i.Tip; t != nil {
+		var marker string
+		if i.IsDefault {
+			marker = "default"
+		} else if i.AheadBehindLoaded {
+			marker = fmt.Sprintf("↑%d ↓%d", i.Ahead, i.Behind)
+		}
+		if marker != "" {
+			desc += " " + st.ItemDesc.Render(marker)
+		}
+
+		info := "updated " + d.common.FormatTime(t.When)
+		if t.Author != "" {
+			info = t.Author + ", " + d.common.FormatTime(t.When)
+		}
+		margin := func() int {
+			return m.Width() -
+				horizontalFrameSize -
+				lipgloss.Width(selector) -
+				lipgloss.Width(ref) -
+				lipgloss.Width(desc) -
+				lipgloss.Width(sha) -
+				2 // 2 is for the padding and truncation symbol
+		}
+
+		if t.Summary != "" {
+			// Leave room for the author and date.
+			msgMargin := margin() - lipgloss.Width(info) - 2
+			if msgMargin > 0 {
+				msg := common.TruncateString(t.Summary, msgMargin)
+				desc += " " + st.ItemDesc.Faint(false).Render(msg)
+			}
+		}
+		if infoMargin := margin(); infoMargin >= 0 {
+			desc += " " + st.ItemDesc.Render(common.TruncateString(info, infoMargin))
+		}
 	} else if c != nil {
 		onMargin := m.Width() -
 			horizontalFrameSize -
@@ -171,7 +226,7 @@ func (d RefItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 			lipgloss.Width(sha) -
 			2 // 2 is for the padding and truncation symbol
 		if onMargin >= 0 {
-			on := common.TruncateString("updated "+humanize.Time(c.Committer.When), onMargin)
+			on := common.TruncateString("updated "+d.common.FormatTime(c.Committer.When), onMargin)
 			desc += " " + st.ItemDesc.Render(on)
 		}
 	}
@@ -3,6 +3,7 @@ package repo
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -10,12 +11,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/footer"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/statusbar"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/tabs"
+	"github.com/dustin/go-humanize"
 )
 
 type state int
@@ -46,6 +49,42 @@ type CopyMsg struct {
 // SwitchTabMsg is a message to switch tabs.
 type SwitchTabMsg common.TabComponent
 
+// repoStatsMsg is a message that contains the object statistics and the
+// disk usage of a repository.
+type repoStatsMsg struct {
+	repo  string
+	stats *git.ObjectStats
+	size  *backend.RepositorySize
+}
+
+// repoContributorsMsg is a message that contains the top contributors of a
+// repository.
+type repoContributorsMsg struct {
+	repo         string
+	contributors []git.Contributor
+}
+
+// repoActivityMsg is a message that contains the weekly commit activity of a
+// repository.
+type repoActivityMsg struct {
+	repo     string
+	activity []git.ActivityBucket
+}
+
+// repoCreatorMsg is a message that contains the username of the user who
+// created a repository.
+type repoCreatorMsg struct {
+	repo    string
+	creator string
+}
+
+// topContributors is the number of contributors shown in the header.
+const topContributors = 3
+
+// activityWeeks is the number of weeks of commit activity shown in the
+// header.
+const activityWeeks = 26
+
 // Repo is a view for a git repository.
 type Repo struct {
 	common       common.Common
@@ -55,6 +94,11 @@ type Repo struct {
 	statusbar    *statusbar.Model
 	panes        []common.TabComponent
 	ref          *git.Reference
+	stats        *git.ObjectStats
+	size         *backend.RepositorySize
+	contributors []git.Contributor
+	activity     []git.ActivityBucket
+	creator      string
 	state        state
 	spinner      spinner.Model
 	panesReady   []bool
@@ -153,11 +197,40 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RepoMsg:
 		// Set the state to loading when we get a new repository.
 		r.selectedRepo = msg
+		r.stats = nil
+		r.size = nil
+		r.contributors = nil
+		r.activity = nil
+		r.creator = ""
 		cmds = append(cmds,
 			r.Init(),
+			r.fetchStats(msg),
+			r.fetchContributors(msg),
+			r.fetchActivity(msg),
+			r.fetchCreator(msg),
 			// This will set the selected repo in each pane's model.
 			r.updateModels(msg),
 		)
+	case repoStatsMsg:
+		if r.selectedRepo != nil && r.selectedRepo.Name() == msg.repo {
+			r.stats = msg.stats
+			r.size = msg.size
+			// The header might have grown, update the panes' sizes.
+			r.SetSize(r.common.Width, r.common.Height)
+		}
+	case repoContributorsMsg:
+		if r.selectedRepo != nil && r.selectedRepo.Name() == msg.repo {
+			r.contributors = msg.contributors
+			r.SetSize(r.common.Width, r.common.Height)
+		}
+	case repoActivityMsg:
+		if r.selectedRepo != nil && r.selectedRepo.Name() == msg.repo {
+			r.activity = msg.activity
+		}
+	case repoCreatorMsg:
+		if r.selectedRepo != nil && r.selectedRepo.Name() == msg.repo {
+			r.creator = msg.creator
+		}
 	case RefMsg:
 		r.ref = msg
 		cmds = append(cmds, r.updateModels(msg))
@@ -172,14 +245,17 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tabs.ActiveTabMsg:
 		r.activeTab = int(msg)
 	case tea.KeyMsg, tea.MouseMsg:
-		t, cmd := r.tabs.Update(msg)
-		r.tabs = t.(*tabs.Tabs)
-		if cmd != nil {
-			cmds = append(cmds, cmd)
+		// Don't switch tabs while the active pane is capturing input.
+		if _, ok := msg.(tea.KeyMsg); !ok || !r.CapturesInput() {
+			t, cmd := r.tabs.Update(msg)
+			r.tabs = t.(*tabs.Tabs)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 		if r.selectedRepo != nil {
 			urlID := fmt.Sprintf("%s-url", r.selectedRepo.Name())
-			cmd := r.common.CloneCmd(r.common.Config().SSH.PublicURL, r.selectedRepo.Name())
+			cmd := r.common.CloneCmd(r.selectedRepo.Name())
 			if msg, ok := msg.(tea.MouseMsg); ok && r.common.Zone.Get(urlID).InBounds(msg) {
 				cmds = append(cmds, copyCmd(cmd, "Command copied to clipboard"))
 			}
@@ -223,8 +299,14 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, r.updateTabComponent(&Log{}, msg))
 	case RefItemsMsg:
 		cmds = append(cmds, r.updateTabComponent(&Refs{refPrefix: msg.prefix}, msg))
+	case RefAheadBehindMsg:
+		cmds = append(cmds, r.updateTabComponent(&Refs{refPrefix: msg.prefix}, msg))
+	case RefCompareMsg:
+		cmds = append(cmds, r.updateTabComponent(&Refs{refPrefix: msg.prefix}, msg))
 	case StashListMsg, StashPatchMsg:
 		cmds = append(cmds, r.updateTabComponent(&Stash{}, msg))
+	case IssueItemsMsg, IssueCreatedMsg:
+		cmds = append(cmds, r.updateTabComponent(&Issues{}, msg))
 	// We have two spinners, one is used to when loading the repository and the
 	// other is used when loading the log.
 	// Check if the spinner ID matches the spinner model.
@@ -277,7 +359,7 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RepoMsg, RefMsg, tabs.ActiveTabMsg, tea.KeyMsg, tea.MouseMsg,
 		FileItemsMsg, FileContentMsg, FileBlameMsg, selector.ActiveMsg,
 		LogItemsMsg, GoBackMsg, LogDiffMsg, EmptyRepoMsg,
-		StashListMsg, StashPatchMsg:
+		StashListMsg, StashPatchMsg, RefCompareMsg:
 		r.setStatusBarInfo()
 	}
 
@@ -342,15 +424,86 @@ func (r *Repo) headerView() string {
 	urlStyle := r.common.Styles.URLStyle.
 		Width(r.common.Width - lipgloss.Width(header) - 1).
 		Align(lipgloss.Right)
-	var url string
-	if cfg := r.common.Config(); cfg != nil {
-		url = r.common.CloneCmd(cfg.SSH.PublicURL, r.selectedRepo.Name())
-	}
+	url := r.common.CloneCmd(r.selectedRepo.Name())
 	url = common.TruncateString(url, r.common.Width-lipgloss.Width(header)-1)
 	url = r.common.Zone.Mark(
 		fmt.Sprintf("%s-url", r.selectedRepo.Name()),
 		urlStyle.Render(url),
 	)
+	// The header is at most two lines tall, the stats, the top
+	// contributors, the activity and the creation share the line below the
+	// URL.
+	info := make([]string, 0, 2)
+	var breakdown string
+	if r.stats != nil {
+		size := humanize.IBytes(uint64(r.stats.TotalSize())) //nolint:gosec
+		if s := r.size; s != nil {
+			size = humanize.IBytes(uint64(s.Total())) //nolint:gosec
+			breakdown = fmt.Sprintf(" (packs %s, loose %s, refs %s",
+				humanize.IBytes(uint64(s.Packs)), //nolint:gosec
+				humanize.IBytes(uint64(s.Loose)), //nolint:gosec
+				humanize.IBytes(uint64(s.Refs)),  //nolint:gosec
+			)
+			if s.LFS > 0 {
+				breakdown += ", LFS " + humanize.IBytes(uint64(s.LFS)) //nolint:gosec
+			}
+			breakdown += ")"
+		}
+		info = append(info, fmt.Sprintf("%s objects · %d%% loose · %d packs · %s",
+			humanize.Comma(r.stats.Objects()),
+			int(r.stats.LooseRatio()*100),
+			r.stats.Packs,
+			size,
+		))
+	}
+	if len(r.contributors) > 0 {
+		top := make([]string, 0, topContributors)
+		for i, c := range r.contributors {
+			if i == topContributors {
+				break
+			}
+			top = append(top, fmt.Sprintf("%s (%s)", c.Name, humanize.Comma(c.Commits)))
+		}
+		info = append(info, "by "+strings.Join(top, ", "))
+	}
+	if len(r.activity) > 0 {
+		counts := make([]int64, len(r.activity))
+		var total int64
+		for i, b := range r.activity {
+			counts[i] = b.Commits
+			total += b.Commits
+		}
+		// Don't draw a flat line for inactive repos.
+		if total > 0 {
+			info = append(info, common.Sparkline(counts))
+		}
+	}
+	if created := r.selectedRepo.CreatedAt(); !created.IsZero() {
+		c := "created " + r.common.FormatTime(created)
+		if r.creator != "" {
+			c += " by " + r.creator
+		}
+		info = append(info, c)
+	}
+	if len(info) > 0 {
+		width := r.common.Width - lipgloss.Width(header) - 1
+		// The disk usage breakdown is only shown if the whole line fits,
+		// it would push the contributors out otherwise.
+		if breakdown != "" {
+			detailed := append([]string{info[0] + breakdown}, info[1:]...)
+			if lipgloss.Width(strings.Join(detailed, " · ")) <= width {
+				info = detailed
+			}
+		}
+		stats := common.TruncateString(strings.Join(info, " · "), width)
+		url = lipgloss.JoinVertical(lipgloss.Right,
+			url,
+			r.common.Styles.Repo.HeaderStats.
+				Width(r.common.Width-lipgloss.Width(header)-1).
+				Align(lipgloss.Right).
+				Render(stats),
+		)
+	}
 
 	header = lipgloss.JoinHorizontal(lipgloss.Top, header, url)
 
@@ -377,6 +530,91 @@ func (r *Repo) setStatusBarInfo() {
 	r.statusbar.SetStatus(key, value, info, extra)
 }
 
+func (r *Repo) fetchStats(repo proto.Repository) tea.Cmd {
+	return func() tea.Msg {
+		be := r.common.Backend()
+		if be == nil || repo == nil {
+			return nil
+		}
+
+		stats, err := be.RepositoryStats(r.common.Context(), repo)
+		if err != nil {
+			r.common.Logger.Debugf("ui: repo: error getting stats: %v", err)
+			return nil
+		}
+
+		// The breakdown is optional, the stats are still shown without it.
+		size, err := be.RepositorySizeDetailed(r.common.Context(), repo.Name())
+		if err != nil {
+			r.common.Logger.Debugf("ui: repo: error getting size: %v", err)
+		}
+
+		return repoStatsMsg{repo: repo.Name(), stats: stats, size: size}
+	}
+}
+
+func (r *Repo) fetchContributors(repo proto.Repository) tea.Cmd {
+	return func() tea.Msg {
+		be := r.common.Backend()
+		if be == nil || repo == nil {
+			return nil
+		}
+
+		contributors, err := be.Contributors(r.common.Context(), repo, "")
+		if err != nil {
+			r.common.Logger.Debugf("ui: repo: error getting contributors: %v", err)
+			return nil
+		}
+
+		return repoContributorsMsg{repo: repo.Name(), contributors: contributors}
+	}
+}
+
+func (r *Repo) fetchActivity(repo proto.Repository) tea.Cmd {
+	return func() tea.Msg {
+		be := r.common.Backend()
+		if be == nil || repo == nil {
+			return nil
+		}
+
+		since := time.Now().AddDate(0, 0, -7*(activityWeeks-1))
+		activity, err := be.CommitActivity(r.common.Context(), repo, "", since, git.ActivityWeek)
+		if err != nil {
+			r.common.Logger.Debugf("ui: repo: error getting commit activity: %v", err)
+			return nil
+		}
+
+		return repoActivityMsg{repo: repo.Name(), activity: activity}
+	}
+}
+
+func (r *Repo) fetchCreator(repo proto.Repository) tea.Cmd {
+	return func() tea.Msg {
+		be := r.common.Backend()
+		if be == nil || repo == nil || repo.CreatedBy() == 0 {
+			return nil
+		}
+
+		user, err := be.UserByID(r.common.Context(), repo.CreatedBy())
+		if err != nil {
+			r.common.Logger.Debugf("ui: repo: error getting creator: %v", err)
+			return nil
+		}
+
+		return repoCreatorMsg{repo: repo.Name(), creator: user.Username()}
+	}
+}
+
+// CapturesInput returns whether the active pane is capturing keyboard input,
+// e.g. a text input is focused.
+func (r *Repo) CapturesInput() bool {
+	if r.activeTab >= len(r.panes) {
+		return false
+	}
+	c, ok := r.panes[r.activeTab].(interface{ CapturesInput() bool })
+	return ok && c.CapturesInput()
+}
+
 func (r *Repo) updateTabComponent(c common.TabComponent, msg tea.Msg) tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
 	for i, b := range r.panes {
@@ -201,7 +201,7 @@ func (s *Stash) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				title,
 				"",
 				renderSummary(msg.Diff, s.common.Styles, s.common.Width),
-				renderDiff(msg.Diff, s.common.Width),
+				renderDiff(msg.Diff, s.common.Width, s.common.ColorProfile()),
 			)
 			cmds = append(cmds, s.code.SetContent(content, ".diff"))
 			s.code.GotoTop()
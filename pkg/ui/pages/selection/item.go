@@ -13,7 +13,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
-	"github.com/dustin/go-humanize"
 )
 
 var _ sort.Interface = Items{}
@@ -28,6 +27,9 @@ func (it Items) Len() int {
 
 // Less implements sort.Interface.
 func (it Items) Less(i int, j int) bool {
+	if it[i].pinned != it[j].pinned {
+		return it[i].pinned
+	}
 	if it[i].lastUpdate == nil && it[j].lastUpdate != nil {
 		return false
 	}
@@ -50,6 +52,13 @@ type Item struct {
 	repo       proto.Repository
 	lastUpdate *time.Time
 	cmd        string
+	pinned     bool
+
+	// commit is the last commit of the repository. It's loaded lazily when
+	// the item becomes visible.
+	commit       *proto.CommitSummary
+	commitLoaded bool
+	empty        bool
 }
 
 // New creates a new Item.
@@ -59,14 +68,10 @@ func NewItem(c common.Common, repo proto.Repository) (Item, error) {
 	if !lu.IsZero() {
 		lastUpdate = &lu
 	}
-	var cmd string
-	if cfg := c.Config(); cfg != nil {
-		cmd = c.CloneCmd(cfg.SSH.PublicURL, repo.Name())
-	}
 	return Item{
 		repo:       repo,
 		lastUpdate: lastUpdate,
-		cmd:        cmd,
+		cmd:        c.CloneCmd(repo.Name()),
 	}, nil
 }
 
@@ -96,6 +101,22 @@ func (i Item) Command() string {
 	return i.cmd
 }
 
+// LastCommit returns the item last commit view.
+func (i Item) LastCommit() string {
+	switch {
+	case i.empty:
+		return "empty repository"
+	case i.commit == nil:
+		return ""
+	}
+
+	s := i.commit.ShortHash() + " " + i.commit.Summary
+	if i.commit.Author != "" {
+		s += " · " + i.commit.Author
+	}
+	return s
+}
+
 // ItemDelegate is the delegate for the item.
 type ItemDelegate struct {
 	common     *common.Common
@@ -168,12 +189,18 @@ func (d *ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	if i.repo.IsPrivate() {
 		title += " 🔒"
 	}
+	if i.repo.IsArchived() {
+		title += " 📦"
+	}
+	if i.pinned {
+		title += " 📌"
+	}
 	if isSelected {
 		title += " "
 	}
 	var updatedStr string
 	if i.lastUpdate != nil {
-		updatedStr = fmt.Sprintf(" Updated %s", humanize.Time(*i.lastUpdate))
+		updatedStr = fmt.Sprintf(" Updated %s", d.common.FormatTime(*i.lastUpdate))
 	}
 	if m.Width()-styles.Base.GetHorizontalFrameSize()-lipgloss.Width(updatedStr)-lipgloss.Width(title) <= 0 {
 		updatedStr = ""
@@ -203,6 +230,10 @@ func (d *ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	s.WriteString(desc)
 	s.WriteRune('\n')
 
+	commit := common.TruncateString(i.LastCommit(), m.Width()-styles.Base.GetHorizontalFrameSize())
+	s.WriteString(styles.Commit.Render(commit))
+	s.WriteRune('\n')
+
 	cmd := i.Command()
 	cmdStyler := styles.Command.Render
 	if d.copiedIdx == index {
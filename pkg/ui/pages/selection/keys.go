@@ -0,0 +1,34 @@
+package selection
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+// renderKeys renders the user's public keys, one per line, with their
+// fingerprint, type and comment. The key of the session is marked as
+// current.
+func (s *Selection) renderKeys(keys []proto.PublicKey, current ssh.PublicKey) string {
+	dim := s.common.Renderer.NewStyle().
+		Foreground(s.common.Styles.InactiveBorderColor)
+	var sb strings.Builder
+	for i, pk := range keys {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("  ")
+		sb.WriteString(pk.ShortFingerprint())
+		sb.WriteString("  ")
+		sb.WriteString(dim.Render(pk.Key.Type()))
+		if pk.Comment != "" {
+			sb.WriteString("  ")
+			sb.WriteString(pk.Comment)
+		}
+		if current != nil && ssh.FingerprintSHA256(current) == pk.Fingerprint() {
+			sb.WriteString(dim.Render(" (current)"))
+		}
+	}
+	return sb.String()
+}
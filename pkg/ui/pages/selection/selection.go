@@ -8,16 +8,18 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/code"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/tabs"
+	"github.com/charmbracelet/soft-serve/pkg/ui/components/viewport"
 )
 
 const (
 	defaultNoContent = "No readme found.\n\nCreate a `.soft-serve` repository and add a `README.md` file to display readme."
+	noKeysContent    = "No public keys."
 )
 
 type pane int
@@ -25,6 +27,7 @@ type pane int
 const (
 	selectorPane pane = iota
 	readmePane
+	keysPane
 	lastPane
 )
 
@@ -32,6 +35,7 @@ func (p pane) String() string {
 	return []string{
 		"Repositories",
 		"About",
+		"Keys",
 	}[p]
 }
 
@@ -39,15 +43,34 @@ func (p pane) String() string {
 type Selection struct {
 	common     common.Common
 	readme     *code.Code
+	keys       *viewport.Viewport
 	selector   *selector.Selector
 	activePane pane
 	tabs       *tabs.Tabs
+
+	// pendingCommits tracks the repositories whose last commit is being
+	// loaded.
+	pendingCommits map[string]struct{}
+}
+
+// lastCommitMsg is a message sent when the last commit of a repository is
+// loaded.
+type lastCommitMsg struct {
+	repo   string
+	commit *proto.CommitSummary
+	err    error
+}
+
+// pinMsg is a message sent when a repository is pinned or unpinned.
+type pinMsg struct {
+	repo   string
+	pinned bool
 }
 
 // New creates a new selection model.
 func New(c common.Common) *Selection {
 	ts := make([]string, lastPane)
-	for i, b := range []pane{selectorPane, readmePane} {
+	for i, b := range []pane{selectorPane, readmePane, keysPane} {
 		ts[i] = b.String()
 	}
 	t := tabs.New(c, ts)
@@ -57,9 +80,10 @@ func New(c common.Common) *Selection {
 	t.TabDot = c.Styles.TopLevelActiveTabDot
 	t.UseDot = true
 	sel := &Selection{
-		common:     c,
-		activePane: selectorPane, // start with the selector focused
-		tabs:       t,
+		common:         c,
+		activePane:     selectorPane, // start with the selector focused
+		tabs:           t,
+		pendingCommits: make(map[string]struct{}),
 	}
 	readme := code.New(c, "", "")
 	readme.UseGlamour = true
@@ -74,6 +98,8 @@ func New(c common.Common) *Selection {
 	selector.DisableQuitKeybindings()
 	sel.selector = selector
 	sel.readme = readme
+	sel.keys = viewport.New(c)
+	sel.keys.SetContent(c.Styles.NoContent.Render(noKeysContent))
 	return sel
 }
 
@@ -100,6 +126,7 @@ func (s *Selection) SetSize(width, height int) {
 	s.tabs.SetSize(width, height-hm)
 	s.selector.SetSize(width-wm, height-hm)
 	s.readme.SetSize(width-wm, height-hm-1) // -1 for readme status line
+	s.keys.SetSize(width-wm, height-hm)
 }
 
 // IsFiltering returns true if the selector is currently filtering.
@@ -124,6 +151,9 @@ func (s *Selection) ShortHelp() []key.Binding {
 			k.ClearFilter,
 			copyKey,
 		)
+		if s.canPin() {
+			kb = append(kb, s.common.KeyMap.Pin)
+		}
 	}
 	return kb
 }
@@ -136,7 +166,7 @@ func (s *Selection) FullHelp() [][]key.Binding {
 		},
 	}
 	switch s.activePane {
-	case readmePane:
+	case readmePane, keysPane:
 		k := s.readme.KeyMap
 		b = append(b, []key.Binding{
 			k.PageDown,
@@ -159,6 +189,9 @@ func (s *Selection) FullHelp() [][]key.Binding {
 				s.common.KeyMap.Select,
 				copyKey,
 			)
+			if s.canPin() {
+				b[0] = append(b[0], s.common.KeyMap.Pin)
+			}
 		}
 		b = append(b, []key.Binding{
 			k.CursorUp,
@@ -191,48 +224,129 @@ func (s *Selection) Init() tea.Cmd {
 	ctx := s.common.Context()
 	be := s.common.Backend()
 	pk := s.common.PublicKey()
-	if pk == nil && !be.AllowKeyless(ctx) {
+	if pk == nil && !be.AllowKeyless(ctx) && !be.AnonymousRepos() {
 		return nil
 	}
 
-	repos, err := be.Repositories(ctx)
+	// The server readme is shown to everyone.
+	if r, err := be.Repository(ctx, ".soft-serve"); err == nil {
+		if readme, path, err := backend.Readme(r, nil); err == nil {
+			readmeCmd = s.readme.SetContent(readme, path)
+		}
+	}
+
+	if user := proto.UserFromContext(ctx); user != nil {
+		if keys, err := be.UserPublicKeys(ctx, user.Username()); err != nil {
+			s.common.Logger.Debugf("ui: failed to get public keys: %v", err)
+		} else if len(keys) > 0 {
+			s.keys.SetContent(s.renderKeys(keys, pk))
+		}
+	}
+
+	repos, err := be.VisibleRepositories(ctx, pk)
 	if err != nil {
 		return common.ErrorCmd(err)
 	}
+	pinned := make(map[string]bool)
+	if names, err := be.PinnedRepositories(ctx, proto.UserFromContext(ctx)); err != nil {
+		s.common.Logger.Debugf("ui: failed to get pinned repositories: %v", err)
+	} else {
+		for _, n := range names {
+			pinned[n] = true
+		}
+	}
 	sortedItems := make(Items, 0)
 	for _, r := range repos {
-		if r.Name() == ".soft-serve" {
-			readme, path, err := backend.Readme(r, nil)
-			if err != nil {
-				continue
-			}
-
-			readmeCmd = s.readme.SetContent(readme, path)
-		}
-
 		if r.IsHidden() {
 			continue
 		}
-		al := be.AccessLevelByPublicKey(ctx, r.Name(), pk)
-		if al >= access.ReadOnlyAccess {
-			item, err := NewItem(s.common, r)
-			if err != nil {
-				s.common.Logger.Debugf("ui: failed to create item for %s: %v", r.Name(), err)
-				continue
-			}
-			sortedItems = append(sortedItems, item)
+		item, err := NewItem(s.common, r)
+		if err != nil {
+			s.common.Logger.Debugf("ui: failed to create item for %s: %v", r.Name(), err)
+			continue
 		}
+		item.pinned = pinned[r.Name()]
+		sortedItems = append(sortedItems, item)
 	}
+	s.pendingCommits = make(map[string]struct{})
+	return tea.Batch(
+		s.selector.Init(),
+		s.setItems(sortedItems),
+		readmeCmd,
+		s.loadVisibleCommits(),
+	)
+}
+
+// setItems sorts and sets the items of the selector.
+func (s *Selection) setItems(sortedItems Items) tea.Cmd {
 	sort.Sort(sortedItems)
 	items := make([]selector.IdentifiableItem, len(sortedItems))
 	for i, it := range sortedItems {
 		items[i] = it
 	}
-	return tea.Batch(
-		s.selector.Init(),
-		s.selector.SetItems(items),
-		readmeCmd,
-	)
+	return s.selector.SetItems(items)
+}
+
+// canPin returns whether the user can pin repositories, anonymous users
+// can't.
+func (s *Selection) canPin() bool {
+	return s.common.Backend() != nil && proto.UserFromContext(s.common.Context()) != nil
+}
+
+// togglePin pins the selected repository, or unpins it if it's pinned.
+func (s *Selection) togglePin() tea.Cmd {
+	item, ok := s.selector.SelectedItem().(Item)
+	if !ok || !s.canPin() {
+		return nil
+	}
+
+	ctx := s.common.Context()
+	be := s.common.Backend()
+	user := proto.UserFromContext(ctx)
+	repo := item.ID()
+	pinned := !item.pinned
+	return func() tea.Msg {
+		var err error
+		if pinned {
+			err = be.PinRepository(ctx, user, repo)
+		} else {
+			err = be.UnpinRepository(ctx, user, repo)
+		}
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+		return pinMsg{repo: repo, pinned: pinned}
+	}
+}
+
+// loadVisibleCommits loads the last commit of the repositories visible on the
+// current page that haven't been loaded yet.
+func (s *Selection) loadVisibleCommits() tea.Cmd {
+	be := s.common.Backend()
+	if be == nil {
+		return nil
+	}
+
+	ctx := s.common.Context()
+	cmds := make([]tea.Cmd, 0)
+	for _, it := range s.selector.PageItems() {
+		item, ok := it.(Item)
+		if !ok || item.commitLoaded {
+			continue
+		}
+		if _, ok := s.pendingCommits[item.ID()]; ok {
+			continue
+		}
+
+		s.pendingCommits[item.ID()] = struct{}{}
+		repo := item.repo
+		cmds = append(cmds, func() tea.Msg {
+			commit, err := be.LastCommit(ctx, repo)
+			return lastCommitMsg{repo: repo.Name(), commit: commit, err: err}
+		})
+	}
+
+	return tea.Batch(cmds...)
 }
 
 // Update implements tea.Model.
@@ -245,6 +359,11 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		k, cmd := s.keys.Update(msg)
+		s.keys = k.(*viewport.Viewport)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 		m, cmd := s.selector.Update(msg)
 		s.selector = m.(*selector.Selector)
 		if cmd != nil {
@@ -256,6 +375,9 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch {
 			case key.Matches(msg, s.common.KeyMap.Back):
 				cmds = append(cmds, s.selector.Init())
+			case key.Matches(msg, s.common.KeyMap.Pin) &&
+				s.activePane == selectorPane && !s.IsFiltering():
+				cmds = append(cmds, s.togglePin())
 			}
 		}
 		t, cmd := s.tabs.Update(msg)
@@ -265,6 +387,38 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case tabs.ActiveTabMsg:
 		s.activePane = pane(msg)
+	case lastCommitMsg:
+		delete(s.pendingCommits, msg.repo)
+		if msg.err != nil {
+			s.common.Logger.Debugf("ui: failed to get last commit for %s: %v", msg.repo, msg.err)
+		}
+		for i, it := range s.selector.Items() {
+			if item, ok := it.(Item); ok && item.ID() == msg.repo {
+				item.commit = msg.commit
+				item.commitLoaded = true
+				item.empty = msg.commit == nil && msg.err == nil
+				cmds = append(cmds, s.selector.SetItem(i, item))
+				break
+			}
+		}
+	case pinMsg:
+		sortedItems := make(Items, 0, len(s.selector.Items()))
+		for _, it := range s.selector.Items() {
+			if item, ok := it.(Item); ok {
+				if item.ID() == msg.repo {
+					item.pinned = msg.pinned
+				}
+				sortedItems = append(sortedItems, item)
+			}
+		}
+		cmds = append(cmds, s.setItems(sortedItems))
+		// Keep the repository selected where it moved to.
+		for i, it := range s.selector.Items() {
+			if item, ok := it.(Item); ok && item.ID() == msg.repo {
+				s.selector.Select(i)
+				break
+			}
+		}
 	}
 	switch s.activePane {
 	case readmePane:
@@ -273,12 +427,20 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+	case keysPane:
+		k, cmd := s.keys.Update(msg)
+		s.keys = k.(*viewport.Viewport)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	case selectorPane:
 		m, cmd := s.selector.Update(msg)
 		s.selector = m.(*selector.Selector)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		// Load the last commits of the items that became visible.
+		cmds = append(cmds, s.loadVisibleCommits())
 	}
 	return s, tea.Batch(cmds...)
 }
@@ -306,6 +468,11 @@ func (s *Selection) View() string {
 			s.readme.View(),
 			readmeStatus,
 		))
+	case keysPane:
+		ks := s.common.Renderer.NewStyle().
+			Width(s.common.Width - wm).
+			Height(s.common.Height - hm)
+		view = ks.Render(s.keys.View())
 	}
 	if s.activePane != selectorPane || s.FilterState() != list.Filtering {
 		tabs := s.common.Styles.Tabs.Render(s.tabs.View())
@@ -28,6 +28,7 @@ type Styles struct {
 			Desc    lipgloss.Style
 			Command lipgloss.Style
 			Updated lipgloss.Style
+			Commit  lipgloss.Style
 		}
 		Active struct {
 			Base    lipgloss.Style
@@ -35,17 +36,19 @@ type Styles struct {
 			Desc    lipgloss.Style
 			Command lipgloss.Style
 			Updated lipgloss.Style
+			Commit  lipgloss.Style
 		}
 	}
 
 	Repo struct {
-		Base       lipgloss.Style
-		Title      lipgloss.Style
-		Command    lipgloss.Style
-		Body       lipgloss.Style
-		Header     lipgloss.Style
-		HeaderName lipgloss.Style
-		HeaderDesc lipgloss.Style
+		Base        lipgloss.Style
+		Title       lipgloss.Style
+		Command     lipgloss.Style
+		Body        lipgloss.Style
+		Header      lipgloss.Style
+		HeaderName  lipgloss.Style
+		HeaderDesc  lipgloss.Style
+		HeaderStats lipgloss.Style
 	}
 
 	Footer      lipgloss.Style
@@ -141,6 +144,23 @@ type Styles struct {
 		Selector lipgloss.Style
 	}
 
+	Issue struct {
+		Normal struct {
+			Title lipgloss.Style
+		}
+		Active struct {
+			Title lipgloss.Style
+		}
+		ID         lipgloss.Style
+		Open       lipgloss.Style
+		Closed     lipgloss.Style
+		Meta       lipgloss.Style
+		Selector   lipgloss.Style
+		FormLabel  lipgloss.Style
+		FormHelp   lipgloss.Style
+		FormMargin lipgloss.Style
+	}
+
 	Spinner          lipgloss.Style
 	SpinnerContainer lipgloss.Style
 
@@ -166,15 +186,20 @@ type Styles struct {
 
 // DefaultStyles returns default styles for the UI.
 func DefaultStyles(r *lipgloss.Renderer) *Styles {
-	highlightColor := lipgloss.Color("210")
-	highlightColorDim := lipgloss.Color("174")
-	selectorColor := lipgloss.Color("167")
-	hashColor := lipgloss.Color("185")
+	return NewStyles(r, DarkTheme)
+}
+
+// NewStyles returns the styles of the UI with the colors of a theme.
+func NewStyles(r *lipgloss.Renderer, t Theme) *Styles {
+	highlightColor := t.Highlight
+	highlightColorDim := t.HighlightDim
+	selectorColor := t.Selector
+	hashColor := t.Hash
 
 	s := new(Styles)
 
-	s.ActiveBorderColor = lipgloss.Color("62")
-	s.InactiveBorderColor = lipgloss.Color("241")
+	s.ActiveBorderColor = t.ActiveBorder
+	s.InactiveBorderColor = t.InactiveBorder
 
 	s.App = r.NewStyle().
 		Margin(1, 2)
@@ -184,60 +209,66 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		MarginLeft(1).
 		MarginBottom(1).
 		Padding(0, 1).
-		Background(lipgloss.Color("57")).
-		Foreground(lipgloss.Color("229")).
+		Background(t.ServerNameBackground).
+		Foreground(t.ServerNameForeground).
 		Bold(true)
 
 	s.TopLevelNormalTab = r.NewStyle().
 		MarginRight(2)
 
 	s.TopLevelActiveTab = s.TopLevelNormalTab.
-		Foreground(lipgloss.Color("36"))
+		Foreground(t.Accent)
 
 	s.TopLevelActiveTabDot = r.NewStyle().
-		Foreground(lipgloss.Color("36"))
+		Foreground(t.Accent)
 
 	s.RepoSelector.Normal.Base = r.NewStyle().
 		PaddingLeft(1).
 		Border(lipgloss.Border{Left: " "}, false, false, false, true).
-		Height(3)
+		Height(4)
 
 	s.RepoSelector.Normal.Title = r.NewStyle().Bold(true)
 
 	s.RepoSelector.Normal.Desc = r.NewStyle().
-		Foreground(lipgloss.Color("243"))
+		Foreground(t.Muted)
 
 	s.RepoSelector.Normal.Command = r.NewStyle().
-		Foreground(lipgloss.Color("132"))
+		Foreground(t.Command)
 
 	s.RepoSelector.Normal.Updated = r.NewStyle().
-		Foreground(lipgloss.Color("243"))
+		Foreground(t.Muted)
+
+	s.RepoSelector.Normal.Commit = r.NewStyle().
+		Foreground(t.Subtle)
 
 	s.RepoSelector.Active.Base = s.RepoSelector.Normal.Base.
 		BorderStyle(lipgloss.Border{Left: "┃"}).
-		BorderForeground(lipgloss.Color("176"))
+		BorderForeground(t.ActiveItemBorder)
 
 	s.RepoSelector.Active.Title = s.RepoSelector.Normal.Title.
-		Foreground(lipgloss.Color("212"))
+		Foreground(t.Primary)
 
 	s.RepoSelector.Active.Desc = s.RepoSelector.Normal.Desc.
-		Foreground(lipgloss.Color("246"))
+		Foreground(t.TextDim)
 
 	s.RepoSelector.Active.Updated = s.RepoSelector.Normal.Updated.
-		Foreground(lipgloss.Color("212"))
+		Foreground(t.Primary)
 
 	s.RepoSelector.Active.Command = s.RepoSelector.Normal.Command.
-		Foreground(lipgloss.Color("204"))
+		Foreground(t.CommandActive)
+
+	s.RepoSelector.Active.Commit = s.RepoSelector.Normal.Commit.
+		Foreground(t.TextDim)
 
 	s.MenuItem = r.NewStyle().
 		PaddingLeft(1).
 		Border(lipgloss.Border{
 			Left: " ",
 		}, false, false, false, true).
-		Height(3)
+		Height(4)
 
 	s.MenuLastUpdate = r.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(t.Subtle).
 		Align(lipgloss.Right)
 
 	s.Repo.Base = r.NewStyle()
@@ -246,7 +277,7 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		Padding(0, 2)
 
 	s.Repo.Command = r.NewStyle().
-		Foreground(lipgloss.Color("168"))
+		Foreground(t.Link)
 
 	s.Repo.Body = r.NewStyle().
 		Margin(1, 0)
@@ -254,14 +285,18 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 	s.Repo.Header = r.NewStyle().
 		MaxHeight(2).
 		Border(lipgloss.NormalBorder(), false, false, true, false).
-		BorderForeground(lipgloss.Color("236"))
+		BorderForeground(t.Border)
 
 	s.Repo.HeaderName = r.NewStyle().
-		Foreground(lipgloss.Color("212")).
+		Foreground(t.Primary).
 		Bold(true)
 
 	s.Repo.HeaderDesc = r.NewStyle().
-		Foreground(lipgloss.Color("243"))
+		Foreground(t.Muted)
+
+	s.Repo.HeaderStats = r.NewStyle().
+		MarginLeft(1).
+		Foreground(t.Subtle)
 
 	s.Footer = r.NewStyle().
 		MarginTop(1).
@@ -269,35 +304,35 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		Height(1)
 
 	s.Branch = r.NewStyle().
-		Foreground(lipgloss.Color("203")).
-		Background(lipgloss.Color("236")).
+		Foreground(t.BranchForeground).
+		Background(t.BranchBackground).
 		Padding(0, 1)
 
 	s.HelpKey = r.NewStyle().
-		Foreground(lipgloss.Color("241"))
+		Foreground(t.Subtle)
 
 	s.HelpValue = r.NewStyle().
-		Foreground(lipgloss.Color("239"))
+		Foreground(t.Faint)
 
 	s.HelpDivider = r.NewStyle().
-		Foreground(lipgloss.Color("237")).
+		Foreground(t.Divider).
 		SetString(" • ")
 
 	s.URLStyle = r.NewStyle().
 		MarginLeft(1).
-		Foreground(lipgloss.Color("168"))
+		Foreground(t.Link)
 
 	s.Error = r.NewStyle().
 		MarginTop(2)
 
 	s.ErrorTitle = r.NewStyle().
-		Foreground(lipgloss.Color("230")).
-		Background(lipgloss.Color("204")).
+		Foreground(t.Inverse).
+		Background(t.Error).
 		Bold(true).
 		Padding(0, 1)
 
 	s.ErrorBody = r.NewStyle().
-		Foreground(lipgloss.Color("252")).
+		Foreground(t.Text).
 		MarginLeft(2)
 
 	s.LogItem.Normal.Base = r.NewStyle().
@@ -320,17 +355,17 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		Foreground(highlightColor)
 
 	s.LogItem.Normal.Title = r.NewStyle().
-		Foreground(lipgloss.Color("105"))
+		Foreground(t.LogTitle)
 
 	s.LogItem.Active.Title = r.NewStyle().
 		Foreground(highlightColor).
 		Bold(true)
 
 	s.LogItem.Normal.Desc = r.NewStyle().
-		Foreground(lipgloss.Color("246"))
+		Foreground(t.TextDim)
 
 	s.LogItem.Active.Desc = r.NewStyle().
-		Foreground(lipgloss.Color("95"))
+		Foreground(t.HighlightFaint)
 
 	s.LogItem.Active.Keyword = s.LogItem.Active.Desc.
 		Foreground(highlightColorDim)
@@ -353,11 +388,11 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		MarginLeft(2)
 
 	s.Log.CommitStatsAdd = r.NewStyle().
-		Foreground(lipgloss.Color("42")).
+		Foreground(t.Added).
 		Bold(true)
 
 	s.Log.CommitStatsDel = r.NewStyle().
-		Foreground(lipgloss.Color("203")).
+		Foreground(t.Removed).
 		Bold(true)
 
 	s.Log.Paginator = r.NewStyle().
@@ -378,7 +413,7 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 	s.Ref.Active.Base = r.NewStyle()
 
 	s.Ref.Normal.ItemTag = r.NewStyle().
-		Foreground(lipgloss.Color("39"))
+		Foreground(t.Dir)
 
 	s.Ref.Active.ItemTag = r.NewStyle().
 		Bold(true).
@@ -419,20 +454,20 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		Foreground(highlightColor)
 
 	s.Tree.Normal.FileDir = r.NewStyle().
-		Foreground(lipgloss.Color("39"))
+		Foreground(t.Dir)
 
 	s.Tree.Active.FileDir = r.NewStyle().
 		Foreground(highlightColor)
 
 	s.Tree.Normal.FileMode = s.Tree.Active.FileName.
 		Width(10).
-		Foreground(lipgloss.Color("243"))
+		Foreground(t.Muted)
 
 	s.Tree.Active.FileMode = s.Tree.Normal.FileMode.
 		Foreground(highlightColorDim)
 
 	s.Tree.Normal.FileSize = s.Tree.Normal.FileName.
-		Foreground(lipgloss.Color("243"))
+		Foreground(t.Muted)
 
 	s.Tree.Active.FileSize = s.Tree.Normal.FileName.
 		Foreground(highlightColorDim)
@@ -453,14 +488,14 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 	s.Spinner = r.NewStyle().
 		MarginTop(1).
 		MarginLeft(2).
-		Foreground(lipgloss.Color("205"))
+		Foreground(t.Spinner)
 
 	s.SpinnerContainer = r.NewStyle()
 
 	s.NoContent = r.NewStyle().
 		MarginTop(1).
 		MarginLeft(2).
-		Foreground(lipgloss.Color("242"))
+		Foreground(t.Placeholder)
 
 	s.StatusBar = r.NewStyle().
 		Height(1)
@@ -468,28 +503,28 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 	s.StatusBarKey = r.NewStyle().
 		Bold(true).
 		Padding(0, 1).
-		Background(lipgloss.Color("206")).
-		Foreground(lipgloss.Color("228"))
+		Background(t.StatusKeyBackground).
+		Foreground(t.StatusKeyForeground)
 
 	s.StatusBarValue = r.NewStyle().
 		Padding(0, 1).
-		Background(lipgloss.Color("235")).
-		Foreground(lipgloss.Color("243"))
+		Background(t.StatusValueBackground).
+		Foreground(t.Muted)
 
 	s.StatusBarInfo = r.NewStyle().
 		Padding(0, 1).
-		Background(lipgloss.Color("212")).
-		Foreground(lipgloss.Color("230"))
+		Background(t.Primary).
+		Foreground(t.Inverse)
 
 	s.StatusBarBranch = r.NewStyle().
 		Padding(0, 1).
-		Background(lipgloss.Color("62")).
-		Foreground(lipgloss.Color("230"))
+		Background(t.ActiveBorder).
+		Foreground(t.Inverse)
 
 	s.StatusBarHelp = r.NewStyle().
 		Padding(0, 1).
-		Background(lipgloss.Color("237")).
-		Foreground(lipgloss.Color("243"))
+		Background(t.Divider).
+		Foreground(t.Muted)
 
 	s.Tabs = r.NewStyle().
 		Height(1)
@@ -498,16 +533,16 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 
 	s.TabActive = r.NewStyle().
 		Underline(true).
-		Foreground(lipgloss.Color("36"))
+		Foreground(t.Accent)
 
 	s.TabSeparator = r.NewStyle().
 		SetString("│").
 		Padding(0, 1).
-		Foreground(lipgloss.Color("238"))
+		Foreground(t.Separator)
 
-	s.Code.LineDigit = r.NewStyle().Foreground(lipgloss.Color("239"))
+	s.Code.LineDigit = r.NewStyle().Foreground(t.Faint)
 
-	s.Code.LineBar = r.NewStyle().Foreground(lipgloss.Color("236"))
+	s.Code.LineBar = r.NewStyle().Foreground(t.Border)
 
 	s.Stash.Normal.Message = r.NewStyle().MarginLeft(1)
 
@@ -521,5 +556,34 @@ func DefaultStyles(r *lipgloss.Renderer) *Styles {
 		Width(1).
 		Foreground(selectorColor)
 
+	s.Issue.Normal.Title = r.NewStyle().MarginLeft(1)
+
+	s.Issue.Active.Title = s.Issue.Normal.Title.Foreground(selectorColor)
+
+	s.Issue.ID = r.NewStyle().
+		Foreground(hashColor)
+
+	s.Issue.Open = r.NewStyle().
+		Foreground(t.Added)
+
+	s.Issue.Closed = r.NewStyle().
+		Foreground(t.Removed)
+
+	s.Issue.Meta = r.NewStyle().
+		Faint(true)
+
+	s.Issue.Selector = s.Stash.Selector
+
+	s.Issue.FormLabel = r.NewStyle().
+		Bold(true).
+		Foreground(t.Primary)
+
+	s.Issue.FormHelp = r.NewStyle().
+		Foreground(t.Placeholder)
+
+	s.Issue.FormMargin = r.NewStyle().
+		MarginTop(1).
+		MarginLeft(2)
+
 	return s
 }
@@ -0,0 +1,212 @@
+package styles
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the color scheme of the UI.
+type Theme struct {
+	// Highlight is the color of selected items.
+	Highlight    lipgloss.Color
+	HighlightDim lipgloss.Color
+	// HighlightFaint is the color of secondary text of selected items.
+	HighlightFaint lipgloss.Color
+	Selector       lipgloss.Color
+	Hash           lipgloss.Color
+
+	ActiveBorder     lipgloss.Color
+	InactiveBorder   lipgloss.Color
+	ActiveItemBorder lipgloss.Color
+
+	ServerNameBackground lipgloss.Color
+	ServerNameForeground lipgloss.Color
+
+	// Primary is the color of titles and names.
+	Primary lipgloss.Color
+	// Accent is the color of active tabs.
+	Accent lipgloss.Color
+	// Inverse is the color of text on colored backgrounds.
+	Inverse lipgloss.Color
+
+	Text        lipgloss.Color
+	TextDim     lipgloss.Color
+	Muted       lipgloss.Color
+	Subtle      lipgloss.Color
+	Faint       lipgloss.Color
+	Placeholder lipgloss.Color
+
+	Border    lipgloss.Color
+	Divider   lipgloss.Color
+	Separator lipgloss.Color
+
+	Command       lipgloss.Color
+	CommandActive lipgloss.Color
+	Link          lipgloss.Color
+	LogTitle      lipgloss.Color
+	// Dir is the color of directories and tags.
+	Dir lipgloss.Color
+
+	BranchForeground lipgloss.Color
+	BranchBackground lipgloss.Color
+
+	Added   lipgloss.Color
+	Removed lipgloss.Color
+	Error   lipgloss.Color
+	Spinner lipgloss.Color
+
+	StatusKeyBackground   lipgloss.Color
+	StatusKeyForeground   lipgloss.Color
+	StatusValueBackground lipgloss.Color
+}
+
+// Built-in theme names.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// DarkTheme is the default theme, for terminals with a dark background.
+var DarkTheme = Theme{
+	Highlight:             "210",
+	HighlightDim:          "174",
+	HighlightFaint:        "95",
+	Selector:              "167",
+	Hash:                  "185",
+	ActiveBorder:          "62",
+	InactiveBorder:        "241",
+	ActiveItemBorder:      "176",
+	ServerNameBackground:  "57",
+	ServerNameForeground:  "229",
+	Primary:               "212",
+	Accent:                "36",
+	Inverse:               "230",
+	Text:                  "252",
+	TextDim:               "246",
+	Muted:                 "243",
+	Subtle:                "241",
+	Faint:                 "239",
+	Placeholder:           "242",
+	Border:                "236",
+	Divider:               "237",
+	Separator:             "238",
+	Command:               "132",
+	CommandActive:         "204",
+	Link:                  "168",
+	LogTitle:              "105",
+	Dir:                   "39",
+	BranchForeground:      "203",
+	BranchBackground:      "236",
+	Added:                 "42",
+	Removed:               "203",
+	Error:                 "204",
+	Spinner:               "205",
+	StatusKeyBackground:   "206",
+	StatusKeyForeground:   "228",
+	StatusValueBackground: "235",
+}
+
+// LightTheme is a theme for terminals with a light background.
+var LightTheme = Theme{
+	Highlight:             "160",
+	HighlightDim:          "131",
+	HighlightFaint:        "137",
+	Selector:              "161",
+	Hash:                  "130",
+	ActiveBorder:          "62",
+	InactiveBorder:        "248",
+	ActiveItemBorder:      "127",
+	ServerNameBackground:  "57",
+	ServerNameForeground:  "230",
+	Primary:               "125",
+	Accent:                "29",
+	Inverse:               "231",
+	Text:                  "235",
+	TextDim:               "240",
+	Muted:                 "242",
+	Subtle:                "245",
+	Faint:                 "247",
+	Placeholder:           "244",
+	Border:                "252",
+	Divider:               "250",
+	Separator:             "250",
+	Command:               "90",
+	CommandActive:         "161",
+	Link:                  "90",
+	LogTitle:              "25",
+	Dir:                   "25",
+	BranchForeground:      "160",
+	BranchBackground:      "254",
+	Added:                 "28",
+	Removed:               "160",
+	Error:                 "160",
+	Spinner:               "163",
+	StatusKeyBackground:   "162",
+	StatusKeyForeground:   "231",
+	StatusValueBackground: "254",
+}
+
+// HighContrastTheme is an accessible theme using the basic bright ANSI colors
+// on a dark background.
+var HighContrastTheme = Theme{
+	Highlight:             "11",
+	HighlightDim:          "11",
+	HighlightFaint:        "11",
+	Selector:              "11",
+	Hash:                  "14",
+	ActiveBorder:          "15",
+	InactiveBorder:        "7",
+	ActiveItemBorder:      "11",
+	ServerNameBackground:  "15",
+	ServerNameForeground:  "0",
+	Primary:               "11",
+	Accent:                "14",
+	Inverse:               "0",
+	Text:                  "15",
+	TextDim:               "15",
+	Muted:                 "15",
+	Subtle:                "7",
+	Faint:                 "7",
+	Placeholder:           "7",
+	Border:                "7",
+	Divider:               "8",
+	Separator:             "15",
+	Command:               "13",
+	CommandActive:         "11",
+	Link:                  "14",
+	LogTitle:              "14",
+	Dir:                   "14",
+	BranchForeground:      "0",
+	BranchBackground:      "11",
+	Added:                 "10",
+	Removed:               "9",
+	Error:                 "9",
+	Spinner:               "11",
+	StatusKeyBackground:   "11",
+	StatusKeyForeground:   "0",
+	StatusValueBackground: "0",
+}
+
+var themes = map[string]Theme{
+	ThemeDark:         DarkTheme,
+	ThemeLight:        LightTheme,
+	ThemeHighContrast: HighContrastTheme,
+}
+
+// LookupTheme returns the built-in theme with the given name.
+func LookupTheme(name string) (Theme, bool) {
+	t, ok := themes[name]
+	return t, ok
+}
+
+// ThemeNames returns the names of the built-in themes, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -8,16 +8,18 @@ import (
 )
 
 // SanitizeRepo returns a sanitized version of the given repository name.
+// Leading, trailing and repeated slashes, "." and ".." elements, and a
+// trailing ".git" are removed, so "repo", "/repo/", "./repo.git" and
+// "repo/.git" all name the same repository.
 func SanitizeRepo(repo string) string {
 	// We need to use an absolute path for the path to be cleaned correctly.
-	repo = strings.TrimPrefix(repo, "/")
-	repo = "/" + repo
-
 	// We're using path instead of filepath here because this is not OS dependent
 	// looking at you Windows
-	repo = path.Clean(repo)
+	repo = path.Clean("/" + repo)
 	repo = strings.TrimSuffix(repo, ".git")
-	return repo[1:]
+	// Clean again, trimming "repo/.git" leaves a trailing slash.
+	repo = path.Clean(repo)
+	return strings.TrimPrefix(repo, "/")
 }
 
 // ValidateUsername returns an error if any of the given usernames are invalid.
@@ -53,3 +55,29 @@ func ValidateRepo(repo string) error {
 
 	return nil
 }
+
+// ValidateBranch returns an error if the given branch name is invalid. It
+// follows the rules of git check-ref-format --branch.
+func ValidateBranch(branch string) error {
+	if branch == "" || branch == "@" || strings.HasPrefix(branch, "-") ||
+		strings.HasPrefix(branch, "/") || strings.HasSuffix(branch, "/") ||
+		strings.HasSuffix(branch, ".") || strings.Contains(branch, "..") ||
+		strings.Contains(branch, "//") || strings.Contains(branch, "@{") ||
+		strings.ContainsAny(branch, " ~^:?*[\\\x7f") {
+		return fmt.Errorf("invalid branch name: %q", branch)
+	}
+
+	for _, r := range branch {
+		if r < ' ' {
+			return fmt.Errorf("branch name cannot contain control characters")
+		}
+	}
+
+	for _, c := range strings.Split(branch, "/") {
+		if strings.HasPrefix(c, ".") || strings.HasSuffix(c, ".lock") {
+			return fmt.Errorf("branch name components cannot start with a dot or end with .lock")
+		}
+	}
+
+	return nil
+}
@@ -45,6 +45,19 @@ func TestSanitizeRepo(t *testing.T) {
 		{"with.dot", "with.dot"},
 		{"/with_forward_slash", "with_forward_slash"},
 		{"withgitsuffix.git", "withgitsuffix"},
+		{"repo/", "repo"},
+		{"repo.git/", "repo"},
+		{"./repo", "repo"},
+		{"repo/.git", "repo"},
+		{"repo/.git/", "repo"},
+		{"//repo//", "repo"},
+		{"a//b///c.git", "a/b/c"},
+		{"a/./b/../c", "a/c"},
+		{"../../repo", "repo"},
+		{".git", ""},
+		{"/", ""},
+		{"", ""},
+		{"repo.git.git", "repo.git"},
 	}
 	for _, c := range cases {
 		t.Run(c.in, func(t *testing.T) {
@@ -54,3 +67,16 @@ func TestSanitizeRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBranch(t *testing.T) {
+	for _, branch := range []string{"main", "release/v1", "feature-1", "a.b"} {
+		if err := ValidateBranch(branch); err != nil {
+			t.Errorf("expected %q to be valid, got %v", branch, err)
+		}
+	}
+	for _, branch := range []string{"", "@", "-main", "/main", "main/", "main.", "a..b", "a//b", "a@{b", "a b", "a:b", "a\\b", ".hidden", "a/.b", "main.lock", "a\x01b"} {
+		if err := ValidateBranch(branch); err == nil {
+			t.Errorf("expected %q to be invalid", branch)
+		}
+	}
+}
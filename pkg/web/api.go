@@ -0,0 +1,423 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	gitb "github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/gorilla/mux"
+)
+
+// apiPrefix is the path prefix of the repository API.
+const apiPrefix = "/api/repos/"
+
+// APIError is the body of API error responses.
+type APIError struct {
+	Message string `json:"message"`
+}
+
+// APITree is the body of tree API responses.
+type APITree struct {
+	// Ref is the commit hash the ref resolved to.
+	Ref string `json:"ref"`
+	// Path is the path of the tree, empty for the root tree.
+	Path    string         `json:"path"`
+	Entries []APITreeEntry `json:"entries"`
+}
+
+// APITreeEntry is an entry of a tree API response.
+type APITreeEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Type is the git object type, "blob", "tree", or "commit" for
+	// submodules.
+	Type string `json:"type"`
+	// Size is the size of blobs in bytes, 0 otherwise.
+	Size int64 `json:"size"`
+	// Mode is the git file mode in octal, e.g. "100644".
+	Mode string `json:"mode"`
+}
+
+// APIGraph is the body of commit graph API responses.
+type APIGraph struct {
+	// Ref is the commit hash the ref resolved to.
+	Ref     string `json:"ref"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+	// More is true if there are commits on the next page.
+	More    bool               `json:"more"`
+	Commits []gitb.GraphCommit `json:"commits"`
+}
+
+const (
+	// defaultGraphPerPage is the default number of commits per commit graph
+	// page.
+	defaultGraphPerPage = 100
+	// maxGraphPerPage is the maximum number of commits per commit graph page.
+	maxGraphPerPage = 1000
+)
+
+// APIMergeStrategy is the body of merge strategy API responses.
+type APIMergeStrategy struct {
+	// MergeStrategy is the preferred merge strategy of the repository, empty
+	// if it isn't set.
+	MergeStrategy proto.MergeStrategy `json:"merge_strategy"`
+}
+
+// APIActivity is the body of commit activity API responses.
+type APIActivity struct {
+	Interval gitb.ActivityInterval `json:"interval"`
+	// Buckets are the commit counts of each interval, oldest first, empty if
+	// the repository has no commits.
+	Buckets []gitb.ActivityBucket `json:"buckets"`
+}
+
+// APIController is a router for the repository API.
+//
+//	GET /api/repos/{repo}/tree/{ref}/{path} lists a directory.
+//	GET /api/repos/{repo}/raw/{ref}/{path} returns the contents of a file.
+//	GET /api/repos/{repo}/graph?ref=&page=&per_page= returns the commit graph.
+//	GET /api/repos/{repo}/merge-strategy returns the preferred merge strategy.
+//	GET /api/repos/{repo}/activity?ref=&interval=&since= returns the number of
+//	commits per day or week, for up to a year.
+//
+// Refs containing slashes are matched against the shortest leading path
+// segments that resolve to a commit.
+func APIController(_ context.Context, r *mux.Router) {
+	r.PathPrefix(apiPrefix).Handler(withStorage(GitRoute{
+		method:  []string{http.MethodGet},
+		handler: serviceAPI,
+	}))
+}
+
+func serviceAPI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+	be := backend.FromContext(ctx)
+
+	repoName, endpoint, rest, ok := parseAPIPath(r.URL.Path)
+	if !ok {
+		renderAPIError(w, http.StatusNotFound, "not found")
+		return
+	}
+	hdrRobots(w, r, repoName)
+
+	user, err := authenticate(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrInvalidPassword):
+			renderAPIError(w, http.StatusForbidden, "bad credentials")
+			return
+		case errors.Is(err, proto.ErrUserNotFound):
+		default:
+			logger.Error("failed to authenticate", "err", err)
+		}
+	}
+
+	if user == nil && !be.AllowKeyless(ctx) && !be.IsAnonymousRepo(repoName) {
+		askCredentials(w, r)
+		renderAPIError(w, http.StatusUnauthorized, "credentials needed")
+		return
+	}
+
+	// Don't hint that the repo exists if the user doesn't have access.
+	repo, err := be.Repository(ctx, repoName)
+	if err != nil || be.AccessLevelForUser(ctx, repoName, user) < access.ReadOnlyAccess {
+		renderAPIError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	rr, err := repo.Open()
+	if err != nil {
+		logger.Error("failed to open repository", "repo", repoName, "err", err)
+		renderAPIError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	switch endpoint {
+	case "graph":
+		serveAPIGraph(w, r, rr)
+		return
+	case "merge-strategy":
+		ms, err := be.MergeStrategy(ctx, repo.Name())
+		if err != nil {
+			logger.Error("failed to get merge strategy", "repo", repoName, "err", err)
+			renderAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		renderAPIJSON(w, http.StatusOK, APIMergeStrategy{MergeStrategy: ms})
+		return
+	case "activity":
+		serveAPIActivity(w, r, repo)
+		return
+	}
+
+	hash, fp, ok := resolveAPIRef(rr, rest)
+	if !ok {
+		renderAPIError(w, http.StatusNotFound, "reference not found")
+		return
+	}
+
+	tree, err := rr.LsTree(hash)
+	if err != nil {
+		logger.Error("failed to list tree", "repo", repoName, "ref", hash, "err", err)
+		renderAPIError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	var entry *gitb.TreeEntry
+	if fp != "" {
+		entry, err = tree.TreeEntry(fp)
+		if errors.Is(err, gitb.ErrRevisionNotExist) {
+			renderAPIError(w, http.StatusNotFound, "file not found")
+			return
+		} else if err != nil {
+			logger.Error("failed to get tree entry", "repo", repoName, "path", fp, "err", err)
+			renderAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+	}
+
+	switch endpoint {
+	case "tree":
+		serveAPITree(w, tree, entry, hash, fp)
+	case "raw":
+		serveAPIRaw(w, entry)
+	}
+}
+
+// serveAPITree writes the entries of the tree at fp, or the entry itself
+// if it isn't a tree.
+func serveAPITree(w http.ResponseWriter, tree *gitb.Tree, entry *gitb.TreeEntry, hash, fp string) {
+	ents := gitb.Entries{}
+	switch {
+	case entry == nil:
+		var err error
+		ents, err = tree.Entries()
+		if err != nil {
+			renderAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+	case entry.IsTree():
+		sub, err := tree.SubTree(fp)
+		if err != nil {
+			renderAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		ents, err = sub.Entries()
+		if err != nil {
+			renderAPIError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+	default:
+		ents = append(ents, entry)
+	}
+
+	ents.Sort()
+	resp := APITree{
+		Ref:     hash,
+		Path:    fp,
+		Entries: make([]APITreeEntry, 0, len(ents)),
+	}
+	for _, e := range ents {
+		te := APITreeEntry{
+			Name: e.Name(),
+			Path: path.Join(fp, e.Name()),
+			Type: string(e.Type()),
+			Mode: fmt.Sprintf("%06o", e.Blob().Mode()),
+		}
+		if entry != nil && !entry.IsTree() {
+			te.Path = fp
+		}
+		if e.IsBlob() {
+			te.Size = e.Size()
+		}
+		resp.Entries = append(resp.Entries, te)
+	}
+
+	renderAPIJSON(w, http.StatusOK, resp)
+}
+
+// serveAPIGraph writes a page of the commit graph of the ref query
+// parameter, HEAD by default.
+func serveAPIGraph(w http.ResponseWriter, r *http.Request, rr *gitb.Repository) {
+	q := r.URL.Query()
+	ref := q.Get("ref")
+	if ref == "" {
+		ref = "HEAD"
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage <= 0 {
+		perPage = defaultGraphPerPage
+	} else if perPage > maxGraphPerPage {
+		perPage = maxGraphPerPage
+	}
+
+	if strings.HasPrefix(ref, "-") {
+		renderAPIError(w, http.StatusNotFound, "reference not found")
+		return
+	}
+	out, err := gitb.NewCommand("rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}").RunInDir(rr.Path)
+	if err != nil {
+		renderAPIError(w, http.StatusNotFound, "reference not found")
+		return
+	}
+	hash := strings.TrimSpace(string(out))
+
+	commits, more, err := rr.Graph(hash, (page-1)*perPage, perPage)
+	if err != nil {
+		log.FromContext(r.Context()).Error("failed to get commit graph", "ref", hash, "err", err)
+		renderAPIError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, APIGraph{
+		Ref:     hash,
+		Page:    page,
+		PerPage: perPage,
+		More:    more,
+		Commits: commits,
+	})
+}
+
+func serveAPIActivity(w http.ResponseWriter, r *http.Request, repo proto.Repository) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	interval := gitb.ActivityWeek
+	if v := q.Get("interval"); v != "" {
+		var err error
+		interval, err = gitb.ParseActivityInterval(v)
+		if err != nil {
+			renderAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// A missing since means as far back as possible.
+	var since time.Time
+	if v := q.Get("since"); v != "" {
+		var err error
+		since, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			since, err = time.Parse(time.RFC3339, v)
+		}
+		if err != nil {
+			renderAPIError(w, http.StatusBadRequest, "invalid since, must be a date or an RFC 3339 time")
+			return
+		}
+	}
+
+	buckets, err := backend.FromContext(ctx).CommitActivity(ctx, repo, q.Get("ref"), since, interval)
+	if errors.Is(err, gitb.ErrRevisionNotExist) {
+		renderAPIError(w, http.StatusNotFound, "reference not found")
+		return
+	} else if err != nil {
+		log.FromContext(ctx).Error("failed to get commit activity", "repo", repo.Name(), "err", err)
+		renderAPIError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if buckets == nil {
+		buckets = []gitb.ActivityBucket{}
+	}
+	renderAPIJSON(w, http.StatusOK, APIActivity{Interval: interval, Buckets: buckets})
+}
+
+// serveAPIRaw writes the contents of a file.
+func serveAPIRaw(w http.ResponseWriter, entry *gitb.TreeEntry) {
+	if entry == nil || !entry.IsBlob() {
+		renderAPIError(w, http.StatusNotFound, "file not found")
+		return
+	}
+
+	bts, err := entry.Contents()
+	if err != nil {
+		renderAPIError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	// Never serve repository contents as active content, e.g. HTML or SVG.
+	ct := http.DetectContentType(bts)
+	if strings.HasPrefix(ct, "text/") {
+		ct = "text/plain; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", ct)
+	w.Header().Set("Content-Length", strconv.Itoa(len(bts)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(bts) // nolint: errcheck
+}
+
+// parseAPIPath splits an API path into the repository name, the endpoint,
+// and the rest of the path holding the ref and file path. The graph,
+// merge-strategy, and activity endpoints have no rest.
+func parseAPIPath(p string) (repo, endpoint, rest string, ok bool) {
+	p = strings.TrimPrefix(p, apiPrefix)
+	for _, e := range []string{"tree", "raw"} {
+		// Repository names can have slashes, use the first endpoint.
+		if i := strings.Index(p, "/"+e+"/"); i > 0 && (endpoint == "" || i < len(repo)) {
+			repo, endpoint, rest = p[:i], e, p[i+len(e)+2:]
+		}
+	}
+	if endpoint == "" {
+		for _, e := range []string{"graph", "merge-strategy", "activity"} {
+			if repo, ok := strings.CutSuffix(p, "/"+e); ok && repo != "" {
+				return repo, e, "", true
+			}
+		}
+	}
+
+	return repo, endpoint, rest, endpoint != "" && rest != ""
+}
+
+// resolveAPIRef resolves the ref at the start of rest, and returns its
+// commit hash and the cleaned file path following it. File paths can't
+// escape the repository tree.
+func resolveAPIRef(r *gitb.Repository, rest string) (hash, fp string, ok bool) {
+	segs := strings.Split(rest, "/")
+	for i := 1; i <= len(segs); i++ {
+		ref := strings.Join(segs[:i], "/")
+		if ref == "" || strings.HasPrefix(ref, "-") {
+			return "", "", false
+		}
+
+		out, err := gitb.NewCommand("rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}").RunInDir(r.Path)
+		if err != nil {
+			continue
+		}
+		hash = strings.TrimSpace(string(out))
+
+		fp = strings.TrimPrefix(path.Clean("/"+strings.Join(segs[i:], "/")), "/")
+		return hash, fp, true
+	}
+
+	return "", "", false
+}
+
+func renderAPIJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("error encoding json", "err", err)
+	}
+}
+
+func renderAPIError(w http.ResponseWriter, statusCode int, message string) {
+	renderAPIJSON(w, statusCode, APIError{Message: message})
+}
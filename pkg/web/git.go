@@ -22,7 +22,6 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/git"
 	"github.com/charmbracelet/soft-serve/pkg/lfs"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
-	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -91,9 +90,29 @@ func withParams(next http.Handler) http.Handler {
 			vars["service"] = git.ReceivePackService.String()
 		}
 
-		repo = utils.SanitizeRepo(repo)
+		repo = cfg.SanitizeRepo(repo)
+
+		// Follow the redirect of a renamed repository. Git follows, and
+		// warns about, redirects of the initial ref discovery request. Any
+		// other request is served by the renamed repository.
+		be := backend.FromContext(ctx)
+		if _, err := be.Repository(ctx, repo); errors.Is(err, proto.ErrRepoNotFound) {
+			if target, err := be.ResolveRedirect(ctx, repo); err == nil {
+				if r.Method == http.MethodGet && vars["file"] == "info/refs" {
+					u := *r.URL
+					u.Path = cfg.HTTP.BasePath + "/" + target + ".git/info/refs"
+					u.RawPath = ""
+					http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+					return
+				}
+
+				repo = target
+			}
+		}
+
 		vars["repo"] = repo
 		vars["dir"] = filepath.Join(cfg.DataPath, "repos", repo+".git")
+		hdrRobots(w, r, repo)
 
 		// Add repo suffix (.git)
 		r.URL.Path = fmt.Sprintf("%s.git/%s", repo, vars["file"])
@@ -103,17 +122,34 @@ func withParams(next http.Handler) http.Handler {
 	})
 }
 
+// withStorage keeps the encrypted storage open while the request is served,
+// see backend.OpenStorage.
+func withStorage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		release, err := backend.FromContext(ctx).OpenStorage(ctx)
+		if err != nil {
+			log.FromContext(ctx).Error("error mounting encrypted storage", "err", err)
+			renderStatus(http.StatusServiceUnavailable)(w, r)
+			return
+		}
+
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
 // GitController is a router for git services.
 func GitController(_ context.Context, r *mux.Router) {
 	basePrefix := "/{repo:.*}"
 	for _, route := range gitRoutes {
 		// NOTE: withParam must always be the outermost wrapper, otherwise the
 		// request vars will not be set.
-		r.Handle(basePrefix+route.path, withParams(withAccess(route)))
+		r.Handle(basePrefix+route.path, withParams(withStorage(withAccess(route))))
 	}
 
 	// Handle go-get
-	r.Handle(basePrefix, withParams(withAccess(http.HandlerFunc(GoGetHandler)))).Methods(http.MethodGet)
+	r.Handle(basePrefix, withParams(withStorage(withAccess(http.HandlerFunc(GoGetHandler))))).Methods(http.MethodGet)
 }
 
 var gitRoutes = []GitRoute{
@@ -222,7 +258,7 @@ func withAccess(next http.Handler) http.HandlerFunc {
 			}
 		}
 
-		if user == nil && !be.AllowKeyless(ctx) {
+		if user == nil && !be.AllowKeyless(ctx) && !be.IsAnonymousRepo(repoName) {
 			askCredentials(w, r)
 			renderUnauthorized(w, r)
 			return
@@ -260,10 +296,34 @@ func withAccess(next http.Handler) http.HandlerFunc {
 				return
 			}
 
+			if repo != nil && repo.IsArchived() {
+				http.Error(w, proto.ErrRepoArchived.Error(), http.StatusForbidden)
+				return
+			}
+
+			if err := be.CheckPush(ctx, repoName); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+
+			// Don't start receiving objects on an almost full disk.
+			if err := be.CheckFreeDisk(0); errors.Is(err, proto.ErrLowDiskSpace) {
+				http.Error(w, err.Error(), http.StatusInsufficientStorage)
+				return
+			} else if err != nil {
+				logger.Error("failed to check free disk space", "err", err)
+			}
+
 			// Create the repo if it doesn't exist.
 			if repo == nil {
-				repo, err = be.CreateRepository(ctx, repoName, user, proto.RepositoryOptions{})
-				if err != nil {
+				repo, _, err = be.AutoCreateRepository(ctx, repoName, user)
+				if errors.Is(err, proto.ErrAutoCreateLimit) {
+					http.Error(w, err.Error(), http.StatusTooManyRequests)
+					return
+				} else if errors.Is(err, proto.ErrRepoQuota) || errors.Is(err, proto.ErrReservedName) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				} else if err != nil {
 					logger.Error("failed to create repository", "repo", repoName, "err", err)
 					renderInternalServerError(w, r)
 					return
@@ -289,6 +349,13 @@ func withAccess(next http.Handler) http.HandlerFunc {
 				return
 			}
 
+			if service == git.UploadPackService {
+				if err := be.CheckFetch(ctx, repoName); err != nil {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+			}
+
 		case strings.HasPrefix(file, "info/lfs"):
 			if !cfg.LFS.Enabled {
 				logger.Debug("LFS is not enabled, skipping")
@@ -380,7 +447,22 @@ func serviceRpc(w http.ResponseWriter, r *http.Request) {
 
 	if service == git.ReceivePackService {
 		gitHttpReceiveCounter.WithLabelValues(repoName)
+
+		endPush, err := backend.FromContext(ctx).BeginPush(repoName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer endPush()
+	}
+
+	user := proto.UserFromContext(ctx)
+	release, err := backend.FromContext(ctx).AcquireGitOperation(ctx, user, r.RemoteAddr)
+	if err != nil {
+		logger.Errorf("failed to wait for git operation: %v", err)
+		return
 	}
+	defer release()
 
 	w.Header().Set("Content-Type", fmt.Sprintf("application/x-%s-result", service))
 	w.Header().Set("Connection", "Keep-Alive")
@@ -395,10 +477,13 @@ func serviceRpc(w http.ResponseWriter, r *http.Request) {
 		Stdout: &stdout,
 		Dir:    dir,
 		Args:   []string{"--stateless-rpc"},
+
+		BufferSize: cfg.Git.TransferBufferSize,
+		Limits:     git.ServiceLimits(cfg),
 	}
 
-	user := proto.UserFromContext(ctx)
 	cmd.Env = cfg.Environ()
+	cmd.Env = append(cmd.Env, git.SigningEnv(cfg)...)
 	cmd.Env = append(cmd.Env, []string{
 		"SOFT_SERVE_REPO_NAME=" + repoName,
 		"SOFT_SERVE_REPO_PATH=" + dir,
@@ -415,10 +500,7 @@ func serviceRpc(w http.ResponseWriter, r *http.Request) {
 		}...)
 	}
 
-	var (
-		err    error
-		reader io.ReadCloser
-	)
+	var reader io.ReadCloser
 
 	// Handle gzip encoding
 	reader = r.Body
@@ -436,15 +518,60 @@ func serviceRpc(w http.ResponseWriter, r *http.Request) {
 	cmd.Stdin = reader
 	cmd.Stdout = &flushResponseWriter{w}
 
-	if err := service.Handler(ctx, cmd); err != nil {
+	var fetch *git.FetchTracker
+	var limiter *git.NegotiationLimiter
+	if service == git.UploadPackService {
+		fetch = git.TrackFetch(&cmd)
+		if n := cfg.Git.MaxNegotiationRounds; n > 0 {
+			limiter = git.LimitNegotiation(&cmd, n)
+		}
+	}
+
+	var shallow *git.ShallowPushGuard
+	if service == git.ReceivePackService && cfg.Git.RejectShallowPush {
+		shallow = git.RejectShallowPush(&cmd)
+	}
+
+	err = service.Handler(ctx, cmd)
+	if limiter != nil && limiter.Exceeded() {
+		logger.Warn("aborted git session", "repo", repoName, "err", git.ErrTooManyNegotiationRounds)
+		git.WritePktlineErr(w, git.ErrTooManyNegotiationRounds) // nolint: errcheck
+		return
+	} else if shallow != nil && shallow.Rejected() {
+		logger.Warn("rejected shallow push", "repo", repoName)
+		if shallow.SideBand() {
+			git.WriteSidebandErr(w, git.ErrShallowPush) // nolint: errcheck
+		} else {
+			git.WritePktlineErr(w, git.ErrShallowPush) // nolint: errcheck
+		}
+		return
+	} else if git.IsResourceLimit(err) {
+		logger.Warn("aborted git session", "repo", repoName, "err", err)
+		git.WritePktlineErr(w, err) // nolint: errcheck
+		return
+	} else if err != nil {
 		logger.Errorf("failed to handle service: %v", err)
 		return
 	}
 
+	if fetch != nil && fetch.Fetched() {
+		if err := backend.FromContext(ctx).AuditRead(ctx, repoName, user, "http", fetch.Clone()); err != nil {
+			logger.Errorf("failed to audit repository read: %v", err)
+		}
+	}
+
 	if service == git.ReceivePackService {
 		if err := git.EnsureDefaultBranch(ctx, cmd.Dir); err != nil {
 			logger.Errorf("failed to ensure default branch: %s", err)
 		}
+
+		if err := backend.FromContext(ctx).RecordPush(ctx, repoName); err != nil {
+			logger.Errorf("failed to record push: %v", err)
+		}
+
+		if err := backend.FromContext(ctx).UpdateReadmeDescription(ctx, repoName); err != nil {
+			logger.Errorf("failed to update description from readme: %v", err)
+		}
 	}
 }
 
@@ -497,10 +624,12 @@ func getInfoRefs(w http.ResponseWriter, r *http.Request) {
 			Stdout: &refs,
 			Dir:    dir,
 			Args:   []string{"--stateless-rpc", "--advertise-refs"},
+			Limits: git.ServiceLimits(cfg),
 		}
 
 		user := proto.UserFromContext(ctx)
 		cmd.Env = cfg.Environ()
+		cmd.Env = append(cmd.Env, git.SigningEnv(cfg)...)
 		cmd.Env = append(cmd.Env, []string{
 			"SOFT_SERVE_REPO_NAME=" + repoName,
 			"SOFT_SERVE_REPO_PATH=" + dir,
@@ -538,6 +667,9 @@ func getInfoRefs(w http.ResponseWriter, r *http.Request) {
 		w.Write(refs.Bytes()) // nolint: errcheck
 	} else {
 		// Dumb HTTP
+		if !allowDumbProtocol(w, r) {
+			return
+		}
 		updateServerInfo(ctx, dir) // nolint: errcheck
 		hdrNocache(w)
 		sendFile("text/plain; charset=utf-8", w, r)
@@ -545,30 +677,66 @@ func getInfoRefs(w http.ResponseWriter, r *http.Request) {
 }
 
 func getInfoPacks(w http.ResponseWriter, r *http.Request) {
+	if !allowDumbProtocol(w, r) {
+		return
+	}
 	hdrCacheForever(w)
 	sendFile("text/plain; charset=utf-8", w, r)
 }
 
 func getLooseObject(w http.ResponseWriter, r *http.Request) {
+	if !allowDumbProtocol(w, r) {
+		return
+	}
 	hdrCacheForever(w)
 	sendFile("application/x-git-loose-object", w, r)
 }
 
 func getPackFile(w http.ResponseWriter, r *http.Request) {
+	if !allowDumbProtocol(w, r) {
+		return
+	}
 	hdrCacheForever(w)
 	sendFile("application/x-git-packed-objects", w, r)
 }
 
 func getIdxFile(w http.ResponseWriter, r *http.Request) {
+	if !allowDumbProtocol(w, r) {
+		return
+	}
 	hdrCacheForever(w)
 	sendFile("application/x-git-packed-objects-toc", w, r)
 }
 
 func getTextFile(w http.ResponseWriter, r *http.Request) {
+	if !allowDumbProtocol(w, r) {
+		return
+	}
 	hdrNocache(w)
 	sendFile("text/plain", w, r)
 }
 
+// allowDumbProtocol returns true if the request can be served over the dumb
+// HTTP protocol. It's only enabled for public repositories when
+// HTTP.AllowDumbProtocol is set, other requests aren't found.
+func allowDumbProtocol(w http.ResponseWriter, r *http.Request) bool {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	repo := proto.RepositoryFromContext(ctx)
+	if !cfg.HTTP.AllowDumbProtocol || repo == nil || repo.IsPrivate() {
+		renderNotFound(w, r)
+		return false
+	}
+
+	// Dumb fetches request many files, the PreFetch hook runs for each.
+	if err := backend.FromContext(ctx).CheckFetch(ctx, repo.Name()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
 func sendFile(contentType string, w http.ResponseWriter, r *http.Request) {
 	dir, file := mux.Vars(r)["dir"], mux.Vars(r)["file"]
 	reqFile := filepath.Join(dir, file)
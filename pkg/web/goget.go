@@ -9,7 +9,6 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
-	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -78,7 +77,7 @@ func GoGetHandler(w http.ResponseWriter, r *http.Request) {
 			Config     *config.Config
 			ImportRoot string
 		}{
-			Repo:       utils.SanitizeRepo(repo),
+			Repo:       cfg.SanitizeRepo(repo),
 			Config:     cfg,
 			ImportRoot: importRoot.Host,
 		}); err != nil {
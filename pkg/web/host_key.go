@@ -0,0 +1,83 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/gorilla/mux"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// hostKeyPath is the path of the SSH host keys endpoint.
+const hostKeyPath = "/.well-known/ssh-hostkey"
+
+// APIHostKey is a SSH host key in host key JSON responses.
+type APIHostKey struct {
+	Type string `json:"type"`
+	// Key is the public key in authorized_keys format.
+	Key         string `json:"key"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// HostKeyController is a router for the SSH host keys endpoint. It returns
+// the public keys the SSH server offers, the current host key followed by
+// retired keys still in their grace period, so that clients can add them to
+// their known_hosts before connecting. It doesn't require authentication.
+//
+//	GET /.well-known/ssh-hostkey returns the keys in authorized_keys format,
+//	each preceded by a comment with its SHA256 fingerprint.
+//	GET /.well-known/ssh-hostkey?format=json returns a list of APIHostKey.
+func HostKeyController(_ context.Context, r *mux.Router) {
+	r.HandleFunc(hostKeyPath, serveHostKeys).Methods(http.MethodGet, http.MethodHead)
+}
+
+func serveHostKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.SSH.Enabled {
+		renderNotFound(w, r)
+		return
+	}
+
+	be := backend.FromContext(ctx)
+	signers, err := be.HostSigners()
+	if err != nil {
+		log.FromContext(ctx).Error("failed to read host keys", "err", err)
+		renderStatus(http.StatusInternalServerError)(w, r)
+		return
+	}
+
+	// Only public keys are ever written.
+	keys := make([]APIHostKey, 0, len(signers))
+	for _, s := range signers {
+		pk := s.PublicKey()
+		keys = append(keys, APIHostKey{
+			Type:        pk.Type(),
+			Key:         string(bytes.TrimSpace(gossh.MarshalAuthorizedKey(pk))),
+			Fingerprint: gossh.FingerprintSHA256(pk),
+		})
+	}
+
+	hdrNocache(w)
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(keys)
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString("# " + k.Fingerprint + "\n")
+		buf.WriteString(k.Key + "\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	logr "github.com/charmbracelet/soft-serve/pkg/log"
 	"github.com/dustin/go-humanize"
 )
 
@@ -68,20 +70,36 @@ func (r *logWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return nil, nil, fmt.Errorf("http.Hijacker not implemented")
 }
 
-// NewLoggingMiddleware returns a new logging middleware.
+// NewLoggingMiddleware returns a new logging middleware. Only a sample of the
+// successful requests is logged, see Log.SampleRate, requests failing with a
+// 4xx or 5xx status are always logged.
 func NewLoggingMiddleware(next http.Handler, logger *log.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		writer := &logWriter{code: http.StatusOK, ResponseWriter: w}
-		logger.Debug("request",
+		reqArgs := []interface{}{
 			"method", r.Method,
 			"path", r.URL,
-			"addr", r.RemoteAddr)
+			"addr", r.RemoteAddr,
+		}
+		cfg := config.FromContext(r.Context())
+		sampled := cfg == nil || logr.Sample(cfg.Log.SampleRate)
+		if sampled {
+			logger.Debug("request", reqArgs...)
+		}
 		next.ServeHTTP(writer, r)
 		elapsed := time.Since(start)
-		logger.Debug("response",
+		if !sampled && writer.code < http.StatusBadRequest {
+			return
+		}
+		var respArgs []interface{}
+		if !sampled {
+			// The request wasn't logged.
+			respArgs = reqArgs
+		}
+		logger.Debug("response", append(respArgs,
 			"status", fmt.Sprintf("%d %s", writer.code, http.StatusText(writer.code)),
 			"bytes", humanize.Bytes(uint64(writer.bytes)), //nolint:gosec
-			"time", elapsed)
+			"time", elapsed)...)
 	})
 }
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/gorilla/mux"
+)
+
+// ReadyController is a router for the readiness endpoint. It responds with
+// 503 Service Unavailable when the server can't accept pushes, e.g. because
+// the data directory disk is almost full.
+//
+//	GET /readyz
+func ReadyController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/readyz", serveReady).Methods(http.MethodGet, http.MethodHead)
+}
+
+func serveReady(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	hdrNocache(w)
+	if err := be.CheckFreeDisk(0); errors.Is(err, proto.ErrLowDiskSpace) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		log.FromContext(ctx).Error("failed to check free disk space", "err", err)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
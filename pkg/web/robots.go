@@ -0,0 +1,95 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/gorilla/mux"
+)
+
+// RobotsController is a router for the robots.txt file. It disallows the
+// public repositories that search engines can't index, or every path with
+// exceptions for the repositories that can be indexed, depending on the
+// server robots policy. Private and hidden repositories aren't listed, their
+// pages are kept out of indexes by the X-Robots-Tag header instead.
+//
+//	GET /robots.txt
+func RobotsController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/robots.txt", serveRobots).Methods(http.MethodGet, http.MethodHead)
+}
+
+func serveRobots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	be := backend.FromContext(ctx)
+	repos, err := be.Repositories(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error("failed to list repositories", "err", err)
+		renderStatus(http.StatusInternalServerError)(w, r)
+		return
+	}
+
+	noindex := cfg.HTTP.RobotsPolicy == proto.RobotsNoindex.String()
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if noindex {
+		b.WriteString("Disallow: " + cfg.HTTP.BasePath + "/\n")
+	}
+
+	var rules int
+	for _, repo := range repos {
+		if repo.IsPrivate() || repo.IsHidden() {
+			continue
+		}
+
+		// List the repositories that differ from the server policy.
+		policy := be.EffectiveRobotsPolicy(repo)
+		if (policy == proto.RobotsNoindex) == noindex {
+			continue
+		}
+
+		directive := "Disallow"
+		if noindex {
+			directive = "Allow"
+		}
+		for _, p := range repoRobotsPaths(cfg.HTTP.BasePath, repo.Name()) {
+			b.WriteString(directive + ": " + p + "\n")
+		}
+		rules++
+	}
+
+	if !noindex && rules == 0 {
+		// An empty rule allows everything.
+		b.WriteString("Disallow:\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// repoRobotsPaths returns the path prefixes of the pages of a repository.
+func repoRobotsPaths(basePath, repo string) []string {
+	return []string{
+		basePath + "/" + repo + "/",
+		basePath + "/" + repo + ".git/",
+		basePath + apiPrefix + repo + "/",
+	}
+}
+
+// hdrRobots keeps the response out of search indexes unless the repository
+// can be indexed. Repositories that don't exist aren't indexed, so that
+// private repositories can't be told apart from them.
+func hdrRobots(w http.ResponseWriter, r *http.Request, repo string) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	rr, err := be.Repository(ctx, repo)
+	if err != nil || be.EffectiveRobotsPolicy(rr) == proto.RobotsNoindex {
+		w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	}
+}
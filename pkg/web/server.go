@@ -3,17 +3,27 @@ package web
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 )
 
 // NewRouter returns a new HTTP router.
 func NewRouter(ctx context.Context) http.Handler {
+	cfg := config.FromContext(ctx)
 	logger := log.FromContext(ctx).WithPrefix("http")
 	router := mux.NewRouter()
 
+	// Readiness, robots, host key, and API routes, before the git routes
+	// which match any repository path
+	ReadyController(ctx, router)
+	RobotsController(ctx, router)
+	HostKeyController(ctx, router)
+	APIController(ctx, router)
+
 	// Git routes
 	GitController(ctx, router)
 
@@ -21,10 +31,30 @@ func NewRouter(ctx context.Context) http.Handler {
 
 	// Context handler
 	// Adds context to the request
-	h := NewLoggingMiddleware(router, logger)
+	h := withBasePath(cfg.HTTP.BasePath, router)
+	h = NewLoggingMiddleware(h, logger)
 	h = NewContextHandler(ctx)(h)
 	h = handlers.CompressHandler(h)
 	h = handlers.RecoveryHandler()(h)
 
 	return h
 }
+
+// withBasePath strips basePath from request paths before routing them.
+// Requests outside of basePath aren't found.
+func withBasePath(basePath string, next http.Handler) http.Handler {
+	if basePath == "" {
+		return next
+	}
+
+	strip := http.StripPrefix(basePath, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		if !strings.HasPrefix(p, basePath) || (len(p) > len(basePath) && p[len(basePath)] != '/') {
+			renderNotFound(w, r)
+			return
+		}
+
+		strip.ServeHTTP(w, r)
+	})
+}
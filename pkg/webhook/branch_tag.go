@@ -55,13 +55,17 @@ func NewBranchTagEvent(ctx context.Context, user proto.User, repo proto.Reposito
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
-			Sender: User{
-				ID:       user.ID(),
-				Username: user.Username(),
-			},
 		},
 	}
 
+	// Branches pruned by the server don't have a sender.
+	if user != nil {
+		payload.Sender = User{
+			ID:       user.ID(),
+			Username: user.Username(),
+		}
+	}
+
 	cfg := config.FromContext(ctx)
 	payload.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
 	payload.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
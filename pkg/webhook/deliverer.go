@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+// queueTimeout is the maximum time spent publishing a single delivery to a
+// message broker.
+const queueTimeout = 30 * time.Second
+
+// ErrInvalidBackend is returned when the webhook delivery backend is unknown.
+var ErrInvalidBackend = errors.New("invalid webhook delivery backend")
+
+// Request is a webhook request. It's the message published to message
+// brokers.
+type Request struct {
+	ID        string            `json:"id"`
+	WebhookID int64             `json:"webhook_id"`
+	Event     string            `json:"event"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	Body      string            `json:"body"`
+}
+
+// Response is the response to a webhook request.
+type Response struct {
+	Status  int
+	Headers http.Header
+	Body    string
+}
+
+// Deliverer delivers webhook requests.
+type Deliverer interface {
+	Deliver(ctx context.Context, req Request) (*Response, error)
+}
+
+// NewDeliverer returns the deliverer configured in cfg.
+func NewDeliverer(cfg config.WebhooksConfig) (Deliverer, error) {
+	switch cfg.Backend {
+	case "", "http":
+		return HTTP{Client: http.DefaultClient}, nil
+	case "redis":
+		return Redis{URL: cfg.QueueURL, CAPath: cfg.QueueCAPath, Stream: cfg.Queue}, nil
+	case "nats":
+		return NATS{URL: cfg.QueueURL, CAPath: cfg.QueueCAPath, Subject: cfg.Queue}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBackend, cfg.Backend)
+	}
+}
+
+// deliverer returns the deliverer of the config in the context.
+func deliverer(ctx context.Context) (Deliverer, error) {
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return HTTP{Client: http.DefaultClient}, nil
+	}
+
+	return NewDeliverer(cfg.Webhooks)
+}
+
+// HTTP delivers webhook requests to the webhook URLs.
+type HTTP struct {
+	Client *http.Client
+}
+
+// Deliver implements Deliverer.
+func (h HTTP) Deliver(ctx context.Context, r Request) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, strings.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := h.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		Status:  res.StatusCode,
+		Headers: res.Header,
+		Body:    string(b),
+	}, nil
+}
+
+// queued is the response of deliveries published to a message broker.
+func queued() *Response {
+	return &Response{Status: http.StatusAccepted}
+}
+
+// queueClients are the message broker clients by backend and URL. Clients
+// pool their connections and are shared by all deliveries.
+var (
+	queueClientsMu sync.Mutex
+	queueClients   = map[string]any{}
+)
+
+// queueClient returns the cached client of key, or opens a new one. open is
+// also called when valid reports the cached client can't be used anymore.
+func queueClient[T any](key string, open func() (T, error), valid func(T) bool) (T, error) {
+	queueClientsMu.Lock()
+	defer queueClientsMu.Unlock()
+
+	if c, ok := queueClients[key].(T); ok && valid(c) {
+		return c, nil
+	}
+
+	c, err := open()
+	if err != nil {
+		return c, err
+	}
+
+	queueClients[key] = c
+	return c, nil
+}
+
+// queueTLSConfig returns the TLS config used to connect to a message broker
+// at host. caPath is the path to the PEM certificates of the broker, empty
+// uses the system certificates.
+func queueTLSConfig(host, caPath string) (*tls.Config, error) {
+	tc := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if caPath == "" {
+		return tc, nil
+	}
+
+	pem, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tc.RootCAs = x509.NewCertPool()
+	if !tc.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %q", caPath)
+	}
+
+	return tc, nil
+}
@@ -0,0 +1,221 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var testRequest = Request{
+	ID:        "1a2b",
+	WebhookID: 1,
+	Event:     "push",
+	Method:    http.MethodPost,
+	Headers:   map[string]string{"Content-Type": "application/json"},
+	Body:      `{"ref":"refs/heads/main"}` + "\n",
+}
+
+func checkMessage(t *testing.T, msg string) {
+	t.Helper()
+	var req Request
+	if err := json.Unmarshal([]byte(msg), &req); err != nil {
+		t.Fatalf("invalid message %q: %v", msg, err)
+	}
+	if req.Body != testRequest.Body || req.ID != testRequest.ID || req.Headers["Content-Type"] != "application/json" {
+		t.Errorf("unexpected message: %+v", req)
+	}
+}
+
+func TestNewDeliverer(t *testing.T) {
+	for backend, want := range map[string]Deliverer{
+		"":      HTTP{Client: http.DefaultClient},
+		"http":  HTTP{Client: http.DefaultClient},
+		"redis": Redis{URL: "redis://localhost", Stream: "hooks"},
+		"nats":  NATS{URL: "redis://localhost", Subject: "hooks"},
+	} {
+		d, err := NewDeliverer(config.WebhooksConfig{Backend: backend, QueueURL: "redis://localhost", Queue: "hooks"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d != want {
+			t.Errorf("%q: expected %#v, got %#v", backend, want, d)
+		}
+	}
+
+	if _, err := NewDeliverer(config.WebhooksConfig{Backend: "kafka"}); err == nil {
+		t.Error("expected an error for an unknown backend")
+	}
+}
+
+func TestHTTPDeliver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != testRequest.Body || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-Test", "ok")
+		io.WriteString(w, "thanks") // nolint: errcheck
+	}))
+	defer srv.Close()
+
+	req := testRequest
+	req.URL = srv.URL
+	res, err := HTTP{Client: srv.Client()}.Deliver(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != http.StatusOK || res.Body != "thanks" || res.Headers.Get("X-Test") != "ok" {
+		t.Errorf("unexpected response: %+v", res)
+	}
+}
+
+func TestRedisDeliver(t *testing.T) {
+	m := miniredis.RunT(t)
+	m.RequireAuth("secret")
+
+	r := Redis{URL: "redis://:secret@" + m.Addr() + "/2", Stream: "hooks"}
+	for i := 0; i < 2; i++ {
+		res, err := r.Deliver(context.Background(), testRequest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != http.StatusAccepted {
+			t.Errorf("expected status %d, got %d", http.StatusAccepted, res.Status)
+		}
+	}
+
+	m.Select(2)
+	entries, err := m.Stream("hooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if len(e.Values) != 2 || e.Values[0] != "request" {
+			t.Fatalf("unexpected entry: %q", e.Values)
+		}
+		checkMessage(t, e.Values[1])
+	}
+}
+
+func TestRedisDeliverError(t *testing.T) {
+	m := miniredis.RunT(t)
+	m.Set("hooks", "value") // nolint: errcheck
+
+	_, err := Redis{URL: "redis://" + m.Addr(), Stream: "hooks"}.Deliver(context.Background(), testRequest)
+	if err == nil || !strings.Contains(err.Error(), "WRONGTYPE") {
+		t.Errorf("expected a redis error, got %v", err)
+	}
+}
+
+// runNATS runs a NATS server with JetStream enabled.
+func runNATS(t *testing.T) *server.Server {
+	t.Helper()
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+	if !s.ReadyForConnections(10 * time.Second) {
+		t.Fatal("nats server not ready")
+	}
+
+	return s
+}
+
+func TestNATSDeliver(t *testing.T) {
+	ctx := context.Background()
+	s := runNATS(t)
+	js, err := NATS{URL: s.ClientURL()}.open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer js.Conn().Close()
+
+	stream, err := js.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     "WEBHOOKS",
+		Subjects: []string{"soft-serve.webhooks"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := NATS{URL: s.ClientURL(), Subject: "soft-serve.webhooks"}
+	for i := 0; i < 2; i++ {
+		res, err := n.Deliver(ctx, testRequest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != http.StatusAccepted {
+			t.Errorf("expected status %d, got %d", http.StatusAccepted, res.Status)
+		}
+	}
+
+	// Publishing the same request again is deduplicated.
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != 1 {
+		t.Fatalf("expected 1 message, got %d", info.State.Msgs)
+	}
+
+	msg, err := stream.GetLastMsgForSubject(ctx, "soft-serve.webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMessage(t, string(msg.Data))
+}
+
+func TestNATSDeliverError(t *testing.T) {
+	s := runNATS(t)
+
+	// The subject isn't bound to a stream, nothing stores the request.
+	_, err := NATS{URL: s.ClientURL(), Subject: "hooks"}.Deliver(context.Background(), testRequest)
+	if !errors.Is(err, jetstream.ErrNoStreamResponse) {
+		t.Errorf("expected a no stream error, got %v", err)
+	}
+}
+
+func TestQueueTLSConfig(t *testing.T) {
+	tc, err := queueTLSConfig("queue.example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tc.ServerName != "queue.example.com" || tc.RootCAs != nil {
+		t.Errorf("unexpected config: %+v", tc)
+	}
+
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(ca, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queueTLSConfig("queue.example.com", ca); err == nil {
+		t.Error("expected an error for invalid certificates")
+	}
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis adds webhook requests to a Redis stream. Workers read the stream with
+// a consumer group and acknowledge requests once they delivered them, so
+// requests of failed workers are delivered by others.
+type Redis struct {
+	// URL is the Redis server URL, e.g. "redis://:password@localhost:6379/0".
+	// "rediss" URLs connect over TLS.
+	URL string
+	// CAPath is the path to the PEM certificates of the server, empty uses
+	// the system certificates.
+	CAPath string
+	// Stream is the stream requests are added to, in the "request" field of
+	// the entries.
+	Stream string
+}
+
+// Deliver implements Deliverer.
+func (r Redis) Deliver(ctx context.Context, req Request) (*Response, error) {
+	c, err := queueClient("redis "+r.URL+" "+r.CAPath, r.open, func(*redis.Client) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queueTimeout)
+	defer cancel()
+
+	if err := c.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.Stream,
+		Values: map[string]any{"request": msg},
+	}).Err(); err != nil {
+		return nil, err
+	}
+
+	return queued(), nil
+}
+
+// open opens a client to the Redis server.
+func (r Redis) open() (*redis.Client, error) {
+	opts, err := redis.ParseURL(r.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TLSConfig != nil {
+		opts.TLSConfig, err = queueTLSConfig(opts.TLSConfig.ServerName, r.CAPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return redis.NewClient(opts), nil
+}
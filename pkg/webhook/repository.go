@@ -29,6 +29,12 @@ const (
 	RepositoryEventActionVisibilityChange RepositoryEventAction = "visibility_change"
 	// RepositoryEventActionDefaultBranchChange is a repository default branch changed event.
 	RepositoryEventActionDefaultBranchChange RepositoryEventAction = "default_branch_change"
+	// RepositoryEventActionTransfer is a repository ownership transferred event.
+	RepositoryEventActionTransfer RepositoryEventAction = "transfer"
+	// RepositoryEventActionArchive is a repository archived event.
+	RepositoryEventActionArchive RepositoryEventAction = "archive"
+	// RepositoryEventActionUnarchive is a repository unarchived event.
+	RepositoryEventActionUnarchive RepositoryEventAction = "unarchive"
 )
 
 // NewRepositoryEvent sends a repository event.
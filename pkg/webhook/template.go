@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+)
+
+// ErrInvalidTemplate is returned when a webhook payload template is invalid.
+var ErrInvalidTemplate = errors.New("invalid webhook template")
+
+var templateFuncs = template.FuncMap{
+	// json encodes a value as JSON, use it to embed strings in JSON bodies.
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ParseTemplate parses a webhook payload template. Templates use the Go
+// text/template syntax and are executed with the event payload, for example
+// `{"text": {{ json .Repository.Name }}}`.
+func ParseTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("webhook").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return t, nil
+}
+
+// ValidateTemplate makes sure tmpl parses, and that it can be executed with
+// the payloads of events.
+func ValidateTemplate(tmpl string, events []Event) error {
+	t, err := ParseTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, emptyPayload(e)); err != nil {
+			return fmt.Errorf("%w: %s event: %v", ErrInvalidTemplate, e, err)
+		}
+	}
+
+	return nil
+}
+
+// emptyPayload returns an empty payload of the given event.
+func emptyPayload(e Event) EventPayload {
+	common := Common{EventType: e}
+	switch e {
+	case EventBranchTagCreate, EventBranchTagDelete:
+		return BranchTagEvent{Common: common}
+	case EventCollaborator:
+		return CollaboratorEvent{Common: common}
+	case EventPush:
+		return PushEvent{Common: common}
+	default:
+		return RepositoryEvent{Common: common}
+	}
+}
+
+// renderTemplate renders the payload template of a webhook.
+func renderTemplate(tmpl string, payload interface{}) (string, error) {
+	t, err := ParseTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, payload); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	cases := []struct {
+		name   string
+		tmpl   string
+		events []Event
+		valid  bool
+	}{
+		{"valid", `{"text": {{ json .Repository.Name }}}`, []Event{EventPush, EventRepository}, true},
+		{"syntax", `{"text": {{ .Repository.Name }`, nil, false},
+		{"unknown function", `{{ upper .Repository.Name }}`, nil, false},
+		{"event field", `{{ range .Commits }}{{ .ID }}{{ end }}`, []Event{EventPush}, true},
+		{"missing event field", `{{ range .Commits }}{{ .ID }}{{ end }}`, []Event{EventPush, EventRepository}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateTemplate(c.tmpl, c.events)
+			if c.valid && err != nil {
+				t.Errorf("expected valid template, got %v", err)
+			}
+			if !c.valid && !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("expected ErrInvalidTemplate, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	payload := PushEvent{
+		Common:  Common{Repository: Repository{Name: `repo "1"`}},
+		Commits: []Commit{{ID: "abc"}, {ID: "def"}},
+	}
+	body, err := renderTemplate(`{"repo": {{ json .Repository.Name }}, "commits": {{ len .Commits }}}`, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"repo": "repo \"1\"", "commits": 2}`; body != want {
+		t.Errorf("expected %q, got %q", want, body)
+	}
+}
@@ -9,10 +9,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
@@ -36,43 +37,37 @@ type Delivery struct {
 	Event Event
 }
 
-// do sends a webhook.
-// Caller must close the returned body.
-func do(ctx context.Context, url string, method string, headers http.Header, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header = headers
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-// SendWebhook sends a webhook event.
+// SendWebhook sends a webhook event with the configured deliverer.
 func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload interface{}) error {
 	var buf bytes.Buffer
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 
 	contentType := ContentType(w.ContentType) //nolint:gosec
-	switch contentType {
-	case ContentTypeJSON:
+	if contentType != ContentTypeJSON && contentType != ContentTypeForm {
+		return ErrInvalidContentType
+	}
+
+	// A template renders the body instead of the default encoding. A
+	// rendering failure is recorded as the delivery error.
+	var renderErr error
+	switch {
+	case w.Template != "":
+		body, err := renderTemplate(w.Template, payload)
+		if err != nil {
+			renderErr = fmt.Errorf("render template: %w", err)
+		}
+		buf.WriteString(body) // nolint: errcheck
+	case contentType == ContentTypeJSON:
 		if err := json.NewEncoder(&buf).Encode(payload); err != nil {
 			return err
 		}
-	case ContentTypeForm:
+	case contentType == ContentTypeForm:
 		v, err := query.Values(payload)
 		if err != nil {
 			return err
 		}
 		buf.WriteString(v.Encode()) // nolint: errcheck
-	default:
-		return ErrInvalidContentType
 	}
 
 	headers := http.Header{}
@@ -94,34 +89,58 @@ func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload int
 		headers.Add("X-SoftServe-Signature", "sha256="+hex.EncodeToString(sig.Sum(nil)))
 	}
 
-	res, reqErr := do(ctx, w.URL, http.MethodPost, headers, &buf)
+	req := Request{
+		ID:        id.String(),
+		WebhookID: w.ID,
+		Event:     event.String(),
+		URL:       w.URL,
+		Method:    http.MethodPost,
+		Headers:   make(map[string]string, len(headers)),
+		Body:      reqBody,
+	}
 	var reqHeaders string
 	for k, v := range headers {
+		req.Headers[k] = v[0]
 		reqHeaders += k + ": " + v[0] + "\n"
 	}
 
+	var res *Response
+	var duration time.Duration
+	reqErr := renderErr
+	if reqErr == nil {
+		var d Deliverer
+		d, reqErr = deliverer(ctx)
+		if reqErr == nil {
+			start := time.Now()
+			res, reqErr = d.Deliver(ctx, req)
+			duration = time.Since(start)
+		}
+	}
+
 	resStatus := 0
 	resHeaders := ""
 	resBody := ""
 
 	if res != nil {
-		resStatus = res.StatusCode
-		for k, v := range res.Header {
+		resStatus = res.Status
+		for k, v := range res.Headers {
 			resHeaders += k + ": " + v[0] + "\n"
 		}
+		resBody = res.Body
+	}
 
-		if res.Body != nil {
-			defer res.Body.Close() // nolint: errcheck
-			b, err := io.ReadAll(res.Body)
-			if err != nil {
-				return err
-			}
+	if err := datastore.CreateWebhookDelivery(ctx, dbx, id, w.ID, int(event), w.URL, http.MethodPost, reqErr, reqHeaders, reqBody, resStatus, resHeaders, resBody, duration); err != nil {
+		return db.WrapError(err)
+	}
 
-			resBody = string(b)
+	// Only the latest deliveries of each webhook are kept.
+	if cfg := config.FromContext(ctx); cfg != nil && cfg.Webhooks.MaxDeliveries > 0 {
+		if err := datastore.PruneWebhookDeliveries(ctx, dbx, w.ID, cfg.Webhooks.MaxDeliveries); err != nil {
+			return db.WrapError(err)
 		}
 	}
 
-	return db.WrapError(datastore.CreateWebhookDelivery(ctx, dbx, id, w.ID, int(event), w.URL, http.MethodPost, reqErr, reqHeaders, reqBody, resStatus, resHeaders, resBody))
+	return nil
 }
 
 // SendEvent sends a webhook event.
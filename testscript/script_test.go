@@ -10,13 +10,16 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -90,6 +93,7 @@ func TestScript(t *testing.T) {
 		Cmds: map[string]func(ts *testscript.TestScript, neg bool, args []string){
 			"soft":                   cmdSoft("admin", admin1.Signer()),
 			"usoft":                  cmdSoft("user1", user1.Signer()),
+			"uenroll":                cmdEnroll(user1.Signer()),
 			"git":                    cmdGit(admin1Key),
 			"ugit":                   cmdGit(user1Key),
 			"curl":                   cmdCurl,
@@ -98,6 +102,7 @@ func TestScript(t *testing.T) {
 			"readfile":               cmdReadfile,
 			"dos2unix":               cmdDos2Unix,
 			"new-webhook":            cmdNewWebhook,
+			"notifyserver":           cmdNotifyServer,
 			"ensureserverrunning":    cmdEnsureServerRunning,
 			"ensureservernotrunning": cmdEnsureServerNotRunning,
 			"stopserver":             cmdStopserver,
@@ -127,6 +132,7 @@ func TestScript(t *testing.T) {
 			e.Setenv("ADMIN1_AUTHORIZED_KEY", admin1.AuthorizedKey())
 			e.Setenv("ADMIN2_AUTHORIZED_KEY", admin2.AuthorizedKey())
 			e.Setenv("USER1_AUTHORIZED_KEY", user1.AuthorizedKey())
+			e.Setenv("USER1_FINGERPRINT", ssh.FingerprintSHA256(user1.PublicKey()))
 			e.Setenv("SSH_KNOWN_HOSTS_FILE", filepath.Join(t.TempDir(), "known_hosts"))
 			e.Setenv("SSH_KNOWN_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
 
@@ -151,7 +157,7 @@ func TestScript(t *testing.T) {
 			cfg.DataPath = data
 			cfg.Name = serverName
 			cfg.InitialAdminKeys = []string{admin1.AuthorizedKey()}
-			cfg.SSH.ListenAddr = sshListen
+			cfg.SSH.ListenAddr = config.ListenAddrs{sshListen}
 			cfg.SSH.PublicURL = "ssh://" + sshListen
 			cfg.Git.ListenAddr = gitListen
 			cfg.HTTP.ListenAddr = httpListen
@@ -200,6 +206,11 @@ func cmdSoft(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bo
 				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 			},
 		)
+		if err != nil && neg {
+			// The server refused the connection.
+			fmt.Fprintln(ts.Stderr(), err)
+			return
+		}
 		ts.Check(err)
 		defer cli.Close()
 
@@ -214,10 +225,56 @@ func cmdSoft(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bo
 	}
 }
 
+// cmdEnroll authenticates with key, answering the keyboard-interactive
+// prompts with the given usernames in order, and prints the instructions of
+// the server.
+func cmdEnroll(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
+	return func(ts *testscript.TestScript, neg bool, args []string) {
+		answers := args
+		cli, err := ssh.Dial(
+			"tcp",
+			net.JoinHostPort("localhost", ts.Getenv("SSH_PORT")),
+			&ssh.ClientConfig{
+				User: "user1",
+				Auth: []ssh.AuthMethod{
+					ssh.PublicKeys(key),
+					ssh.KeyboardInteractive(func(_, instruction string, questions []string, _ []bool) ([]string, error) {
+						fmt.Fprintln(ts.Stdout(), instruction)
+						if len(questions) == 0 {
+							return nil, nil
+						}
+						if len(answers) == 0 {
+							return []string{""}, nil
+						}
+						answer := answers[0]
+						answers = answers[1:]
+						return []string{answer}, nil
+					}),
+				},
+				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			},
+		)
+		if err != nil {
+			fmt.Fprintln(ts.Stderr(), err)
+		} else {
+			cli.Close() // nolint: errcheck
+		}
+		check(ts, err, neg)
+	}
+}
+
+// uiWaitTimeout is how long the ui command waits for a pattern in the output
+// of the UI.
+const uiWaitTimeout = 30 * time.Second
+
+// cmdUI runs the UI and sends it the quoted string inputs, one key at a
+// time. The other arguments are regular expressions to wait for in the
+// output of the UI after the previous input, e.g. for a view to be rendered
+// before the next keys are sent.
 func cmdUI(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
 	return func(ts *testscript.TestScript, neg bool, args []string) {
 		if len(args) < 1 {
-			ts.Fatalf("usage: ui <quoted string input>")
+			ts.Fatalf("usage: ui <quoted string input | regexp to wait for>...")
 			return
 		}
 
@@ -230,11 +287,11 @@ func cmdUI(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []stri
 				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 			},
 		)
-		check(ts, err, neg)
+		ts.Check(err)
 		defer cli.Close()
 
 		sess, err := cli.NewSession()
-		check(ts, err, neg)
+		ts.Check(err)
 		defer sess.Close()
 
 		// XXX: this is a hack to make the UI tests work
@@ -242,38 +299,99 @@ func cmdUI(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []stri
 		// in the output
 		defer ts.Stdout().Write([]byte("\n"))
 
-		sess.Stdout = ts.Stdout()
+		var out syncBuffer
+		sess.Stdout = io.MultiWriter(ts.Stdout(), &out)
 		sess.Stderr = ts.Stderr()
 
 		stdin, err := sess.StdinPipe()
-		check(ts, err, neg)
+		ts.Check(err)
 
 		err = sess.RequestPty("dumb", 40, 80, ssh.TerminalModes{})
-		check(ts, err, neg)
-		check(ts, sess.Start(""), neg)
+		ts.Check(err)
+		ts.Check(sess.Start(""))
 
-		in, err := strconv.Unquote(args[0])
-		check(ts, err, neg)
-		reader := strings.NewReader(in)
+		done := make(chan error, 1)
 		go func() {
-			defer stdin.Close()
-			for {
-				r, _, err := reader.ReadRune()
-				if err == io.EOF {
-					break
+			done <- sess.Wait()
+		}()
+
+		var since int
+		for _, arg := range args {
+			if !strings.HasPrefix(arg, `"`) {
+				re, err := regexp.Compile(arg)
+				ts.Check(err)
+				if err := waitForOutput(&out, since, re, done); err != nil {
+					ts.Fatalf("%v", err)
 				}
-				check(ts, err, neg)
+				continue
+			}
+
+			in, err := strconv.Unquote(arg)
+			ts.Check(err)
+			since = out.Len()
+			for _, r := range in {
 				stdin.Write([]byte(string(r))) // nolint: errcheck
 
 				// Wait for the UI to process the input
 				time.Sleep(100 * time.Millisecond)
 			}
-		}()
+		}
+		stdin.Close() // nolint: errcheck
+
+		// Only the session can fail, e.g. when the key can't open the UI.
+		check(ts, <-done, neg)
+	}
+}
 
-		check(ts, sess.Wait(), neg)
+// waitForOutput waits for re to match the output of a UI session written
+// after the offset since. If the session exits first, its result is sent
+// back to done.
+func waitForOutput(out *syncBuffer, since int, re *regexp.Regexp, done chan error) error {
+	timeout := time.After(uiWaitTimeout)
+	for {
+		if re.Match(out.Bytes()[since:]) {
+			return nil
+		}
+
+		select {
+		case err := <-done:
+			done <- err
+			if re.Match(out.Bytes()[since:]) {
+				return nil
+			}
+			return fmt.Errorf("ui exited before the output matched %q", re)
+		case <-timeout:
+			return fmt.Errorf("timed out waiting for the output to match %q", re)
+		case <-time.After(50 * time.Millisecond):
+		}
 	}
 }
 
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Bytes returns a copy of the buffer contents.
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
+
+func (b *syncBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
 func cmdDos2Unix(ts *testscript.TestScript, neg bool, args []string) {
 	if neg {
 		ts.Fatalf("unsupported: ! dos2unix")
@@ -394,6 +512,37 @@ func cmdNewWebhook(ts *testscript.TestScript, neg bool, args []string) {
 	ts.Setenv(args[0], whSite+"/"+site.UUID)
 }
 
+// cmdNotifyServer starts a local server that receives notifications and
+// appends their JSON payloads, one per line, to a file.
+func cmdNotifyServer(ts *testscript.TestScript, neg bool, args []string) {
+	if len(args) != 2 {
+		ts.Fatalf("usage: notifyserver <env-name> <file>")
+	}
+
+	path := ts.MkAbs(args[1])
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close() // nolint: errcheck
+		f.Write(body)   // nolint: errcheck
+	}))
+	ts.Defer(srv.Close)
+
+	ts.Setenv(args[0], srv.URL)
+}
+
 func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 	var verbose bool
 	var headers []string
@@ -409,13 +558,15 @@ func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 				return err
 			}
 
-			req, err := http.NewRequest(method, url.String(), nil)
-			if err != nil {
-				return err
+			// Use a sized body so that requests have a Content-Length.
+			var body io.Reader
+			if data != "" {
+				body = strings.NewReader(data)
 			}
 
-			if data != "" {
-				req.Body = io.NopCloser(strings.NewReader(data))
+			req, err := http.NewRequest(method, url.String(), body)
+			if err != nil {
+				return err
 			}
 
 			if verbose {